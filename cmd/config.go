@@ -35,7 +35,9 @@ func init() {
 	// Add default configuration values
 	viper.SetDefault("server.port", 3000)
 	viper.SetDefault("server.env", "development")
-	viper.SetDefault("server.apikey", "") // Empty string means no master key
+	viper.SetDefault("server.apikey", "")                          // Empty string means no master key
+	viper.SetDefault("server.default_timezone", "Asia/Bangkok")    // Used for users without a timezone preference
+	viper.SetDefault("server.public_url", "http://localhost:3000") // Base of links to the API, such as signed file URLs
 	viper.SetDefault("database.host", "localhost")
 	viper.SetDefault("database.port", 5432)
 	viper.SetDefault("database.sslmode", "disable")
@@ -43,12 +45,105 @@ func init() {
 	viper.SetDefault("logging.level", "debug")
 	viper.SetDefault("logging.format", "json")
 
+	// Mailer
+	viper.SetDefault("mailer.provider", "smtp") // smtp, ses or dev (writes .eml files)
+	viper.SetDefault("mailer.from", "no-reply@dailyalu.mom")
+	viper.SetDefault("mailer.dev.dir", "tmp/mail")
+
 	//add ses values
-	viper.SetDefault("aws.ses.sender", "no-reply@dailyalu.com")
 	viper.SetDefault("aws.ses.access_key", "")
 	viper.SetDefault("aws.ses.access_secret_key", "")
 	viper.SetDefault("aws.region", "ap-southeast-1")
 
+	// File storage
+	viper.SetDefault("storage.driver", "local") // local or s3
+	viper.SetDefault("storage.local.directory", "storage")
+	viper.SetDefault("storage.signing_secret", "") // Signs local file URLs; defaults to jwt.secret
+	viper.SetDefault("storage.timeout", "30s")
+	viper.SetDefault("aws.s3.endpoint", "")          // Set for S3-compatible services such as MinIO
+	viper.SetDefault("aws.s3.use_path_style", false) // Required by most S3-compatible services
+	viper.SetDefault("aws.s3.access_key", "")        // Empty to use the default AWS credential chain
+	viper.SetDefault("aws.s3.access_secret_key", "")
+
+	// Attachments
+	viper.SetDefault("attachments.max_size", 10<<20) // Bytes
+	viper.SetDefault("attachments.thumbnail_size", 320)
+	viper.SetDefault("attachments.url_expiry", "15m") // Lifetime of signed download URLs
+
+	// Password policy
+	viper.SetDefault("password.policy.min_length", 8)
+	viper.SetDefault("password.policy.require_uppercase", true)
+	viper.SetDefault("password.policy.require_lowercase", true)
+	viper.SetDefault("password.policy.require_digit", true)
+	viper.SetDefault("password.policy.require_symbol", false)
+	viper.SetDefault("password.policy.disallow_personal_info", true)
+	viper.SetDefault("password.policy.history_size", 5)
+	viper.SetDefault("password.policy.check_breached", true)
+	viper.SetDefault("password.hash.algorithm", "argon2id")
+	viper.SetDefault("password.hash.memory", 65536)
+	viper.SetDefault("password.hash.iterations", 3)
+	viper.SetDefault("password.hash.parallelism", 2)
+	viper.SetDefault("password.hash.salt_length", 16)
+	viper.SetDefault("password.hash.key_length", 32)
+	viper.SetDefault("password.hash.bcrypt_cost", 10)
+
+	// Immunization schedule
+	viper.SetDefault("immunization.schedule", "idai")  // Bundled schedule: idai or cdc
+	viper.SetDefault("immunization.schedule_file", "") // YAML or JSON schedule, overrides immunization.schedule
+
+	// Reminder scheduler
+	viper.SetDefault("reminders.enabled", true)
+	viper.SetDefault("reminders.poll_interval", "30s")
+	viper.SetDefault("reminders.batch_size", 50)
+	viper.SetDefault("reminders.lease", "2m")         // Claimed reminders are retried by another instance after this
+	viper.SetDefault("reminders.max_attempts", 5)     // Deliveries tried per occurrence
+	viper.SetDefault("reminders.retry_backoff", "1m") // Doubled after every failed attempt
+	viper.SetDefault("reminders.timeout", "30s")      // Per delivery, shorter than the lease
+	viper.SetDefault("notifier.webhook.timeout", "10s")
+	viper.SetDefault("notifier.push.fake", false) // Record push messages instead of sending them
+	viper.SetDefault("notifier.push.timeout", "10s")
+	viper.SetDefault("notifier.push.fcm.enabled", false)
+	viper.SetDefault("notifier.push.apns.enabled", false)
+	viper.SetDefault("notifier.push.apns.production", false)
+
+	// Requests to user supplied URLs, such as webhooks
+	viper.SetDefault("outbound.allow_private_networks", false) // Allow loopback and private addresses, for local testing only
+
+	// Webhook dispatcher
+	viper.SetDefault("webhooks.enabled", true)
+	viper.SetDefault("webhooks.poll_interval", "5s")
+	viper.SetDefault("webhooks.batch_size", 50)
+	viper.SetDefault("webhooks.lease", "2m")          // Claimed deliveries are retried by another instance after this
+	viper.SetDefault("webhooks.max_attempts", 8)      // Attempts before a delivery fails
+	viper.SetDefault("webhooks.retry_backoff", "30s") // Doubled after every failed attempt
+	viper.SetDefault("webhooks.timeout", "10s")
+
+	// Outbox dispatcher
+	viper.SetDefault("outbox.enabled", true)
+	viper.SetDefault("outbox.poll_interval", "2s")
+	viper.SetDefault("outbox.batch_size", 50)
+	viper.SetDefault("outbox.lease", "2m")          // Claimed messages are retried by another instance after this
+	viper.SetDefault("outbox.max_attempts", 10)     // Attempts before a message is dead-lettered
+	viper.SetDefault("outbox.retry_backoff", "15s") // Doubled after every failed attempt
+	viper.SetDefault("outbox.timeout", "30s")
+
+	// Weekly digest
+	viper.SetDefault("digest.enabled", true)
+	viper.SetDefault("digest.poll_interval", "15m")
+	viper.SetDefault("digest.batch_size", 100)
+	viper.SetDefault("digest.send_hour", 8) // Local hour on the first day of the week
+
+	// Import worker
+	viper.SetDefault("imports.enabled", true)
+	viper.SetDefault("imports.poll_interval", "5s")
+	viper.SetDefault("imports.batch_size", 200) // Rows imported per transaction
+	viper.SetDefault("imports.lease", "2m")     // Claimed imports are resumed by another instance after this
+
+	// Realtime event streams
+	viper.SetDefault("realtime.broker", "memory") // "postgres" to share events across instances
+	viper.SetDefault("realtime.channel", "dailyalu_events")
+	viper.SetDefault("realtime.buffer", 64) // Events queued per connection before it is dropped
+
 	// Rate limiter configuration
 	viper.SetDefault("ratelimit.enabled", true)
 	viper.SetDefault("ratelimit.default.max", 60)        // 60 requests
@@ -59,6 +154,12 @@ func init() {
 	viper.SetDefault("ratelimit.endpoints.post.api_v1_auth_login.expiration", 60)
 	viper.SetDefault("ratelimit.endpoints.post.api_v1_auth_register.max", 20)
 	viper.SetDefault("ratelimit.endpoints.post.api_v1_auth_register.expiration", 60)
+	viper.SetDefault("ratelimit.endpoints.post.v1_auth_magic-link.max", 5)
+	viper.SetDefault("ratelimit.endpoints.post.v1_auth_magic-link.expiration", 60)
+	viper.SetDefault("ratelimit.endpoints.post.v1_auth_magic-link_verify.max", 10)
+	viper.SetDefault("ratelimit.endpoints.post.v1_auth_magic-link_verify.expiration", 60)
+	viper.SetDefault("ratelimit.endpoints.post.v1_children_childId_report_email.max", 5)
+	viper.SetDefault("ratelimit.endpoints.post.v1_children_childId_report_email.expiration", 3600)
 
 	// Enable environment variable overrides
 	viper.SetEnvPrefix("DAILYALU")
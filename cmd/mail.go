@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"context"
+	"dailyalu-server/internal/service/mailer"
+	mailerDomain "dailyalu-server/internal/service/mailer/domain"
+	"dailyalu-server/internal/service/mailer/templates"
+	"dailyalu-server/pkg/pdf"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var mailCmd = &cobra.Command{
+	Use:   "mail",
+	Short: "Preview and test email templates",
+}
+
+var mailPreviewCmd = &cobra.Command{
+	Use:   "preview <template>",
+	Short: "Render an email template to HTML and text files",
+	Long: `Render an email template to HTML and text files without sending it.
+
+The template is rendered with sample values, overridden by the fields of the
+--data JSON file, e.g. {"Name": "Ani", "VerificationURL": "https://..."}.
+Templates: ` + strings.Join(templates.Names(), ", "),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		locale, _ := cmd.Flags().GetString("locale")
+		dataFile, _ := cmd.Flags().GetString("data")
+		outDir, _ := cmd.Flags().GetString("out")
+
+		data, err := loadMailData(name, dataFile)
+		if err != nil {
+			return err
+		}
+
+		registry, err := templates.NewRegistry()
+		if err != nil {
+			return err
+		}
+
+		rendered, err := registry.Render(name, locale, data)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(outDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		base := filepath.Join(outDir, fmt.Sprintf("%s.%s", name, templates.ResolveLocale(locale)))
+		files := map[string]string{
+			base + ".html": rendered.HTML,
+			base + ".txt":  rendered.Text,
+		}
+		for path, content := range files {
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+		}
+
+		fmt.Printf("Subject: %s\n", rendered.Subject)
+		fmt.Printf("HTML:    %s\n", base+".html")
+		fmt.Printf("Text:    %s\n", base+".txt")
+		return nil
+	},
+}
+
+var mailSendTestCmd = &cobra.Command{
+	Use:   "send-test <template>",
+	Short: "Send an email template through the configured mail provider",
+	Long: `Render an email template like "mail preview" and deliver it to --to
+through the provider configured under mailer.
+Templates: ` + strings.Join(templates.Names(), ", "),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		to, _ := cmd.Flags().GetString("to")
+		locale, _ := cmd.Flags().GetString("locale")
+		dataFile, _ := cmd.Flags().GetString("data")
+
+		data, err := loadMailData(name, dataFile)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		mailerService, err := mailer.NewMailerServiceFromConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to configure mailer: %w", err)
+		}
+
+		switch data := data.(type) {
+		case *mailerDomain.EmailVerificationData:
+			data.To, data.Locale = to, locale
+			err = mailerService.SendVerificationEmail(ctx, data)
+		case *mailerDomain.MagicLinkEmailData:
+			data.To, data.Locale = to, locale
+			err = mailerService.SendMagicLinkEmail(ctx, data)
+		case *mailerDomain.NotificationEmailData:
+			data.To, data.Locale = to, locale
+			err = mailerService.SendNotificationEmail(ctx, data)
+		case *mailerDomain.WeeklyDigestEmailData:
+			data.To, data.Locale = to, locale
+			err = mailerService.SendWeeklyDigestEmail(ctx, data)
+		case *mailerDomain.ReportEmailData:
+			data.To, data.Locale = to, locale
+			err = mailerService.SendReportEmail(ctx, data)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+
+		fmt.Printf("Sent %s (%s) to %s\n", name, templates.ResolveLocale(locale), to)
+		return nil
+	},
+}
+
+// mailSamples creates the data of each email filled with sample values
+var mailSamples = map[string]func() any{
+	templates.Verification: func() any {
+		return &mailerDomain.EmailVerificationData{
+			Name:            "Ani",
+			VerificationURL: "https://dailyalu.mom/verify-email?token=sample-token",
+		}
+	},
+	templates.MagicLink: func() any {
+		return &mailerDomain.MagicLinkEmailData{
+			Name:             "Ani",
+			MagicLinkURL:     "https://dailyalu.mom/magic-link?token=sample-token",
+			ExpiresInMinutes: 15,
+		}
+	},
+	templates.Notification: func() any {
+		return &mailerDomain.NotificationEmailData{
+			Name:    "Ani",
+			Title:   "Time for Budi's medication",
+			Message: "Paracetamol syrup, 2.5 ml.\nGive it after meals.",
+		}
+	},
+	templates.WeeklyDigest: func() any {
+		return &mailerDomain.WeeklyDigestEmailData{
+			Name:      "Ani",
+			WeekStart: "2025-03-10",
+			WeekEnd:   "2025-03-16",
+			Children: []mailerDomain.DigestChild{
+				{
+					Name:       "Budi",
+					SleepHours: 98.5,
+					Feeds:      52,
+					Diapers:    41,
+					Growth: []mailerDomain.DigestGrowth{
+						{Measure: mailerDomain.MeasureWeight, Value: 6.2, Change: 0.15, HasChange: true, Unit: "kg"},
+						{Measure: mailerDomain.MeasureLength, Value: 61.5, Unit: "cm"},
+					},
+				},
+			},
+		}
+	},
+	templates.Report: func() any {
+		return &mailerDomain.ReportEmailData{
+			Name:        "Ani",
+			ChildName:   "Budi",
+			PeriodStart: "2025-03-01",
+			PeriodEnd:   "2025-03-14",
+			Report: mailerDomain.Attachment{
+				Filename:    "budi-report.pdf",
+				ContentType: "application/pdf",
+				Data:        samplePDF(),
+			},
+		}
+	},
+}
+
+// samplePDF is a one page PDF standing in for a report
+func samplePDF() []byte {
+	doc := pdf.New()
+	doc.AddPage().Text(56, 80, pdf.HelveticaBold, 18, pdf.Black, "Sample report")
+	content, _ := doc.Bytes()
+	return content
+}
+
+// loadMailData returns the sample data of the email with the fields of the
+// JSON file, if any, applied on top
+func loadMailData(name, path string) (any, error) {
+	sample, ok := mailSamples[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q, expected one of: %s", name, strings.Join(templates.Names(), ", "))
+	}
+	data := sample()
+
+	if path == "" {
+		return data, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data file: %w", err)
+	}
+	if err := json.Unmarshal(content, data); err != nil {
+		return nil, fmt.Errorf("invalid data file %s: %w", path, err)
+	}
+
+	return data, nil
+}
+
+func init() {
+	mailPreviewCmd.Flags().String("locale", templates.DefaultLocale, "Locale to render, e.g. en or id")
+	mailPreviewCmd.Flags().String("data", "", "JSON file with the template data")
+	mailPreviewCmd.Flags().String("out", "tmp/mail-preview", "Directory the rendered files are written to")
+
+	mailSendTestCmd.Flags().String("to", "", "Recipient address")
+	mailSendTestCmd.Flags().String("locale", templates.DefaultLocale, "Locale to render, e.g. en or id")
+	mailSendTestCmd.Flags().String("data", "", "JSON file with the template data")
+	mailSendTestCmd.MarkFlagRequired("to")
+
+	mailCmd.AddCommand(mailPreviewCmd)
+	mailCmd.AddCommand(mailSendTestCmd)
+	rootCmd.AddCommand(mailCmd)
+}
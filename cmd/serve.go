@@ -1,11 +1,19 @@
 package cmd
 
 import (
+	"context"
 	"dailyalu-server/internal/container"
+	"dailyalu-server/internal/module/immunization/schedule"
 	"dailyalu-server/internal/router"
+	"dailyalu-server/internal/security/password"
+	"dailyalu-server/internal/service/mailer"
+	"dailyalu-server/internal/service/notifier/push"
+	"dailyalu-server/internal/service/realtime"
+	"dailyalu-server/internal/service/storage"
+	"dailyalu-server/internal/service/worker"
+	"dailyalu-server/internal/utils"
 	"dailyalu-server/pkg/app_log/zap_log"
 	"dailyalu-server/pkg/db/postgres"
-	"dailyalu-server/pkg/mailer/smtp"
 	"fmt"
 	"time"
 
@@ -27,40 +35,108 @@ var serveCmd = &cobra.Command{
 			return fmt.Errorf("failed to initialize logger: %w", err)
 		}
 
+		// Initialize password hashing
+		password.SetDefaultHasher(password.NewHasher(password.NewParamsFromConfig()))
+
 		// Initialize database
-		db, err := postgres.NewConnection(postgres.Config{
+		dbConfig := postgres.Config{
 			Host:     viper.GetString("database.host"),
 			Port:     viper.GetInt("database.port"),
 			User:     viper.GetString("database.user"),
 			Password: viper.GetString("database.password"),
 			DBName:   viper.GetString("database.name"),
 			SSLMode:  viper.GetString("database.sslmode"),
-		})
+		}
+		db, err := postgres.NewConnection(dbConfig)
 		if err != nil {
 			return fmt.Errorf("failed to connect to database: %w", err)
 		}
 
-		// newSes, err := ses.InitSes(context.TODO())
-		// if err != nil {
-		// 	return fmt.Errorf("failed to get mailer service: %w", err)
-		// }
+		mailerService, err := mailer.NewMailerServiceFromConfig(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to configure mailer: %w", err)
+		}
+
+		defaultLocation, err := utils.LoadLocation(viper.GetString("server.default_timezone"))
+		if err != nil {
+			return fmt.Errorf("invalid server.default_timezone: %w", err)
+		}
+
+		immunizationSchedule, err := schedule.NewFromConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load immunization schedule: %w", err)
+		}
 
-		newSmtp := smtp.InitSmtp()
+		pushSender, err := push.NewSenderFromConfig()
+		if err != nil {
+			return fmt.Errorf("failed to configure push notifications: %w", err)
+		}
+
+		realtimeBroker, err := realtime.NewBrokerFromConfig(db, dbConfig.DSN())
+		if err != nil {
+			return fmt.Errorf("failed to start realtime broker: %w", err)
+		}
+
+		fileStorage, err := storage.NewStorageFromConfig(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to configure file storage: %w", err)
+		}
+
+		// An attempt outliving its lease could be handled twice
+		for _, prefix := range []string{"reminders", "webhooks", "outbox"} {
+			if !viper.GetBool(prefix + ".enabled") {
+				continue
+			}
+			if err := worker.NewConfigFromConfig(prefix).Validate(); err != nil {
+				return fmt.Errorf("invalid %s settings: %w", prefix, err)
+			}
+		}
 
 		// Initialize dependency container
 		cont := container.NewContainer(
 			db,
-			newSmtp,
+			mailerService,
 			viper.GetString("jwt.secret"),
 			viper.GetString("jwt.refresh-secret-key"),
 			viper.GetDuration("jwt.expiry")*time.Hour,
 			viper.GetDuration("jwt.refresh-expiry")*time.Hour,
+			defaultLocation,
+			immunizationSchedule,
+			pushSender,
+			realtimeBroker,
+			fileStorage,
 		)
 		defer cont.Close()
 
+		// Start background workers, stopped when the server returns
+		workerCtx, stopWorkers := context.WithCancel(context.Background())
+		defer stopWorkers()
+
+		if viper.GetBool("reminders.enabled") {
+			go cont.GetReminderScheduler().Run(workerCtx)
+		}
+
+		if viper.GetBool("webhooks.enabled") {
+			go cont.GetWebhookDispatcher().Run(workerCtx)
+		}
+
+		if viper.GetBool("outbox.enabled") {
+			go cont.GetOutboxDispatcher().Run(workerCtx)
+		}
+
+		if viper.GetBool("digest.enabled") {
+			go cont.GetDigestScheduler().Run(workerCtx)
+		}
+
+		if viper.GetBool("imports.enabled") {
+			go cont.GetImportWorker().Run(workerCtx)
+		}
+
 		// Initialize Fiber app
+		// Bodies may carry an attachment and the rest of its multipart form
 		app := fiber.New(fiber.Config{
-			AppName: "DailyAlu API Server",
+			AppName:   "DailyAlu API Server",
+			BodyLimit: max(fiber.DefaultBodyLimit, int(viper.GetInt64("attachments.max_size"))+1<<20),
 		})
 
 		// Add global middleware
@@ -77,6 +153,87 @@ var serveCmd = &cobra.Command{
 			app,
 			cont.GetActivityHandler(),
 			cont.GetSecurityMiddleware(),
+			cont.GetTimezoneMiddleware(),
+		)
+
+		router.SetupGrowthRoutes(
+			app,
+			cont.GetGrowthHandler(),
+			cont.GetSecurityMiddleware(),
+		)
+
+		router.SetupReportRoutes(
+			app,
+			cont.GetReportHandler(),
+			cont.GetSecurityMiddleware(),
+			cont.GetTimezoneMiddleware(),
+		)
+
+		router.SetupImportRoutes(
+			app,
+			cont.GetImportHandler(),
+			cont.GetSecurityMiddleware(),
+			cont.GetTimezoneMiddleware(),
+		)
+
+		router.SetupAttachmentRoutes(
+			app,
+			cont.GetAttachmentHandler(),
+			cont.GetSecurityMiddleware(),
+		)
+
+		if fileHandler := cont.GetFileHandler(); fileHandler != nil {
+			router.SetupFileRoutes(app, fileHandler)
+		}
+
+		router.SetupMilestoneRoutes(
+			app,
+			cont.GetMilestoneHandler(),
+			cont.GetSecurityMiddleware(),
+		)
+
+		router.SetupImmunizationRoutes(
+			app,
+			cont.GetImmunizationHandler(),
+			cont.GetSecurityMiddleware(),
+		)
+
+		router.SetupMedicationRoutes(
+			app,
+			cont.GetMedicationHandler(),
+			cont.GetSecurityMiddleware(),
+			cont.GetTimezoneMiddleware(),
+		)
+
+		router.SetupReminderRoutes(
+			app,
+			cont.GetReminderHandler(),
+			cont.GetSecurityMiddleware(),
+			cont.GetTimezoneMiddleware(),
+		)
+
+		router.SetupDeviceRoutes(
+			app,
+			cont.GetDeviceHandler(),
+			cont.GetSecurityMiddleware(),
+		)
+
+		router.SetupRealtimeRoutes(
+			app,
+			cont.GetRealtimeHandler(),
+			cont.GetSecurityMiddleware(),
+		)
+
+		router.SetupWebhookRoutes(
+			app,
+			cont.GetWebhookHandler(),
+			cont.GetSecurityMiddleware(),
+		)
+
+		router.SetupOutboxRoutes(
+			app,
+			cont.GetOutboxHandler(),
+			cont.GetSecurityMiddleware(),
 		)
 
 		router.SetupToolsRoutes(
@@ -0,0 +1,3 @@
+-- Drop magic link tokens table and its dependencies
+DROP INDEX IF EXISTS idx_magic_link_tokens_email_created_at;
+DROP TABLE IF EXISTS magic_link_tokens;
@@ -0,0 +1,13 @@
+-- Create magic link tokens table
+CREATE TABLE IF NOT EXISTS magic_link_tokens (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL
+);
+
+-- Create index on email for per-email rate limiting
+CREATE INDEX IF NOT EXISTS idx_magic_link_tokens_email_created_at ON magic_link_tokens(email, created_at);
@@ -0,0 +1,5 @@
+-- Drop OIDC tables and their dependencies
+DROP INDEX IF EXISTS idx_oidc_auth_requests_expires_at;
+DROP TABLE IF EXISTS oidc_auth_requests;
+DROP INDEX IF EXISTS idx_user_identities_user_id;
+DROP TABLE IF EXISTS user_identities;
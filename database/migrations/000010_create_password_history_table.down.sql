@@ -0,0 +1,3 @@
+-- Drop password history table and its dependencies
+DROP INDEX IF EXISTS idx_password_history_user_id_created_at;
+DROP TABLE IF EXISTS password_history;
@@ -0,0 +1,21 @@
+-- Create user preferences table
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id VARCHAR(255) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    volume_unit VARCHAR(10) NOT NULL DEFAULT 'ml',
+    weight_unit VARCHAR(10) NOT NULL DEFAULT 'kg',
+    length_unit VARCHAR(10) NOT NULL DEFAULT 'cm',
+    locale VARCHAR(35) NOT NULL DEFAULT 'en',
+    first_day_of_week VARCHAR(10) NOT NULL DEFAULT 'monday',
+    clock_format VARCHAR(3) NOT NULL DEFAULT '24h',
+    notify_email_reminders BOOLEAN NOT NULL DEFAULT TRUE,
+    notify_push_reminders BOOLEAN NOT NULL DEFAULT TRUE,
+    notify_weekly_digest BOOLEAN NOT NULL DEFAULT TRUE,
+    notify_product_updates BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Existing users get the default preferences
+INSERT INTO user_preferences (user_id)
+SELECT id FROM users
+ON CONFLICT (user_id) DO NOTHING;
@@ -0,0 +1,9 @@
+ALTER TABLE children
+DROP COLUMN date_of_birth,
+DROP COLUMN sex,
+DROP COLUMN gestational_age_weeks,
+DROP COLUMN gestational_age_days,
+DROP COLUMN birth_weight_grams,
+DROP COLUMN birth_length_cm,
+DROP COLUMN birth_head_circumference_cm,
+DROP COLUMN photo_url;
@@ -0,0 +1,9 @@
+ALTER TABLE children
+ADD date_of_birth DATE,
+ADD sex VARCHAR(10) NOT NULL DEFAULT '',
+ADD gestational_age_weeks SMALLINT,
+ADD gestational_age_days SMALLINT,
+ADD birth_weight_grams INTEGER,
+ADD birth_length_cm NUMERIC(4, 1),
+ADD birth_head_circumference_cm NUMERIC(4, 1),
+ADD photo_url TEXT NOT NULL DEFAULT '';
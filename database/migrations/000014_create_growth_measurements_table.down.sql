@@ -0,0 +1,2 @@
+-- Drop growth measurements table
+DROP TABLE IF EXISTS growth_measurements;
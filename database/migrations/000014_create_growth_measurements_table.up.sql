@@ -0,0 +1,19 @@
+-- Create growth measurements table
+CREATE TABLE IF NOT EXISTS growth_measurements (
+    id BIGSERIAL PRIMARY KEY,
+    child_id BIGINT NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    measured_on DATE NOT NULL,
+    weight_kg NUMERIC(5, 3),
+    length_cm NUMERIC(4, 1),
+    head_circumference_cm NUMERIC(4, 1),
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT growth_measurements_has_value CHECK (
+        weight_kg IS NOT NULL OR length_cm IS NOT NULL OR head_circumference_cm IS NOT NULL
+    )
+);
+
+-- Create index on child_id and measured_on
+CREATE INDEX IF NOT EXISTS idx_growth_measurements_child_id_measured_on ON growth_measurements(child_id, measured_on);
@@ -0,0 +1,2 @@
+-- Drop milestone achievements table
+DROP TABLE IF EXISTS milestone_achievements;
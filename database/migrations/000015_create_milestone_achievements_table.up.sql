@@ -0,0 +1,12 @@
+-- Create milestone achievements table
+CREATE TABLE IF NOT EXISTS milestone_achievements (
+    id BIGSERIAL PRIMARY KEY,
+    child_id BIGINT NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    milestone_id VARCHAR(64) NOT NULL,
+    achieved_on DATE NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT milestone_achievements_child_milestone_key UNIQUE (child_id, milestone_id)
+);
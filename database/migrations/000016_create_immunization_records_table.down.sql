@@ -0,0 +1,2 @@
+-- Drop immunization records table
+DROP TABLE IF EXISTS immunization_records;
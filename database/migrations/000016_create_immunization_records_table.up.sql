@@ -0,0 +1,15 @@
+-- Create immunization records table
+CREATE TABLE IF NOT EXISTS immunization_records (
+    id BIGSERIAL PRIMARY KEY,
+    child_id BIGINT NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    vaccine_code VARCHAR(64) NOT NULL,
+    dose_number SMALLINT NOT NULL,
+    given_on DATE NOT NULL,
+    lot_number VARCHAR(64) NOT NULL DEFAULT '',
+    provider VARCHAR(255) NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT immunization_records_child_dose_key UNIQUE (child_id, vaccine_code, dose_number)
+);
@@ -0,0 +1,6 @@
+-- Drop the plan reference from activities
+DROP INDEX IF EXISTS idx_activities_medication_plan;
+ALTER TABLE activities DROP COLUMN IF EXISTS medication_plan_id;
+
+-- Drop medication plans table
+DROP TABLE IF EXISTS medication_plans;
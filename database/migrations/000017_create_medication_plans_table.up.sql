@@ -0,0 +1,24 @@
+-- Create medication plans table
+CREATE TABLE IF NOT EXISTS medication_plans (
+    id BIGSERIAL PRIMARY KEY,
+    child_id BIGINT NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    drug_name VARCHAR(255) NOT NULL,
+    dose NUMERIC(10, 3) NOT NULL,
+    unit VARCHAR(20) NOT NULL,
+    interval_minutes INTEGER NOT NULL,
+    max_doses_per_24h SMALLINT NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_medication_plans_child_id ON medication_plans(child_id);
+
+-- Let medicine activities reference the plan they were given under
+ALTER TABLE activities
+    ADD COLUMN IF NOT EXISTS medication_plan_id BIGINT REFERENCES medication_plans(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_activities_medication_plan ON activities(medication_plan_id, happens_at);
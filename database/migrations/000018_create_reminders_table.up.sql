@@ -0,0 +1,26 @@
+-- Create reminders table
+CREATE TABLE IF NOT EXISTS reminders (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    child_id BIGINT REFERENCES children(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    channel VARCHAR(20) NOT NULL,
+    webhook_url TEXT NOT NULL DEFAULT '',
+    fire_at TIMESTAMPTZ,
+    rule VARCHAR(100) NOT NULL DEFAULT '',
+    timezone VARCHAR(64) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMPTZ,
+    last_run_at TIMESTAMPTZ,
+    locked_until TIMESTAMPTZ,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminders_user_id ON reminders(user_id);
+
+-- Due reminders are looked up by the scheduler on every poll
+CREATE INDEX IF NOT EXISTS idx_reminders_due ON reminders(next_run_at) WHERE active;
@@ -0,0 +1,14 @@
+-- Create devices table for push notification tokens
+CREATE TABLE IF NOT EXISTS devices (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    platform VARCHAR(20) NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    token TEXT NOT NULL,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT devices_token_key UNIQUE (token)
+);
+
+CREATE INDEX IF NOT EXISTS idx_devices_user_id ON devices(user_id);
@@ -0,0 +1,2 @@
+-- Drop outbox messages table
+DROP TABLE IF EXISTS outbox_messages;
@@ -0,0 +1,22 @@
+-- Create outbox messages table. Messages are written in the same transaction
+-- as the change that causes them and dispatched by a background worker, so
+-- they are neither lost nor sent for changes that were rolled back.
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id BIGSERIAL PRIMARY KEY,
+    topic VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ,
+    last_attempt_at TIMESTAMPTZ,
+    locked_until TIMESTAMPTZ,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Pending messages are looked up by the dispatcher on every poll
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_due ON outbox_messages(next_attempt_at) WHERE status = 'pending';
+
+-- Dead-lettered messages are listed for admins
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_dead ON outbox_messages(updated_at DESC) WHERE status = 'dead';
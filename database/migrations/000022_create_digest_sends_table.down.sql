@@ -0,0 +1,2 @@
+-- Drop digest sends table
+DROP TABLE IF EXISTS digest_sends;
@@ -0,0 +1,11 @@
+-- Create digest sends table. A row is written for every week handled for a
+-- user, in the same transaction as the queued digest email, so running the
+-- digest job again never emails the same week twice.
+CREATE TABLE IF NOT EXISTS digest_sends (
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    week_start DATE NOT NULL,
+    -- NULL when the week had nothing to report and no email was queued
+    outbox_message_id BIGINT,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, week_start)
+);
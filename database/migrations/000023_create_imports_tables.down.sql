@@ -0,0 +1,3 @@
+-- Drop imports tables
+DROP TABLE IF EXISTS import_rows;
+DROP TABLE IF EXISTS imports;
@@ -0,0 +1,45 @@
+-- Create imports table, one row per export file of another app uploaded for
+-- a child
+CREATE TABLE IF NOT EXISTS imports (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    child_id BIGINT NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    source VARCHAR(50) NOT NULL,
+    filename VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    mapped_rows INTEGER NOT NULL DEFAULT 0,
+    unmapped_rows INTEGER NOT NULL DEFAULT 0,
+    duplicate_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    imported_rows INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    locked_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_imports_child_id ON imports(child_id, created_at DESC);
+
+-- Committed imports are looked up by the import worker on every poll
+CREATE INDEX IF NOT EXISTS idx_imports_queued ON imports(updated_at) WHERE status IN ('queued', 'running');
+
+-- Create import rows table. Rows keep the fields found in the file and the
+-- activity they map to, so that a preview can be reviewed and an interrupted
+-- commit resumed where it stopped.
+CREATE TABLE IF NOT EXISTS import_rows (
+    id BIGSERIAL PRIMARY KEY,
+    import_id BIGINT NOT NULL REFERENCES imports(id) ON DELETE CASCADE,
+    line INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    type VARCHAR(255) NOT NULL DEFAULT '',
+    details JSONB,
+    happens_at TIMESTAMPTZ,
+    reason TEXT NOT NULL DEFAULT '',
+    fields JSONB NOT NULL,
+    activity_id BIGINT
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_rows_import_id ON import_rows(import_id, status, id);
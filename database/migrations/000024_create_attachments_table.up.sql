@@ -0,0 +1,24 @@
+-- Create attachments table. Files are kept in the configured storage under
+-- storage_key; the table only records where they are.
+CREATE TABLE IF NOT EXISTS attachments (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    child_id BIGINT NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+    -- Attachments outlive the activity they were attached to
+    activity_id BIGINT REFERENCES activities(id) ON DELETE SET NULL,
+    kind VARCHAR(20) NOT NULL,
+    filename VARCHAR(255) NOT NULL DEFAULT '',
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    width INTEGER NOT NULL DEFAULT 0,
+    height INTEGER NOT NULL DEFAULT 0,
+    storage_key VARCHAR(512) NOT NULL UNIQUE,
+    thumbnail_key VARCHAR(512) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_child_id ON attachments(child_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_attachments_activity_id ON attachments(activity_id) WHERE activity_id IS NOT NULL;
+
+-- A child has at most one profile photo
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attachments_child_photo ON attachments(child_id) WHERE kind = 'photo';
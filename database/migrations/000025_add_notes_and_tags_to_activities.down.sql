@@ -0,0 +1,6 @@
+-- Drop activity tags and notes
+DROP TABLE IF EXISTS activity_tags;
+DROP TABLE IF EXISTS tags;
+DROP INDEX IF EXISTS idx_activities_search_vector;
+ALTER TABLE activities DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE activities DROP COLUMN IF EXISTS notes;
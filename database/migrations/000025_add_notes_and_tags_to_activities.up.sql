@@ -0,0 +1,36 @@
+-- Free-text notes on activities
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS notes TEXT NOT NULL DEFAULT '';
+
+-- Full-text search over the notes and the free-text details fields. The
+-- 'simple' configuration does not stem, so notes in any language match
+-- word for word. Notes rank above details.
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', notes), 'A') ||
+    setweight(to_tsvector('simple',
+        coalesce(details->>'notes', '') || ' ' ||
+        coalesce(details->>'name', '') || ' ' ||
+        coalesce(details->>'food', '') || ' ' ||
+        coalesce(details->>'medicine', '') || ' ' ||
+        coalesce(details->>'place', '')
+    ), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_activities_search_vector ON activities USING GIN (search_vector);
+
+-- Create tags table; every user has their own tags
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (user_id, name)
+);
+
+-- Create activity tags join table
+CREATE TABLE IF NOT EXISTS activity_tags (
+    activity_id BIGINT NOT NULL REFERENCES activities(id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (activity_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_activity_tags_tag_id ON activity_tags(tag_id);
@@ -0,0 +1,2 @@
+-- Drop the reminder webhook secret
+ALTER TABLE reminders DROP COLUMN IF EXISTS webhook_secret;
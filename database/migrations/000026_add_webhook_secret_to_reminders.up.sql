@@ -0,0 +1,7 @@
+-- Secret signing the webhook deliveries of a reminder
+ALTER TABLE reminders ADD COLUMN IF NOT EXISTS webhook_secret VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Existing webhook reminders get a random secret, shown with the reminder
+UPDATE reminders
+SET webhook_secret = 'whsec_' || replace(gen_random_uuid()::text, '-', '') || replace(gen_random_uuid()::text, '-', '')
+WHERE channel = 'webhook' AND webhook_secret = '';
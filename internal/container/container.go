@@ -1,21 +1,69 @@
 package container
 
 import (
+	"context"
 	"dailyalu-server/internal/handler/api"
 	"dailyalu-server/internal/middleware"
+	activityDomain "dailyalu-server/internal/module/activity/domain"
 	activityRepo "dailyalu-server/internal/module/activity/repository"
 	activityUseCase "dailyalu-server/internal/module/activity/usecase"
+	attachmentRepo "dailyalu-server/internal/module/attachment/repository"
+	attachmentUseCase "dailyalu-server/internal/module/attachment/usecase"
 	childrenRepo "dailyalu-server/internal/module/children/repository"
 	childrenUseCase "dailyalu-server/internal/module/children/usecase"
+	deviceRepo "dailyalu-server/internal/module/device/repository"
+	deviceUseCase "dailyalu-server/internal/module/device/usecase"
+	digestRepo "dailyalu-server/internal/module/digest/repository"
+	digestScheduler "dailyalu-server/internal/module/digest/scheduler"
+	growthRepo "dailyalu-server/internal/module/growth/repository"
+	growthUseCase "dailyalu-server/internal/module/growth/usecase"
+	immunizationRepo "dailyalu-server/internal/module/immunization/repository"
+	"dailyalu-server/internal/module/immunization/schedule"
+	immunizationUseCase "dailyalu-server/internal/module/immunization/usecase"
+	importRepo "dailyalu-server/internal/module/importer/repository"
+	importUseCase "dailyalu-server/internal/module/importer/usecase"
+	importWorker "dailyalu-server/internal/module/importer/worker"
+	medicationDomain "dailyalu-server/internal/module/medication/domain"
+	medicationRepo "dailyalu-server/internal/module/medication/repository"
+	medicationUseCase "dailyalu-server/internal/module/medication/usecase"
+	milestoneRepo "dailyalu-server/internal/module/milestone/repository"
+	milestoneUseCase "dailyalu-server/internal/module/milestone/usecase"
+	outboxDispatcher "dailyalu-server/internal/module/outbox/dispatcher"
+	outboxDomain "dailyalu-server/internal/module/outbox/domain"
+	outboxRepo "dailyalu-server/internal/module/outbox/repository"
+	outboxUseCase "dailyalu-server/internal/module/outbox/usecase"
+	reminderRepo "dailyalu-server/internal/module/reminder/repository"
+	"dailyalu-server/internal/module/reminder/scheduler"
+	reminderUseCase "dailyalu-server/internal/module/reminder/usecase"
+	reportDomain "dailyalu-server/internal/module/report/domain"
+	reportUseCase "dailyalu-server/internal/module/report/usecase"
+	userDomain "dailyalu-server/internal/module/user/domain"
 	"dailyalu-server/internal/module/user/repository"
 	"dailyalu-server/internal/module/user/usecase"
+	"dailyalu-server/internal/module/webhook/dispatcher"
+	webhookRepo "dailyalu-server/internal/module/webhook/repository"
+	webhookUseCase "dailyalu-server/internal/module/webhook/usecase"
 	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/security/oidc"
+	"dailyalu-server/internal/security/outbound"
+	"dailyalu-server/internal/security/password"
 	"dailyalu-server/internal/security/token"
-	"dailyalu-server/internal/service/mailer"
 	mailerDomain "dailyalu-server/internal/service/mailer/domain"
-	"dailyalu-server/pkg/mailer/smtp"
+	"dailyalu-server/internal/service/notifier"
+	notifierDomain "dailyalu-server/internal/service/notifier/domain"
+	"dailyalu-server/internal/service/notifier/push"
+	realtimeDomain "dailyalu-server/internal/service/realtime/domain"
+	"dailyalu-server/internal/service/storage"
+	storageDomain "dailyalu-server/internal/service/storage/domain"
+	"dailyalu-server/internal/utils"
+	"dailyalu-server/pkg/app_log/zap_log"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
+
+	"go.uber.org/zap"
 )
 
 // Container holds all the dependencies for the application
@@ -25,71 +73,328 @@ type Container struct {
 
 	// Managers
 	jwtManager *jwt.JWTManager
+	urlPolicy  outbound.Policy
 
 	// Repositories
-	userRepository     repository.IUserRepository
-	activityRepository activityRepo.IActivityRepository
-	childrenRepository childrenRepo.IChildrenRepository
+	userRepository         repository.IUserRepository
+	identityRepository     repository.IIdentityRepository
+	preferencesRepository  repository.IPreferencesRepository
+	activityRepository     activityRepo.IActivityRepository
+	childrenRepository     childrenRepo.IChildrenRepository
+	growthRepository       growthRepo.IGrowthRepository
+	milestoneRepository    milestoneRepo.IMilestoneRepository
+	immunizationRepository immunizationRepo.IImmunizationRepository
+	medicationRepository   medicationRepo.IMedicationRepository
+	reminderRepository     reminderRepo.IReminderRepository
+	deviceRepository       deviceRepo.IDeviceRepository
+	webhookRepository      webhookRepo.IWebhookRepository
+	outboxRepository       outboxRepo.IOutboxRepository
+	digestRepository       digestRepo.IDigestRepository
+	importRepository       importRepo.IImportRepository
+	attachmentRepository   attachmentRepo.IAttachmentRepository
 
 	// Use Cases
-	userUseCase     usecase.IUserUseCase
-	activityUseCase activityUseCase.IActivityUseCase
-	childrenUseCase childrenUseCase.IChildrenUseCase
+	userUseCase         usecase.IUserUseCase
+	socialLoginUseCase  usecase.ISocialLoginUseCase
+	preferencesUseCase  usecase.IPreferencesUseCase
+	activityUseCase     activityUseCase.IActivityUseCase
+	childrenUseCase     childrenUseCase.IChildrenUseCase
+	growthUseCase       growthUseCase.IGrowthUseCase
+	milestoneUseCase    milestoneUseCase.IMilestoneUseCase
+	immunizationUseCase immunizationUseCase.IImmunizationUseCase
+	medicationUseCase   medicationUseCase.IMedicationUseCase
+	reminderUseCase     reminderUseCase.IReminderUseCase
+	deviceUseCase       deviceUseCase.IDeviceUseCase
+	webhookUseCase      webhookUseCase.IWebhookUseCase
+	outboxUseCase       outboxUseCase.IOutboxUseCase
+	reportUseCase       reportUseCase.IReportUseCase
+	importUseCase       importUseCase.IImportUseCase
+	attachmentUseCase   attachmentUseCase.IAttachmentUseCase
 
 	// Handlers
-	userHandler     *api.UserHandler
-	activityHandler *api.ActivityHandler
-	childrenHandler *api.ChildrenHandler
+	userHandler         *api.UserHandler
+	activityHandler     *api.ActivityHandler
+	childrenHandler     *api.ChildrenHandler
+	growthHandler       *api.GrowthHandler
+	milestoneHandler    *api.MilestoneHandler
+	immunizationHandler *api.ImmunizationHandler
+	medicationHandler   *api.MedicationHandler
+	reminderHandler     *api.ReminderHandler
+	deviceHandler       *api.DeviceHandler
+	realtimeHandler     *api.RealtimeHandler
+	webhookHandler      *api.WebhookHandler
+	outboxHandler       *api.OutboxHandler
+	reportHandler       *api.ReportHandler
+	importHandler       *api.ImportHandler
+	attachmentHandler   *api.AttachmentHandler
+	fileHandler         *api.FileHandler
 
 	// Middleware
 	securityMiddleware *middleware.SecurityMiddleware
 	errorMiddleware    *middleware.ErrorMiddleware
+	timezoneMiddleware *middleware.TimezoneMiddleware
 
 	//Token Verification Service
 	tokenService *token.TokenService
 
 	//Mailer Service
 	mailerService mailerDomain.IMailerService
+
+	// Notifiers by delivery channel
+	notifiers map[string]notifierDomain.INotifier
+
+	// Live event streams
+	realtimeBroker realtimeDomain.IBroker
+
+	// Uploaded files
+	fileStorage storageDomain.IStorage
+
+	// Background workers
+	reminderScheduler *scheduler.Scheduler
+	webhookDispatcher *dispatcher.Dispatcher
+	outboxDispatcher  *outboxDispatcher.Dispatcher
+	digestScheduler   *digestScheduler.Scheduler
+	importWorker      *importWorker.Worker
+
+	// External identity providers
+	oidcProviders *oidc.Providers
 }
 
 // NewContainer creates a new dependency injection container
-func NewContainer(db *sql.DB, smtp *smtp.Smtp, jwtSecret, jwtRefreshSecretKey string, jwtExpiry, jwtRefreshExpiry time.Duration) *Container {
+func NewContainer(db *sql.DB, mailerService mailerDomain.IMailerService, jwtSecret, jwtRefreshSecretKey string, jwtExpiry, jwtRefreshExpiry time.Duration, defaultLocation *time.Location, immunizationSchedule *schedule.Schedule, pushSender *push.Sender, realtimeBroker realtimeDomain.IBroker, fileStorage storageDomain.IStorage) *Container {
 	c := &Container{
-		db: db,
+		db:             db,
+		mailerService:  mailerService,
+		realtimeBroker: realtimeBroker,
+		fileStorage:    fileStorage,
 	}
 
 	// Initialize JWT manager
 	c.jwtManager = jwt.NewJWTManager(jwtSecret, jwtRefreshSecretKey, jwtExpiry, jwtRefreshExpiry)
 
-	// Initialize mailer
-	c.mailerService = mailer.NewSmtpMailerService(smtp)
+	// Requests to user supplied URLs stay off local and private networks
+	c.urlPolicy = outbound.NewPolicyFromConfig()
+
+	// Initialize notifiers
+	c.notifiers = map[string]notifierDomain.INotifier{
+		notifierDomain.ChannelEmail:   notifier.NewEmailNotifier(c.mailerService),
+		notifierDomain.ChannelWebhook: notifier.NewWebhookNotifierFromConfig(c.urlPolicy),
+	}
+	if pushSender.Enabled() {
+		c.notifiers[notifierDomain.ChannelPush] = notifier.NewPushNotifier(pushSender, c.listPushDevices, c.removePushDevice)
+	}
 
 	// Initialize repositories
 	c.userRepository = repository.NewPostgresUserRepository(db)
+	c.identityRepository = repository.NewPostgresIdentityRepository(db)
+	c.preferencesRepository = repository.NewPostgresPreferencesRepository(db)
 	c.activityRepository = activityRepo.NewActivityRepository(db)
 	c.childrenRepository = childrenRepo.NewPostgresChildrenRepository(db)
+	c.growthRepository = growthRepo.NewPostgresGrowthRepository(db)
+	c.milestoneRepository = milestoneRepo.NewPostgresMilestoneRepository(db)
+	c.immunizationRepository = immunizationRepo.NewPostgresImmunizationRepository(db)
+	c.medicationRepository = medicationRepo.NewPostgresMedicationRepository(db)
+	c.reminderRepository = reminderRepo.NewPostgresReminderRepository(db)
+	c.deviceRepository = deviceRepo.NewPostgresDeviceRepository(db)
+	c.webhookRepository = webhookRepo.NewPostgresWebhookRepository(db)
+	c.outboxRepository = outboxRepo.NewPostgresOutboxRepository(db)
+	c.digestRepository = digestRepo.NewPostgresDigestRepository(db)
+	c.importRepository = importRepo.NewPostgresImportRepository(db)
+	c.attachmentRepository = attachmentRepo.NewPostgresAttachmentRepository(db)
 
 	c.tokenService = token.NewTokenService()
+	c.oidcProviders = oidc.NewProvidersFromConfig()
 
 	// Initialize use cases
-	c.userUseCase = usecase.NewUserUseCase(c.userRepository, c.jwtManager, c.tokenService, c.mailerService)
-	c.activityUseCase = activityUseCase.NewActivityUseCase(c.activityRepository)
+	c.userUseCase = usecase.NewUserUseCase(c.userRepository, c.preferencesRepository, c.jwtManager, c.tokenService, c.mailerService, password.NewPolicyFromConfig(), c.publishUserEvent)
+	c.socialLoginUseCase = usecase.NewSocialLoginUseCase(c.userRepository, c.identityRepository, c.oidcProviders, c.jwtManager)
+	c.preferencesUseCase = usecase.NewPreferencesUseCase(c.preferencesRepository)
+	c.activityUseCase = activityUseCase.NewActivityUseCase(c.activityRepository, c.resolveUnitPreferences, c.checkMedicationDose, c.publishActivityEvent)
 	c.childrenUseCase = childrenUseCase.NewChildrenUseCase(c.childrenRepository)
+	c.growthUseCase = growthUseCase.NewGrowthUseCase(c.growthRepository, c.childrenUseCase)
+	c.milestoneUseCase = milestoneUseCase.NewMilestoneUseCase(c.milestoneRepository, c.childrenUseCase)
+	c.immunizationUseCase = immunizationUseCase.NewImmunizationUseCase(c.immunizationRepository, c.childrenUseCase, immunizationSchedule)
+	c.medicationUseCase = medicationUseCase.NewMedicationUseCase(c.medicationRepository, c.childrenUseCase)
+	c.reminderUseCase = reminderUseCase.NewReminderUseCase(c.reminderRepository, c.childrenUseCase, c.notificationChannels(), c.urlPolicy)
+	c.deviceUseCase = deviceUseCase.NewDeviceUseCase(c.deviceRepository)
+	c.webhookUseCase = webhookUseCase.NewWebhookUseCase(c.webhookRepository, c.urlPolicy)
+	c.outboxUseCase = outboxUseCase.NewOutboxUseCase(c.outboxRepository)
+	c.reportUseCase = reportUseCase.NewReportUseCase(c.activityRepository, c.childrenUseCase, c.growthUseCase, c.mailerService, c.outboxRepository, c.resolveRecipient)
+	c.importUseCase = importUseCase.NewImportUseCase(c.importRepository, c.childrenUseCase)
+	c.attachmentUseCase = attachmentUseCase.NewAttachmentUseCase(c.attachmentRepository, c.activityRepository, c.childrenUseCase, c.fileStorage, attachmentUseCase.NewConfigFromConfig())
 
 	// Initialize handlers
-	c.userHandler = api.NewUserHandler(c.userUseCase)
+	c.userHandler = api.NewUserHandler(c.userUseCase, c.socialLoginUseCase, c.preferencesUseCase)
 	c.activityHandler = api.NewActivityHandler(c.activityUseCase)
-	c.childrenHandler = api.NewChildrenHandler(c.childrenUseCase)
+	c.childrenHandler = api.NewChildrenHandler(c.childrenUseCase, c.attachmentUseCase.PhotoURLs)
+	c.growthHandler = api.NewGrowthHandler(c.growthUseCase)
+	c.milestoneHandler = api.NewMilestoneHandler(c.milestoneUseCase)
+	c.immunizationHandler = api.NewImmunizationHandler(c.immunizationUseCase)
+	c.medicationHandler = api.NewMedicationHandler(c.medicationUseCase)
+	c.reminderHandler = api.NewReminderHandler(c.reminderUseCase)
+	c.deviceHandler = api.NewDeviceHandler(c.deviceUseCase)
+	c.realtimeHandler = api.NewRealtimeHandler(c.realtimeBroker, c.canAccessChild)
+	c.webhookHandler = api.NewWebhookHandler(c.webhookUseCase)
+	c.outboxHandler = api.NewOutboxHandler(c.outboxUseCase)
+	c.reportHandler = api.NewReportHandler(c.reportUseCase)
+	c.importHandler = api.NewImportHandler(c.importUseCase)
+	c.attachmentHandler = api.NewAttachmentHandler(c.attachmentUseCase)
+	// Files on the local disk are downloaded through the API
+	if localStorage, ok := c.fileStorage.(*storage.LocalStorage); ok {
+		c.fileHandler = api.NewFileHandler(localStorage)
+	}
+
+	// Initialize background workers
+	c.reminderScheduler = scheduler.NewScheduler(c.reminderRepository, c.notifiers, c.resolveRecipient, scheduler.NewConfigFromConfig())
+	c.webhookDispatcher = dispatcher.NewDispatcher(c.webhookRepository, c.urlPolicy, dispatcher.NewConfigFromConfig())
+	outboxHandlers := outboxDispatcher.MailHandlers(c.mailerService)
+	outboxHandlers[outboxDomain.TopicReportEmail] = c.sendReportEmail
+	c.outboxDispatcher = outboxDispatcher.NewDispatcher(c.outboxRepository, outboxHandlers, outboxDispatcher.NewConfigFromConfig())
+	c.digestScheduler = digestScheduler.NewScheduler(c.digestRepository, defaultLocation, digestScheduler.NewConfigFromConfig())
+	c.importWorker = importWorker.NewWorker(c.importRepository, importWorker.NewConfigFromConfig())
 
 	// Initialize middleware
 	c.securityMiddleware = middleware.NewSecurityMiddleware(middleware.SecurityConfig{
 		JWTManager: c.jwtManager,
 	})
 	c.errorMiddleware = middleware.NewErrorMiddleware()
+	c.timezoneMiddleware = middleware.NewTimezoneMiddleware(c.resolveUserTimezone, defaultLocation)
 
 	return c
 }
 
+// resolveUserTimezone returns the timezone saved on the user's profile
+func (c *Container) resolveUserTimezone(userID string) (string, error) {
+	user, err := c.userRepository.GetByID(userID)
+	if err != nil || user == nil {
+		return "", err
+	}
+	return user.Timezone, nil
+}
+
+// resolveUnitPreferences returns the measurement units the user prefers
+func (c *Container) resolveUnitPreferences(userID string) (utils.UnitTargets, error) {
+	preferences, err := c.preferencesUseCase.Get(context.Background(), userID)
+	if err != nil {
+		return utils.UnitTargets{}, err
+	}
+	return utils.UnitTargets{
+		Volume: preferences.VolumeUnit,
+		Weight: preferences.WeightUnit,
+		Length: preferences.LengthUnit,
+	}, nil
+}
+
+// checkMedicationDose validates a medicine dose against its medication plan
+func (c *Container) checkMedicationDose(ctx context.Context, req *medicationDomain.DoseCheckRequest) ([]string, error) {
+	return c.medicationUseCase.CheckDose(ctx, req)
+}
+
+// publishActivityEvent streams an activity change to live clients and
+// queues it for the owner's webhooks. Failing to publish does not fail the
+// change itself.
+func (c *Container) publishActivityEvent(ctx context.Context, event string, activity *activityDomain.Activity) {
+	data, err := json.Marshal(activity)
+	if err == nil {
+		err = c.realtimeBroker.Publish(ctx, &realtimeDomain.Event{
+			Type:       event,
+			ChildID:    int64(activity.ChildID),
+			Data:       data,
+			OccurredAt: time.Now().UTC(),
+		})
+	}
+	if err != nil && zap_log.Logger != nil {
+		zap_log.Logger.Warn("Failed to publish activity event", zap.String("event", event), zap.Error(err))
+	}
+
+	c.publishWebhookEvent(ctx, activity.UserID, event, activity)
+}
+
+// publishUserEvent queues a user change for the user's webhooks
+func (c *Container) publishUserEvent(ctx context.Context, event string, user *userDomain.User) {
+	c.publishWebhookEvent(ctx, user.ID, event, user)
+}
+
+// publishWebhookEvent queues an event for the user's webhooks, logging
+// instead of failing the change it describes
+func (c *Container) publishWebhookEvent(ctx context.Context, userID, event string, data interface{}) {
+	if err := c.webhookUseCase.Publish(ctx, userID, event, data); err != nil && zap_log.Logger != nil {
+		zap_log.Logger.Warn("Failed to queue webhook event", zap.String("event", event), zap.Error(err))
+	}
+}
+
+// sendReportEmail handles a queued report email. Jobs for a child that was
+// deleted or is no longer accessible are dead-lettered.
+func (c *Container) sendReportEmail(ctx context.Context, message *outboxDomain.Message) error {
+	var job reportDomain.ReportEmailJob
+	if err := json.Unmarshal(message.Payload, &job); err != nil {
+		return fmt.Errorf("%w: invalid report email payload: %v", outboxDispatcher.ErrPermanent, err)
+	}
+
+	err := c.reportUseCase.SendEmail(ctx, &job)
+	if errors.Is(err, childrenUseCase.ErrChildNotFound) || errors.Is(err, childrenUseCase.ErrUnauthorizedAccess) {
+		return fmt.Errorf("%w: %v", outboxDispatcher.ErrPermanent, err)
+	}
+	return err
+}
+
+// canAccessChild checks that the user may see the child's live events
+func (c *Container) canAccessChild(childID int64, userID string) error {
+	_, err := c.childrenUseCase.GetChild(childID, userID)
+	return err
+}
+
+// resolveRecipient returns the contact details notifications are sent to
+func (c *Container) resolveRecipient(userID string) (*notifierDomain.Recipient, error) {
+	user, err := c.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user %s not found", userID)
+	}
+	recipient := &notifierDomain.Recipient{
+		UserID: user.ID,
+		Email:  user.Email,
+		Name:   user.Name,
+	}
+	if preferences, err := c.preferencesUseCase.Get(context.Background(), userID); err == nil {
+		recipient.Locale = preferences.Locale
+		recipient.RemindersOff = map[string]bool{
+			notifierDomain.ChannelEmail: !preferences.Notifications.EmailReminders,
+			notifierDomain.ChannelPush:  !preferences.Notifications.PushReminders,
+		}
+	}
+	return recipient, nil
+}
+
+// listPushDevices returns the push tokens registered by the user
+func (c *Container) listPushDevices(userID string) ([]push.Device, error) {
+	devices, err := c.deviceRepository.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	pushDevices := make([]push.Device, len(devices))
+	for i, device := range devices {
+		pushDevices[i] = push.Device{Token: device.Token, Provider: device.Provider}
+	}
+	return pushDevices, nil
+}
+
+// removePushDevice unregisters a token the push provider rejected
+func (c *Container) removePushDevice(token string) error {
+	return c.deviceRepository.DeleteByToken(token)
+}
+
+// notificationChannels returns the channels notifications can be sent through
+func (c *Container) notificationChannels() []string {
+	channels := make([]string, 0, len(c.notifiers))
+	for channel := range c.notifiers {
+		channels = append(channels, channel)
+	}
+	return channels
+}
+
 // GetUserHandler returns the user handler
 func (c *Container) GetUserHandler() *api.UserHandler {
 	return c.userHandler
@@ -105,6 +410,97 @@ func (c *Container) GetChildrenHandler() *api.ChildrenHandler {
 	return c.childrenHandler
 }
 
+// GetGrowthHandler returns the growth handler
+func (c *Container) GetGrowthHandler() *api.GrowthHandler {
+	return c.growthHandler
+}
+
+// GetReportHandler returns the report handler
+func (c *Container) GetReportHandler() *api.ReportHandler {
+	return c.reportHandler
+}
+
+// GetImportHandler returns the import handler
+func (c *Container) GetImportHandler() *api.ImportHandler {
+	return c.importHandler
+}
+
+// GetAttachmentHandler returns the attachment handler
+func (c *Container) GetAttachmentHandler() *api.AttachmentHandler {
+	return c.attachmentHandler
+}
+
+// GetFileHandler returns the handler serving local files, or nil when files
+// are kept in S3
+func (c *Container) GetFileHandler() *api.FileHandler {
+	return c.fileHandler
+}
+
+// GetMilestoneHandler returns the milestone handler
+func (c *Container) GetMilestoneHandler() *api.MilestoneHandler {
+	return c.milestoneHandler
+}
+
+// GetImmunizationHandler returns the immunization handler
+func (c *Container) GetImmunizationHandler() *api.ImmunizationHandler {
+	return c.immunizationHandler
+}
+
+// GetMedicationHandler returns the medication handler
+func (c *Container) GetMedicationHandler() *api.MedicationHandler {
+	return c.medicationHandler
+}
+
+// GetReminderHandler returns the reminder handler
+func (c *Container) GetReminderHandler() *api.ReminderHandler {
+	return c.reminderHandler
+}
+
+// GetDeviceHandler returns the device handler
+func (c *Container) GetDeviceHandler() *api.DeviceHandler {
+	return c.deviceHandler
+}
+
+// GetRealtimeHandler returns the realtime handler
+func (c *Container) GetRealtimeHandler() *api.RealtimeHandler {
+	return c.realtimeHandler
+}
+
+// GetWebhookHandler returns the webhook handler
+func (c *Container) GetWebhookHandler() *api.WebhookHandler {
+	return c.webhookHandler
+}
+
+// GetReminderScheduler returns the reminder scheduler
+func (c *Container) GetReminderScheduler() *scheduler.Scheduler {
+	return c.reminderScheduler
+}
+
+// GetWebhookDispatcher returns the webhook dispatcher
+func (c *Container) GetWebhookDispatcher() *dispatcher.Dispatcher {
+	return c.webhookDispatcher
+}
+
+// GetOutboxHandler returns the outbox handler
+func (c *Container) GetOutboxHandler() *api.OutboxHandler {
+	return c.outboxHandler
+}
+
+// GetOutboxDispatcher returns the outbox dispatcher
+func (c *Container) GetOutboxDispatcher() *outboxDispatcher.Dispatcher {
+	return c.outboxDispatcher
+}
+
+// GetDigestScheduler returns the weekly digest scheduler
+func (c *Container) GetDigestScheduler() *digestScheduler.Scheduler {
+	return c.digestScheduler
+}
+
+// GetImportWorker returns the import worker
+func (c *Container) GetImportWorker() *importWorker.Worker {
+	return c.importWorker
+}
+
 // GetSecurityMiddleware returns the security middleware
 func (c *Container) GetSecurityMiddleware() *middleware.SecurityMiddleware {
 	return c.securityMiddleware
@@ -115,8 +511,16 @@ func (c *Container) GetErrorMiddleware() *middleware.ErrorMiddleware {
 	return c.errorMiddleware
 }
 
+// GetTimezoneMiddleware returns the timezone middleware
+func (c *Container) GetTimezoneMiddleware() *middleware.TimezoneMiddleware {
+	return c.timezoneMiddleware
+}
+
 // Close closes any resources held by the container
 func (c *Container) Close() error {
+	if c.realtimeBroker != nil {
+		c.realtimeBroker.Close()
+	}
 	if c.db != nil {
 		return c.db.Close()
 	}
@@ -1,17 +1,23 @@
 package api
 
 import (
+	"bufio"
+	"context"
 	"dailyalu-server/internal/module/activity/domain"
+	"dailyalu-server/internal/module/activity/export"
 	"dailyalu-server/internal/module/activity/usecase"
 	"dailyalu-server/internal/utils"
 	"dailyalu-server/internal/validator"
+	"dailyalu-server/pkg/app_log/zap_log"
 	"dailyalu-server/pkg/response"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
 type ActivityHandler struct {
@@ -108,27 +114,94 @@ func (h *ActivityHandler) Delete(c *fiber.Ctx) error {
 }
 
 func (h *ActivityHandler) Search(c *fiber.Ctx) error {
+	req, err := searchRequestFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	// Get search results
+	activityResponse, err := h.activityUseCase.Search(c.Context(), req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	// Create pagination from the response
+	pagination := response.NewPagination(
+		activityResponse.Pagination.Total, 
+		activityResponse.Pagination.PageSize, 
+		activityResponse.Pagination.CurrentPage,
+	)
+
+	// Return paginated response
+	return response.SuccessWithPagination(
+		c, 
+		fiber.StatusOK, 
+		"Activities retrieved successfully", 
+		activityResponse.Activities, 
+		pagination,
+	)
+}
+
+// Export handles downloading the activities matching the search filters as
+// CSV, JSON or iCalendar. Activities are streamed as they are read.
+func (h *ActivityHandler) Export(c *fiber.Ctx) error {
+	req, err := searchRequestFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	format := c.Query("format", domain.ExportFormatCSV)
+	if !slices.Contains(domain.ExportFormats, format) {
+		return response.MapDomainError(usecase.ErrInvalidExportFormat)
+	}
+
+	// The body is written after the handler returns, so the export gets a
+	// context of its own carrying the user's time zone
+	ctx := utils.WithLocation(context.Background(), utils.LocationFromContext(c.Context()))
+
+	c.Set(fiber.HeaderContentType, export.ContentType(format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="activities.%s"`, format))
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.activityUseCase.Export(ctx, req, format, w); err != nil && zap_log.Logger != nil {
+			// Headers are already sent, the download ends early
+			zap_log.Logger.Error("failed to export activities", zap.String("user_id", req.UserID), zap.Error(err))
+		}
+	})
+
+	return nil
+}
+
+// searchRequestFromQuery reads the search filters shared by search and export
+func searchRequestFromQuery(c *fiber.Ctx) (*domain.SearchActivityRequest, error) {
 	userID := utils.GetUserIDFromContext(c)
 	req := &domain.SearchActivityRequest{
 		UserID:   userID,
+		ChildID:  c.QueryInt("child_id"),
 		Type:     c.Query("type"),
 		Page:     c.QueryInt("page", 1),
 		PageSize: c.QueryInt("page_size", 10),
+		Query:    c.Query("q"),
+	}
+
+	// Tags are given comma separated
+	if tags := c.Query("tags"); tags != "" {
+		req.Tags = strings.Split(tags, ",")
 	}
 
 	// Parse dates if provided
 	if startDate := c.Query("start_date"); startDate != "" {
-		date, err := time.Parse(time.RFC3339, startDate)
+		date, err := utils.DateRangeParsing(c.Context(), startDate, false)
 		if err != nil {
-			return response.NewBadRequestError("Invalid start_date format")
+			return nil, response.NewBadRequestError("Invalid start_date format")
 		}
 		req.StartDate = date
 	}
 
 	if endDate := c.Query("end_date"); endDate != "" {
-		date, err := time.Parse(time.RFC3339, endDate)
+		date, err := utils.DateRangeParsing(c.Context(), endDate, true)
 		if err != nil {
-			return response.NewBadRequestError("Invalid end_date format")
+			return nil, response.NewBadRequestError("Invalid end_date format")
 		}
 		req.EndDate = date
 	}
@@ -137,30 +210,10 @@ func (h *ActivityHandler) Search(c *fiber.Ctx) error {
 	if details := c.Query("details"); details != "" {
 		var detailsMap map[string]interface{}
 		if err := json.Unmarshal([]byte(details), &detailsMap); err != nil {
-			return response.NewBadRequestError("Invalid details format")
+			return nil, response.NewBadRequestError("Invalid details format")
 		}
 		req.Details = detailsMap
 	}
 
-	// Get search results
-	activityResponse, err := h.activityUseCase.Search(c.Context(), req)
-	if err != nil {
-		return response.MapDomainError(err)
-	}
-
-	// Create pagination from the response
-	pagination := response.NewPagination(
-		activityResponse.Pagination.Total, 
-		activityResponse.Pagination.PageSize, 
-		activityResponse.Pagination.CurrentPage,
-	)
-
-	// Return paginated response
-	return response.SuccessWithPagination(
-		c, 
-		fiber.StatusOK, 
-		"Activities retrieved successfully", 
-		activityResponse.Activities, 
-		pagination,
-	)
+	return req, nil
 }
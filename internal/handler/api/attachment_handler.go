@@ -0,0 +1,244 @@
+package api
+
+import (
+	"dailyalu-server/internal/module/attachment/domain"
+	"dailyalu-server/internal/module/attachment/usecase"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/pkg/response"
+	"io"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AttachmentHandler handles HTTP requests for the photos and documents of
+// children
+type AttachmentHandler struct {
+	attachmentUseCase usecase.IAttachmentUseCase
+}
+
+// NewAttachmentHandler creates a new attachment handler
+func NewAttachmentHandler(attachmentUseCase usecase.IAttachmentUseCase) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentUseCase: attachmentUseCase,
+	}
+}
+
+// Upload handles uploading a file for a child, optionally attached to one of
+// its activities
+func (h *AttachmentHandler) Upload(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	req := &domain.UploadRequest{
+		UserID:  userID,
+		ChildID: childID,
+	}
+
+	if value := c.FormValue("activity_id"); value != "" {
+		activityID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return response.NewBadRequestError("Invalid activity ID")
+		}
+		req.ActivityID = &activityID
+	}
+
+	if req.Filename, req.Content, err = readUpload(c); err != nil {
+		return err
+	}
+
+	attachment, err := h.attachmentUseCase.Upload(c.Context(), req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "Attachment uploaded successfully", attachment)
+}
+
+// GetAttachments handles listing the attachments of a child, or of one of
+// its activities
+func (h *AttachmentHandler) GetAttachments(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	req := &domain.GetAttachmentsRequest{
+		UserID:  userID,
+		ChildID: childID,
+	}
+
+	if value := c.Query("activity_id"); value != "" {
+		activityID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return response.NewBadRequestError("Invalid activity ID")
+		}
+		req.ActivityID = &activityID
+	}
+
+	attachments, err := h.attachmentUseCase.GetAttachments(c.Context(), req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Attachments retrieved successfully", attachments)
+}
+
+// GetAttachment handles retrieving an attachment with fresh download URLs
+func (h *AttachmentHandler) GetAttachment(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, id, err := parseAttachmentParams(c)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := h.attachmentUseCase.GetAttachment(c.Context(), childID, id, userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Attachment retrieved successfully", attachment)
+}
+
+// DeleteAttachment handles removing an attachment and its files
+func (h *AttachmentHandler) DeleteAttachment(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, id, err := parseAttachmentParams(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.attachmentUseCase.DeleteAttachment(c.Context(), childID, id, userID); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Attachment deleted successfully", nil)
+}
+
+// SetPhoto handles uploading the profile photo of a child
+func (h *AttachmentHandler) SetPhoto(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	req := &domain.UploadRequest{
+		UserID:  userID,
+		ChildID: childID,
+	}
+
+	if req.Filename, req.Content, err = readUpload(c); err != nil {
+		return err
+	}
+
+	photo, err := h.attachmentUseCase.SetPhoto(c.Context(), req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Profile photo updated successfully", photo)
+}
+
+// GetPhoto handles retrieving the profile photo of a child
+func (h *AttachmentHandler) GetPhoto(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	photo, err := h.attachmentUseCase.GetPhoto(c.Context(), childID, userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Profile photo retrieved successfully", photo)
+}
+
+// DeletePhoto handles removing the profile photo of a child
+func (h *AttachmentHandler) DeletePhoto(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	if err := h.attachmentUseCase.DeletePhoto(c.Context(), childID, userID); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Profile photo deleted successfully", nil)
+}
+
+// readUpload reads the file uploaded as the "file" form field
+func readUpload(c *fiber.Ctx) (string, []byte, error) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return "", nil, response.NewBadRequestError("Upload the file as the \"file\" form field")
+	}
+	content, err := file.Open()
+	if err != nil {
+		return "", nil, response.NewBadRequestError("Invalid file")
+	}
+	defer content.Close()
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return "", nil, response.NewBadRequestError("Invalid file")
+	}
+	return file.Filename, data, nil
+}
+
+func parseAttachmentParams(c *fiber.Ctx) (int64, int64, error) {
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return 0, 0, response.NewBadRequestError("Invalid child ID")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return 0, 0, response.NewBadRequestError("Invalid attachment ID")
+	}
+
+	return childID, id, nil
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"dailyalu-server/internal/module/children/domain"
 	"dailyalu-server/internal/module/children/usecase"
 	"dailyalu-server/internal/security/jwt"
@@ -11,15 +12,21 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// ChildPhotoURLs returns links to the profile photos of the user's
+// children, by child ID
+type ChildPhotoURLs func(ctx context.Context, userID string, childIDs []int64) (map[int64]string, error)
+
 // ChildrenHandler handles HTTP requests for children
 type ChildrenHandler struct {
 	childrenUseCase usecase.IChildrenUseCase
+	photoURLs       ChildPhotoURLs
 }
 
 // NewChildrenHandler creates a new children handler
-func NewChildrenHandler(childrenUseCase usecase.IChildrenUseCase) *ChildrenHandler {
+func NewChildrenHandler(childrenUseCase usecase.IChildrenUseCase, photoURLs ChildPhotoURLs) *ChildrenHandler {
 	return &ChildrenHandler{
 		childrenUseCase: childrenUseCase,
+		photoURLs:       photoURLs,
 	}
 }
 
@@ -75,8 +82,12 @@ func (h *ChildrenHandler) GetChild(c *fiber.Ctx) error {
 	if err != nil {
 		return response.MapDomainError(err)
 	}
+	children := []domain.Child{*child}
+	if err := h.linkPhotos(c, userID, children); err != nil {
+		return response.MapDomainError(err)
+	}
 
-	return response.Success(c, fiber.StatusOK, "Child retrieved successfully", child)
+	return response.Success(c, fiber.StatusOK, "Child retrieved successfully", children[0])
 }
 
 // GetChildren handles retrieving all children for a user with pagination
@@ -103,6 +114,9 @@ func (h *ChildrenHandler) GetChildren(c *fiber.Ctx) error {
 	if err != nil {
 		return response.MapDomainError(err)
 	}
+	if err := h.linkPhotos(c, userID, result.Children); err != nil {
+		return response.MapDomainError(err)
+	}
 
 	// Create pagination metadata
 	pagination := response.NewPagination(
@@ -156,6 +170,34 @@ func (h *ChildrenHandler) UpdateChild(c *fiber.Ctx) error {
 	if err != nil {
 		return response.MapDomainError(err)
 	}
+	children := []domain.Child{*child}
+	if err := h.linkPhotos(c, userID, children); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Child updated successfully", children[0])
+}
+
+// linkPhotos sets the photo_url of children with a profile photo to a
+// freshly signed link to it
+func (h *ChildrenHandler) linkPhotos(c *fiber.Ctx, userID string, children []domain.Child) error {
+	if h.photoURLs == nil || len(children) == 0 {
+		return nil
+	}
 
-	return response.Success(c, fiber.StatusOK, "Child updated successfully", child)
-}
\ No newline at end of file
+	ids := make([]int64, len(children))
+	for i, child := range children {
+		ids[i] = child.ID
+	}
+
+	urls, err := h.photoURLs(c.Context(), userID, ids)
+	if err != nil {
+		return err
+	}
+	for i := range children {
+		if url, ok := urls[children[i].ID]; ok {
+			children[i].PhotoURL = url
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"dailyalu-server/internal/module/device/domain"
+	"dailyalu-server/internal/module/device/usecase"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/validator"
+	"dailyalu-server/pkg/response"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DeviceHandler handles HTTP requests for push devices
+type DeviceHandler struct {
+	deviceUseCase usecase.IDeviceUseCase
+}
+
+// NewDeviceHandler creates a new device handler
+func NewDeviceHandler(deviceUseCase usecase.IDeviceUseCase) *DeviceHandler {
+	return &DeviceHandler{
+		deviceUseCase: deviceUseCase,
+	}
+}
+
+// RegisterDevice handles registering the push token of a device
+func (h *DeviceHandler) RegisterDevice(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	req := &domain.RegisterDeviceRequest{}
+	if err := c.BodyParser(req); err != nil {
+		return response.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	req.UserID = userID
+
+	device, err := h.deviceUseCase.RegisterDevice(req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "Device registered successfully", device)
+}
+
+// GetDevices handles retrieving the user's devices
+func (h *DeviceHandler) GetDevices(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	devices, err := h.deviceUseCase.GetDevices(userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Devices retrieved successfully", devices)
+}
+
+// DeleteDevice handles unregistering a device
+func (h *DeviceHandler) DeleteDevice(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid device ID")
+	}
+
+	if err := h.deviceUseCase.DeleteDevice(id, userID); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Device deleted successfully", nil)
+}
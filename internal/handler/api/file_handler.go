@@ -0,0 +1,64 @@
+package api
+
+import (
+	"dailyalu-server/internal/service/storage"
+	storageDomain "dailyalu-server/internal/service/storage/domain"
+	"dailyalu-server/pkg/response"
+	"errors"
+	"fmt"
+	"mime"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FileHandler serves files kept on the local disk through the signed URLs
+// handed out with attachments. Files in S3 are downloaded from the bucket
+// directly.
+type FileHandler struct {
+	storage *storage.LocalStorage
+}
+
+// NewFileHandler creates a new file handler
+func NewFileHandler(storage *storage.LocalStorage) *FileHandler {
+	return &FileHandler{
+		storage: storage,
+	}
+}
+
+// Download handles downloading a file with a signed URL. The signature is
+// the only credential: no session is needed.
+func (h *FileHandler) Download(c *fiber.Ctx) error {
+	key := c.Params("*")
+	expires := c.Query("expires")
+
+	if err := h.storage.Verify(key, expires, c.Query("signature"), time.Now()); err != nil {
+		if errors.Is(err, storage.ErrURLExpired) {
+			return response.NewForbiddenError("Download link has expired")
+		}
+		return response.NewForbiddenError("Invalid download link")
+	}
+
+	file, err := h.storage.Get(c.Context(), key)
+	if err != nil {
+		if errors.Is(err, storageDomain.ErrObjectNotFound) {
+			return response.NewNotFoundError("File not found")
+		}
+		return response.NewInternalError(err)
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = fiber.MIMEOctetStream
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+
+	// Browsers may keep the file until the link expires
+	if unix, err := strconv.ParseInt(expires, 10, 64); err == nil {
+		c.Set(fiber.HeaderCacheControl, fmt.Sprintf("private, max-age=%d", max(0, unix-time.Now().Unix())))
+	}
+
+	return c.SendStream(file)
+}
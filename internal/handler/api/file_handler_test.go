@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"dailyalu-server/internal/middleware"
+	"dailyalu-server/internal/security/apikey"
+	"dailyalu-server/internal/service/storage"
+	"dailyalu-server/pkg/response"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestDownloadWithoutCredentials(t *testing.T) {
+	files, err := storage.NewLocalStorage(t.TempDir(), "http://localhost:3000"+storage.LocalFilesPath, []byte("signing-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := files.Put(context.Background(), "children/1/photo.jpg", []byte("jpeg"), "image/jpeg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Registered like the server does: behind the global API key check
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			if appErr, ok := err.(*response.AppError); ok {
+				return response.Error(c, appErr)
+			}
+			return fiber.DefaultErrorHandler(c, err)
+		},
+	})
+	app.Use(middleware.NewAPIKeyMiddleware(apikey.NewAPIKeyService()).ValidateAPIKey())
+	app.Get(storage.LocalFilesPath+"*", NewFileHandler(files).Download)
+
+	signedURL, err := files.SignedURL(context.Background(), "children/1/photo.jpg", time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parsed, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", parsed.RequestURI(), nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != fiber.StatusOK || string(body) != "jpeg" {
+		t.Fatalf("expected the file, got %d: %s", resp.StatusCode, body)
+	}
+
+	// Without the signature the link is refused
+	resp, err = app.Test(httptest.NewRequest("GET", parsed.Path, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode == fiber.StatusOK {
+		t.Error("expected an unsigned link to be refused")
+	}
+
+	// Other routes still need an API key
+	resp, err = app.Test(httptest.NewRequest("GET", "/v1/children", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Errorf("expected 401 without an API key, got %d", resp.StatusCode)
+	}
+}
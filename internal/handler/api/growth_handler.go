@@ -0,0 +1,127 @@
+package api
+
+import (
+	"dailyalu-server/internal/module/growth/domain"
+	"dailyalu-server/internal/module/growth/standards"
+	"dailyalu-server/internal/module/growth/usecase"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/validator"
+	"dailyalu-server/pkg/response"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GrowthHandler handles HTTP requests for growth measurements
+type GrowthHandler struct {
+	growthUseCase usecase.IGrowthUseCase
+}
+
+// NewGrowthHandler creates a new growth handler
+func NewGrowthHandler(growthUseCase usecase.IGrowthUseCase) *GrowthHandler {
+	return &GrowthHandler{
+		growthUseCase: growthUseCase,
+	}
+}
+
+// CreateMeasurement handles recording a growth measurement
+func (h *GrowthHandler) CreateMeasurement(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	req := &domain.CreateMeasurementRequest{}
+	if err := c.BodyParser(req); err != nil {
+		return response.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	req.ChildID = childID
+	req.UserID = userID
+
+	measurement, err := h.growthUseCase.CreateMeasurement(req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "Measurement recorded successfully", measurement)
+}
+
+// GetMeasurements handles retrieving all measurements of a child
+func (h *GrowthHandler) GetMeasurements(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	measurements, err := h.growthUseCase.GetMeasurements(childID, userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Measurements retrieved successfully", measurements)
+}
+
+// DeleteMeasurement handles removing a measurement
+func (h *GrowthHandler) DeleteMeasurement(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid measurement ID")
+	}
+
+	if err := h.growthUseCase.DeleteMeasurement(childID, id, userID); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Measurement deleted successfully", nil)
+}
+
+// GetSeries handles retrieving a growth chart series for one indicator
+func (h *GrowthHandler) GetSeries(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	indicator := c.Query("indicator", standards.WeightForAge)
+
+	series, err := h.growthUseCase.GetSeries(childID, userID, indicator)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Growth series retrieved successfully", series)
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"dailyalu-server/internal/module/immunization/domain"
+	"dailyalu-server/internal/module/immunization/usecase"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/validator"
+	"dailyalu-server/pkg/response"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ImmunizationHandler handles HTTP requests for immunizations
+type ImmunizationHandler struct {
+	immunizationUseCase usecase.IImmunizationUseCase
+}
+
+// NewImmunizationHandler creates a new immunization handler
+func NewImmunizationHandler(immunizationUseCase usecase.IImmunizationUseCase) *ImmunizationHandler {
+	return &ImmunizationHandler{
+		immunizationUseCase: immunizationUseCase,
+	}
+}
+
+// GetSchedule handles retrieving the configured national vaccine schedule
+func (h *ImmunizationHandler) GetSchedule(c *fiber.Ctx) error {
+	return response.Success(c, fiber.StatusOK, "Immunization schedule retrieved successfully", h.immunizationUseCase.GetSchedule())
+}
+
+// RecordDose handles recording a vaccine dose given to a child
+func (h *ImmunizationHandler) RecordDose(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	req := &domain.RecordDoseRequest{}
+	if err := c.BodyParser(req); err != nil {
+		return response.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	req.ChildID = childID
+	req.UserID = userID
+
+	record, err := h.immunizationUseCase.RecordDose(req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "Dose recorded successfully", record)
+}
+
+// GetRecords handles retrieving the doses given to a child
+func (h *ImmunizationHandler) GetRecords(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	records, err := h.immunizationUseCase.GetRecords(childID, userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Dose records retrieved successfully", records)
+}
+
+// DeleteRecord handles removing a dose record
+func (h *ImmunizationHandler) DeleteRecord(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid dose record ID")
+	}
+
+	if err := h.immunizationUseCase.DeleteRecord(childID, id, userID); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Dose record deleted successfully", nil)
+}
+
+// GetChildSchedule handles retrieving a child's schedule, optionally
+// filtered by status
+func (h *ImmunizationHandler) GetChildSchedule(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	schedule, err := h.immunizationUseCase.GetChildSchedule(childID, userID, c.Query("status"))
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Immunization schedule retrieved successfully", schedule)
+}
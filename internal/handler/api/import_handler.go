@@ -0,0 +1,216 @@
+package api
+
+import (
+	"dailyalu-server/internal/module/importer/domain"
+	"dailyalu-server/internal/module/importer/usecase"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/validator"
+	"dailyalu-server/pkg/response"
+	"io"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ImportHandler handles HTTP requests for imports from other apps
+type ImportHandler struct {
+	importUseCase usecase.IImportUseCase
+}
+
+// NewImportHandler creates a new import handler
+func NewImportHandler(importUseCase usecase.IImportUseCase) *ImportHandler {
+	return &ImportHandler{
+		importUseCase: importUseCase,
+	}
+}
+
+// Preview handles uploading an export file and previewing its import
+func (h *ImportHandler) Preview(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	req := &domain.PreviewImportRequest{
+		UserID:  userID,
+		ChildID: childID,
+		Source:  c.FormValue("source"),
+	}
+
+	if errors := validator.ValidateStruct(req); len(errors) > 0 {
+		return response.NewValidationErrorWithDetails("Validation failed", errors)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return response.NewBadRequestError("Upload the export file as the \"file\" form field")
+	}
+	content, err := file.Open()
+	if err != nil {
+		return response.NewBadRequestError("Invalid export file")
+	}
+	defer content.Close()
+
+	req.Filename = file.Filename
+	if req.Content, err = io.ReadAll(content); err != nil {
+		return response.NewBadRequestError("Invalid export file")
+	}
+
+	preview, err := h.importUseCase.Preview(c.Context(), req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "Import previewed successfully", preview)
+}
+
+// GetImports handles retrieving the imports of a child
+func (h *ImportHandler) GetImports(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	imports, err := h.importUseCase.GetImports(childID, userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Imports retrieved successfully", imports)
+}
+
+// GetImport handles retrieving an import and its progress
+func (h *ImportHandler) GetImport(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, id, err := parseImportParams(c)
+	if err != nil {
+		return err
+	}
+
+	imp, err := h.importUseCase.GetImport(childID, id, userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Import retrieved successfully", imp)
+}
+
+// GetRows handles listing the rows of an import, optionally with a status
+func (h *ImportHandler) GetRows(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, id, err := parseImportParams(c)
+	if err != nil {
+		return err
+	}
+
+	paginationReq := response.ParsePaginationRequest(c)
+
+	req := &domain.GetRowsRequest{
+		UserID:   userID,
+		ChildID:  childID,
+		ImportID: id,
+		Status:   c.Query("status"),
+		Page:     paginationReq.Page,
+		PageSize: paginationReq.PageSize,
+	}
+
+	if errors := validator.ValidateStruct(req); len(errors) > 0 {
+		return response.NewValidationErrorWithDetails("Validation failed", errors)
+	}
+
+	result, err := h.importUseCase.GetRows(req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	pagination := response.NewPagination(
+		result.Pagination.Total,
+		result.Pagination.PageSize,
+		result.Pagination.CurrentPage,
+	)
+
+	return response.SuccessWithPagination(
+		c,
+		fiber.StatusOK,
+		"Import rows retrieved successfully",
+		result.Rows,
+		pagination,
+	)
+}
+
+// Commit handles queueing a previewed import
+func (h *ImportHandler) Commit(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, id, err := parseImportParams(c)
+	if err != nil {
+		return err
+	}
+
+	imp, err := h.importUseCase.Commit(childID, id, userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusAccepted, "Import queued successfully", imp)
+}
+
+// DeleteImport handles removing an import
+func (h *ImportHandler) DeleteImport(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, id, err := parseImportParams(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.importUseCase.DeleteImport(childID, id, userID); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Import deleted successfully", nil)
+}
+
+func parseImportParams(c *fiber.Ctx) (int64, int64, error) {
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return 0, 0, response.NewBadRequestError("Invalid child ID")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return 0, 0, response.NewBadRequestError("Invalid import ID")
+	}
+
+	return childID, id, nil
+}
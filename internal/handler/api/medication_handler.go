@@ -0,0 +1,168 @@
+package api
+
+import (
+	"dailyalu-server/internal/module/medication/domain"
+	"dailyalu-server/internal/module/medication/usecase"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/validator"
+	"dailyalu-server/pkg/response"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// MedicationHandler handles HTTP requests for medication plans
+type MedicationHandler struct {
+	medicationUseCase usecase.IMedicationUseCase
+}
+
+// NewMedicationHandler creates a new medication handler
+func NewMedicationHandler(medicationUseCase usecase.IMedicationUseCase) *MedicationHandler {
+	return &MedicationHandler{
+		medicationUseCase: medicationUseCase,
+	}
+}
+
+// CreatePlan handles adding a medication plan for a child
+func (h *MedicationHandler) CreatePlan(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	req := &domain.CreatePlanRequest{}
+	if err := c.BodyParser(req); err != nil {
+		return response.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	req.ChildID = childID
+	req.UserID = userID
+
+	plan, err := h.medicationUseCase.CreatePlan(c.Context(), req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "Medication plan created successfully", plan)
+}
+
+// GetPlans handles retrieving the medication plans of a child
+func (h *MedicationHandler) GetPlans(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	plans, err := h.medicationUseCase.GetPlans(c.Context(), childID, userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Medication plans retrieved successfully", plans)
+}
+
+// GetPlan handles retrieving a medication plan and its next allowed dose
+func (h *MedicationHandler) GetPlan(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid medication plan ID")
+	}
+
+	plan, err := h.medicationUseCase.GetPlan(c.Context(), childID, id, userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Medication plan retrieved successfully", plan)
+}
+
+// UpdatePlan handles updating a medication plan
+func (h *MedicationHandler) UpdatePlan(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid medication plan ID")
+	}
+
+	req := &domain.UpdatePlanRequest{}
+	if err := c.BodyParser(req); err != nil {
+		return response.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	req.ID = id
+	req.ChildID = childID
+	req.UserID = userID
+
+	plan, err := h.medicationUseCase.UpdatePlan(c.Context(), req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Medication plan updated successfully", plan)
+}
+
+// DeletePlan handles removing a medication plan
+func (h *MedicationHandler) DeletePlan(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid medication plan ID")
+	}
+
+	if err := h.medicationUseCase.DeletePlan(c.Context(), childID, id, userID); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Medication plan deleted successfully", nil)
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"dailyalu-server/internal/module/milestone/domain"
+	"dailyalu-server/internal/module/milestone/usecase"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/validator"
+	"dailyalu-server/pkg/response"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// MilestoneHandler handles HTTP requests for developmental milestones
+type MilestoneHandler struct {
+	milestoneUseCase usecase.IMilestoneUseCase
+}
+
+// NewMilestoneHandler creates a new milestone handler
+func NewMilestoneHandler(milestoneUseCase usecase.IMilestoneUseCase) *MilestoneHandler {
+	return &MilestoneHandler{
+		milestoneUseCase: milestoneUseCase,
+	}
+}
+
+// GetCatalog handles retrieving the built-in milestone catalog
+func (h *MilestoneHandler) GetCatalog(c *fiber.Ctx) error {
+	return response.Success(c, fiber.StatusOK, "Milestones retrieved successfully", h.milestoneUseCase.GetCatalog())
+}
+
+// RecordAchievement handles recording that a child reached a milestone
+func (h *MilestoneHandler) RecordAchievement(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	req := &domain.RecordAchievementRequest{}
+	if err := c.BodyParser(req); err != nil {
+		return response.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	req.ChildID = childID
+	req.UserID = userID
+	req.MilestoneID = c.Params("milestoneId")
+
+	achievement, err := h.milestoneUseCase.RecordAchievement(req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Milestone recorded successfully", achievement)
+}
+
+// GetAchievements handles retrieving the milestones a child has reached
+func (h *MilestoneHandler) GetAchievements(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	achievements, err := h.milestoneUseCase.GetAchievements(childID, userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Milestones retrieved successfully", achievements)
+}
+
+// DeleteAchievement handles removing a recorded milestone
+func (h *MilestoneHandler) DeleteAchievement(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	if err := h.milestoneUseCase.DeleteAchievement(childID, c.Params("milestoneId"), userID); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Milestone removed successfully", nil)
+}
+
+// GetProgress handles listing upcoming and overdue milestones of a child
+func (h *MilestoneHandler) GetProgress(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	progress, err := h.milestoneUseCase.GetProgress(childID, userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Milestone progress retrieved successfully", progress)
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"dailyalu-server/internal/module/outbox/domain"
+	"dailyalu-server/internal/module/outbox/usecase"
+	"dailyalu-server/internal/validator"
+	"dailyalu-server/pkg/response"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// OutboxHandler handles admin requests for outbox messages
+type OutboxHandler struct {
+	outboxUseCase usecase.IOutboxUseCase
+}
+
+// NewOutboxHandler creates a new outbox handler
+func NewOutboxHandler(outboxUseCase usecase.IOutboxUseCase) *OutboxHandler {
+	return &OutboxHandler{
+		outboxUseCase: outboxUseCase,
+	}
+}
+
+// GetMessages handles listing outbox messages. Dead-lettered messages are
+// listed unless another status is asked for.
+func (h *OutboxHandler) GetMessages(c *fiber.Ctx) error {
+	paginationReq := response.ParsePaginationRequest(c)
+
+	req := &domain.GetMessagesRequest{
+		Status:   c.Query("status", domain.MessageDead),
+		Page:     paginationReq.Page,
+		PageSize: paginationReq.PageSize,
+	}
+
+	if errors := validator.ValidateStruct(req); len(errors) > 0 {
+		return response.NewValidationErrorWithDetails("Validation failed", errors)
+	}
+
+	result, err := h.outboxUseCase.GetMessages(req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	pagination := response.NewPagination(
+		result.Pagination.Total,
+		result.Pagination.PageSize,
+		result.Pagination.CurrentPage,
+	)
+
+	return response.SuccessWithPagination(
+		c,
+		fiber.StatusOK,
+		"Outbox messages retrieved successfully",
+		result.Messages,
+		pagination,
+	)
+}
+
+// GetMessage handles retrieving an outbox message
+func (h *OutboxHandler) GetMessage(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid message ID")
+	}
+
+	message, err := h.outboxUseCase.GetMessage(id)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Outbox message retrieved successfully", message)
+}
+
+// RetryMessage handles queueing a dead message again
+func (h *OutboxHandler) RetryMessage(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid message ID")
+	}
+
+	message, err := h.outboxUseCase.RetryMessage(id)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusAccepted, "Outbox message queued successfully", message)
+}
+
+// DeleteMessage handles discarding an outbox message
+func (h *OutboxHandler) DeleteMessage(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid message ID")
+	}
+
+	if err := h.outboxUseCase.DeleteMessage(id); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Outbox message deleted successfully", nil)
+}
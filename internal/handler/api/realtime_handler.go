@@ -0,0 +1,242 @@
+package api
+
+import (
+	"bufio"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	"dailyalu-server/internal/security/jwt"
+	realtimeDomain "dailyalu-server/internal/service/realtime/domain"
+	"dailyalu-server/pkg/response"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+)
+
+// realtimeHeartbeat keeps idle connections open through proxies, which
+// commonly close them after a minute without traffic
+const realtimeHeartbeat = 25 * time.Second
+
+// realtimeAccessTTL is how long the outcome of a child access check is
+// trusted before it is checked again, so revoked access is noticed
+const realtimeAccessTTL = time.Minute
+
+// ChildAccessChecker returns an error unless the user may see the child's
+// events
+type ChildAccessChecker func(childID int64, userID string) error
+
+// RealtimeHandler streams live events over WebSocket and Server-Sent Events
+type RealtimeHandler struct {
+	broker         realtimeDomain.IBroker
+	canAccessChild ChildAccessChecker
+}
+
+// NewRealtimeHandler creates a new realtime handler
+func NewRealtimeHandler(broker realtimeDomain.IBroker, canAccessChild ChildAccessChecker) *RealtimeHandler {
+	return &RealtimeHandler{
+		broker:         broker,
+		canAccessChild: canAccessChild,
+	}
+}
+
+// childAccess is the remembered outcome of a child access check
+type childAccess struct {
+	allowed   bool
+	checkedAt time.Time
+}
+
+// eventFilter passes the events of the children a connected user can access.
+// Definite outcomes of the access checks are remembered for
+// realtimeAccessTTL; failed checks are retried on the next event.
+type eventFilter struct {
+	userID         string
+	childID        int64
+	access         map[int64]childAccess
+	canAccessChild ChildAccessChecker
+	now            func() time.Time
+}
+
+func (f *eventFilter) allows(event *realtimeDomain.Event) bool {
+	if f.childID != 0 && event.ChildID != f.childID {
+		return false
+	}
+
+	now := f.now()
+	if access, ok := f.access[event.ChildID]; ok && now.Sub(access.checkedAt) < realtimeAccessTTL {
+		return access.allowed
+	}
+
+	err := f.canAccessChild(event.ChildID, f.userID)
+	switch {
+	case err == nil:
+		f.access[event.ChildID] = childAccess{allowed: true, checkedAt: now}
+		return true
+	case errors.Is(err, childrenUsecase.ErrChildNotFound), errors.Is(err, childrenUsecase.ErrUnauthorizedAccess):
+		f.access[event.ChildID] = childAccess{allowed: false, checkedAt: now}
+		return false
+	default:
+		// The check itself failed; skip this event without remembering it
+		delete(f.access, event.ChildID)
+		return false
+	}
+}
+
+// newFilter reads the optional child_id parameter, which limits the stream to
+// one child
+func (h *RealtimeHandler) newFilter(c *fiber.Ctx) (*eventFilter, error) {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return nil, response.NewUnauthorizedError("Authentication required")
+	}
+
+	filter := &eventFilter{
+		userID:         userID,
+		access:         map[int64]childAccess{},
+		canAccessChild: h.canAccessChild,
+		now:            time.Now,
+	}
+
+	if param := c.Query("child_id"); param != "" {
+		childID, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return nil, response.NewBadRequestError("Invalid child ID")
+		}
+		if err := h.canAccessChild(childID, userID); err != nil {
+			return nil, response.MapDomainError(err)
+		}
+		filter.childID = childID
+		filter.access[childID] = childAccess{allowed: true, checkedAt: filter.now()}
+	}
+
+	return filter, nil
+}
+
+// Events handles streaming events as Server-Sent Events. Each event is sent
+// with its type as the event name and the event as JSON data.
+func (h *RealtimeHandler) Events(c *fiber.Ctx) error {
+	filter, err := h.newFilter(c)
+	if err != nil {
+		return err
+	}
+
+	sub := h.broker.Subscribe()
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+
+		heartbeat := time.NewTicker(realtimeHeartbeat)
+		defer heartbeat.Stop()
+
+		// Ask EventSource to reconnect quickly after the stream ends
+		fmt.Fprint(w, "retry: 3000\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-sub.Events():
+				if !ok {
+					return
+				}
+				if !filter.allows(event) {
+					continue
+				}
+				payload, err := json.Marshal(event)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			}
+
+			// Writing fails once the client has disconnected
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// Upgrade checks the WebSocket handshake and authorizes the stream before
+// the connection is upgraded
+func (h *RealtimeHandler) Upgrade(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	filter, err := h.newFilter(c)
+	if err != nil {
+		return err
+	}
+	c.Locals("realtimeFilter", filter)
+
+	return c.Next()
+}
+
+// WebSocket handles streaming events as JSON text messages. Clients are not
+// expected to send anything; the connection is closed when the client closes
+// it or stops answering pings.
+func (h *RealtimeHandler) WebSocket() fiber.Handler {
+	return websocket.New(func(conn *websocket.Conn) {
+		filter := conn.Locals("realtimeFilter").(*eventFilter)
+
+		sub := h.broker.Subscribe()
+		defer sub.Close()
+
+		conn.SetReadDeadline(time.Now().Add(2 * realtimeHeartbeat))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(2 * realtimeHeartbeat))
+		})
+
+		// Reading processes pongs and notices when the client goes away
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		heartbeat := time.NewTicker(realtimeHeartbeat)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-closed:
+				return
+			case event, ok := <-sub.Events():
+				if !ok {
+					// Dropped for falling behind; the client reconnects and refetches
+					conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, ""), time.Now().Add(time.Second))
+					return
+				}
+				if !filter.allows(event) {
+					continue
+				}
+				conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+				if err := conn.WriteJSON(event); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+					return
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	realtimeDomain "dailyalu-server/internal/service/realtime/domain"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEventFilterAccessCache(t *testing.T) {
+	now := time.Date(2025, 3, 20, 9, 0, 0, 0, time.UTC)
+	checks := 0
+	var result error
+
+	filter := &eventFilter{
+		userID: "user-1",
+		access: map[int64]childAccess{},
+		canAccessChild: func(childID int64, userID string) error {
+			checks++
+			return result
+		},
+		now: func() time.Time { return now },
+	}
+	event := &realtimeDomain.Event{ChildID: 7}
+
+	// A failed check hides the event but is retried
+	result = errors.New("connection reset")
+	if filter.allows(event) {
+		t.Fatal("event allowed after failed check")
+	}
+	result = nil
+	if !filter.allows(event) {
+		t.Fatal("event denied after successful check")
+	}
+	if checks != 2 {
+		t.Fatalf("checks = %d, want 2", checks)
+	}
+
+	// Access is trusted until the TTL passes, then checked again
+	result = childrenUsecase.ErrUnauthorizedAccess
+	now = now.Add(realtimeAccessTTL / 2)
+	if !filter.allows(event) {
+		t.Fatal("cached access not used")
+	}
+	now = now.Add(realtimeAccessTTL)
+	if filter.allows(event) {
+		t.Fatal("revoked access not noticed")
+	}
+
+	// A definite denial is remembered
+	result = nil
+	if filter.allows(event) {
+		t.Fatal("cached denial not used")
+	}
+	if checks != 3 {
+		t.Fatalf("checks = %d, want 3", checks)
+	}
+}
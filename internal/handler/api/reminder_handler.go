@@ -0,0 +1,141 @@
+package api
+
+import (
+	"dailyalu-server/internal/module/reminder/domain"
+	"dailyalu-server/internal/module/reminder/usecase"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/validator"
+	"dailyalu-server/pkg/response"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReminderHandler handles HTTP requests for reminders
+type ReminderHandler struct {
+	reminderUseCase usecase.IReminderUseCase
+}
+
+// NewReminderHandler creates a new reminder handler
+func NewReminderHandler(reminderUseCase usecase.IReminderUseCase) *ReminderHandler {
+	return &ReminderHandler{
+		reminderUseCase: reminderUseCase,
+	}
+}
+
+// CreateReminder handles scheduling a reminder
+func (h *ReminderHandler) CreateReminder(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	req := &domain.CreateReminderRequest{}
+	if err := c.BodyParser(req); err != nil {
+		return response.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	req.UserID = userID
+
+	reminder, err := h.reminderUseCase.CreateReminder(c.Context(), req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "Reminder created successfully", reminder)
+}
+
+// GetReminders handles retrieving the user's reminders
+func (h *ReminderHandler) GetReminders(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	reminders, err := h.reminderUseCase.GetReminders(c.Context(), userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Reminders retrieved successfully", reminders)
+}
+
+// GetReminder handles retrieving a reminder
+func (h *ReminderHandler) GetReminder(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid reminder ID")
+	}
+
+	reminder, err := h.reminderUseCase.GetReminder(c.Context(), id, userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Reminder retrieved successfully", reminder)
+}
+
+// UpdateReminder handles changing and rescheduling a reminder
+func (h *ReminderHandler) UpdateReminder(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid reminder ID")
+	}
+
+	req := &domain.UpdateReminderRequest{}
+	if err := c.BodyParser(req); err != nil {
+		return response.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	req.ID = id
+	req.UserID = userID
+
+	reminder, err := h.reminderUseCase.UpdateReminder(c.Context(), req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Reminder updated successfully", reminder)
+}
+
+// DeleteReminder handles removing a reminder
+func (h *ReminderHandler) DeleteReminder(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid reminder ID")
+	}
+
+	if err := h.reminderUseCase.DeleteReminder(c.Context(), id, userID); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Reminder deleted successfully", nil)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"dailyalu-server/internal/module/report/domain"
+	"dailyalu-server/internal/module/report/usecase"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/validator"
+	"dailyalu-server/pkg/response"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReportHandler handles HTTP requests for child reports
+type ReportHandler struct {
+	reportUseCase usecase.IReportUseCase
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(reportUseCase usecase.IReportUseCase) *ReportHandler {
+	return &ReportHandler{
+		reportUseCase: reportUseCase,
+	}
+}
+
+// Download handles generating the PDF report of a child
+func (h *ReportHandler) Download(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	req := &domain.ReportRequest{
+		ChildID: childID,
+		UserID:  userID,
+		From:    c.Query("from"),
+		To:      c.Query("to"),
+	}
+
+	// The period is given in the query; a GET has no body to parse
+	if errors := validator.ValidateStruct(req); len(errors) > 0 {
+		return response.NewValidationErrorWithDetails("Validation failed", errors)
+	}
+
+	document, err := h.reportUseCase.Generate(c.Context(), req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	c.Set(fiber.HeaderContentType, domain.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, document.Filename))
+	return c.Send(document.Content)
+}
+
+// Email handles queueing the PDF report of a child to be sent by email
+func (h *ReportHandler) Email(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	childID, err := strconv.ParseInt(c.Params("childId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid child ID")
+	}
+
+	req := &domain.EmailReportRequest{}
+	if err := c.BodyParser(req); err != nil {
+		return response.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	req.ChildID = childID
+	req.UserID = userID
+
+	if err := h.reportUseCase.Email(c.Context(), req); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusAccepted, "Report will be sent shortly", nil)
+}
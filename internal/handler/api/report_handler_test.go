@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"dailyalu-server/internal/module/report/domain"
+	"dailyalu-server/internal/module/report/usecase"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/pkg/response"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// mockReportUseCase returns a fixed document and records the request
+type mockReportUseCase struct {
+	usecase.IReportUseCase
+	lastRequest *domain.ReportRequest
+}
+
+func (m *mockReportUseCase) Generate(ctx context.Context, req *domain.ReportRequest) (*domain.Document, error) {
+	m.lastRequest = req
+	return &domain.Document{Filename: "budi-report.pdf", Content: []byte("%PDF-1.4")}, nil
+}
+
+func newReportTestApp(reportUseCase usecase.IReportUseCase) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			if appErr, ok := err.(*response.AppError); ok {
+				return response.Error(c, appErr)
+			}
+			return fiber.DefaultErrorHandler(c, err)
+		},
+	})
+	handler := NewReportHandler(reportUseCase)
+	app.Get("/v1/children/:childId/report", func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Claims{UserID: "user-1"})
+		return c.Next()
+	}, handler.Download)
+	return app
+}
+
+func TestReportHandlerDownload(t *testing.T) {
+	reportUseCase := &mockReportUseCase{}
+	app := newReportTestApp(reportUseCase)
+
+	// Browsers send the download without a body or content type
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/children/1/report?from=2025-03-01&to=2025-03-03", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", resp.StatusCode, body)
+	}
+	if resp.Header.Get(fiber.HeaderContentType) != domain.ContentType || string(body) != "%PDF-1.4" {
+		t.Errorf("unexpected download %s: %q", resp.Header.Get(fiber.HeaderContentType), body)
+	}
+	if req := reportUseCase.lastRequest; req.ChildID != 1 || req.UserID != "user-1" || req.From != "2025-03-01" || req.To != "2025-03-03" {
+		t.Errorf("unexpected report request %+v", req)
+	}
+
+	// The period is still validated
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/children/1/report?from=2025-03-01", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected status 400 without to, got %d", resp.StatusCode)
+	}
+}
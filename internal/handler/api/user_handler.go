@@ -12,12 +12,16 @@ import (
 )
 
 type UserHandler struct {
-	userUseCase usecase.IUserUseCase
+	userUseCase        usecase.IUserUseCase
+	socialLoginUseCase usecase.ISocialLoginUseCase
+	preferencesUseCase usecase.IPreferencesUseCase
 }
 
-func NewUserHandler(userUseCase usecase.IUserUseCase) *UserHandler {
+func NewUserHandler(userUseCase usecase.IUserUseCase, socialLoginUseCase usecase.ISocialLoginUseCase, preferencesUseCase usecase.IPreferencesUseCase) *UserHandler {
 	return &UserHandler{
-		userUseCase: userUseCase,
+		userUseCase:        userUseCase,
+		socialLoginUseCase: socialLoginUseCase,
+		preferencesUseCase: preferencesUseCase,
 	}
 }
 
@@ -64,6 +68,51 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 	return response.Success(c, fiber.StatusOK, "Login successful", loginResult)
 }
 
+// RequestMagicLink emails a single-use sign-in link to the given address
+func (h *UserHandler) RequestMagicLink(c *fiber.Ctx) error {
+	req := &domain.MagicLinkRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		return response.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	if err := h.userUseCase.RequestMagicLink(c.Context(), req); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	// Always return success even if email doesn't exist (for security)
+	return response.Success(
+		c,
+		fiber.StatusOK,
+		"If your email is registered with us, you will receive a sign-in link shortly",
+		nil,
+	)
+}
+
+// MagicLinkLogin exchanges a sign-in link token for an access and refresh token pair
+func (h *UserHandler) MagicLinkLogin(c *fiber.Ctx) error {
+	req := &domain.MagicLinkLoginRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		return response.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	loginResult, err := h.userUseCase.LoginWithMagicLink(c.Context(), req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Login successful", loginResult)
+}
+
 func (h *UserHandler) GetUser(c *fiber.Ctx) error {
 	// Get user ID from JWT token
 	userID := utils.GetUserIDFromContext(c)
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"dailyalu-server/internal/module/user/domain"
+	"dailyalu-server/internal/utils"
+	"dailyalu-server/internal/validator"
+	"dailyalu-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// OIDCAuthorize starts sign-in with an external identity provider
+func (h *UserHandler) OIDCAuthorize(c *fiber.Ctx) error {
+	result, err := h.socialLoginUseCase.Authorize(c.Context(), c.Params("provider"), "")
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Authorization URL created successfully", result)
+}
+
+// OIDCCallback completes sign-in with an external identity provider
+func (h *UserHandler) OIDCCallback(c *fiber.Ctx) error {
+	req := &domain.OIDCCallbackRequest{}
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	req.Provider = c.Params("provider")
+
+	loginResult, err := h.socialLoginUseCase.Login(c.Context(), req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Login successful", loginResult)
+}
+
+// GetIdentities lists the identity providers linked to the current user
+func (h *UserHandler) GetIdentities(c *fiber.Ctx) error {
+	userID := utils.GetUserIDFromContext(c)
+
+	identities, err := h.socialLoginUseCase.ListIdentities(c.Context(), userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Identities retrieved successfully", identities)
+}
+
+// LinkIdentityAuthorize starts linking an identity provider to the current user
+func (h *UserHandler) LinkIdentityAuthorize(c *fiber.Ctx) error {
+	userID := utils.GetUserIDFromContext(c)
+
+	result, err := h.socialLoginUseCase.Authorize(c.Context(), c.Params("provider"), userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Authorization URL created successfully", result)
+}
+
+// LinkIdentityCallback completes linking an identity provider to the current user
+func (h *UserHandler) LinkIdentityCallback(c *fiber.Ctx) error {
+	req := &domain.OIDCCallbackRequest{}
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	req.Provider = c.Params("provider")
+	userID := utils.GetUserIDFromContext(c)
+
+	identity, err := h.socialLoginUseCase.Link(c.Context(), userID, req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Identity linked successfully", identity)
+}
+
+// UnlinkIdentity removes a linked identity provider from the current user
+func (h *UserHandler) UnlinkIdentity(c *fiber.Ctx) error {
+	userID := utils.GetUserIDFromContext(c)
+
+	if err := h.socialLoginUseCase.Unlink(c.Context(), userID, c.Params("provider")); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Identity unlinked successfully", nil)
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"dailyalu-server/internal/module/user/domain"
+	"dailyalu-server/internal/utils"
+	"dailyalu-server/internal/validator"
+	"dailyalu-server/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetPreferences returns the preferences of the current user
+func (h *UserHandler) GetPreferences(c *fiber.Ctx) error {
+	userID := utils.GetUserIDFromContext(c)
+
+	preferences, err := h.preferencesUseCase.Get(c.Context(), userID)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Preferences retrieved successfully", preferences)
+}
+
+// UpdatePreferences changes the fields present in the request body
+func (h *UserHandler) UpdatePreferences(c *fiber.Ctx) error {
+	userID := utils.GetUserIDFromContext(c)
+
+	req := &domain.UpdatePreferencesRequest{}
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	preferences, err := h.preferencesUseCase.Update(c.Context(), userID, req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Preferences updated successfully", preferences)
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"dailyalu-server/internal/module/webhook/domain"
+	"dailyalu-server/internal/module/webhook/usecase"
+	"dailyalu-server/internal/security/apikey"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/validator"
+	"dailyalu-server/pkg/response"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// WebhookHandler handles HTTP requests for webhook subscriptions
+type WebhookHandler struct {
+	webhookUseCase usecase.IWebhookUseCase
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookUseCase usecase.IWebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUseCase: webhookUseCase,
+	}
+}
+
+// callerAPIKeyID returns the ID of the API key the request was made with.
+// Subscriptions are scoped to it, so each partner app only sees its own.
+func callerAPIKeyID(c *fiber.Ctx) string {
+	if key, ok := c.Locals("api_key").(*apikey.APIKey); ok && key != nil {
+		return key.ID
+	}
+	return ""
+}
+
+// CreateSubscription handles subscribing an endpoint to events
+func (h *WebhookHandler) CreateSubscription(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	req := &domain.CreateSubscriptionRequest{}
+	if err := c.BodyParser(req); err != nil {
+		return response.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	req.UserID = userID
+	req.APIKeyID = callerAPIKeyID(c)
+
+	subscription, err := h.webhookUseCase.CreateSubscription(req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusCreated, "Webhook created successfully", subscription)
+}
+
+// GetSubscriptions handles retrieving the caller's subscriptions
+func (h *WebhookHandler) GetSubscriptions(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	subscriptions, err := h.webhookUseCase.GetSubscriptions(userID, callerAPIKeyID(c))
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Webhooks retrieved successfully", subscriptions)
+}
+
+// GetSubscription handles retrieving a subscription
+func (h *WebhookHandler) GetSubscription(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid webhook ID")
+	}
+
+	subscription, err := h.webhookUseCase.GetSubscription(id, userID, callerAPIKeyID(c))
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Webhook retrieved successfully", subscription)
+}
+
+// UpdateSubscription handles changing a subscription
+func (h *WebhookHandler) UpdateSubscription(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid webhook ID")
+	}
+
+	req := &domain.UpdateSubscriptionRequest{}
+	if err := c.BodyParser(req); err != nil {
+		return response.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validator.ValidateRequest(c, req); err != nil {
+		return err
+	}
+
+	req.ID = id
+	req.UserID = userID
+	req.APIKeyID = callerAPIKeyID(c)
+
+	subscription, err := h.webhookUseCase.UpdateSubscription(req)
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Webhook updated successfully", subscription)
+}
+
+// DeleteSubscription handles removing a subscription
+func (h *WebhookHandler) DeleteSubscription(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid webhook ID")
+	}
+
+	if err := h.webhookUseCase.DeleteSubscription(id, userID, callerAPIKeyID(c)); err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Webhook deleted successfully", nil)
+}
+
+// GetDeliveries handles retrieving the delivery log of a subscription
+func (h *WebhookHandler) GetDeliveries(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid webhook ID")
+	}
+
+	deliveries, err := h.webhookUseCase.GetDeliveries(id, userID, callerAPIKeyID(c))
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusOK, "Webhook deliveries retrieved successfully", deliveries)
+}
+
+// Redeliver handles sending the event of a past delivery again
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*jwt.Claims)
+	userID := claims.UserID
+	if userID == "" {
+		return response.NewUnauthorizedError("Authentication required")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid webhook ID")
+	}
+
+	deliveryID, err := strconv.ParseInt(c.Params("deliveryId"), 10, 64)
+	if err != nil {
+		return response.NewBadRequestError("Invalid delivery ID")
+	}
+
+	delivery, err := h.webhookUseCase.Redeliver(id, deliveryID, userID, callerAPIKeyID(c))
+	if err != nil {
+		return response.MapDomainError(err)
+	}
+
+	return response.Success(c, fiber.StatusAccepted, "Webhook redelivery queued successfully", delivery)
+}
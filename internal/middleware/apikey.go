@@ -2,6 +2,9 @@ package middleware
 
 import (
 	"dailyalu-server/internal/security/apikey"
+	"dailyalu-server/internal/service/storage"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -18,8 +21,19 @@ func NewAPIKeyMiddleware(apiKeyService *apikey.APIKeyService) *APIKeyMiddleware
 // ValidateAPIKey middleware validates the API key in the request header
 func (m *APIKeyMiddleware) ValidateAPIKey() fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		// Signed file URLs are opened by browsers, e.g. as <img src>; the
+		// signature is their credential
+		if strings.HasPrefix(c.Path(), storage.LocalFilesPath) {
+			return c.Next()
+		}
+
 		// Get API key from header
 		key := c.Get("X-API-Key")
+
+		// Browsers cannot set headers on WebSocket and EventSource connections
+		if key == "" && isStreamRequest(c) {
+			key = c.Query("api_key")
+		}
 		if key == "" {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"error": "API key is required",
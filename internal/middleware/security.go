@@ -5,6 +5,8 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/gofiber/websocket/v2"
+	"strings"
 	"time"
 )
 
@@ -26,30 +28,61 @@ type SecurityMiddleware struct {
 
 // JWT middleware for authentication
 func (m *SecurityMiddleware) JWT() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return m.authenticate(c, c.Get("Authorization"))
+	}
+}
+
+// StreamJWT authenticates like JWT, but also accepts the token in the
+// access_token query parameter, since browsers cannot set headers on
+// WebSocket and EventSource connections
+func (m *SecurityMiddleware) StreamJWT() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		token := c.Get("Authorization")
 		if token == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Missing authorization header",
-			})
+			token = c.Query("access_token")
 		}
+		return m.authenticate(c, token)
+	}
+}
 
-		// Remove 'Bearer ' prefix if present
-		if len(token) > 7 && token[:7] == "Bearer " {
-			token = token[7:]
-		}
+// realtimePathPrefix is where the WebSocket and EventSource streams are
+// served
+const realtimePathPrefix = "/v1/realtime/"
 
-		claims, err := m.jwtManager.Validate(token)
-		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Invalid or expired token",
-			})
-		}
+// isStreamRequest reports whether the request opens a WebSocket or an
+// EventSource stream of the realtime endpoints. Other routes never accept
+// credentials in the query, whatever the request headers say.
+func isStreamRequest(c *fiber.Ctx) bool {
+	if !strings.HasPrefix(c.Path(), realtimePathPrefix) {
+		return false
+	}
+	return websocket.IsWebSocketUpgrade(c) || strings.Contains(c.Get(fiber.HeaderAccept), "text/event-stream")
+}
+
+// authenticate validates the token and stores its claims for the handlers
+func (m *SecurityMiddleware) authenticate(c *fiber.Ctx, token string) error {
+	if token == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Missing authorization header",
+		})
+	}
 
-		// Store user information in context
-		c.Locals("user", claims)
-		return c.Next()
+	// Remove 'Bearer ' prefix if present
+	if len(token) > 7 && token[:7] == "Bearer " {
+		token = token[7:]
 	}
+
+	claims, err := m.jwtManager.Validate(token)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid or expired token",
+		})
+	}
+
+	// Store user information in context
+	c.Locals("user", claims)
+	return c.Next()
 }
 
 // RoleAuth middleware for role-based authorization
@@ -74,7 +107,7 @@ func CORSConfig() fiber.Handler {
 	return cors.New(cors.Config{
 		AllowOrigins:     "http://localhost:3001,https://dailyalu.mom,http://localhost:5173,",
 		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS,PATCH",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Requested-With, X-API-Key",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Requested-With, X-API-Key, X-Timezone",
 		ExposeHeaders:    "Content-Length",
 		AllowCredentials: true,
 		MaxAge:           24 * 60 * 60, // 24 hours
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"dailyalu-server/internal/utils"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TimezoneResolver returns the IANA timezone saved on the user's profile
+type TimezoneResolver func(userID string) (string, error)
+
+type TimezoneMiddleware struct {
+	resolve         TimezoneResolver
+	defaultLocation *time.Location
+}
+
+func NewTimezoneMiddleware(resolve TimezoneResolver, defaultLocation *time.Location) *TimezoneMiddleware {
+	if defaultLocation == nil {
+		defaultLocation = time.UTC
+	}
+	return &TimezoneMiddleware{
+		resolve:         resolve,
+		defaultLocation: defaultLocation,
+	}
+}
+
+// Handle stores the time zone of the request in the context. The X-Timezone
+// header wins over the profile setting, which wins over the server default.
+// Must run after the JWT middleware.
+func (m *TimezoneMiddleware) Handle() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if header := c.Get(utils.TimezoneHeader); header != "" {
+			loc, err := utils.LoadLocation(header)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Invalid " + utils.TimezoneHeader + " header",
+				})
+			}
+			utils.SetLocation(c, loc)
+			return c.Next()
+		}
+
+		loc := m.defaultLocation
+		if m.resolve != nil {
+			if name, err := m.resolve(utils.GetUserIDFromContext(c)); err == nil && name != "" {
+				if userLoc, err := utils.LoadLocation(name); err == nil {
+					loc = userLoc
+				}
+			}
+		}
+
+		utils.SetLocation(c, loc)
+		return c.Next()
+	}
+}
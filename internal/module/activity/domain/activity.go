@@ -2,19 +2,90 @@ package domain
 
 import (
 	"encoding/json"
+	"sort"
+	"strings"
 	"time"
 )
 
+// TypeMedicine is the activity type of a medicine dose. Only medicine
+// activities may reference a medication plan.
+const TypeMedicine = "medicine"
+
+// Export formats
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+	ExportFormatICS  = "ics"
+)
+
+// ExportFormats lists the formats activities can be exported in
+var ExportFormats = []string{ExportFormatCSV, ExportFormatJSON, ExportFormatICS}
+
+// Events published when activities change
+const (
+	EventCreated = "activity.created"
+	EventUpdated = "activity.updated"
+	EventDeleted = "activity.deleted"
+)
+
 // Activity represents a baby activity record
 type Activity struct {
-	ID        int             `json:"id"`
-	UserID    string          `json:"user_id"`
-	ChildID   int             `json:"child_id"`
-	Type      string          `json:"type"`
-	Details   json.RawMessage `json:"details"`
-	HappensAt time.Time       `json:"happens_at"`
-	CreatedAt time.Time       `json:"created_at"`
-	UpdatedAt time.Time       `json:"updated_at"`
+	ID               int             `json:"id"`
+	UserID           string          `json:"user_id"`
+	ChildID          int             `json:"child_id"`
+	Type             string          `json:"type"`
+	Details          json.RawMessage `json:"details"`
+	Notes            string          `json:"notes,omitempty"`
+	Tags             []string        `json:"tags"`
+	MedicationPlanID *int64          `json:"medication_plan_id,omitempty"`
+	HappensAt        time.Time       `json:"happens_at"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+	Warnings         []string        `json:"warnings,omitempty"`
+}
+
+// Duration returns how long the activity lasted, such as a sleep or a
+// feeding session. Details give it either as "duration_minutes" or as an
+// "ended_at" time; it is zero when they give neither.
+func (a *Activity) Duration() time.Duration {
+	return DetailsDuration(a.Details, a.HappensAt)
+}
+
+// DetailsDuration returns the duration given by activity details for an
+// activity starting at start
+func DetailsDuration(details json.RawMessage, start time.Time) time.Duration {
+	var fields struct {
+		DurationMinutes *float64 `json:"duration_minutes"`
+		EndedAt         string   `json:"ended_at"`
+	}
+	if err := json.Unmarshal(details, &fields); err != nil {
+		return 0
+	}
+
+	if fields.DurationMinutes != nil && *fields.DurationMinutes > 0 {
+		return time.Duration(*fields.DurationMinutes * float64(time.Minute))
+	}
+	if endedAt, err := time.Parse(time.RFC3339, fields.EndedAt); err == nil && endedAt.After(start) {
+		return endedAt.Sub(start)
+	}
+	return 0
+}
+
+// NormalizeTags lowercases and trims tags, and drops empty and repeated
+// ones. Tags are returned sorted.
+func NormalizeTags(tags []string) []string {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
 }
 
 // CreateActivityRequest represents the request to create a new activity
@@ -24,6 +95,14 @@ type CreateActivityRequest struct {
 	Type      string          `json:"type" validate:"required"`
 	Details   json.RawMessage `json:"details" validate:"required"`
 	HappensAt string          `json:"happens_at" validate:"required"`
+	Notes     string          `json:"notes" validate:"max=5000"`
+	Tags      []string        `json:"tags" validate:"max=20,dive,max=50"`
+
+	// MedicationPlanID links a medicine dose to a medication plan. Doses
+	// exceeding the plan's daily maximum are rejected unless
+	// AllowExceedingMax is set.
+	MedicationPlanID  *int64 `json:"medication_plan_id,omitempty"`
+	AllowExceedingMax bool   `json:"allow_exceeding_max,omitempty"`
 }
 
 // UpdateActivityRequest represents the request to update an activity
@@ -33,6 +112,12 @@ type UpdateActivityRequest struct {
 	ChildID   int             `json:"child_id"`
 	Details   json.RawMessage `json:"details" validate:"required"`
 	HappensAt string          `json:"happens_at" validate:"required"`
+	// Notes and Tags are kept when left out; an empty list removes every
+	// tag
+	Notes *string  `json:"notes" validate:"omitempty,max=5000"`
+	Tags  []string `json:"tags" validate:"max=20,dive,max=50"`
+
+	AllowExceedingMax bool `json:"allow_exceeding_max,omitempty"`
 }
 
 // SearchActivityRequest represents the request to search activities
@@ -45,6 +130,13 @@ type SearchActivityRequest struct {
 	Details   map[string]interface{} `json:"details"` // For JSONB search
 	Page      int                    `json:"page" validate:"min=1"`
 	PageSize  int                    `json:"page_size" validate:"min=1,max=100"`
+
+	// Tags limits the search to activities having every tag
+	Tags []string `json:"tags"`
+	// Query is a full-text search of notes and details, in web search
+	// syntax: "quoted phrases", or and -excluded words. Results are ranked
+	// by relevance instead of time when it is set.
+	Query string `json:"q"`
 }
 
 // Pagination represents pagination information
@@ -0,0 +1,93 @@
+package export
+
+import (
+	"dailyalu-server/internal/module/activity/domain"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvWriter writes one row per activity. Details are flattened into a
+// "<type>.<field>" column for every field used by each activity type, so
+// rows only fill the columns of their own type.
+type csvWriter struct {
+	w       *csv.Writer
+	columns []csvColumn
+	header  bool
+}
+
+type csvColumn struct {
+	activityType string
+	key          string
+}
+
+var csvBaseColumns = []string{"id", "child_id", "type", "happens_at", "medication_plan_id", "created_at", "updated_at", "notes", "tags"}
+
+func newCSVWriter(w io.Writer, detailKeys map[string][]string) *csvWriter {
+	var columns []csvColumn
+	for _, activityType := range sortedKeys(detailKeys) {
+		for _, key := range detailKeys[activityType] {
+			columns = append(columns, csvColumn{activityType: activityType, key: key})
+		}
+	}
+
+	return &csvWriter{w: csv.NewWriter(w), columns: columns}
+}
+
+func (cw *csvWriter) writeHeader() error {
+	if cw.header {
+		return nil
+	}
+	cw.header = true
+
+	header := append([]string(nil), csvBaseColumns...)
+	for _, column := range cw.columns {
+		header = append(header, column.activityType+"."+column.key)
+	}
+	return cw.w.Write(header)
+}
+
+func (cw *csvWriter) Write(activity *domain.Activity) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	planID := ""
+	if activity.MedicationPlanID != nil {
+		planID = strconv.FormatInt(*activity.MedicationPlanID, 10)
+	}
+
+	record := []string{
+		strconv.Itoa(activity.ID),
+		strconv.Itoa(activity.ChildID),
+		activity.Type,
+		activity.HappensAt.Format(time.RFC3339),
+		planID,
+		activity.CreatedAt.Format(time.RFC3339),
+		activity.UpdatedAt.Format(time.RFC3339),
+		activity.Notes,
+		strings.Join(activity.Tags, ";"),
+	}
+
+	fields := detailFields(activity.Details)
+	for _, column := range cw.columns {
+		value := ""
+		if column.activityType == activity.Type {
+			value = fields[column.key]
+		}
+		record = append(record, value)
+	}
+
+	return cw.w.Write(record)
+}
+
+func (cw *csvWriter) Close() error {
+	// An empty export still gets its header
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	cw.w.Flush()
+	return cw.w.Error()
+}
@@ -0,0 +1,88 @@
+// Package export writes activities as files that can be shared outside the
+// app, such as a feeding log for a pediatrician. Writers encode one activity
+// at a time so that exports are streamed.
+package export
+
+import (
+	"dailyalu-server/internal/module/activity/domain"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Writer encodes activities to an output
+type Writer interface {
+	// Write encodes one activity
+	Write(activity *domain.Activity) error
+	// Close writes what follows the last activity and flushes the output
+	Close() error
+}
+
+// NewWriter creates a writer for the format. CSV needs the details fields
+// of each activity type up front to write its header.
+func NewWriter(format string, w io.Writer, detailKeys map[string][]string) (Writer, error) {
+	switch format {
+	case domain.ExportFormatCSV:
+		return newCSVWriter(w, detailKeys), nil
+	case domain.ExportFormatJSON:
+		return newJSONWriter(w), nil
+	case domain.ExportFormatICS:
+		return newICSWriter(w), nil
+	}
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+// ContentType returns the media type of the format
+func ContentType(format string) string {
+	switch format {
+	case domain.ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case domain.ExportFormatICS:
+		return "text/calendar; charset=utf-8"
+	}
+	return "application/json"
+}
+
+// detailFields returns the top-level fields of details rendered as text:
+// strings as they are, null as empty and anything else as JSON. Details that
+// are not an object have no fields.
+func detailFields(details json.RawMessage) map[string]string {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(details, &object); err != nil {
+		return nil
+	}
+
+	fields := make(map[string]string, len(object))
+	for key, raw := range object {
+		var text string
+		switch {
+		case json.Unmarshal(raw, &text) == nil:
+		case string(raw) == "null":
+		default:
+			text = string(raw)
+		}
+		fields[key] = text
+	}
+	return fields
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// typeTitle turns an activity type such as "tummy_time" into "Tummy time"
+func typeTitle(activityType string) string {
+	title := strings.ReplaceAll(activityType, "_", " ")
+	if title == "" {
+		return title
+	}
+	return strings.ToUpper(title[:1]) + title[1:]
+}
@@ -0,0 +1,143 @@
+package export
+
+import (
+	"bytes"
+	"dailyalu-server/internal/module/activity/domain"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testActivities() []domain.Activity {
+	loc := time.FixedZone("WIB", 7*60*60)
+	at := time.Date(2025, 3, 20, 21, 0, 0, 0, loc)
+	planID := int64(3)
+
+	return []domain.Activity{
+		{ID: 1, ChildID: 1, Type: "feeding", Details: json.RawMessage(`{"amount": 120, "unit": "ml", "notes": "Formula, warm"}`), HappensAt: at, CreatedAt: at, UpdatedAt: at},
+		{ID: 2, ChildID: 1, Type: "sleep", Details: json.RawMessage(`{"duration_minutes": 90, "place": "crib"}`), Notes: "Woke up once", Tags: []string{"nap", "teething"}, HappensAt: at.Add(time.Hour), CreatedAt: at, UpdatedAt: at},
+		{ID: 3, ChildID: 2, Type: "medicine", Details: json.RawMessage(`{"amount": 2.5, "unit": "ml", "tags": ["fever"]}`), MedicationPlanID: &planID, HappensAt: at.Add(2 * time.Hour), CreatedAt: at, UpdatedAt: at},
+	}
+}
+
+func writeAll(t *testing.T, format string, detailKeys map[string][]string, activities []domain.Activity) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	writer, err := NewWriter(format, &out, detailKeys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range activities {
+		if err := writer.Write(&activities[i]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return out.String()
+}
+
+func TestCSVWriter(t *testing.T) {
+	detailKeys := map[string][]string{
+		"sleep":    {"duration_minutes", "place"},
+		"feeding":  {"amount", "notes", "unit"},
+		"medicine": {"amount", "tags", "unit"},
+	}
+
+	got := writeAll(t, domain.ExportFormatCSV, detailKeys, testActivities())
+	expected := strings.Join([]string{
+		"id,child_id,type,happens_at,medication_plan_id,created_at,updated_at,notes,tags,feeding.amount,feeding.notes,feeding.unit,medicine.amount,medicine.tags,medicine.unit,sleep.duration_minutes,sleep.place",
+		`1,1,feeding,2025-03-20T21:00:00+07:00,,2025-03-20T21:00:00+07:00,2025-03-20T21:00:00+07:00,,,120,"Formula, warm",ml,,,,,`,
+		`2,1,sleep,2025-03-20T22:00:00+07:00,,2025-03-20T21:00:00+07:00,2025-03-20T21:00:00+07:00,Woke up once,nap;teething,,,,,,,90,crib`,
+		`3,2,medicine,2025-03-20T23:00:00+07:00,3,2025-03-20T21:00:00+07:00,2025-03-20T21:00:00+07:00,,,,,,2.5,"[""fever""]",ml,,`,
+		"",
+	}, "\n")
+	if got != expected {
+		t.Errorf("unexpected CSV:\n%s\nexpected:\n%s", got, expected)
+	}
+
+	// An empty export has only the header
+	if got := writeAll(t, domain.ExportFormatCSV, nil, nil); got != strings.Join(csvBaseColumns, ",")+"\n" {
+		t.Errorf("unexpected empty CSV %q", got)
+	}
+}
+
+func TestJSONWriter(t *testing.T) {
+	got := writeAll(t, domain.ExportFormatJSON, nil, testActivities())
+
+	var activities []domain.Activity
+	if err := json.Unmarshal([]byte(got), &activities); err != nil {
+		t.Fatalf("expected a JSON array, got %s: %v", got, err)
+	}
+	if len(activities) != 3 || activities[2].ID != 3 || *activities[2].MedicationPlanID != 3 {
+		t.Errorf("unexpected activities %+v", activities)
+	}
+
+	if got := writeAll(t, domain.ExportFormatJSON, nil, nil); got != "[]\n" {
+		t.Errorf("unexpected empty JSON %q", got)
+	}
+}
+
+func TestICSWriter(t *testing.T) {
+	got := writeAll(t, domain.ExportFormatICS, nil, testActivities())
+
+	if !strings.HasPrefix(got, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n") || !strings.HasSuffix(got, "END:VCALENDAR\r\n") {
+		t.Errorf("unexpected calendar:\n%s", got)
+	}
+	if strings.Count(got, "BEGIN:VEVENT") != 3 {
+		t.Errorf("expected 3 events:\n%s", got)
+	}
+	for _, line := range []string{
+		"UID:activity-1@dailyalu.mom\r\n",
+		"DTSTART:20250320T140000Z\r\n",
+		"SUMMARY:Feeding\r\n",
+		`DESCRIPTION:amount: 120\nnotes: Formula\, warm\nunit: ml` + "\r\n",
+		"DTSTART:20250320T150000Z\r\nDURATION:PT1H30M\r\nSUMMARY:Sleep\r\nCATEGORIES:sleep,nap,teething\r\n",
+		`DESCRIPTION:Woke up once\nduration_minutes: 90\nplace: crib` + "\r\n",
+	} {
+		if !strings.Contains(got, line) {
+			t.Errorf("expected %q in:\n%s", line, got)
+		}
+	}
+	// Only activities with a duration have one
+	if strings.Count(got, "DURATION:") != 1 {
+		t.Errorf("expected a single duration:\n%s", got)
+	}
+	for _, line := range strings.Split(got, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+}
+
+func TestICSFormatting(t *testing.T) {
+	durations := map[time.Duration]string{
+		90 * time.Minute:             "PT1H30M",
+		26*time.Hour + 5*time.Second: "P1DT2H5S",
+		24 * time.Hour:               "P1D",
+		0:                            "PT0S",
+	}
+	for duration, expected := range durations {
+		if got := icsDuration(duration); got != expected {
+			t.Errorf("expected %s for %v, got %s", expected, duration, got)
+		}
+	}
+
+	long := "DESCRIPTION:" + strings.Repeat("é", 50)
+	folded := foldLine(long)
+	if strings.ReplaceAll(folded, "\r\n ", "") != long {
+		t.Errorf("folding changed the line: %q", folded)
+	}
+	for _, line := range strings.Split(folded, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+
+	if _, err := NewWriter("xml", &bytes.Buffer{}, nil); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
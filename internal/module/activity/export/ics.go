@@ -0,0 +1,165 @@
+package export
+
+import (
+	"dailyalu-server/internal/module/activity/domain"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icsWriter writes an iCalendar (RFC 5545) calendar with one VEVENT per
+// activity. Activities whose details give a duration, such as sleeps, span
+// it; the others are events without length.
+type icsWriter struct {
+	w      io.Writer
+	header bool
+}
+
+const icsTimeFormat = "20060102T150405Z"
+
+func newICSWriter(w io.Writer) *icsWriter {
+	return &icsWriter{w: w}
+}
+
+func (iw *icsWriter) writeHeader() error {
+	if iw.header {
+		return nil
+	}
+	iw.header = true
+
+	return iw.writeLines(
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//DailyAlu//Activity Export//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:DailyAlu activities",
+	)
+}
+
+func (iw *icsWriter) Write(activity *domain.Activity) error {
+	if err := iw.writeHeader(); err != nil {
+		return err
+	}
+
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:activity-" + strconv.Itoa(activity.ID) + "@dailyalu.mom",
+		"DTSTAMP:" + activity.UpdatedAt.UTC().Format(icsTimeFormat),
+		"DTSTART:" + activity.HappensAt.UTC().Format(icsTimeFormat),
+	}
+	if duration := activity.Duration(); duration > 0 {
+		lines = append(lines, "DURATION:"+icsDuration(duration))
+	}
+	// Tags are categories next to the activity type
+	categories := []string{icsText(activity.Type)}
+	for _, tag := range activity.Tags {
+		categories = append(categories, icsText(tag))
+	}
+	lines = append(lines,
+		"SUMMARY:"+icsText(typeTitle(activity.Type)),
+		"CATEGORIES:"+strings.Join(categories, ","),
+	)
+
+	// Notes come first, followed by the details
+	var description []string
+	if activity.Notes != "" {
+		description = append(description, activity.Notes)
+	}
+	fields := detailFields(activity.Details)
+	for _, key := range sortedKeys(fields) {
+		description = append(description, key+": "+fields[key])
+	}
+	if len(description) > 0 {
+		lines = append(lines, "DESCRIPTION:"+icsText(strings.Join(description, "\n")))
+	}
+	lines = append(lines, "END:VEVENT")
+
+	return iw.writeLines(lines...)
+}
+
+func (iw *icsWriter) Close() error {
+	if err := iw.writeHeader(); err != nil {
+		return err
+	}
+	return iw.writeLines("END:VCALENDAR")
+}
+
+// writeLines writes content lines ended by CRLF
+func (iw *icsWriter) writeLines(lines ...string) error {
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldLine(line))
+		b.WriteString("\r\n")
+	}
+
+	_, err := io.WriteString(iw.w, b.String())
+	return err
+}
+
+// foldLine splits a content line longer than 75 octets, on character
+// boundaries. Continuation lines start with a space, which counts towards
+// their length.
+func foldLine(line string) string {
+	var b strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+// icsText escapes a TEXT value
+func icsText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// icsDuration formats a positive duration, e.g. PT1H30M or P1DT2H
+func icsDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+
+	var b strings.Builder
+	b.WriteString("P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if hours > 0 || minutes > 0 || seconds > 0 {
+		b.WriteString("T")
+		if hours > 0 {
+			fmt.Fprintf(&b, "%dH", hours)
+		}
+		if minutes > 0 {
+			fmt.Fprintf(&b, "%dM", minutes)
+		}
+		if seconds > 0 {
+			fmt.Fprintf(&b, "%dS", seconds)
+		}
+	}
+	if b.Len() == 1 {
+		b.WriteString("T0S")
+	}
+	return b.String()
+}
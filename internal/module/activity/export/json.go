@@ -0,0 +1,45 @@
+package export
+
+import (
+	"dailyalu-server/internal/module/activity/domain"
+	"encoding/json"
+	"io"
+)
+
+// jsonWriter writes a JSON array of activities in the format of the API
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{w: w}
+}
+
+func (jw *jsonWriter) Write(activity *domain.Activity) error {
+	encoded, err := json.Marshal(activity)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	if jw.count == 0 {
+		separator = "[\n"
+	}
+	jw.count++
+
+	if _, err := io.WriteString(jw.w, separator); err != nil {
+		return err
+	}
+	_, err = jw.w.Write(encoded)
+	return err
+}
+
+func (jw *jsonWriter) Close() error {
+	end := "\n]\n"
+	if jw.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(jw.w, end)
+	return err
+}
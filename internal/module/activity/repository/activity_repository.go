@@ -7,8 +7,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/lib/pq"
 )
 
+// activityColumns are the columns read into an activity. Tags are gathered
+// from the join table, sorted.
+const activityColumns = `id, user_id, child_id, type, details, notes,
+	ARRAY(
+		SELECT tags.name FROM activity_tags JOIN tags ON tags.id = activity_tags.tag_id
+		WHERE activity_tags.activity_id = activities.id
+		ORDER BY tags.name
+	) AS tags,
+	medication_plan_id, happens_at, created_at, updated_at`
+
+// searchConfig is the text search configuration of activities.search_vector
+const searchConfig = "simple"
+
 type activityRepository struct {
 	db *sql.DB
 }
@@ -17,46 +33,89 @@ func NewActivityRepository(db *sql.DB) IActivityRepository {
 	return &activityRepository{db: db}
 }
 
-func (r *activityRepository) Create(ctx context.Context, activity *domain.Activity) error {
+// Queryer is implemented by both *sql.DB and *sql.Tx
+type Queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Insert writes an activity and its tags with q. Other repositories pass
+// their transaction so the activity is only created if their change
+// commits.
+func Insert(ctx context.Context, q Queryer, activity *domain.Activity) error {
 	query := `
-		INSERT INTO activities (user_id, child_id, type, details, happens_at, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO activities (user_id, child_id, type, details, notes, medication_plan_id, happens_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id
 	`
-	err := r.db.QueryRowContext(ctx, query,
+	err := q.QueryRowContext(ctx, query,
 		activity.UserID,
 		activity.ChildID,
 		activity.Type,
 		activity.Details,
+		activity.Notes,
+		activity.MedicationPlanID,
 		activity.HappensAt,
 		activity.CreatedAt,
 		activity.UpdatedAt,
 	).Scan(&activity.ID)
+	if err != nil {
+		return err
+	}
+
+	return addTags(ctx, q, activity)
+}
+
+// addTags links an activity to its tags, creating the owner's tags that do
+// not exist yet
+func addTags(ctx context.Context, q Queryer, activity *domain.Activity) error {
+	if len(activity.Tags) == 0 {
+		return nil
+	}
 
+	query := `
+		INSERT INTO tags (user_id, name, created_at)
+		SELECT $1, name, $3 FROM unnest($2::TEXT[]) AS name
+		ON CONFLICT (user_id, name) DO NOTHING
+	`
+	if _, err := q.ExecContext(ctx, query, activity.UserID, pq.Array(activity.Tags), time.Now()); err != nil {
+		return fmt.Errorf("failed to create tags: %w", err)
+	}
+
+	query = `
+		INSERT INTO activity_tags (activity_id, tag_id)
+		SELECT $1, id FROM tags WHERE user_id = $2 AND name = ANY($3)
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := q.ExecContext(ctx, query, activity.ID, activity.UserID, pq.Array(activity.Tags)); err != nil {
+		return fmt.Errorf("failed to tag activity: %w", err)
+	}
+
+	return nil
+}
+
+func (r *activityRepository) Create(ctx context.Context, activity *domain.Activity) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create activity: %w", err)
 	}
+	defer tx.Rollback()
 
-	return nil
+	if err := Insert(ctx, tx, activity); err != nil {
+		return fmt.Errorf("failed to create activity: %w", err)
+	}
+
+	return tx.Commit()
 }
 
 func (r *activityRepository) GetByID(ctx context.Context, id int) (*domain.Activity, error) {
 	query := `
-		SELECT id, user_id, child_id, type, details, happens_at, created_at, updated_at
+		SELECT ` + activityColumns + `
 		FROM activities
 		WHERE id = $1
 	`
 	activity := &domain.Activity{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&activity.ID,
-		&activity.UserID,
-		&activity.ChildID,
-		&activity.Type,
-		&activity.Details,
-		&activity.HappensAt,
-		&activity.CreatedAt,
-		&activity.UpdatedAt,
-	)
+	err := scanActivity(r.db.QueryRowContext(ctx, query, id), activity)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -71,13 +130,20 @@ func (r *activityRepository) GetByID(ctx context.Context, id int) (*domain.Activ
 
 func (r *activityRepository) Update(ctx context.Context, activity *domain.Activity) error {
 	fmt.Println(activity)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update activity: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE activities
-		SET details = $1, happens_at = $2, updated_at = $3
-		WHERE id = $4
+		SET details = $1, notes = $2, happens_at = $3, updated_at = $4
+		WHERE id = $5
 	`
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		activity.Details,
+		activity.Notes,
 		activity.HappensAt,
 		activity.UpdatedAt,
 		activity.ID,
@@ -96,7 +162,15 @@ func (r *activityRepository) Update(ctx context.Context, activity *domain.Activi
 		return fmt.Errorf("activity not found or unauthorized")
 	}
 
-	return nil
+	// Tags are replaced as a whole
+	if _, err := tx.ExecContext(ctx, `DELETE FROM activity_tags WHERE activity_id = $1`, activity.ID); err != nil {
+		return fmt.Errorf("failed to update activity tags: %w", err)
+	}
+	if err := addTags(ctx, tx, activity); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *activityRepository) Delete(ctx context.Context, id int) error {
@@ -119,6 +193,134 @@ func (r *activityRepository) Delete(ctx context.Context, id int) error {
 }
 
 func (r *activityRepository) Search(ctx context.Context, req *domain.SearchActivityRequest) (*domain.ActivityResponse, error) {
+	conditions, args := searchConditions(req)
+	argCount := len(args) + 1
+
+	// Count total records
+	countQuery := fmt.Sprintf(`
+		SELECT COUNT(*) FROM activities WHERE %s
+	`, strings.Join(conditions, " AND "))
+
+	var total int64
+	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count activities: %w", err)
+	}
+
+	// Calculate pagination
+	offset := (req.Page - 1) * req.PageSize
+	totalPages := (int(total) + req.PageSize - 1) / req.PageSize
+
+	// Get paginated records, the most relevant first when searching text
+	order := "happens_at DESC"
+	if req.Query != "" {
+		order = fmt.Sprintf("ts_rank(search_vector, websearch_to_tsquery('%s', $%d)) DESC, happens_at DESC", searchConfig, argCount)
+		args = append(args, req.Query)
+		argCount++
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM activities
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, activityColumns, strings.Join(conditions, " AND "), order, argCount, argCount+1)
+
+	args = append(args, req.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search activities: %w", err)
+	}
+	defer rows.Close()
+
+	var activities []domain.Activity
+	for rows.Next() {
+		var activity domain.Activity
+		if err := scanActivity(rows, &activity); err != nil {
+			return nil, fmt.Errorf("failed to scan activity: %w", err)
+		}
+		activities = append(activities, activity)
+	}
+
+	return &domain.ActivityResponse{
+		Activities: activities,
+		Pagination: domain.Pagination{
+			Total:       total,
+			CurrentPage: req.Page,
+			PageSize:    req.PageSize,
+			TotalPages:  totalPages,
+		},
+	}, nil
+}
+
+// Export calls fn with every activity matching the filters of req, oldest
+// first. Rows are read one at a time, so exports of any size use constant
+// memory. Pagination is ignored.
+func (r *activityRepository) Export(ctx context.Context, req *domain.SearchActivityRequest, fn func(activity *domain.Activity) error) error {
+	conditions, args := searchConditions(req)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM activities
+		WHERE %s
+		ORDER BY happens_at, id
+	`, activityColumns, strings.Join(conditions, " AND "))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to export activities: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var activity domain.Activity
+		if err := scanActivity(rows, &activity); err != nil {
+			return fmt.Errorf("failed to scan activity: %w", err)
+		}
+		if err := fn(&activity); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// DetailKeys returns the top-level details fields used by the activities
+// matching the filters of req, sorted, by activity type
+func (r *activityRepository) DetailKeys(ctx context.Context, req *domain.SearchActivityRequest) (map[string][]string, error) {
+	conditions, args := searchConditions(req)
+	conditions = append(conditions, "jsonb_typeof(details) = 'object'")
+
+	query := fmt.Sprintf(`
+		SELECT DISTINCT type, jsonb_object_keys(details) AS key
+		FROM activities
+		WHERE %s
+		ORDER BY type, key
+	`, strings.Join(conditions, " AND "))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity detail keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := map[string][]string{}
+	for rows.Next() {
+		var activityType, key string
+		if err := rows.Scan(&activityType, &key); err != nil {
+			return nil, fmt.Errorf("failed to scan activity detail key: %w", err)
+		}
+		keys[activityType] = append(keys[activityType], key)
+	}
+
+	return keys, rows.Err()
+}
+
+// searchConditions builds the WHERE conditions of a search and their
+// arguments, numbered from $1
+func searchConditions(req *domain.SearchActivityRequest) ([]string, []interface{}) {
 	conditions := []string{"1=1"}
 	args := []interface{}{}
 	argCount := 1
@@ -156,72 +358,51 @@ func (r *activityRepository) Search(ctx context.Context, req *domain.SearchActiv
 	// Handle JSONB search
 	if len(req.Details) > 0 {
 		for key, value := range req.Details {
-			jsonbCond := fmt.Sprintf("details->>'%s' = $%d", key, argCount)
+			jsonbCond := fmt.Sprintf("details->>$%d = $%d", argCount, argCount+1)
 			conditions = append(conditions, jsonbCond)
 			valueStr, _ := json.Marshal(value)
-			args = append(args, string(valueStr))
-			argCount++
+			args = append(args, key, string(valueStr))
+			argCount += 2
 		}
 	}
 
-	// Count total records
-	countQuery := fmt.Sprintf(`
-		SELECT COUNT(*) FROM activities WHERE %s
-	`, strings.Join(conditions, " AND "))
-
-	var total int64
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
-	if err != nil {
-		return nil, fmt.Errorf("failed to count activities: %w", err)
+	// Activities having every tag
+	if len(req.Tags) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`id IN (
+			SELECT activity_tags.activity_id FROM activity_tags JOIN tags ON tags.id = activity_tags.tag_id
+			WHERE tags.name = ANY($%d)
+			GROUP BY activity_tags.activity_id
+			HAVING COUNT(*) = $%d
+		)`, argCount, argCount+1))
+		args = append(args, pq.Array(req.Tags), len(req.Tags))
+		argCount += 2
 	}
 
-	// Calculate pagination
-	offset := (req.Page - 1) * req.PageSize
-	totalPages := (int(total) + req.PageSize - 1) / req.PageSize
-
-	// Get paginated records
-	query := fmt.Sprintf(`
-		SELECT id, user_id, child_id, type, details, happens_at, created_at, updated_at
-		FROM activities
-		WHERE %s
-		ORDER BY happens_at DESC
-		LIMIT $%d OFFSET $%d
-	`, strings.Join(conditions, " AND "), argCount, argCount+1)
-
-	args = append(args, req.PageSize, offset)
-
-	rows, err := r.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search activities: %w", err)
+	if req.Query != "" {
+		conditions = append(conditions, fmt.Sprintf("search_vector @@ websearch_to_tsquery('%s', $%d)", searchConfig, argCount))
+		args = append(args, req.Query)
+		argCount++
 	}
-	defer rows.Close()
 
-	var activities []domain.Activity
-	for rows.Next() {
-		var activity domain.Activity
-		err := rows.Scan(
-			&activity.ID,
-			&activity.UserID,
-			&activity.ChildID,
-			&activity.Type,
-			&activity.Details,
-			&activity.HappensAt,
-			&activity.CreatedAt,
-			&activity.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan activity: %w", err)
-		}
-		activities = append(activities, activity)
-	}
+	return conditions, args
+}
 
-	return &domain.ActivityResponse{
-		Activities: activities,
-		Pagination: domain.Pagination{
-			Total:       total,
-			CurrentPage: req.Page,
-			PageSize:    req.PageSize,
-			TotalPages:  totalPages,
-		},
-	}, nil
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanActivity(row rowScanner, activity *domain.Activity) error {
+	return row.Scan(
+		&activity.ID,
+		&activity.UserID,
+		&activity.ChildID,
+		&activity.Type,
+		&activity.Details,
+		&activity.Notes,
+		pq.Array(&activity.Tags),
+		&activity.MedicationPlanID,
+		&activity.HappensAt,
+		&activity.CreatedAt,
+		&activity.UpdatedAt,
+	)
 }
@@ -11,4 +11,9 @@ type IActivityRepository interface {
 	Update(ctx context.Context, activity *domain.Activity) error
 	Delete(ctx context.Context, id int) error
 	Search(ctx context.Context, req *domain.SearchActivityRequest) (*domain.ActivityResponse, error)
+	// Export streams the activities matching the search filters to fn
+	Export(ctx context.Context, req *domain.SearchActivityRequest, fn func(activity *domain.Activity) error) error
+	// DetailKeys returns the details fields used by each activity type
+	// among the activities matching the search filters
+	DetailKeys(ctx context.Context, req *domain.SearchActivityRequest) (map[string][]string, error)
 }
\ No newline at end of file
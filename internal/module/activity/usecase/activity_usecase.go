@@ -3,19 +3,40 @@ package usecase
 import (
 	"context"
 	"dailyalu-server/internal/module/activity/domain"
+	"dailyalu-server/internal/module/activity/export"
 	"dailyalu-server/internal/module/activity/repository"
+	medicationDomain "dailyalu-server/internal/module/medication/domain"
 	"dailyalu-server/internal/utils"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
 	"time"
 )
 
+// UnitPreferencesResolver returns the units a user wants activity details in
+type UnitPreferencesResolver func(userID string) (utils.UnitTargets, error)
+
+// DoseChecker validates a medicine dose against its medication plan and
+// returns warnings about it
+type DoseChecker func(ctx context.Context, req *medicationDomain.DoseCheckRequest) ([]string, error)
+
+// EventPublisher notifies live clients that an activity changed
+type EventPublisher func(ctx context.Context, event string, activity *domain.Activity)
+
 type activityUseCase struct {
-	repo repository.IActivityRepository
+	repo         repository.IActivityRepository
+	resolveUnits UnitPreferencesResolver
+	checkDose    DoseChecker
+	publish      EventPublisher
 }
 
-func NewActivityUseCase(repo repository.IActivityRepository) IActivityUseCase {
+func NewActivityUseCase(repo repository.IActivityRepository, resolveUnits UnitPreferencesResolver, checkDose DoseChecker, publish EventPublisher) IActivityUseCase {
 	return &activityUseCase{
-		repo: repo,
+		repo:         repo,
+		resolveUnits: resolveUnits,
+		checkDose:    checkDose,
+		publish:      publish,
 	}
 }
 
@@ -28,19 +49,32 @@ func (uc *activityUseCase) Create(ctx context.Context, req *domain.CreateActivit
 	}
 
 	activity := &domain.Activity{
-		UserID:    req.UserID,
-		ChildID:   req.ChildID,
-		Type:      req.Type,
-		Details:   req.Details,
-		HappensAt: happensAt,
-		CreatedAt: now,
-		UpdatedAt: now,
+		UserID:           req.UserID,
+		ChildID:          req.ChildID,
+		Type:             req.Type,
+		Details:          req.Details,
+		Notes:            strings.TrimSpace(req.Notes),
+		Tags:             domain.NormalizeTags(req.Tags),
+		MedicationPlanID: req.MedicationPlanID,
+		HappensAt:        happensAt,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if activity.MedicationPlanID != nil && activity.Type != domain.TypeMedicine {
+		return nil, ErrMedicationPlanRequiresMedicine
+	}
+
+	if activity.Warnings, err = uc.checkMedicationDose(ctx, activity, req.AllowExceedingMax); err != nil {
+		return nil, err
 	}
 
 	if err = uc.repo.Create(ctx, activity); err != nil {
 		return nil, fmt.Errorf("failed to create activity: %w", err)
 	}
 
+	uc.present(ctx, activity, nil)
+	uc.publishEvent(ctx, domain.EventCreated, activity)
 	return activity, nil
 }
 
@@ -52,6 +86,7 @@ func (uc *activityUseCase) GetByID(ctx context.Context, id int) (*domain.Activit
 	if activity == nil {
 		return nil, fmt.Errorf("activity not found")
 	}
+	uc.present(ctx, activity, nil)
 	return activity, nil
 }
 
@@ -71,23 +106,45 @@ func (uc *activityUseCase) Update(ctx context.Context, req *domain.UpdateActivit
 
 	// Update fields
 	activity.Details = req.Details
+	if req.Notes != nil {
+		activity.Notes = strings.TrimSpace(*req.Notes)
+	}
+	if req.Tags != nil {
+		activity.Tags = domain.NormalizeTags(req.Tags)
+	}
 	activity.UpdatedAt = time.Now()
 	activity.HappensAt, err = utils.TimeLocationParsing(ctx, req.HappensAt)
 	if err != nil {	
 		return nil, fmt.Errorf("failed to parse time: %w", err)
 	}
 
+	if activity.Warnings, err = uc.checkMedicationDose(ctx, activity, req.AllowExceedingMax); err != nil {
+		return nil, err
+	}
+
 	if err := uc.repo.Update(ctx, activity); err != nil {
 		return nil, fmt.Errorf("failed to update activity: %w", err)
 	}
 
+	uc.present(ctx, activity, nil)
+	uc.publishEvent(ctx, domain.EventUpdated, activity)
 	return activity, nil
 }
 
 func (uc *activityUseCase) Delete(ctx context.Context, id int) error {
+	// Load the activity first so the event can name its child
+	activity, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get activity: %w", err)
+	}
+
 	if err := uc.repo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete activity: %w", err)
 	}
+
+	if activity != nil {
+		uc.publishEvent(ctx, domain.EventDeleted, activity)
+	}
 	return nil
 }
 
@@ -100,11 +157,118 @@ func (uc *activityUseCase) Search(ctx context.Context, req *domain.SearchActivit
 	if req.PageSize < 1 || req.PageSize > 100 {
 		req.PageSize = 10
 	}
+	normalizeSearch(req)
 
 	response, err := uc.repo.Search(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search activities: %w", err)
 	}
 
+	units := map[string]*utils.UnitTargets{}
+	for i := range response.Activities {
+		uc.present(ctx, &response.Activities[i], units)
+	}
+
 	return response, nil
 }
+
+func (uc *activityUseCase) Export(ctx context.Context, req *domain.SearchActivityRequest, format string, w io.Writer) error {
+	if !slices.Contains(domain.ExportFormats, format) {
+		return ErrInvalidExportFormat
+	}
+
+	normalizeSearch(req)
+
+	// CSV columns are known before the first row is written
+	var detailKeys map[string][]string
+	if format == domain.ExportFormatCSV {
+		var err error
+		if detailKeys, err = uc.repo.DetailKeys(ctx, req); err != nil {
+			return fmt.Errorf("failed to export activities: %w", err)
+		}
+	}
+
+	writer, err := export.NewWriter(format, w, detailKeys)
+	if err != nil {
+		return err
+	}
+
+	units := map[string]*utils.UnitTargets{}
+	err = uc.repo.Export(ctx, req, func(activity *domain.Activity) error {
+		uc.present(ctx, activity, units)
+		return writer.Write(activity)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export activities: %w", err)
+	}
+
+	return writer.Close()
+}
+
+// normalizeSearch matches tags the way they are stored and ignores a blank
+// text search
+func normalizeSearch(req *domain.SearchActivityRequest) {
+	if len(req.Tags) > 0 {
+		req.Tags = domain.NormalizeTags(req.Tags)
+	}
+	req.Query = strings.TrimSpace(req.Query)
+}
+
+// publishEvent notifies live clients of a change, if publishing is set up
+func (uc *activityUseCase) publishEvent(ctx context.Context, event string, activity *domain.Activity) {
+	if uc.publish != nil {
+		uc.publish(ctx, event, activity)
+	}
+}
+
+// checkMedicationDose validates a dose logged against a medication plan. The
+// activity itself is left out of the plan's doses so updates are not counted
+// twice.
+func (uc *activityUseCase) checkMedicationDose(ctx context.Context, activity *domain.Activity, allowExceedingMax bool) ([]string, error) {
+	if activity.MedicationPlanID == nil || uc.checkDose == nil {
+		return nil, nil
+	}
+
+	warnings, err := uc.checkDose(ctx, &medicationDomain.DoseCheckRequest{
+		UserID:            activity.UserID,
+		ChildID:           int64(activity.ChildID),
+		PlanID:            *activity.MedicationPlanID,
+		At:                activity.HappensAt,
+		ExcludeActivityID: int64(activity.ID),
+		AllowExceedingMax: allowExceedingMax,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check medication dose: %w", err)
+	}
+
+	return warnings, nil
+}
+
+// present converts an activity for the response: times are moved to the
+// user's time zone and quantities in the details to the owner's preferred
+// units. Medicine doses keep the unit they were measured in. cache avoids
+// resolving the preferences of the same user repeatedly.
+func (uc *activityUseCase) present(ctx context.Context, activity *domain.Activity, cache map[string]*utils.UnitTargets) {
+	loc := utils.LocationFromContext(ctx)
+	activity.HappensAt = activity.HappensAt.In(loc)
+	activity.CreatedAt = activity.CreatedAt.In(loc)
+	activity.UpdatedAt = activity.UpdatedAt.In(loc)
+
+	if uc.resolveUnits == nil || activity.Type == domain.TypeMedicine {
+		return
+	}
+
+	units, ok := cache[activity.UserID]
+	if !ok {
+		if targets, err := uc.resolveUnits(activity.UserID); err == nil {
+			units = &targets
+		}
+		if cache != nil {
+			cache[activity.UserID] = units
+		}
+	}
+
+	if units != nil {
+		activity.Details = utils.ConvertDetailUnits(activity.Details, *units)
+	}
+}
@@ -0,0 +1,9 @@
+package usecase
+
+import "errors"
+
+// Domain errors for activity module
+var (
+	ErrMedicationPlanRequiresMedicine = errors.New("only medicine activities can reference a medication plan")
+	ErrInvalidExportFormat            = errors.New("invalid export format")
+)
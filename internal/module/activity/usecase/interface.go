@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"dailyalu-server/internal/module/activity/domain"
+	"io"
 )
 
 type IActivityUseCase interface {
@@ -11,4 +12,7 @@ type IActivityUseCase interface {
 	Update(ctx context.Context, req *domain.UpdateActivityRequest) (*domain.Activity, error)
 	Delete(ctx context.Context, id int) error
 	Search(ctx context.Context, req *domain.SearchActivityRequest) (*domain.ActivityResponse, error)
+	// Export writes every activity matching the search filters to w in the
+	// format, without pagination
+	Export(ctx context.Context, req *domain.SearchActivityRequest, format string, w io.Writer) error
 }
\ No newline at end of file
@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// Kinds of attachments
+const (
+	// KindFile attachments are photos and documents of a child, optionally
+	// attached to one of the child's activities
+	KindFile = "file"
+	// KindPhoto is the profile photo of a child; a child has at most one
+	KindPhoto = "photo"
+)
+
+// FileTypes maps the content types that can be uploaded to the extension
+// they are stored with. Content types are sniffed from the file, not taken
+// from the upload.
+var FileTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+// IsImage reports whether the content type is an image, the only files
+// profile photos may be
+func IsImage(contentType string) bool {
+	return contentType != "application/pdf"
+}
+
+// Attachment is an uploaded file of a child
+type Attachment struct {
+	ID          int64  `json:"id"`
+	UserID      string `json:"user_id"`
+	ChildID     int64  `json:"child_id"`
+	ActivityID  *int64 `json:"activity_id,omitempty"`
+	Kind        string `json:"kind"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// Width and Height are zero for documents and images that could not be
+	// measured
+	Width        int    `json:"width,omitempty"`
+	Height       int    `json:"height,omitempty"`
+	StorageKey   string `json:"-"`
+	ThumbnailKey string `json:"-"`
+	// URL and ThumbnailURL are signed download links, valid until
+	// URLExpiresAt. They are signed again every time the attachment is read.
+	URL          string     `json:"url,omitempty"`
+	ThumbnailURL string     `json:"thumbnail_url,omitempty"`
+	URLExpiresAt *time.Time `json:"url_expires_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// UploadRequest represents an uploaded file. Profile photos have no
+// activity.
+type UploadRequest struct {
+	UserID     string `json:"-"`
+	ChildID    int64  `json:"-"`
+	ActivityID *int64 `json:"activity_id,omitempty"`
+	Filename   string `json:"-"`
+	Content    []byte `json:"-"`
+}
+
+// GetAttachmentsRequest represents the request to list the attachments of a
+// child, or of one of its activities
+type GetAttachmentsRequest struct {
+	UserID     string `json:"-"`
+	ChildID    int64  `json:"-"`
+	ActivityID *int64 `json:"activity_id,omitempty"`
+}
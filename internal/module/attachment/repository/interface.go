@@ -0,0 +1,22 @@
+package repository
+
+import "dailyalu-server/internal/module/attachment/domain"
+
+// IAttachmentRepository defines the interface for attachment data access
+type IAttachmentRepository interface {
+	Create(attachment *domain.Attachment) error
+	GetByID(id int64) (*domain.Attachment, error)
+	// GetByChild returns the file attachments of a child, only those of the
+	// activity unless it is nil, newest first
+	GetByChild(childID int64, activityID *int64) ([]domain.Attachment, error)
+	// GetPhoto returns the profile photo of a child, or nil when it has none
+	GetPhoto(childID int64) (*domain.Attachment, error)
+	// GetPhotos returns the profile photos of those of the children that
+	// have one
+	GetPhotos(childIDs []int64) ([]domain.Attachment, error)
+	// ReplacePhoto stores the new profile photo of a child and removes the
+	// previous one in one transaction. It returns the previous photo, or nil,
+	// so that its files can be deleted.
+	ReplacePhoto(photo *domain.Attachment) (*domain.Attachment, error)
+	Delete(id int64) error
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/attachment/domain"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const attachmentColumns = `id, user_id, child_id, activity_id, kind, filename, content_type, size, width, height,
+	storage_key, thumbnail_key, created_at`
+
+// PostgresAttachmentRepository implements the attachment repository interface using PostgreSQL
+type PostgresAttachmentRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAttachmentRepository creates a new PostgreSQL attachment repository
+func NewPostgresAttachmentRepository(db *sql.DB) IAttachmentRepository {
+	return &PostgresAttachmentRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new attachment
+func (r *PostgresAttachmentRepository) Create(attachment *domain.Attachment) error {
+	return insert(r.db, attachment)
+}
+
+// GetByID retrieves an attachment by ID
+func (r *PostgresAttachmentRepository) GetByID(id int64) (*domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE id = $1`
+
+	var attachment domain.Attachment
+	if err := scanAttachment(r.db.QueryRow(query, id), &attachment); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// GetByChild retrieves the file attachments of a child
+func (r *PostgresAttachmentRepository) GetByChild(childID int64, activityID *int64) ([]domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments
+		WHERE child_id = $1 AND kind = $2 AND ($3::BIGINT IS NULL OR activity_id = $3)
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Query(query, childID, domain.KindFile, activityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []domain.Attachment{}
+	for rows.Next() {
+		var attachment domain.Attachment
+		if err := scanAttachment(rows, &attachment); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+// GetPhoto retrieves the profile photo of a child
+func (r *PostgresAttachmentRepository) GetPhoto(childID int64) (*domain.Attachment, error) {
+	return getPhoto(r.db, childID, false)
+}
+
+// GetPhotos retrieves the profile photos of several children
+func (r *PostgresAttachmentRepository) GetPhotos(childIDs []int64) ([]domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE child_id = ANY($1) AND kind = $2`
+
+	rows, err := r.db.Query(query, pq.Array(childIDs), domain.KindPhoto)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var photos []domain.Attachment
+	for rows.Next() {
+		var photo domain.Attachment
+		if err := scanAttachment(rows, &photo); err != nil {
+			return nil, err
+		}
+		photos = append(photos, photo)
+	}
+
+	return photos, rows.Err()
+}
+
+// ReplacePhoto swaps the profile photo of a child. The previous photo is
+// locked first so that concurrent uploads replace each other in turn.
+func (r *PostgresAttachmentRepository) ReplacePhoto(photo *domain.Attachment) (*domain.Attachment, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	previous, err := getPhoto(tx, photo.ChildID, true)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil {
+		if _, err := tx.Exec(`DELETE FROM attachments WHERE id = $1`, previous.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := insert(tx, photo); err != nil {
+		return nil, err
+	}
+
+	return previous, tx.Commit()
+}
+
+// Delete removes an attachment
+func (r *PostgresAttachmentRepository) Delete(id int64) error {
+	result, err := r.db.Exec(`DELETE FROM attachments WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func insert(q queryer, attachment *domain.Attachment) error {
+	query := `
+		INSERT INTO attachments (user_id, child_id, activity_id, kind, filename, content_type, size, width, height,
+			storage_key, thumbnail_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id
+	`
+
+	attachment.CreatedAt = time.Now()
+
+	return q.QueryRow(
+		query,
+		attachment.UserID,
+		attachment.ChildID,
+		attachment.ActivityID,
+		attachment.Kind,
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.Width,
+		attachment.Height,
+		attachment.StorageKey,
+		attachment.ThumbnailKey,
+		attachment.CreatedAt,
+	).Scan(&attachment.ID)
+}
+
+func getPhoto(q queryer, childID int64, forUpdate bool) (*domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE child_id = $1 AND kind = $2`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+
+	var attachment domain.Attachment
+	if err := scanAttachment(q.QueryRow(query, childID, domain.KindPhoto), &attachment); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAttachment(row rowScanner, attachment *domain.Attachment) error {
+	return row.Scan(
+		&attachment.ID,
+		&attachment.UserID,
+		&attachment.ChildID,
+		&attachment.ActivityID,
+		&attachment.Kind,
+		&attachment.Filename,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.Width,
+		&attachment.Height,
+		&attachment.StorageKey,
+		&attachment.ThumbnailKey,
+		&attachment.CreatedAt,
+	)
+}
@@ -0,0 +1,150 @@
+// Package thumbnail scales down uploaded photos. It decodes JPEG, PNG and
+// GIF with the standard library; other formats get no thumbnail.
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+// MaxPixels is the largest image, in pixels, that is decoded. It bounds the
+// memory a thumbnail takes to about 4 bytes per pixel.
+const MaxPixels = 40_000_000
+
+// Quality is the JPEG quality of thumbnails
+const Quality = 80
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrInvalidImage      = errors.New("image cannot be decoded")
+	ErrImageTooLarge     = errors.New("image has too many pixels")
+)
+
+// Thumbnail is a scaled down image
+type Thumbnail struct {
+	Content     []byte
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// Supported reports whether thumbnails can be made of images of the content
+// type
+func Supported(contentType string) bool {
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
+
+// Dimensions returns the width and height of an image without decoding it
+func Dimensions(content []byte) (int, int, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return 0, 0, ErrUnsupportedFormat
+		}
+		return 0, 0, ErrInvalidImage
+	}
+	return config.Width, config.Height, nil
+}
+
+// Generate scales an image down to fit in a size by size square, keeping its
+// aspect ratio. Images already small enough are re-encoded as they are.
+// JPEG photos give JPEG thumbnails; PNG and GIF images give PNG thumbnails,
+// which keep transparency.
+func Generate(content []byte, size int) (*Thumbnail, error) {
+	width, height, err := Dimensions(content)
+	if err != nil {
+		return nil, err
+	}
+	if width <= 0 || height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if width*height > MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	thumbWidth, thumbHeight := fit(width, height, size)
+	scaled := scale(img, thumbWidth, thumbHeight)
+
+	var buf bytes.Buffer
+	thumb := &Thumbnail{Width: thumbWidth, Height: thumbHeight}
+	if format == "jpeg" {
+		thumb.ContentType = "image/jpeg"
+		err = jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: Quality})
+	} else {
+		thumb.ContentType = "image/png"
+		err = png.Encode(&buf, scaled)
+	}
+	if err != nil {
+		return nil, err
+	}
+	thumb.Content = buf.Bytes()
+	return thumb, nil
+}
+
+// fit returns the dimensions of a width by height image scaled down to fit
+// in a size by size square
+func fit(width, height, size int) (int, int) {
+	if width <= size && height <= size {
+		return width, height
+	}
+	if width >= height {
+		return size, max(1, height*size/width)
+	}
+	return max(1, width*size/height), size
+}
+
+// scale resizes img to width by height by averaging the source pixels each
+// thumbnail pixel covers. Averaging premultiplied colors keeps the edges of
+// transparent areas clean.
+func scale(img image.Image, width, height int) *image.RGBA {
+	bounds := img.Bounds()
+	src, ok := img.(*image.RGBA)
+	if !ok {
+		src = image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	}
+	srcWidth, srcHeight := src.Bounds().Dx(), src.Bounds().Dy()
+	if srcWidth == width && srcHeight == height {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcHeight/height, max((y+1)*srcHeight/height, y*srcHeight/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcWidth/width, max((x+1)*srcWidth/width, x*srcWidth/width+1)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride+x0*4 : sy*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+
+			count := (y1 - y0) * (x1 - x0)
+			offset := y*dst.Stride + x*4
+			for i := range sum {
+				dst.Pix[offset+i] = uint8(sum[i] / count)
+			}
+		}
+	}
+	return dst
+}
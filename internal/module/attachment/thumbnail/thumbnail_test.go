@@ -0,0 +1,105 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodedImage(t *testing.T, format string, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&buf, img, nil)
+	case "png":
+		err = png.Encode(&buf, img)
+	case "gif":
+		err = gif.Encode(&buf, img, nil)
+	}
+	if err != nil {
+		t.Fatalf("failed to encode test image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		width       int
+		height      int
+		contentType string
+		thumbWidth  int
+		thumbHeight int
+	}{
+		{"landscape photo", "jpeg", 1200, 800, "image/jpeg", 320, 213},
+		{"portrait photo", "jpeg", 600, 900, "image/jpeg", 213, 320},
+		{"small png", "png", 100, 50, "image/png", 100, 50},
+		{"gif", "gif", 640, 640, "image/png", 320, 320},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumb, err := Generate(encodedImage(t, tt.format, tt.width, tt.height), 320)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if thumb.ContentType != tt.contentType || thumb.Width != tt.thumbWidth || thumb.Height != tt.thumbHeight {
+				t.Errorf("expected a %dx%d %s, got a %dx%d %s", tt.thumbWidth, tt.thumbHeight, tt.contentType, thumb.Width, thumb.Height, thumb.ContentType)
+			}
+
+			width, height, err := Dimensions(thumb.Content)
+			if err != nil || width != tt.thumbWidth || height != tt.thumbHeight {
+				t.Errorf("expected the encoded thumbnail to be %dx%d, got %dx%d (%v)", tt.thumbWidth, tt.thumbHeight, width, height, err)
+			}
+		})
+	}
+}
+
+func TestGenerateAveragesPixels(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		img.Set(x, 0, color.NRGBA{A: 255})
+		img.Set(x, 1, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+
+	thumb, err := Generate(buf.Bytes(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decoded, err := png.Decode(bytes.NewReader(thumb.Content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r, _, _, _ := decoded.At(0, 0).RGBA(); r>>8 != 127 {
+		t.Errorf("expected black and white rows to average to grey, got %d", r>>8)
+	}
+}
+
+func TestGenerateRejectsInvalidImages(t *testing.T) {
+	if _, err := Generate([]byte("%PDF-1.7"), 320); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+
+	truncated := encodedImage(t, "jpeg", 100, 100)[:200]
+	if _, err := Generate(truncated, 320); !errors.Is(err, ErrInvalidImage) {
+		t.Errorf("expected ErrInvalidImage, got %v", err)
+	}
+}
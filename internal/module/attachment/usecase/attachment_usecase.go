@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"context"
+	activityRepository "dailyalu-server/internal/module/activity/repository"
+	"dailyalu-server/internal/module/attachment/domain"
+	"dailyalu-server/internal/module/attachment/repository"
+	"dailyalu-server/internal/module/attachment/thumbnail"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	storageDomain "dailyalu-server/internal/service/storage/domain"
+	"dailyalu-server/pkg/app_log/zap_log"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Config limits uploads and download links
+type Config struct {
+	// MaxSize is the largest file, in bytes, that can be uploaded
+	MaxSize int64
+	// ThumbnailSize is the width and height thumbnails fit in
+	ThumbnailSize int
+	// URLExpiry is how long signed download URLs work
+	URLExpiry time.Duration
+}
+
+// NewConfigFromConfig reads the attachment settings under attachments
+func NewConfigFromConfig() Config {
+	return Config{
+		MaxSize:       viper.GetInt64("attachments.max_size"),
+		ThumbnailSize: viper.GetInt("attachments.thumbnail_size"),
+		URLExpiry:     viper.GetDuration("attachments.url_expiry"),
+	}
+}
+
+// AttachmentUseCase implements the attachment use case interface
+type AttachmentUseCase struct {
+	attachmentRepo  repository.IAttachmentRepository
+	activityRepo    activityRepository.IActivityRepository
+	childrenUseCase childrenUsecase.IChildrenUseCase
+	storage         storageDomain.IStorage
+	config          Config
+	logger          *zap.Logger
+	now             func() time.Time
+}
+
+// NewAttachmentUseCase creates a new attachment use case
+func NewAttachmentUseCase(attachmentRepo repository.IAttachmentRepository, activityRepo activityRepository.IActivityRepository, childrenUseCase childrenUsecase.IChildrenUseCase, storage storageDomain.IStorage, config Config) IAttachmentUseCase {
+	logger := zap_log.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &AttachmentUseCase{
+		attachmentRepo:  attachmentRepo,
+		activityRepo:    activityRepo,
+		childrenUseCase: childrenUseCase,
+		storage:         storage,
+		config:          config,
+		logger:          logger,
+		now:             time.Now,
+	}
+}
+
+// Upload stores a file of a child owned by the user
+func (uc *AttachmentUseCase) Upload(ctx context.Context, req *domain.UploadRequest) (*domain.Attachment, error) {
+	if _, err := uc.childrenUseCase.GetChild(req.ChildID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	if req.ActivityID != nil {
+		activity, err := uc.activityRepo.GetByID(ctx, int(*req.ActivityID))
+		if err != nil {
+			return nil, err
+		}
+		if activity == nil || int64(activity.ChildID) != req.ChildID {
+			return nil, ErrActivityNotFound
+		}
+	}
+
+	attachment, err := uc.store(ctx, req, domain.KindFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.attachmentRepo.Create(attachment); err != nil {
+		uc.deleteFiles(ctx, attachment)
+		return nil, err
+	}
+
+	return attachment, uc.sign(ctx, attachment)
+}
+
+// GetAttachments retrieves the file attachments of a child owned by the
+// user, or of one of its activities
+func (uc *AttachmentUseCase) GetAttachments(ctx context.Context, req *domain.GetAttachmentsRequest) ([]domain.Attachment, error) {
+	if _, err := uc.childrenUseCase.GetChild(req.ChildID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := uc.attachmentRepo.GetByChild(req.ChildID, req.ActivityID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range attachments {
+		if err := uc.sign(ctx, &attachments[i]); err != nil {
+			return nil, err
+		}
+	}
+	return attachments, nil
+}
+
+// GetAttachment retrieves a file attachment of a child owned by the user
+func (uc *AttachmentUseCase) GetAttachment(ctx context.Context, childID, id int64, userID string) (*domain.Attachment, error) {
+	attachment, err := uc.getAttachment(childID, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, uc.sign(ctx, attachment)
+}
+
+// DeleteAttachment removes a file attachment and its files
+func (uc *AttachmentUseCase) DeleteAttachment(ctx context.Context, childID, id int64, userID string) error {
+	attachment, err := uc.getAttachment(childID, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.attachmentRepo.Delete(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrAttachmentNotFound
+		}
+		return err
+	}
+
+	uc.deleteFiles(ctx, attachment)
+	return nil
+}
+
+// SetPhoto stores a new profile photo of a child owned by the user and
+// deletes the files of the previous one
+func (uc *AttachmentUseCase) SetPhoto(ctx context.Context, req *domain.UploadRequest) (*domain.Attachment, error) {
+	if _, err := uc.childrenUseCase.GetChild(req.ChildID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	photo, err := uc.store(ctx, req, domain.KindPhoto)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := uc.attachmentRepo.ReplacePhoto(photo)
+	if err != nil {
+		uc.deleteFiles(ctx, photo)
+		return nil, err
+	}
+	if previous != nil {
+		uc.deleteFiles(ctx, previous)
+	}
+
+	return photo, uc.sign(ctx, photo)
+}
+
+// GetPhoto retrieves the profile photo of a child owned by the user
+func (uc *AttachmentUseCase) GetPhoto(ctx context.Context, childID int64, userID string) (*domain.Attachment, error) {
+	photo, err := uc.getPhoto(childID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return photo, uc.sign(ctx, photo)
+}
+
+// PhotoURLs signs links to the profile photos of several children. Photos
+// uploaded by another user are skipped, so links are only handed out to the
+// owner of the child.
+func (uc *AttachmentUseCase) PhotoURLs(ctx context.Context, userID string, childIDs []int64) (map[int64]string, error) {
+	photos, err := uc.attachmentRepo.GetPhotos(childIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make(map[int64]string, len(photos))
+	for i := range photos {
+		photo := &photos[i]
+		if photo.UserID != userID {
+			continue
+		}
+		if err := uc.sign(ctx, photo); err != nil {
+			return nil, err
+		}
+		urls[photo.ChildID] = photo.URL
+		if photo.ThumbnailURL != "" {
+			urls[photo.ChildID] = photo.ThumbnailURL
+		}
+	}
+
+	return urls, nil
+}
+
+// DeletePhoto removes the profile photo of a child owned by the user
+func (uc *AttachmentUseCase) DeletePhoto(ctx context.Context, childID int64, userID string) error {
+	photo, err := uc.getPhoto(childID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.attachmentRepo.Delete(photo.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPhotoNotFound
+		}
+		return err
+	}
+
+	uc.deleteFiles(ctx, photo)
+	return nil
+}
+
+func (uc *AttachmentUseCase) getAttachment(childID, id int64, userID string) (*domain.Attachment, error) {
+	if _, err := uc.childrenUseCase.GetChild(childID, userID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := uc.attachmentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil || attachment.ChildID != childID || attachment.Kind != domain.KindFile {
+		return nil, ErrAttachmentNotFound
+	}
+
+	return attachment, nil
+}
+
+func (uc *AttachmentUseCase) getPhoto(childID int64, userID string) (*domain.Attachment, error) {
+	if _, err := uc.childrenUseCase.GetChild(childID, userID); err != nil {
+		return nil, err
+	}
+
+	photo, err := uc.attachmentRepo.GetPhoto(childID)
+	if err != nil {
+		return nil, err
+	}
+	if photo == nil {
+		return nil, ErrPhotoNotFound
+	}
+
+	return photo, nil
+}
+
+// store validates an uploaded file and puts it, and its thumbnail, in
+// storage. The returned attachment is not recorded yet.
+func (uc *AttachmentUseCase) store(ctx context.Context, req *domain.UploadRequest, kind string) (*domain.Attachment, error) {
+	if len(req.Content) == 0 {
+		return nil, ErrEmptyFile
+	}
+	if int64(len(req.Content)) > uc.config.MaxSize {
+		return nil, fmt.Errorf("%w: the largest file accepted is %d bytes", ErrFileTooLarge, uc.config.MaxSize)
+	}
+
+	// Trust the content rather than the name or type the client gave
+	contentType := http.DetectContentType(req.Content)
+	ext, ok := domain.FileTypes[contentType]
+	if !ok {
+		return nil, ErrUnsupportedFileType
+	}
+	if kind == domain.KindPhoto && !domain.IsImage(contentType) {
+		return nil, ErrPhotoNotImage
+	}
+
+	name := uuid.New().String()
+	attachment := &domain.Attachment{
+		UserID:      req.UserID,
+		ChildID:     req.ChildID,
+		ActivityID:  req.ActivityID,
+		Kind:        kind,
+		Filename:    cleanFilename(req.Filename, ext),
+		ContentType: contentType,
+		Size:        int64(len(req.Content)),
+		StorageKey:  fmt.Sprintf("children/%d/%s%s", req.ChildID, name, ext),
+	}
+
+	var thumb *thumbnail.Thumbnail
+	if thumbnail.Supported(contentType) {
+		var err error
+		if attachment.Width, attachment.Height, err = thumbnail.Dimensions(req.Content); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+		}
+		if thumb, err = thumbnail.Generate(req.Content, uc.config.ThumbnailSize); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+		}
+		attachment.ThumbnailKey = fmt.Sprintf("children/%d/%s_thumb%s", req.ChildID, name, domain.FileTypes[thumb.ContentType])
+	}
+
+	if err := uc.storage.Put(ctx, attachment.StorageKey, req.Content, contentType); err != nil {
+		return nil, err
+	}
+	if thumb != nil {
+		if err := uc.storage.Put(ctx, attachment.ThumbnailKey, thumb.Content, thumb.ContentType); err != nil {
+			uc.deleteFiles(ctx, attachment)
+			return nil, err
+		}
+	}
+
+	return attachment, nil
+}
+
+// sign sets the download URLs of an attachment
+func (uc *AttachmentUseCase) sign(ctx context.Context, attachment *domain.Attachment) error {
+	expires := uc.now().Add(uc.config.URLExpiry).UTC().Truncate(time.Second)
+
+	url, err := uc.storage.SignedURL(ctx, attachment.StorageKey, expires)
+	if err != nil {
+		return err
+	}
+	attachment.URL = url
+
+	if attachment.ThumbnailKey != "" {
+		if attachment.ThumbnailURL, err = uc.storage.SignedURL(ctx, attachment.ThumbnailKey, expires); err != nil {
+			return err
+		}
+	}
+
+	attachment.URLExpiresAt = &expires
+	return nil
+}
+
+// deleteFiles removes the files of an attachment. Failures are logged
+// rather than returned: a leftover file is harmless, and the attachment
+// pointing to it is already gone.
+func (uc *AttachmentUseCase) deleteFiles(ctx context.Context, attachment *domain.Attachment) {
+	for _, key := range []string{attachment.StorageKey, attachment.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+		if err := uc.storage.Delete(ctx, key); err != nil {
+			uc.logger.Warn("Failed to delete attachment file", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
+
+// cleanFilename keeps the base name of an uploaded file for display,
+// naming it after its type when the client gave none
+func cleanFilename(name, ext string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" || strings.TrimSpace(name) == "" {
+		return "file" + ext
+	}
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[len(runes)-255:])
+	}
+	return name
+}
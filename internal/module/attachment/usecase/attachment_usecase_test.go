@@ -0,0 +1,320 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	activityDomain "dailyalu-server/internal/module/activity/domain"
+	activityRepository "dailyalu-server/internal/module/activity/repository"
+	"dailyalu-server/internal/module/attachment/domain"
+	"dailyalu-server/internal/module/attachment/repository"
+	"dailyalu-server/internal/module/children/childrentest"
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	storageDomain "dailyalu-server/internal/service/storage/domain"
+	"errors"
+	"image"
+	"image/jpeg"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockAttachmentRepository keeps attachments in memory
+type MockAttachmentRepository struct {
+	repository.IAttachmentRepository
+	Attachments map[int64]*domain.Attachment
+	nextID      int64
+}
+
+func (m *MockAttachmentRepository) Create(attachment *domain.Attachment) error {
+	m.nextID++
+	attachment.ID = m.nextID
+	copied := *attachment
+	m.Attachments[attachment.ID] = &copied
+	return nil
+}
+
+func (m *MockAttachmentRepository) GetByID(id int64) (*domain.Attachment, error) {
+	attachment, ok := m.Attachments[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *attachment
+	return &copied, nil
+}
+
+func (m *MockAttachmentRepository) GetPhoto(childID int64) (*domain.Attachment, error) {
+	for _, attachment := range m.Attachments {
+		if attachment.ChildID == childID && attachment.Kind == domain.KindPhoto {
+			copied := *attachment
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockAttachmentRepository) GetPhotos(childIDs []int64) ([]domain.Attachment, error) {
+	var photos []domain.Attachment
+	for _, childID := range childIDs {
+		if photo, _ := m.GetPhoto(childID); photo != nil {
+			photos = append(photos, *photo)
+		}
+	}
+	return photos, nil
+}
+
+func (m *MockAttachmentRepository) ReplacePhoto(photo *domain.Attachment) (*domain.Attachment, error) {
+	previous, _ := m.GetPhoto(photo.ChildID)
+	if previous != nil {
+		delete(m.Attachments, previous.ID)
+	}
+	return previous, m.Create(photo)
+}
+
+func (m *MockAttachmentRepository) Delete(id int64) error {
+	delete(m.Attachments, id)
+	return nil
+}
+
+// MockActivityRepository knows a single activity
+type MockActivityRepository struct {
+	activityRepository.IActivityRepository
+	Activity *activityDomain.Activity
+}
+
+func (m *MockActivityRepository) GetByID(ctx context.Context, id int) (*activityDomain.Activity, error) {
+	if m.Activity == nil || m.Activity.ID != id {
+		return nil, nil
+	}
+	return m.Activity, nil
+}
+
+// MockStorage keeps files in memory and signs URLs with their key
+type MockStorage struct {
+	Files map[string][]byte
+}
+
+func (m *MockStorage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	m.Files[key] = content
+	return nil
+}
+
+func (m *MockStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	content, ok := m.Files[key]
+	if !ok {
+		return nil, storageDomain.ErrObjectNotFound
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (m *MockStorage) Delete(ctx context.Context, key string) error {
+	delete(m.Files, key)
+	return nil
+}
+
+func (m *MockStorage) SignedURL(ctx context.Context, key string, expires time.Time) (string, error) {
+	return "https://files.test/" + key + "?expires=" + expires.Format(time.RFC3339), nil
+}
+
+var now = time.Date(2025, 3, 20, 8, 0, 0, 0, time.UTC)
+
+func newTestUseCase() (*AttachmentUseCase, *MockAttachmentRepository, *MockStorage) {
+	repo := &MockAttachmentRepository{Attachments: map[int64]*domain.Attachment{}}
+	store := &MockStorage{Files: map[string][]byte{}}
+	uc := NewAttachmentUseCase(
+		repo,
+		&MockActivityRepository{Activity: &activityDomain.Activity{ID: 7, ChildID: 1, UserID: "user-1"}},
+		&childrentest.UseCase{Child: &childrenDomain.Child{ID: 1, UserID: "user-1"}},
+		store,
+		Config{MaxSize: 1 << 20, ThumbnailSize: 64, URLExpiry: 15 * time.Minute},
+	).(*AttachmentUseCase)
+	uc.now = childrentest.Clock(now)
+	return uc, repo, store
+}
+
+func photo(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatalf("failed to encode test photo: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestUpload(t *testing.T) {
+	uc, repo, store := newTestUseCase()
+	activityID := int64(7)
+
+	attachment, err := uc.Upload(context.Background(), &domain.UploadRequest{
+		UserID:     "user-1",
+		ChildID:    1,
+		ActivityID: &activityID,
+		Filename:   `C:\Users\mom\first bath.jpeg`,
+		Content:    photo(t, 256, 128),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if attachment.ContentType != "image/jpeg" || attachment.Width != 256 || attachment.Height != 128 {
+		t.Errorf("expected a 256x128 JPEG, got a %dx%d %s", attachment.Width, attachment.Height, attachment.ContentType)
+	}
+	if attachment.Filename != "first bath.jpeg" {
+		t.Errorf("expected the base name of the file, got %q", attachment.Filename)
+	}
+	if !strings.HasPrefix(attachment.StorageKey, "children/1/") || !strings.HasSuffix(attachment.StorageKey, ".jpg") {
+		t.Errorf("unexpected storage key %q", attachment.StorageKey)
+	}
+	if _, ok := store.Files[attachment.StorageKey]; !ok {
+		t.Error("expected the file to be stored")
+	}
+	if _, ok := store.Files[attachment.ThumbnailKey]; !ok || attachment.ThumbnailKey == "" {
+		t.Error("expected a thumbnail to be stored")
+	}
+	if !strings.Contains(attachment.URL, attachment.StorageKey) || !strings.Contains(attachment.ThumbnailURL, attachment.ThumbnailKey) {
+		t.Errorf("expected signed URLs, got %q and %q", attachment.URL, attachment.ThumbnailURL)
+	}
+	if attachment.URLExpiresAt == nil || !attachment.URLExpiresAt.Equal(now.Add(15*time.Minute)) {
+		t.Errorf("expected the URLs to expire after 15 minutes, got %v", attachment.URLExpiresAt)
+	}
+	if repo.Attachments[attachment.ID].URL != "" {
+		t.Error("expected signed URLs not to be stored")
+	}
+
+	// PDFs are stored without a thumbnail
+	document, err := uc.Upload(context.Background(), &domain.UploadRequest{
+		UserID:  "user-1",
+		ChildID: 1,
+		Content: []byte("%PDF-1.7\n1 0 obj\n"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if document.ContentType != "application/pdf" || document.ThumbnailKey != "" || document.Filename != "file.pdf" {
+		t.Errorf("unexpected document %+v", document)
+	}
+}
+
+func TestUploadRejectsInvalidFiles(t *testing.T) {
+	otherActivity := int64(8)
+	corrupt := photo(t, 64, 64)[:300]
+
+	tests := []struct {
+		name     string
+		req      domain.UploadRequest
+		expected error
+	}{
+		{"empty", domain.UploadRequest{UserID: "user-1", ChildID: 1}, ErrEmptyFile},
+		{"too large", domain.UploadRequest{UserID: "user-1", ChildID: 1, Content: make([]byte, 1<<20+1)}, ErrFileTooLarge},
+		{"text", domain.UploadRequest{UserID: "user-1", ChildID: 1, Filename: "photo.jpg", Content: []byte("not a photo")}, ErrUnsupportedFileType},
+		{"corrupt image", domain.UploadRequest{UserID: "user-1", ChildID: 1, Content: corrupt}, ErrInvalidImage},
+		{"unknown activity", domain.UploadRequest{UserID: "user-1", ChildID: 1, ActivityID: &otherActivity, Content: []byte("%PDF-1.7")}, ErrActivityNotFound},
+		{"other user", domain.UploadRequest{UserID: "user-2", ChildID: 1, Content: []byte("%PDF-1.7")}, childrenUsecase.ErrUnauthorizedAccess},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, repo, store := newTestUseCase()
+			if _, err := uc.Upload(context.Background(), &tt.req); !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+			if len(repo.Attachments) != 0 || len(store.Files) != 0 {
+				t.Error("expected nothing to be stored")
+			}
+		})
+	}
+}
+
+func TestUploadRejectsActivityOfAnotherChild(t *testing.T) {
+	uc, _, _ := newTestUseCase()
+	uc.activityRepo = &MockActivityRepository{Activity: &activityDomain.Activity{ID: 7, ChildID: 2, UserID: "user-1"}}
+	activityID := int64(7)
+
+	_, err := uc.Upload(context.Background(), &domain.UploadRequest{UserID: "user-1", ChildID: 1, ActivityID: &activityID, Content: []byte("%PDF-1.7")})
+	if !errors.Is(err, ErrActivityNotFound) {
+		t.Errorf("expected ErrActivityNotFound, got %v", err)
+	}
+}
+
+func TestSetPhotoReplacesPreviousPhoto(t *testing.T) {
+	uc, repo, store := newTestUseCase()
+	ctx := context.Background()
+
+	if _, err := uc.SetPhoto(ctx, &domain.UploadRequest{UserID: "user-1", ChildID: 1, Content: []byte("%PDF-1.7")}); !errors.Is(err, ErrPhotoNotImage) {
+		t.Errorf("expected ErrPhotoNotImage, got %v", err)
+	}
+
+	first, err := uc.SetPhoto(ctx, &domain.UploadRequest{UserID: "user-1", ChildID: 1, Content: photo(t, 32, 32)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := uc.SetPhoto(ctx, &domain.UploadRequest{UserID: "user-1", ChildID: 1, Content: photo(t, 48, 48)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(repo.Attachments) != 1 {
+		t.Errorf("expected only the new photo to be kept, got %d attachments", len(repo.Attachments))
+	}
+	if _, ok := store.Files[first.StorageKey]; ok {
+		t.Error("expected the files of the previous photo to be deleted")
+	}
+	if _, ok := store.Files[first.ThumbnailKey]; ok {
+		t.Error("expected the thumbnail of the previous photo to be deleted")
+	}
+
+	current, err := uc.GetPhoto(ctx, 1, "user-1")
+	if err != nil || current.ID != second.ID || current.URL == "" {
+		t.Errorf("expected the new photo with a signed URL, got %+v (%v)", current, err)
+	}
+
+	// Children are presented with the thumbnail of their photo, to their
+	// owner only
+	urls, err := uc.PhotoURLs(ctx, "user-1", []int64{1, 2})
+	if err != nil || len(urls) != 1 || !strings.HasPrefix(urls[1], "https://files.test/"+second.ThumbnailKey+"?") {
+		t.Errorf("expected the thumbnail link of child 1, got %v (%v)", urls, err)
+	}
+	if urls, err := uc.PhotoURLs(ctx, "user-2", []int64{1}); err != nil || len(urls) != 0 {
+		t.Errorf("expected no links for another user, got %v (%v)", urls, err)
+	}
+
+	// Profile photos are not file attachments
+	if _, err := uc.GetAttachment(ctx, 1, second.ID, "user-1"); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("expected ErrAttachmentNotFound, got %v", err)
+	}
+
+	if err := uc.DeletePhoto(ctx, 1, "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.Files) != 0 {
+		t.Errorf("expected every file to be deleted, got %d", len(store.Files))
+	}
+	if _, err := uc.GetPhoto(ctx, 1, "user-1"); !errors.Is(err, ErrPhotoNotFound) {
+		t.Errorf("expected ErrPhotoNotFound, got %v", err)
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	uc, repo, store := newTestUseCase()
+	ctx := context.Background()
+
+	attachment, err := uc.Upload(ctx, &domain.UploadRequest{UserID: "user-1", ChildID: 1, Content: photo(t, 16, 16)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := uc.DeleteAttachment(ctx, 1, attachment.ID, "user-2"); !errors.Is(err, childrenUsecase.ErrUnauthorizedAccess) {
+		t.Errorf("expected ErrUnauthorizedAccess, got %v", err)
+	}
+	if err := uc.DeleteAttachment(ctx, 1, attachment.ID, "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.Attachments) != 0 || len(store.Files) != 0 {
+		t.Error("expected the attachment and its files to be deleted")
+	}
+	if _, err := uc.GetAttachment(ctx, 1, attachment.ID, "user-1"); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("expected ErrAttachmentNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import "errors"
+
+// Domain errors for attachment module
+var (
+	ErrAttachmentNotFound  = errors.New("attachment not found")
+	ErrPhotoNotFound       = errors.New("child has no profile photo")
+	ErrActivityNotFound    = errors.New("activity not found")
+	ErrEmptyFile           = errors.New("file is empty")
+	ErrFileTooLarge        = errors.New("file is too large")
+	ErrUnsupportedFileType = errors.New("unsupported file type")
+	ErrPhotoNotImage       = errors.New("profile photo must be an image")
+	// ErrInvalidImage wraps the reason an image cannot be read
+	ErrInvalidImage = errors.New("invalid image")
+)
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/attachment/domain"
+)
+
+// IAttachmentUseCase defines the interface for the photos and documents of
+// children. Attachments are returned with freshly signed download URLs.
+type IAttachmentUseCase interface {
+	// Upload validates a file, stores it with a thumbnail when it is a photo
+	// and attaches it to the child, or to one of the child's activities
+	Upload(ctx context.Context, req *domain.UploadRequest) (*domain.Attachment, error)
+	GetAttachments(ctx context.Context, req *domain.GetAttachmentsRequest) ([]domain.Attachment, error)
+	GetAttachment(ctx context.Context, childID, id int64, userID string) (*domain.Attachment, error)
+	DeleteAttachment(ctx context.Context, childID, id int64, userID string) error
+	// SetPhoto stores the profile photo of a child, replacing the previous
+	// one
+	SetPhoto(ctx context.Context, req *domain.UploadRequest) (*domain.Attachment, error)
+	GetPhoto(ctx context.Context, childID int64, userID string) (*domain.Attachment, error)
+	// PhotoURLs returns signed links to the profile photos of the user's
+	// children, by child ID, preferring the thumbnail. Children without a
+	// photo are left out.
+	PhotoURLs(ctx context.Context, userID string, childIDs []int64) (map[int64]string, error)
+	DeletePhoto(ctx context.Context, childID int64, userID string) error
+}
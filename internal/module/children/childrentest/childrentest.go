@@ -0,0 +1,49 @@
+// Package childrentest provides test doubles of the children module for the
+// use cases that check access to a child.
+package childrentest
+
+import (
+	"dailyalu-server/internal/module/children/domain"
+	"dailyalu-server/internal/module/children/usecase"
+	"testing"
+	"time"
+)
+
+// UseCase is a children use case that knows a single child. Only GetChild
+// is implemented.
+type UseCase struct {
+	usecase.IChildrenUseCase
+	Child *domain.Child
+}
+
+// GetChild returns the child to its owner, and the errors of the real use
+// case otherwise
+func (m *UseCase) GetChild(id int64, userID string) (*domain.Child, error) {
+	if m.Child == nil || m.Child.ID != id {
+		return nil, usecase.ErrChildNotFound
+	}
+	if m.Child.UserID != userID {
+		return nil, usecase.ErrUnauthorizedAccess
+	}
+	return m.Child, nil
+}
+
+// MustDate parses a YYYY-MM-DD date, failing the test when it is invalid
+func MustDate(t testing.TB, value string) domain.Date {
+	t.Helper()
+	date, err := domain.ParseDate(value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return date
+}
+
+// IntPtr returns a pointer to v
+func IntPtr(v int) *int {
+	return &v
+}
+
+// Clock returns a clock that always reads now
+func Clock(now time.Time) func() time.Time {
+	return func() time.Time { return now }
+}
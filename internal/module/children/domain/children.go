@@ -5,14 +5,75 @@ import (
 	"time"
 )
 
+// Sex values
+const (
+	SexFemale = "female"
+	SexMale   = "male"
+)
+
+// TermGestationalAgeDays is a full term pregnancy of 40 weeks
+const TermGestationalAgeDays = 40 * 7
+
+// CorrectedAgeMaxMonths is the chronological age in months until which the
+// corrected age of premature babies is used
+const CorrectedAgeMaxMonths = 24
+
+// PrematureGestationalAgeWeeks is the gestational age below which a birth is premature
+const PrematureGestationalAgeWeeks = 37
+
+// BirthProfile holds the structured birth data of a child
+type BirthProfile struct {
+	DateOfBirth              *Date    `json:"date_of_birth,omitempty"`
+	Sex                      string   `json:"sex,omitempty" validate:"omitempty,oneof=female male"`
+	GestationalAgeWeeks      *int     `json:"gestational_age_weeks,omitempty" validate:"omitempty,min=22,max=44"`
+	GestationalAgeDays       *int     `json:"gestational_age_days,omitempty" validate:"omitempty,min=0,max=6"`
+	BirthWeightGrams         *int     `json:"birth_weight_grams,omitempty" validate:"omitempty,min=300,max=7000"`
+	BirthLengthCm            *float64 `json:"birth_length_cm,omitempty" validate:"omitempty,min=20,max=70"`
+	BirthHeadCircumferenceCm *float64 `json:"birth_head_circumference_cm,omitempty" validate:"omitempty,min=15,max=45"`
+	PhotoURL                 string   `json:"photo_url,omitempty" validate:"omitempty,url"`
+}
+
+// GestationalAgeAtBirthDays returns the gestational age in days, or false
+// when it is unknown
+func (p *BirthProfile) GestationalAgeAtBirthDays() (int, bool) {
+	if p.GestationalAgeWeeks == nil {
+		return 0, false
+	}
+	days := *p.GestationalAgeWeeks * 7
+	if p.GestationalAgeDays != nil {
+		days += *p.GestationalAgeDays
+	}
+	return days, true
+}
+
+// IsPremature reports whether the child was born before 37 weeks
+func (p *BirthProfile) IsPremature() bool {
+	days, ok := p.GestationalAgeAtBirthDays()
+	return ok && days < PrematureGestationalAgeWeeks*7
+}
+
+// ChildAge is the age of a child computed at response time. Corrected age
+// subtracts the weeks a premature baby was born early and is only reported
+// during the first two years.
+type ChildAge struct {
+	Days            int  `json:"days"`
+	Weeks           int  `json:"weeks"`
+	Months          int  `json:"months"`
+	CorrectedDays   *int `json:"corrected_days,omitempty"`
+	CorrectedWeeks  *int `json:"corrected_weeks,omitempty"`
+	CorrectedMonths *int `json:"corrected_months,omitempty"`
+}
+
 // Child represents a child record
 type Child struct {
-	ID        int64           `json:"id"`
-	UserID    string          `json:"user_id"`
-	Name      string          `json:"name"`
-	Details   json.RawMessage `json:"details,omitempty"`
-	CreatedAt time.Time       `json:"created_at"`
-	UpdatedAt time.Time       `json:"updated_at"`
+	ID      int64           `json:"id"`
+	UserID  string          `json:"user_id"`
+	Name    string          `json:"name"`
+	Details json.RawMessage `json:"details,omitempty"`
+	BirthProfile
+	Age       *ChildAge `json:"age,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // CreateChildRequest represents the request to create a new child
@@ -20,6 +81,7 @@ type CreateChildRequest struct {
 	UserID  string          `json:"user_id" validate:"required"`
 	Name    string          `json:"name" validate:"required"`
 	Details json.RawMessage `json:"details,omitempty"`
+	BirthProfile
 }
 
 // UpdateChildRequest represents the request to update a child
@@ -28,6 +90,7 @@ type UpdateChildRequest struct {
 	UserID  string          `json:"user_id" validate:"required"`
 	Name    string          `json:"name" validate:"required"`
 	Details json.RawMessage `json:"details,omitempty"`
+	BirthProfile
 }
 
 // GetChildrenRequest represents the request to get children with pagination
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// DateLayout is the wire format of calendar dates
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date without time of day, encoded as "YYYY-MM-DD"
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the date of t in its own location
+func NewDate(t time.Time) Date {
+	return Date{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// ParseDate parses a "YYYY-MM-DD" date
+func ParseDate(value string) (Date, error) {
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{t}, nil
+}
+
+func (d Date) String() string {
+	return d.Format(DateLayout)
+}
+
+// DaysUntil returns the number of calendar days from d to other
+func (d Date) DaysUntil(other Date) int {
+	return int(other.Sub(d.Time).Hours() / 24)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return fmt.Errorf("date must be a string in %s format", DateLayout)
+	}
+	parsed, err := ParseDate(value[1 : len(value)-1])
+	if err != nil {
+		return fmt.Errorf("date must be in %s format", DateLayout)
+	}
+	*d = parsed
+	return nil
+}
+
+// Value implements driver.Valuer
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner
+func (d *Date) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = NewDate(v)
+		return nil
+	case string:
+		parsed, err := ParseDate(v)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	case []byte:
+		return d.Scan(string(v))
+	}
+	return fmt.Errorf("cannot scan %T into Date", src)
+}
@@ -21,8 +21,9 @@ func NewPostgresChildrenRepository(db *sql.DB) IChildrenRepository {
 // Create inserts a new child record into the database
 func (r *PostgresChildrenRepository) Create(child *domain.Child) error {
 	query := `
-		INSERT INTO children (user_id, name, details, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO children (user_id, name, details, date_of_birth, sex, gestational_age_weeks, gestational_age_days,
+			birth_weight_grams, birth_length_cm, birth_head_circumference_cm, photo_url, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 		RETURNING id
 	`
 
@@ -40,6 +41,14 @@ func (r *PostgresChildrenRepository) Create(child *domain.Child) error {
 		child.UserID,
 		child.Name,
 		details,
+		child.DateOfBirth,
+		child.Sex,
+		child.GestationalAgeWeeks,
+		child.GestationalAgeDays,
+		child.BirthWeightGrams,
+		child.BirthLengthCm,
+		child.BirthHeadCircumferenceCm,
+		child.PhotoURL,
 		child.CreatedAt,
 		child.UpdatedAt,
 	).Scan(&child.ID)
@@ -50,7 +59,8 @@ func (r *PostgresChildrenRepository) Create(child *domain.Child) error {
 // GetByID retrieves a child by ID
 func (r *PostgresChildrenRepository) GetByID(id int64) (*domain.Child, error) {
 	query := `
-		SELECT id, user_id, name, details, created_at, updated_at
+		SELECT id, user_id, name, details, date_of_birth, sex, gestational_age_weeks, gestational_age_days,
+			birth_weight_grams, birth_length_cm, birth_head_circumference_cm, photo_url, created_at, updated_at
 		FROM children
 		WHERE id = $1
 	`
@@ -63,6 +73,14 @@ func (r *PostgresChildrenRepository) GetByID(id int64) (*domain.Child, error) {
 		&child.UserID,
 		&child.Name,
 		&details,
+		&child.DateOfBirth,
+		&child.Sex,
+		&child.GestationalAgeWeeks,
+		&child.GestationalAgeDays,
+		&child.BirthWeightGrams,
+		&child.BirthLengthCm,
+		&child.BirthHeadCircumferenceCm,
+		&child.PhotoURL,
 		&child.CreatedAt,
 		&child.UpdatedAt,
 	)
@@ -96,7 +114,8 @@ func (r *PostgresChildrenRepository) GetByUserID(userID string, page, pageSize i
 
 	// Get paginated results
 	query := `
-		SELECT id, user_id, name, details, created_at, updated_at
+		SELECT id, user_id, name, details, date_of_birth, sex, gestational_age_weeks, gestational_age_days,
+			birth_weight_grams, birth_length_cm, birth_head_circumference_cm, photo_url, created_at, updated_at
 		FROM children
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -119,6 +138,14 @@ func (r *PostgresChildrenRepository) GetByUserID(userID string, page, pageSize i
 			&child.UserID,
 			&child.Name,
 			&details,
+			&child.DateOfBirth,
+			&child.Sex,
+			&child.GestationalAgeWeeks,
+			&child.GestationalAgeDays,
+			&child.BirthWeightGrams,
+			&child.BirthLengthCm,
+			&child.BirthHeadCircumferenceCm,
+			&child.PhotoURL,
 			&child.CreatedAt,
 			&child.UpdatedAt,
 		)
@@ -144,8 +171,9 @@ func (r *PostgresChildrenRepository) GetByUserID(userID string, page, pageSize i
 func (r *PostgresChildrenRepository) Update(child *domain.Child) error {
 	query := `
 		UPDATE children
-		SET name = $1, details = $2, updated_at = $3
-		WHERE id = $4 AND user_id = $5
+		SET name = $1, details = $2, date_of_birth = $3, sex = $4, gestational_age_weeks = $5, gestational_age_days = $6,
+			birth_weight_grams = $7, birth_length_cm = $8, birth_head_circumference_cm = $9, photo_url = $10, updated_at = $11
+		WHERE id = $12 AND user_id = $13
 	`
 
 	child.UpdatedAt = time.Now()
@@ -159,6 +187,14 @@ func (r *PostgresChildrenRepository) Update(child *domain.Child) error {
 		query,
 		child.Name,
 		details,
+		child.DateOfBirth,
+		child.Sex,
+		child.GestationalAgeWeeks,
+		child.GestationalAgeDays,
+		child.BirthWeightGrams,
+		child.BirthLengthCm,
+		child.BirthHeadCircumferenceCm,
+		child.PhotoURL,
 		child.UpdatedAt,
 		child.ID,
 		child.UserID,
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"dailyalu-server/internal/module/children/domain"
+)
+
+// computeAge returns the age of a child on today, or nil when the date of
+// birth is unknown
+func computeAge(profile *domain.BirthProfile, today domain.Date) *domain.ChildAge {
+	if profile.DateOfBirth == nil {
+		return nil
+	}
+
+	dob := *profile.DateOfBirth
+	days := dob.DaysUntil(today)
+	if days < 0 {
+		days = 0
+	}
+
+	months := monthsBetween(dob, today)
+	age := &domain.ChildAge{
+		Days:   days,
+		Weeks:  days / 7,
+		Months: months,
+	}
+
+	if profile.IsPremature() && months < domain.CorrectedAgeMaxMonths {
+		gestationalAge, _ := profile.GestationalAgeAtBirthDays()
+		daysEarly := domain.TermGestationalAgeDays - gestationalAge
+
+		// Negative until the original due date is reached
+		correctedDays := days - daysEarly
+		correctedWeeks := correctedDays / 7
+		correctedMonths := monthsBetween(domain.NewDate(dob.AddDate(0, 0, daysEarly)), today)
+
+		age.CorrectedDays = &correctedDays
+		age.CorrectedWeeks = &correctedWeeks
+		age.CorrectedMonths = &correctedMonths
+	}
+
+	return age
+}
+
+// monthsBetween returns the number of completed calendar months from from to
+// to, never less than zero
+func monthsBetween(from, to domain.Date) int {
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	if to.Day() < from.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
@@ -5,27 +5,35 @@ import (
 	"dailyalu-server/internal/module/children/repository"
 	"database/sql"
 	"math"
+	"time"
 )
 
 // ChildrenUseCase implements the children business logic
 type ChildrenUseCase struct {
 	childrenRepo repository.IChildrenRepository
+	now          func() time.Time
 }
 
 // NewChildrenUseCase creates a new children use case
 func NewChildrenUseCase(childrenRepo repository.IChildrenRepository) IChildrenUseCase {
 	return &ChildrenUseCase{
 		childrenRepo: childrenRepo,
+		now:          time.Now,
 	}
 }
 
 // CreateChild creates a new child
 func (u *ChildrenUseCase) CreateChild(req *domain.CreateChildRequest) (*domain.Child, error) {
+	if err := u.validateBirthProfile(&req.BirthProfile); err != nil {
+		return nil, err
+	}
+
 	// Create child entity
 	child := &domain.Child{
-		UserID:  req.UserID,
-		Name:    req.Name,
-		Details: req.Details,
+		UserID:       req.UserID,
+		Name:         req.Name,
+		Details:      req.Details,
+		BirthProfile: req.BirthProfile,
 	}
 
 	// Save to repository
@@ -34,6 +42,7 @@ func (u *ChildrenUseCase) CreateChild(req *domain.CreateChildRequest) (*domain.C
 		return nil, err
 	}
 
+	u.setAge(child)
 	return child, nil
 }
 
@@ -55,6 +64,7 @@ func (u *ChildrenUseCase) GetChild(id int64, userID string) (*domain.Child, erro
 		return nil, ErrUnauthorizedAccess
 	}
 
+	u.setAge(child)
 	return child, nil
 }
 
@@ -66,6 +76,10 @@ func (u *ChildrenUseCase) GetChildren(req *domain.GetChildrenRequest) (*domain.C
 		return nil, err
 	}
 
+	for i := range children {
+		u.setAge(&children[i])
+	}
+
 	// Calculate pagination metadata
 	totalPages := int(math.Ceil(float64(total) / float64(req.PageSize)))
 
@@ -85,6 +99,10 @@ func (u *ChildrenUseCase) GetChildren(req *domain.GetChildrenRequest) (*domain.C
 
 // UpdateChild updates an existing child
 func (u *ChildrenUseCase) UpdateChild(req *domain.UpdateChildRequest) (*domain.Child, error) {
+	if err := u.validateBirthProfile(&req.BirthProfile); err != nil {
+		return nil, err
+	}
+
 	// Check if child exists and belongs to the user
 	child, err := u.childrenRepo.GetByID(req.ID)
 	if err != nil {
@@ -103,6 +121,7 @@ func (u *ChildrenUseCase) UpdateChild(req *domain.UpdateChildRequest) (*domain.C
 	// Update child entity
 	child.Name = req.Name
 	child.Details = req.Details
+	child.BirthProfile = req.BirthProfile
 
 	// Save to repository
 	err = u.childrenRepo.Update(child)
@@ -113,5 +132,26 @@ func (u *ChildrenUseCase) UpdateChild(req *domain.UpdateChildRequest) (*domain.C
 		return nil, err
 	}
 
+	u.setAge(child)
 	return child, nil
 }
+
+// validateBirthProfile checks the rules struct validation cannot express
+func (u *ChildrenUseCase) validateBirthProfile(profile *domain.BirthProfile) error {
+	if profile.DateOfBirth != nil && profile.DateOfBirth.After(u.today().Time) {
+		return ErrInvalidDateOfBirth
+	}
+	if profile.GestationalAgeDays != nil && profile.GestationalAgeWeeks == nil {
+		return ErrInvalidChildData
+	}
+	return nil
+}
+
+// setAge fills in the age computed for today
+func (u *ChildrenUseCase) setAge(child *domain.Child) {
+	child.Age = computeAge(&child.BirthProfile, u.today())
+}
+
+func (u *ChildrenUseCase) today() domain.Date {
+	return domain.NewDate(u.now())
+}
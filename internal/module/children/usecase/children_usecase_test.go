@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"dailyalu-server/internal/module/children/domain"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockChildrenRepository is a mock implementation of the children repository
+type MockChildrenRepository struct {
+	CreateFunc      func(child *domain.Child) error
+	GetByIDFunc     func(id int64) (*domain.Child, error)
+	GetByUserIDFunc func(userID string, page, pageSize int) ([]domain.Child, int64, error)
+	UpdateFunc      func(child *domain.Child) error
+}
+
+func (m *MockChildrenRepository) Create(child *domain.Child) error {
+	return m.CreateFunc(child)
+}
+
+func (m *MockChildrenRepository) GetByID(id int64) (*domain.Child, error) {
+	return m.GetByIDFunc(id)
+}
+
+func (m *MockChildrenRepository) GetByUserID(userID string, page, pageSize int) ([]domain.Child, int64, error) {
+	return m.GetByUserIDFunc(userID, page, pageSize)
+}
+
+func (m *MockChildrenRepository) Update(child *domain.Child) error {
+	return m.UpdateFunc(child)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func datePtr(t *testing.T, value string) *domain.Date {
+	date, err := domain.ParseDate(value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &date
+}
+
+func TestComputeAge(t *testing.T) {
+	today, _ := domain.ParseDate("2025-03-28")
+
+	testCases := []struct {
+		name              string
+		profile           domain.BirthProfile
+		expected          *domain.ChildAge
+		expectedCorrected *int
+	}{
+		{
+			name:     "unknown date of birth",
+			profile:  domain.BirthProfile{},
+			expected: nil,
+		},
+		{
+			name:     "term baby",
+			profile:  domain.BirthProfile{DateOfBirth: datePtr(t, "2025-01-10"), GestationalAgeWeeks: intPtr(39)},
+			expected: &domain.ChildAge{Days: 77, Weeks: 11, Months: 2},
+		},
+		{
+			name:              "premature baby born at 32+3 weeks",
+			profile:           domain.BirthProfile{DateOfBirth: datePtr(t, "2025-01-10"), GestationalAgeWeeks: intPtr(32), GestationalAgeDays: intPtr(3)},
+			expected:          &domain.ChildAge{Days: 77, Weeks: 11, Months: 2},
+			expectedCorrected: intPtr(77 - 53),
+		},
+		{
+			name:     "premature child older than two years",
+			profile:  domain.BirthProfile{DateOfBirth: datePtr(t, "2022-01-10"), GestationalAgeWeeks: intPtr(30)},
+			expected: &domain.ChildAge{Days: 1173, Weeks: 167, Months: 38},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			age := computeAge(&tc.profile, today)
+
+			if tc.expected == nil {
+				if age != nil {
+					t.Errorf("expected no age, got %+v", age)
+				}
+				return
+			}
+
+			if age == nil || age.Days != tc.expected.Days || age.Weeks != tc.expected.Weeks || age.Months != tc.expected.Months {
+				t.Fatalf("expected %+v, got %+v", tc.expected, age)
+			}
+
+			if tc.expectedCorrected == nil {
+				if age.CorrectedDays != nil {
+					t.Errorf("expected no corrected age, got %d days", *age.CorrectedDays)
+				}
+				return
+			}
+
+			if age.CorrectedDays == nil || *age.CorrectedDays != *tc.expectedCorrected {
+				t.Errorf("expected corrected age of %d days, got %v", *tc.expectedCorrected, age.CorrectedDays)
+			}
+		})
+	}
+}
+
+func TestChildrenUseCase_CreateChild(t *testing.T) {
+	now := time.Date(2025, 3, 28, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		profile       domain.BirthProfile
+		expectedError error
+	}{
+		{
+			name:    "creates child with birth profile",
+			profile: domain.BirthProfile{DateOfBirth: datePtr(t, "2025-03-01"), Sex: domain.SexFemale, BirthWeightGrams: intPtr(3200)},
+		},
+		{
+			name:          "rejects date of birth in the future",
+			profile:       domain.BirthProfile{DateOfBirth: datePtr(t, "2025-03-29")},
+			expectedError: ErrInvalidDateOfBirth,
+		},
+		{
+			name:          "rejects gestational days without weeks",
+			profile:       domain.BirthProfile{GestationalAgeDays: intPtr(3)},
+			expectedError: ErrInvalidChildData,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := &ChildrenUseCase{
+				childrenRepo: &MockChildrenRepository{
+					CreateFunc: func(child *domain.Child) error {
+						child.ID = 1
+						return nil
+					},
+				},
+				now: func() time.Time { return now },
+			}
+
+			child, err := uc.CreateChild(&domain.CreateChildRequest{UserID: "user-1", Name: "Baby", BirthProfile: tc.profile})
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected error %v, got %v", tc.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if child.Age == nil || child.Age.Days != 27 {
+				t.Errorf("expected age of 27 days, got %+v", child.Age)
+			}
+		})
+	}
+}
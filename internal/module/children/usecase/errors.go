@@ -7,4 +7,5 @@ var (
 	ErrChildNotFound      = errors.New("child not found")
 	ErrUnauthorizedAccess = errors.New("unauthorized access to child data")
 	ErrInvalidChildData   = errors.New("invalid child data")
+	ErrInvalidDateOfBirth = errors.New("date of birth cannot be in the future")
 )
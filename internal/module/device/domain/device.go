@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// Platforms devices run on
+const (
+	PlatformAndroid = "android"
+	PlatformIOS     = "ios"
+	PlatformWeb     = "web"
+)
+
+// Push providers issuing device tokens
+const (
+	ProviderFCM  = "fcm"
+	ProviderAPNs = "apns"
+)
+
+// Device is a phone or browser registered for push notifications. A token
+// belongs to one user; registering it again moves it to the caller.
+type Device struct {
+	ID        int64     `json:"id"`
+	UserID    string    `json:"user_id"`
+	Platform  string    `json:"platform"`
+	Provider  string    `json:"provider"`
+	Token     string    `json:"token"`
+	Name      string    `json:"name,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RegisterDeviceRequest represents the request to register a device token
+type RegisterDeviceRequest struct {
+	UserID   string `json:"-"`
+	Platform string `json:"platform" validate:"required,oneof=android ios web"`
+	Provider string `json:"provider" validate:"required,oneof=fcm apns"`
+	Token    string `json:"token" validate:"required,max=4096"`
+	Name     string `json:"name,omitempty" validate:"max=100"`
+}
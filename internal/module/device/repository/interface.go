@@ -0,0 +1,13 @@
+package repository
+
+import "dailyalu-server/internal/module/device/domain"
+
+// IDeviceRepository defines the interface for device data access
+type IDeviceRepository interface {
+	// Upsert inserts a device or takes over the existing row of its token
+	Upsert(device *domain.Device) error
+	GetByID(id int64) (*domain.Device, error)
+	GetByUserID(userID string) ([]domain.Device, error)
+	Delete(id int64) error
+	DeleteByToken(token string) error
+}
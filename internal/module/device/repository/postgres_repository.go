@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/device/domain"
+	"database/sql"
+	"time"
+)
+
+// PostgresDeviceRepository implements the device repository interface using PostgreSQL
+type PostgresDeviceRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresDeviceRepository creates a new PostgreSQL device repository
+func NewPostgresDeviceRepository(db *sql.DB) IDeviceRepository {
+	return &PostgresDeviceRepository{
+		db: db,
+	}
+}
+
+// Upsert inserts a device, or moves an already registered token to the
+// device's user
+func (r *PostgresDeviceRepository) Upsert(device *domain.Device) error {
+	query := `
+		INSERT INTO devices (user_id, platform, provider, token, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (token) DO UPDATE
+		SET user_id = EXCLUDED.user_id, platform = EXCLUDED.platform, provider = EXCLUDED.provider,
+			name = EXCLUDED.name, updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at, updated_at
+	`
+
+	return r.db.QueryRow(
+		query,
+		device.UserID,
+		device.Platform,
+		device.Provider,
+		device.Token,
+		device.Name,
+		time.Now(),
+	).Scan(&device.ID, &device.CreatedAt, &device.UpdatedAt)
+}
+
+// GetByID retrieves a device by ID
+func (r *PostgresDeviceRepository) GetByID(id int64) (*domain.Device, error) {
+	query := `
+		SELECT id, user_id, platform, provider, token, name, created_at, updated_at
+		FROM devices
+		WHERE id = $1
+	`
+
+	var device domain.Device
+	err := scanDevice(r.db.QueryRow(query, id), &device)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &device, nil
+}
+
+// GetByUserID retrieves the devices of a user, most recently registered first
+func (r *PostgresDeviceRepository) GetByUserID(userID string) ([]domain.Device, error) {
+	query := `
+		SELECT id, user_id, platform, provider, token, name, created_at, updated_at
+		FROM devices
+		WHERE user_id = $1
+		ORDER BY updated_at DESC
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	devices := []domain.Device{}
+	for rows.Next() {
+		var device domain.Device
+		if err := scanDevice(rows, &device); err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, rows.Err()
+}
+
+// Delete removes a device
+func (r *PostgresDeviceRepository) Delete(id int64) error {
+	result, err := r.db.Exec(`DELETE FROM devices WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteByToken removes the device of a token, if any
+func (r *PostgresDeviceRepository) DeleteByToken(token string) error {
+	_, err := r.db.Exec(`DELETE FROM devices WHERE token = $1`, token)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDevice(row rowScanner, device *domain.Device) error {
+	return row.Scan(
+		&device.ID,
+		&device.UserID,
+		&device.Platform,
+		&device.Provider,
+		&device.Token,
+		&device.Name,
+		&device.CreatedAt,
+		&device.UpdatedAt,
+	)
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"dailyalu-server/internal/module/device/domain"
+	"dailyalu-server/internal/module/device/repository"
+	"database/sql"
+	"errors"
+)
+
+// DeviceUseCase implements the device use case interface
+type DeviceUseCase struct {
+	deviceRepo repository.IDeviceRepository
+}
+
+// NewDeviceUseCase creates a new device use case
+func NewDeviceUseCase(deviceRepo repository.IDeviceRepository) IDeviceUseCase {
+	return &DeviceUseCase{
+		deviceRepo: deviceRepo,
+	}
+}
+
+// RegisterDevice stores the push token of a device for the user
+func (uc *DeviceUseCase) RegisterDevice(req *domain.RegisterDeviceRequest) (*domain.Device, error) {
+	if req.Provider == domain.ProviderAPNs && req.Platform != domain.PlatformIOS {
+		return nil, ErrUnsupportedPushTarget
+	}
+
+	device := &domain.Device{
+		UserID:   req.UserID,
+		Platform: req.Platform,
+		Provider: req.Provider,
+		Token:    req.Token,
+		Name:     req.Name,
+	}
+	if err := uc.deviceRepo.Upsert(device); err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+// GetDevices retrieves the devices registered by the user
+func (uc *DeviceUseCase) GetDevices(userID string) ([]domain.Device, error) {
+	return uc.deviceRepo.GetByUserID(userID)
+}
+
+// DeleteDevice unregisters a device of the user
+func (uc *DeviceUseCase) DeleteDevice(id int64, userID string) error {
+	device, err := uc.deviceRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if device == nil || device.UserID != userID {
+		return ErrDeviceNotFound
+	}
+
+	if err := uc.deviceRepo.Delete(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrDeviceNotFound
+		}
+		return err
+	}
+
+	return nil
+}
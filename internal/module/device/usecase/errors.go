@@ -0,0 +1,9 @@
+package usecase
+
+import "errors"
+
+// Domain errors for device module
+var (
+	ErrDeviceNotFound        = errors.New("device not found")
+	ErrUnsupportedPushTarget = errors.New("APNs tokens can only be registered for iOS devices")
+)
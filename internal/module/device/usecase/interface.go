@@ -0,0 +1,10 @@
+package usecase
+
+import "dailyalu-server/internal/module/device/domain"
+
+// IDeviceUseCase defines the interface for device business logic
+type IDeviceUseCase interface {
+	RegisterDevice(req *domain.RegisterDeviceRequest) (*domain.Device, error)
+	GetDevices(userID string) ([]domain.Device, error)
+	DeleteDevice(id int64, userID string) error
+}
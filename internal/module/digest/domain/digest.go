@@ -0,0 +1,184 @@
+package domain
+
+import (
+	activityDomain "dailyalu-server/internal/module/activity/domain"
+	mailerDomain "dailyalu-server/internal/service/mailer/domain"
+	"dailyalu-server/internal/utils"
+	"encoding/json"
+	"math"
+	"time"
+)
+
+// Activity types counted in the digest
+const (
+	ActivityTypeSleep   = "sleep"
+	ActivityTypeFeeding = "feeding"
+	ActivityTypeDiaper  = "diaper"
+)
+
+// Recipient is a user who opted in to the weekly digest
+type Recipient struct {
+	UserID         string
+	Email          string
+	Name           string
+	Timezone       string
+	Locale         string
+	FirstDayOfWeek string
+	WeightUnit     string
+	LengthUnit     string
+	// LastWeekStart is the most recent week already handled for the user
+	LastWeekStart *time.Time
+}
+
+// Child is a child included in a digest
+type Child struct {
+	ID   int64
+	Name string
+}
+
+// Activity is the part of an activity the digest counts
+type Activity struct {
+	ChildID   int64
+	Type      string
+	Details   json.RawMessage
+	HappensAt time.Time
+}
+
+// Measurement is a growth measurement of a child
+type Measurement struct {
+	ChildID             int64
+	MeasuredOn          time.Time
+	WeightKg            *float64
+	LengthCm            *float64
+	HeadCircumferenceCm *float64
+}
+
+// WeekStart returns midnight of the first day of the week containing t, in
+// the location of t. Weeks start on firstDayOfWeek (monday, sunday or
+// saturday), Monday if it is unknown.
+func WeekStart(t time.Time, firstDayOfWeek string) time.Time {
+	first := time.Monday
+	switch firstDayOfWeek {
+	case "sunday":
+		first = time.Sunday
+	case "saturday":
+		first = time.Saturday
+	}
+
+	offset := (int(t.Weekday()) - int(first) + 7) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// SleepDuration returns the length of a sleep activity. Details give it
+// either as "duration_minutes" or as an "ended_at" time.
+func SleepDuration(activity Activity) time.Duration {
+	return activityDomain.DetailsDuration(activity.Details, activity.HappensAt)
+}
+
+// Summarize computes the totals of each child for the week starting at
+// weekStart. Activities are expected to fall within the week; measurements
+// may start earlier so that changes can be computed. Growth values are
+// converted to the recipient's preferred units.
+func Summarize(recipient *Recipient, children []Child, activities []Activity, measurements []Measurement, weekStart time.Time) []mailerDomain.DigestChild {
+	// Measurements are dates without a time zone
+	firstDay := time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, time.UTC)
+	lastDay := firstDay.AddDate(0, 0, 6)
+
+	summaries := make([]mailerDomain.DigestChild, 0, len(children))
+	for _, child := range children {
+		summary := mailerDomain.DigestChild{Name: child.Name}
+
+		var sleep time.Duration
+		for _, activity := range activities {
+			if activity.ChildID != child.ID {
+				continue
+			}
+			switch activity.Type {
+			case ActivityTypeSleep:
+				sleep += SleepDuration(activity)
+			case ActivityTypeFeeding:
+				summary.Feeds++
+			case ActivityTypeDiaper:
+				summary.Diapers++
+			}
+		}
+		summary.SleepHours = math.Round(sleep.Hours()*10) / 10
+
+		var childMeasurements []Measurement
+		for _, m := range measurements {
+			if m.ChildID == child.ID {
+				childMeasurements = append(childMeasurements, m)
+			}
+		}
+
+		measures := []struct {
+			name  string
+			value func(Measurement) *float64
+			from  string
+			to    string
+		}{
+			{mailerDomain.MeasureWeight, func(m Measurement) *float64 { return m.WeightKg }, "kg", recipient.WeightUnit},
+			{mailerDomain.MeasureLength, func(m Measurement) *float64 { return m.LengthCm }, "cm", recipient.LengthUnit},
+			{mailerDomain.MeasureHeadCircumference, func(m Measurement) *float64 { return m.HeadCircumferenceCm }, "cm", recipient.LengthUnit},
+		}
+		for _, measure := range measures {
+			growth, ok := growthChange(childMeasurements, measure.value, firstDay, lastDay)
+			if !ok {
+				continue
+			}
+			growth.Measure = measure.name
+			growth.Unit = measure.from
+			if converted, ok := utils.ConvertUnit(growth.Value, measure.from, measure.to); ok {
+				growth.Value = converted
+				growth.Change, _ = utils.ConvertUnit(growth.Change, measure.from, measure.to)
+				growth.Unit = measure.to
+			}
+			summary.Growth = append(summary.Growth, growth)
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries
+}
+
+// IsEmpty reports whether nothing was recorded for any child
+func IsEmpty(summaries []mailerDomain.DigestChild) bool {
+	for _, summary := range summaries {
+		if summary.SleepHours > 0 || summary.Feeds > 0 || summary.Diapers > 0 || len(summary.Growth) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// growthChange returns the latest value of a measure taken between the two
+// days and its change over the week: since the last measurement before the
+// week, or else since the first one of the week. Measurements are expected in
+// chronological order.
+func growthChange(measurements []Measurement, value func(Measurement) *float64, firstDay, lastDay time.Time) (mailerDomain.DigestGrowth, bool) {
+	var baseline, latest *float64
+	for _, m := range measurements {
+		v := value(m)
+		if v == nil || m.MeasuredOn.After(lastDay) {
+			continue
+		}
+		if m.MeasuredOn.Before(firstDay) || baseline == nil {
+			baseline = v
+		}
+		if !m.MeasuredOn.Before(firstDay) {
+			latest = v
+		}
+	}
+
+	if latest == nil {
+		return mailerDomain.DigestGrowth{}, false
+	}
+
+	growth := mailerDomain.DigestGrowth{Value: *latest}
+	if baseline != latest {
+		growth.Change = math.Round((*latest-*baseline)*1000) / 1000
+		growth.HasChange = true
+	}
+	return growth, true
+}
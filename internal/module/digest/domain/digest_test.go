@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestWeekStart(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	// Thursday
+	now := time.Date(2025, 3, 20, 8, 30, 0, 0, jakarta)
+
+	testCases := []struct {
+		firstDay string
+		expected time.Time
+	}{
+		{"monday", time.Date(2025, 3, 17, 0, 0, 0, 0, jakarta)},
+		{"sunday", time.Date(2025, 3, 16, 0, 0, 0, 0, jakarta)},
+		{"saturday", time.Date(2025, 3, 15, 0, 0, 0, 0, jakarta)},
+		{"", time.Date(2025, 3, 17, 0, 0, 0, 0, jakarta)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.firstDay, func(t *testing.T) {
+			if got := WeekStart(now, tc.firstDay); !got.Equal(tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	// The first day of the week starts its own week
+	monday := time.Date(2025, 3, 17, 0, 0, 0, 0, jakarta)
+	if got := WeekStart(monday, "monday"); !got.Equal(monday) {
+		t.Errorf("expected %v, got %v", monday, got)
+	}
+}
+
+func TestSleepDuration(t *testing.T) {
+	start := time.Date(2025, 3, 17, 20, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		details  string
+		expected time.Duration
+	}{
+		{"duration", `{"duration_minutes": 90}`, 90 * time.Minute},
+		{"end time", `{"ended_at": "2025-03-18T05:30:00+07:00"}`, 2*time.Hour + 30*time.Minute},
+		{"end before start", `{"ended_at": "2025-03-17T19:00:00Z"}`, 0},
+		{"no duration", `{"notes": "nap"}`, 0},
+		{"not an object", `"nap"`, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SleepDuration(Activity{Type: ActivityTypeSleep, Details: json.RawMessage(tc.details), HappensAt: start})
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	weekStart := time.Date(2025, 3, 10, 0, 0, 0, 0, time.FixedZone("WIB", 7*60*60))
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	value := func(v float64) *float64 { return &v }
+
+	recipient := &Recipient{WeightUnit: "lb", LengthUnit: "cm"}
+	children := []Child{{ID: 1, Name: "Budi"}, {ID: 2, Name: "Sari"}}
+	activities := []Activity{
+		{ChildID: 1, Type: ActivityTypeSleep, Details: json.RawMessage(`{"duration_minutes": 600}`)},
+		{ChildID: 1, Type: ActivityTypeSleep, Details: json.RawMessage(`{"duration_minutes": 45}`)},
+		{ChildID: 1, Type: ActivityTypeFeeding, Details: json.RawMessage(`{"amount": 120, "unit": "ml"}`)},
+		{ChildID: 1, Type: ActivityTypeFeeding, Details: json.RawMessage(`{"amount": 90, "unit": "ml"}`)},
+		{ChildID: 1, Type: ActivityTypeDiaper, Details: json.RawMessage(`{"kind": "wet"}`)},
+		{ChildID: 2, Type: ActivityTypeDiaper, Details: json.RawMessage(`{"kind": "dirty"}`)},
+	}
+	measurements := []Measurement{
+		{ChildID: 1, MeasuredOn: day(1), WeightKg: value(6), LengthCm: value(60)},
+		{ChildID: 1, MeasuredOn: day(12), WeightKg: value(6.1)},
+		{ChildID: 1, MeasuredOn: day(16), WeightKg: value(6.2)},
+		{ChildID: 2, MeasuredOn: day(11), LengthCm: value(50)},
+		{ChildID: 2, MeasuredOn: day(14), LengthCm: value(51.5)},
+	}
+
+	summaries := Summarize(recipient, children, activities, measurements, weekStart)
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(summaries))
+	}
+
+	budi := summaries[0]
+	if budi.Name != "Budi" || budi.SleepHours != 10.8 || budi.Feeds != 2 || budi.Diapers != 1 {
+		t.Errorf("unexpected totals %+v", budi)
+	}
+	// Length was not measured during the week
+	if len(budi.Growth) != 1 {
+		t.Fatalf("expected only weight, got %+v", budi.Growth)
+	}
+	weight := budi.Growth[0]
+	if weight.Measure != "weight" || weight.Unit != "lb" || weight.Value != 13.6687 || !weight.HasChange || weight.Change != 0.440925 {
+		t.Errorf("unexpected weight %+v", weight)
+	}
+
+	sari := summaries[1]
+	if sari.Diapers != 1 || sari.SleepHours != 0 || len(sari.Growth) != 1 {
+		t.Fatalf("unexpected totals %+v", sari)
+	}
+	// Without an earlier measurement the change is since the first of the week
+	length := sari.Growth[0]
+	if length.Measure != "length" || length.Value != 51.5 || length.Change != 1.5 || length.Unit != "cm" {
+		t.Errorf("unexpected length %+v", length)
+	}
+
+	if IsEmpty(summaries) {
+		t.Error("expected the summaries not to be empty")
+	}
+	if !IsEmpty(Summarize(recipient, children, nil, nil, weekStart)) {
+		t.Error("expected a week without records to be empty")
+	}
+}
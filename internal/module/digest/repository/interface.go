@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/digest/domain"
+	outboxDomain "dailyalu-server/internal/module/outbox/domain"
+	"time"
+)
+
+// IDigestRepository defines the interface for weekly digest data access
+type IDigestRepository interface {
+	// GetRecipients returns active users opted in to the digest whose ID
+	// sorts after afterUserID, in ID order
+	GetRecipients(afterUserID string, limit int) ([]domain.Recipient, error)
+	GetChildren(userID string) ([]domain.Child, error)
+	// GetActivities returns the counted activities of the user in [from, to)
+	GetActivities(userID string, from, to time.Time) ([]domain.Activity, error)
+	// GetMeasurements returns the user's measurements taken before the day,
+	// oldest first
+	GetMeasurements(userID string, before time.Time) ([]domain.Measurement, error)
+	// RecordSend marks the week as handled for the user and queues the
+	// message, if any, in one transaction. It reports false, queuing
+	// nothing, when the week was already handled.
+	RecordSend(userID string, weekStart time.Time, message *outboxDomain.Message) (bool, error)
+}
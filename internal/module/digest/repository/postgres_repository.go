@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/digest/domain"
+	outboxDomain "dailyalu-server/internal/module/outbox/domain"
+	outboxRepository "dailyalu-server/internal/module/outbox/repository"
+	userDomain "dailyalu-server/internal/module/user/domain"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostgresDigestRepository implements the digest repository interface using PostgreSQL
+type PostgresDigestRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresDigestRepository creates a new PostgreSQL digest repository
+func NewPostgresDigestRepository(db *sql.DB) IDigestRepository {
+	return &PostgresDigestRepository{
+		db: db,
+	}
+}
+
+// GetRecipients retrieves a batch of opted-in users. Users who never saved
+// their preferences have the defaults, and so are opted in.
+func (r *PostgresDigestRepository) GetRecipients(afterUserID string, limit int) ([]domain.Recipient, error) {
+	query := `
+		SELECT u.id, u.email, u.name, u.timezone,
+			COALESCE(p.locale, $4), COALESCE(p.first_day_of_week, $5),
+			COALESCE(p.weight_unit, $6), COALESCE(p.length_unit, $7),
+			(SELECT MAX(s.week_start) FROM digest_sends s WHERE s.user_id = u.id)
+		FROM users u
+		LEFT JOIN user_preferences p ON p.user_id = u.id
+		WHERE COALESCE(p.notify_weekly_digest, $8) AND u.status = $1 AND u.id > $2
+		ORDER BY u.id
+		LIMIT $3
+	`
+
+	defaults := userDomain.DefaultUserPreferences("")
+	rows, err := r.db.Query(query, userDomain.UserStatusActive, afterUserID, limit,
+		defaults.Locale, defaults.FirstDayOfWeek, defaults.WeightUnit, defaults.LengthUnit,
+		defaults.Notifications.WeeklyDigest)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []domain.Recipient
+	for rows.Next() {
+		var recipient domain.Recipient
+		if err := rows.Scan(
+			&recipient.UserID,
+			&recipient.Email,
+			&recipient.Name,
+			&recipient.Timezone,
+			&recipient.Locale,
+			&recipient.FirstDayOfWeek,
+			&recipient.WeightUnit,
+			&recipient.LengthUnit,
+			&recipient.LastWeekStart,
+		); err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	return recipients, rows.Err()
+}
+
+// GetChildren retrieves the children of a user
+func (r *PostgresDigestRepository) GetChildren(userID string) ([]domain.Child, error) {
+	query := `SELECT id, name FROM children WHERE user_id = $1 ORDER BY id`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var children []domain.Child
+	for rows.Next() {
+		var child domain.Child
+		if err := rows.Scan(&child.ID, &child.Name); err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+
+	return children, rows.Err()
+}
+
+// GetActivities retrieves the sleep, feeding and diaper activities of a week
+func (r *PostgresDigestRepository) GetActivities(userID string, from, to time.Time) ([]domain.Activity, error) {
+	query := `
+		SELECT child_id, type, details, happens_at
+		FROM activities
+		WHERE user_id = $1 AND happens_at >= $2 AND happens_at < $3 AND type = ANY($4)
+		ORDER BY happens_at
+	`
+
+	types := pq.Array([]string{domain.ActivityTypeSleep, domain.ActivityTypeFeeding, domain.ActivityTypeDiaper})
+	rows, err := r.db.Query(query, userID, from, to, types)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var activities []domain.Activity
+	for rows.Next() {
+		var activity domain.Activity
+		var details []byte
+		if err := rows.Scan(&activity.ChildID, &activity.Type, &details, &activity.HappensAt); err != nil {
+			return nil, err
+		}
+		activity.Details = details
+		activities = append(activities, activity)
+	}
+
+	return activities, rows.Err()
+}
+
+// GetMeasurements retrieves the growth measurements of a user's children
+func (r *PostgresDigestRepository) GetMeasurements(userID string, before time.Time) ([]domain.Measurement, error) {
+	query := `
+		SELECT child_id, measured_on, weight_kg, length_cm, head_circumference_cm
+		FROM growth_measurements
+		WHERE user_id = $1 AND measured_on < $2
+		ORDER BY child_id, measured_on, id
+	`
+
+	rows, err := r.db.Query(query, userID, before.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var measurements []domain.Measurement
+	for rows.Next() {
+		var measurement domain.Measurement
+		if err := rows.Scan(
+			&measurement.ChildID,
+			&measurement.MeasuredOn,
+			&measurement.WeightKg,
+			&measurement.LengthCm,
+			&measurement.HeadCircumferenceCm,
+		); err != nil {
+			return nil, err
+		}
+		measurements = append(measurements, measurement)
+	}
+
+	return measurements, rows.Err()
+}
+
+// RecordSend marks a week as handled, queuing the digest email with it
+func (r *PostgresDigestRepository) RecordSend(userID string, weekStart time.Time, message *outboxDomain.Message) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var messageID *int64
+	if message != nil {
+		if err := outboxRepository.Insert(tx, message); err != nil {
+			return false, err
+		}
+		messageID = &message.ID
+	}
+
+	query := `
+		INSERT INTO digest_sends (user_id, week_start, outbox_message_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, week_start) DO NOTHING
+	`
+
+	result, err := tx.Exec(query, userID, weekStart.Format("2006-01-02"), messageID, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		// Another run handled the week first, drop the message
+		return false, nil
+	}
+
+	return true, tx.Commit()
+}
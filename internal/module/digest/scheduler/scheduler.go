@@ -0,0 +1,178 @@
+// Package scheduler queues the weekly digest emails. Once a user's week is
+// over in their timezone, the digest of that week is computed and written to
+// the outbox together with a send record, so every week is emailed at most
+// once however often the job runs and on however many instances.
+package scheduler
+
+import (
+	"context"
+	"dailyalu-server/internal/module/digest/domain"
+	"dailyalu-server/internal/module/digest/repository"
+	outboxDomain "dailyalu-server/internal/module/outbox/domain"
+	mailerDomain "dailyalu-server/internal/service/mailer/domain"
+	"dailyalu-server/internal/utils"
+	"dailyalu-server/pkg/app_log/zap_log"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Config controls when digests are sent
+type Config struct {
+	// PollInterval is the time between two looks for due digests
+	PollInterval time.Duration
+	// BatchSize is the number of users loaded at once
+	BatchSize int
+	// SendHour is the hour of the first day of the week, in the user's
+	// timezone, from which the digest of the past week is sent
+	SendHour int
+}
+
+// NewConfigFromConfig reads the scheduler settings under digest
+func NewConfigFromConfig() Config {
+	return Config{
+		PollInterval: viper.GetDuration("digest.poll_interval"),
+		BatchSize:    viper.GetInt("digest.batch_size"),
+		SendHour:     viper.GetInt("digest.send_hour"),
+	}
+}
+
+// Scheduler computes the digests that are due and queues them in the outbox
+type Scheduler struct {
+	digestRepo      repository.IDigestRepository
+	defaultLocation *time.Location
+	config          Config
+	logger          *zap.Logger
+	now             func() time.Time
+}
+
+// NewScheduler creates a digest scheduler. Users without a timezone get
+// their digest by the default location.
+func NewScheduler(digestRepo repository.IDigestRepository, defaultLocation *time.Location, config Config) *Scheduler {
+	logger := zap_log.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &Scheduler{
+		digestRepo:      digestRepo,
+		defaultLocation: defaultLocation,
+		config:          config,
+		logger:          logger,
+		now:             time.Now,
+	}
+}
+
+// Run queues due digests until the context is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunOnce(ctx); err != nil {
+			s.logger.Error("failed to queue weekly digests", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce goes through every opted-in user and queues the digests that are
+// due. It returns the number of digests queued.
+func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
+	now := s.now()
+	queued := 0
+	after := ""
+
+	for {
+		recipients, err := s.digestRepo.GetRecipients(after, s.config.BatchSize)
+		if err != nil {
+			return queued, err
+		}
+
+		for i := range recipients {
+			if ctx.Err() != nil {
+				return queued, nil
+			}
+
+			sent, err := s.process(&recipients[i], now)
+			if err != nil {
+				s.logger.Warn("failed to queue weekly digest", zap.String("user_id", recipients[i].UserID), zap.Error(err))
+				continue
+			}
+			if sent {
+				queued++
+			}
+		}
+
+		if len(recipients) < s.config.BatchSize {
+			return queued, nil
+		}
+		after = recipients[len(recipients)-1].UserID
+	}
+}
+
+// process queues the digest of the user's past week if it is due and was not
+// handled yet. Weeks without anything to report are only recorded.
+func (s *Scheduler) process(recipient *domain.Recipient, now time.Time) (bool, error) {
+	loc := s.defaultLocation
+	if recipient.Timezone != "" {
+		if userLoc, err := utils.LoadLocation(recipient.Timezone); err == nil {
+			loc = userLoc
+		}
+	}
+
+	local := now.In(loc)
+	thisWeek := domain.WeekStart(local, recipient.FirstDayOfWeek)
+	if local.Before(thisWeek.Add(time.Duration(s.config.SendHour) * time.Hour)) {
+		return false, nil
+	}
+
+	weekStart := thisWeek.AddDate(0, 0, -7)
+	if recipient.LastWeekStart != nil && recipient.LastWeekStart.Format("2006-01-02") >= weekStart.Format("2006-01-02") {
+		return false, nil
+	}
+
+	children, err := s.digestRepo.GetChildren(recipient.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	activities, err := s.digestRepo.GetActivities(recipient.UserID, weekStart, thisWeek)
+	if err != nil {
+		return false, err
+	}
+
+	measurements, err := s.digestRepo.GetMeasurements(recipient.UserID, thisWeek)
+	if err != nil {
+		return false, err
+	}
+
+	var message *outboxDomain.Message
+	summaries := domain.Summarize(recipient, children, activities, measurements, weekStart)
+	if !domain.IsEmpty(summaries) {
+		message, err = outboxDomain.NewMessage(outboxDomain.TopicWeeklyDigestEmail, &mailerDomain.WeeklyDigestEmailData{
+			Name:      recipient.Name,
+			WeekStart: weekStart.Format("2006-01-02"),
+			WeekEnd:   thisWeek.AddDate(0, 0, -1).Format("2006-01-02"),
+			Children:  summaries,
+			To:        recipient.Email,
+			Locale:    recipient.Locale,
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	recorded, err := s.digestRepo.RecordSend(recipient.UserID, weekStart, message)
+	if err != nil {
+		return false, err
+	}
+
+	return recorded && message != nil, nil
+}
@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+	"context"
+	"dailyalu-server/internal/module/digest/domain"
+	outboxDomain "dailyalu-server/internal/module/outbox/domain"
+	mailerDomain "dailyalu-server/internal/service/mailer/domain"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// MockDigestRepository keeps send records in memory like the digest_sends
+// primary key
+type MockDigestRepository struct {
+	Recipients []domain.Recipient
+	Children   map[string][]domain.Child
+	Activities map[string][]domain.Activity
+	Sends      map[string]*outboxDomain.Message
+	// ActivityRange is the range the last activities were loaded for
+	ActivityRange [2]time.Time
+}
+
+func (m *MockDigestRepository) GetRecipients(afterUserID string, limit int) ([]domain.Recipient, error) {
+	var recipients []domain.Recipient
+	for _, recipient := range m.Recipients {
+		if recipient.UserID > afterUserID && len(recipients) < limit {
+			recipients = append(recipients, recipient)
+		}
+	}
+	return recipients, nil
+}
+
+func (m *MockDigestRepository) GetChildren(userID string) ([]domain.Child, error) {
+	return m.Children[userID], nil
+}
+
+func (m *MockDigestRepository) GetActivities(userID string, from, to time.Time) ([]domain.Activity, error) {
+	m.ActivityRange = [2]time.Time{from, to}
+	return m.Activities[userID], nil
+}
+
+func (m *MockDigestRepository) GetMeasurements(userID string, before time.Time) ([]domain.Measurement, error) {
+	return nil, nil
+}
+
+func (m *MockDigestRepository) RecordSend(userID string, weekStart time.Time, message *outboxDomain.Message) (bool, error) {
+	key := userID + "/" + weekStart.Format("2006-01-02")
+	if _, ok := m.Sends[key]; ok {
+		return false, nil
+	}
+	m.Sends[key] = message
+	return true, nil
+}
+
+func TestRunOnce(t *testing.T) {
+	// Monday 09:00 in Jakarta, 02:00 UTC
+	now := time.Date(2025, 3, 17, 2, 0, 0, 0, time.UTC)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	lastWeek := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	feeding := domain.Activity{ChildID: 1, Type: domain.ActivityTypeFeeding, Details: json.RawMessage(`{}`)}
+	repo := &MockDigestRepository{
+		Recipients: []domain.Recipient{
+			{UserID: "user-1", Email: "ani@example.com", Name: "Ani", Timezone: "Asia/Jakarta", Locale: "id-ID", FirstDayOfWeek: "monday"},
+			// Nothing recorded last week
+			{UserID: "user-2", Email: "budi@example.com", Name: "Budi", Timezone: "Asia/Jakarta", FirstDayOfWeek: "monday"},
+			// Already handled
+			{UserID: "user-3", Email: "citra@example.com", Name: "Citra", Timezone: "Asia/Jakarta", FirstDayOfWeek: "monday", LastWeekStart: &lastWeek},
+			// Still Sunday night in New York
+			{UserID: "user-4", Email: "dewi@example.com", Name: "Dewi", Timezone: "America/New_York", FirstDayOfWeek: "monday"},
+			// Default location, week started on Sunday
+			{UserID: "user-5", Email: "eko@example.com", Name: "Eko", FirstDayOfWeek: "sunday"},
+		},
+		Children: map[string][]domain.Child{
+			"user-1": {{ID: 1, Name: "Alu"}},
+			"user-2": {{ID: 2, Name: "Bima"}},
+			"user-3": {{ID: 3, Name: "Cici"}},
+			"user-4": {{ID: 4, Name: "Dodi"}},
+			"user-5": {{ID: 5, Name: "Eli"}},
+		},
+		Activities: map[string][]domain.Activity{
+			"user-1": {feeding, feeding},
+			"user-3": {feeding},
+			"user-4": {{ChildID: 4, Type: domain.ActivityTypeFeeding}},
+			"user-5": {{ChildID: 5, Type: domain.ActivityTypeDiaper}},
+		},
+		Sends: map[string]*outboxDomain.Message{},
+	}
+
+	scheduler := NewScheduler(repo, jakarta, Config{BatchSize: 2, SendHour: 8})
+	scheduler.now = func() time.Time { return now }
+
+	queued, err := scheduler.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if queued != 3 {
+		t.Errorf("expected 3 digests queued, got %d", queued)
+	}
+
+	message, ok := repo.Sends["user-1/2025-03-10"]
+	if !ok || message == nil || message.Topic != outboxDomain.TopicWeeklyDigestEmail {
+		t.Fatalf("expected a digest email for user-1, got %+v", message)
+	}
+	var data mailerDomain.WeeklyDigestEmailData
+	if err := json.Unmarshal(message.Payload, &data); err != nil {
+		t.Fatalf("unexpected payload: %v", err)
+	}
+	if data.To != "ani@example.com" || data.Locale != "id-ID" || data.WeekStart != "2025-03-10" || data.WeekEnd != "2025-03-16" {
+		t.Errorf("unexpected digest %+v", data)
+	}
+	if len(data.Children) != 1 || data.Children[0].Name != "Alu" || data.Children[0].Feeds != 2 {
+		t.Errorf("unexpected children %+v", data.Children)
+	}
+
+	// Empty weeks are recorded without an email
+	if message, ok := repo.Sends["user-2/2025-03-10"]; !ok || message != nil {
+		t.Errorf("expected the empty week of user-2 to be recorded without an email")
+	}
+	if _, ok := repo.Sends["user-3/2025-03-10"]; ok {
+		t.Error("expected user-3 to be skipped")
+	}
+	if _, ok := repo.Sends["user-4/2025-03-10"]; ok {
+		t.Error("expected no digest for user-4 before their week ends")
+	}
+	if _, ok := repo.Sends["user-4/2025-03-03"]; !ok {
+		t.Error("expected user-4 to receive the week before")
+	}
+	if _, ok := repo.Sends["user-5/2025-03-09"]; !ok {
+		t.Errorf("expected the Sunday week of user-5, got %v", repo.Sends)
+	}
+	if from := repo.ActivityRange[0]; !from.Equal(time.Date(2025, 3, 9, 0, 0, 0, 0, jakarta)) {
+		t.Errorf("expected activities from the start of the week in the default location, got %v", from)
+	}
+
+	// Running again queues nothing
+	queued, err = scheduler.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if queued != 0 {
+		t.Errorf("expected no digests on the second run, got %d", queued)
+	}
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	"time"
+)
+
+// Measurement is a growth measurement of a child on a given day. At least one
+// of the values is set.
+type Measurement struct {
+	ID                  int64               `json:"id"`
+	ChildID             int64               `json:"child_id"`
+	UserID              string              `json:"user_id"`
+	MeasuredOn          childrenDomain.Date `json:"measured_on"`
+	WeightKg            *float64            `json:"weight_kg,omitempty"`
+	LengthCm            *float64            `json:"length_cm,omitempty"`
+	HeadCircumferenceCm *float64            `json:"head_circumference_cm,omitempty"`
+	Note                string              `json:"note,omitempty"`
+	CreatedAt           time.Time           `json:"created_at"`
+	UpdatedAt           time.Time           `json:"updated_at"`
+}
+
+// Score places a value within the WHO reference population
+type Score struct {
+	ZScore     float64 `json:"z_score"`
+	Percentile float64 `json:"percentile"`
+}
+
+// Scores holds the score of each measured value. A score is missing when the
+// value, the child's sex or date of birth is unknown, or the age is outside
+// the reference tables.
+type Scores struct {
+	WeightForAge            *Score `json:"weight_for_age,omitempty"`
+	LengthForAge            *Score `json:"length_for_age,omitempty"`
+	HeadCircumferenceForAge *Score `json:"head_circumference_for_age,omitempty"`
+}
+
+// MeasurementResult is a measurement with the age used for scoring. For
+// premature babies the age is corrected for the weeks born early.
+type MeasurementResult struct {
+	Measurement
+	AgeDays *int    `json:"age_days,omitempty"`
+	Scores  *Scores `json:"scores,omitempty"`
+}
+
+// CreateMeasurementRequest represents the request to record a measurement
+type CreateMeasurementRequest struct {
+	ChildID             int64                `json:"-"`
+	UserID              string               `json:"-"`
+	MeasuredOn          *childrenDomain.Date `json:"measured_on" validate:"required"`
+	WeightKg            *float64             `json:"weight_kg,omitempty" validate:"omitempty,gt=0,max=50"`
+	LengthCm            *float64             `json:"length_cm,omitempty" validate:"omitempty,gt=0,max=150"`
+	HeadCircumferenceCm *float64             `json:"head_circumference_cm,omitempty" validate:"omitempty,gt=0,max=70"`
+	Note                string               `json:"note,omitempty" validate:"max=500"`
+}
+
+// SeriesPoint is a measured value placed on a growth chart
+type SeriesPoint struct {
+	MeasurementID int64               `json:"measurement_id"`
+	MeasuredOn    childrenDomain.Date `json:"measured_on"`
+	AgeDays       int                 `json:"age_days"`
+	Value         float64             `json:"value"`
+	ZScore        *float64            `json:"z_score,omitempty"`
+	Percentile    *float64            `json:"percentile,omitempty"`
+}
+
+// CurvePoint is the reference value of a percentile curve at a month of age
+type CurvePoint struct {
+	AgeMonths int     `json:"age_months"`
+	AgeDays   int     `json:"age_days"`
+	Value     float64 `json:"value"`
+}
+
+// ReferenceCurve is a WHO percentile line of a growth chart
+type ReferenceCurve struct {
+	Percentile float64      `json:"percentile"`
+	Points     []CurvePoint `json:"points"`
+}
+
+// SeriesResponse holds a child's measurements of one indicator together with
+// the reference curves to draw them against
+type SeriesResponse struct {
+	ChildID   int64            `json:"child_id"`
+	Indicator string           `json:"indicator"`
+	Unit      string           `json:"unit"`
+	Sex       string           `json:"sex"`
+	Corrected bool             `json:"corrected"`
+	Points    []SeriesPoint    `json:"points"`
+	Curves    []ReferenceCurve `json:"curves"`
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/growth/domain"
+)
+
+// IGrowthRepository defines the interface for growth measurement data access
+type IGrowthRepository interface {
+	Create(measurement *domain.Measurement) error
+	GetByID(id int64) (*domain.Measurement, error)
+	GetByChildID(childID int64) ([]domain.Measurement, error)
+	Delete(id int64) error
+}
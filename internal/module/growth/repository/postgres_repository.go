@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/growth/domain"
+	"database/sql"
+	"time"
+)
+
+// PostgresGrowthRepository implements the growth repository interface using PostgreSQL
+type PostgresGrowthRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresGrowthRepository creates a new PostgreSQL growth repository
+func NewPostgresGrowthRepository(db *sql.DB) IGrowthRepository {
+	return &PostgresGrowthRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new measurement into the database
+func (r *PostgresGrowthRepository) Create(measurement *domain.Measurement) error {
+	query := `
+		INSERT INTO growth_measurements (child_id, user_id, measured_on, weight_kg, length_cm, head_circumference_cm,
+			note, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	now := time.Now()
+	measurement.CreatedAt = now
+	measurement.UpdatedAt = now
+
+	return r.db.QueryRow(
+		query,
+		measurement.ChildID,
+		measurement.UserID,
+		measurement.MeasuredOn,
+		measurement.WeightKg,
+		measurement.LengthCm,
+		measurement.HeadCircumferenceCm,
+		measurement.Note,
+		measurement.CreatedAt,
+		measurement.UpdatedAt,
+	).Scan(&measurement.ID)
+}
+
+// GetByID retrieves a measurement by ID
+func (r *PostgresGrowthRepository) GetByID(id int64) (*domain.Measurement, error) {
+	query := `
+		SELECT id, child_id, user_id, measured_on, weight_kg, length_cm, head_circumference_cm, note,
+			created_at, updated_at
+		FROM growth_measurements
+		WHERE id = $1
+	`
+
+	var measurement domain.Measurement
+	err := scanMeasurement(r.db.QueryRow(query, id), &measurement)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &measurement, nil
+}
+
+// GetByChildID retrieves all measurements of a child, oldest first
+func (r *PostgresGrowthRepository) GetByChildID(childID int64) ([]domain.Measurement, error) {
+	query := `
+		SELECT id, child_id, user_id, measured_on, weight_kg, length_cm, head_circumference_cm, note,
+			created_at, updated_at
+		FROM growth_measurements
+		WHERE child_id = $1
+		ORDER BY measured_on, id
+	`
+
+	rows, err := r.db.Query(query, childID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	measurements := []domain.Measurement{}
+	for rows.Next() {
+		var measurement domain.Measurement
+		if err := scanMeasurement(rows, &measurement); err != nil {
+			return nil, err
+		}
+		measurements = append(measurements, measurement)
+	}
+
+	return measurements, rows.Err()
+}
+
+// Delete removes a measurement
+func (r *PostgresGrowthRepository) Delete(id int64) error {
+	result, err := r.db.Exec(`DELETE FROM growth_measurements WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMeasurement(row rowScanner, measurement *domain.Measurement) error {
+	return row.Scan(
+		&measurement.ID,
+		&measurement.ChildID,
+		&measurement.UserID,
+		&measurement.MeasuredOn,
+		&measurement.WeightKg,
+		&measurement.LengthCm,
+		&measurement.HeadCircumferenceCm,
+		&measurement.Note,
+		&measurement.CreatedAt,
+		&measurement.UpdatedAt,
+	)
+}
@@ -0,0 +1,164 @@
+// Package standards computes z-scores and percentiles against the WHO Child
+// Growth Standards using the LMS method.
+package standards
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Indicators covered by the bundled tables
+const (
+	WeightForAge            = "weight_for_age"
+	LengthForAge            = "length_for_age"
+	HeadCircumferenceForAge = "head_circumference_for_age"
+)
+
+// DaysPerMonth is the average month length used by the WHO tables
+const DaysPerMonth = 30.4375
+
+//go:embed data/who_lms.csv
+var whoLMS []byte
+
+// LMS holds the Box-Cox power (L), median (M) and coefficient of variation (S)
+// of a reference distribution
+type LMS struct {
+	L float64
+	M float64
+	S float64
+}
+
+// tableKey identifies the monthly rows of one indicator for one sex
+type tableKey struct {
+	indicator string
+	sex       string
+}
+
+var tables = mustParse(whoLMS)
+
+func mustParse(data []byte) map[tableKey][]LMS {
+	parsed, err := parse(data)
+	if err != nil {
+		panic(fmt.Sprintf("standards: invalid WHO LMS table: %v", err))
+	}
+	return parsed
+}
+
+func parse(data []byte) (map[tableKey][]LMS, error) {
+	parsed := make(map[tableKey][]LMS)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, ",")
+		if len(fields) != 6 {
+			return nil, fmt.Errorf("line %d: expected 6 fields, got %d", line, len(fields))
+		}
+
+		month, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		var values [3]float64
+		for i := range values {
+			if values[i], err = strconv.ParseFloat(fields[3+i], 64); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+
+		key := tableKey{indicator: fields[0], sex: fields[1]}
+		if month != len(parsed[key]) {
+			return nil, fmt.Errorf("line %d: month %d out of order", line, month)
+		}
+		parsed[key] = append(parsed[key], LMS{L: values[0], M: values[1], S: values[2]})
+	}
+	return parsed, scanner.Err()
+}
+
+// Lookup returns the LMS parameters for an age in days, interpolated
+// linearly between the monthly rows. It reports false when the indicator or
+// sex is unknown or the age is outside the table.
+func Lookup(indicator, sex string, ageDays int) (LMS, bool) {
+	rows, ok := tables[tableKey{indicator, sex}]
+	if !ok || ageDays < 0 {
+		return LMS{}, false
+	}
+
+	months := float64(ageDays) / DaysPerMonth
+	lower := int(months)
+	if lower >= len(rows)-1 {
+		if months > float64(len(rows)-1) {
+			return LMS{}, false
+		}
+		return rows[len(rows)-1], true
+	}
+
+	fraction := months - float64(lower)
+	a, b := rows[lower], rows[lower+1]
+	return LMS{
+		L: a.L + (b.L-a.L)*fraction,
+		M: a.M + (b.M-a.M)*fraction,
+		S: a.S + (b.S-a.S)*fraction,
+	}, true
+}
+
+// MonthlyLMS returns the LMS parameters of a whole month, or false when the
+// month is outside the table
+func MonthlyLMS(indicator, sex string, month int) (LMS, bool) {
+	rows, ok := tables[tableKey{indicator, sex}]
+	if !ok || month < 0 || month >= len(rows) {
+		return LMS{}, false
+	}
+	return rows[month], true
+}
+
+// ZScore returns the z-score of a measurement. Beyond three standard
+// deviations the distance is measured in units of the 2-3 SD interval, as
+// WHO recommends, so the skewed tail does not inflate extreme values.
+func (p LMS) ZScore(value float64) float64 {
+	z := p.rawZScore(value)
+	switch {
+	case z > 3:
+		sd3 := p.Value(3)
+		return 3 + (value-sd3)/(sd3-p.Value(2))
+	case z < -3:
+		sd3 := p.Value(-3)
+		return -3 - (sd3-value)/(p.Value(-2)-sd3)
+	}
+	return z
+}
+
+func (p LMS) rawZScore(value float64) float64 {
+	if p.L == 0 {
+		return math.Log(value/p.M) / p.S
+	}
+	return (math.Pow(value/p.M, p.L) - 1) / (p.L * p.S)
+}
+
+// Value returns the measurement at a z-score
+func (p LMS) Value(z float64) float64 {
+	if p.L == 0 {
+		return p.M * math.Exp(p.S*z)
+	}
+	return p.M * math.Pow(1+p.L*p.S*z, 1/p.L)
+}
+
+// Percentile converts a z-score into a percentile between 0 and 100
+func Percentile(z float64) float64 {
+	return 50 * (1 + math.Erf(z/math.Sqrt2))
+}
+
+// ZScoreForPercentile is the inverse of Percentile
+func ZScoreForPercentile(percentile float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*percentile/100-1)
+}
@@ -0,0 +1,66 @@
+package standards
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTablesCoverEveryIndicator(t *testing.T) {
+	for _, indicator := range []string{WeightForAge, LengthForAge, HeadCircumferenceForAge} {
+		for _, sex := range []string{"female", "male"} {
+			if rows := len(tables[tableKey{indicator, sex}]); rows != 25 {
+				t.Errorf("%s/%s: expected 25 monthly rows, got %d", indicator, sex, rows)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	birth, ok := Lookup(WeightForAge, "male", 0)
+	if !ok || birth.M != 3.3464 {
+		t.Fatalf("expected birth median 3.3464, got %v (%v)", birth.M, ok)
+	}
+
+	// Halfway between month 1 and month 2
+	mid, ok := Lookup(LengthForAge, "female", 46)
+	if !ok {
+		t.Fatal("expected a value for 1.5 months")
+	}
+	if math.Abs(mid.M-(53.6872+57.0673)/2) > 0.1 {
+		t.Errorf("expected interpolated median near 55.38, got %v", mid.M)
+	}
+
+	if _, ok := Lookup(WeightForAge, "male", 800); ok {
+		t.Error("expected no value beyond 24 months")
+	}
+	if _, ok := Lookup(WeightForAge, "", 10); ok {
+		t.Error("expected no value for an unknown sex")
+	}
+}
+
+func TestZScoreAndPercentile(t *testing.T) {
+	lms, _ := MonthlyLMS(WeightForAge, "male", 0)
+
+	if z := lms.ZScore(lms.M); math.Abs(z) > 1e-9 {
+		t.Errorf("expected median z-score 0, got %v", z)
+	}
+	if p := Percentile(0); p != 50 {
+		t.Errorf("expected 50th percentile, got %v", p)
+	}
+
+	for _, z := range []float64{-2, -1, 1, 2} {
+		if got := lms.ZScore(lms.Value(z)); math.Abs(got-z) > 1e-9 {
+			t.Errorf("z=%v: round trip gave %v", z, got)
+		}
+	}
+
+	// Beyond 3 SD the distance is measured in 2-3 SD intervals
+	sd3, sd2 := lms.Value(3), lms.Value(2)
+	if got := lms.ZScore(sd3 + (sd3 - sd2)); math.Abs(got-4) > 1e-9 {
+		t.Errorf("expected restricted z-score 4, got %v", got)
+	}
+
+	if z := ZScoreForPercentile(97); math.Abs(Percentile(z)-97) > 1e-9 {
+		t.Errorf("expected percentile round trip, got %v", Percentile(z))
+	}
+}
@@ -0,0 +1,12 @@
+package usecase
+
+import "errors"
+
+// Domain errors for growth module
+var (
+	ErrMeasurementNotFound    = errors.New("measurement not found")
+	ErrEmptyMeasurement       = errors.New("measurement has no values")
+	ErrInvalidMeasurementDate = errors.New("measurement date must be between the date of birth and today")
+	ErrInvalidIndicator       = errors.New("invalid growth indicator")
+	ErrIncompleteBirthProfile = errors.New("child date of birth and sex are required for growth charts")
+)
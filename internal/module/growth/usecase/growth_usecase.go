@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	"dailyalu-server/internal/module/growth/domain"
+	"dailyalu-server/internal/module/growth/repository"
+	"dailyalu-server/internal/module/growth/standards"
+	"database/sql"
+	"math"
+	"time"
+)
+
+// chartPercentiles are the reference curves drawn on growth charts
+var chartPercentiles = []float64{3, 15, 50, 85, 97}
+
+// indicatorUnits maps each indicator to the unit of its values
+var indicatorUnits = map[string]string{
+	standards.WeightForAge:            "kg",
+	standards.LengthForAge:            "cm",
+	standards.HeadCircumferenceForAge: "cm",
+}
+
+// GrowthUseCase implements the growth business logic
+type GrowthUseCase struct {
+	growthRepo      repository.IGrowthRepository
+	childrenUseCase childrenUsecase.IChildrenUseCase
+	now             func() time.Time
+}
+
+// NewGrowthUseCase creates a new growth use case
+func NewGrowthUseCase(growthRepo repository.IGrowthRepository, childrenUseCase childrenUsecase.IChildrenUseCase) IGrowthUseCase {
+	return &GrowthUseCase{
+		growthRepo:      growthRepo,
+		childrenUseCase: childrenUseCase,
+		now:             time.Now,
+	}
+}
+
+// CreateMeasurement records a measurement of a child owned by the user
+func (u *GrowthUseCase) CreateMeasurement(req *domain.CreateMeasurementRequest) (*domain.MeasurementResult, error) {
+	if req.WeightKg == nil && req.LengthCm == nil && req.HeadCircumferenceCm == nil {
+		return nil, ErrEmptyMeasurement
+	}
+
+	child, err := u.childrenUseCase.GetChild(req.ChildID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	measuredOn := *req.MeasuredOn
+	if measuredOn.After(childrenDomain.NewDate(u.now()).Time) ||
+		(child.DateOfBirth != nil && measuredOn.Before(child.DateOfBirth.Time)) {
+		return nil, ErrInvalidMeasurementDate
+	}
+
+	measurement := &domain.Measurement{
+		ChildID:             child.ID,
+		UserID:              req.UserID,
+		MeasuredOn:          measuredOn,
+		WeightKg:            req.WeightKg,
+		LengthCm:            req.LengthCm,
+		HeadCircumferenceCm: req.HeadCircumferenceCm,
+		Note:                req.Note,
+	}
+
+	if err := u.growthRepo.Create(measurement); err != nil {
+		return nil, err
+	}
+
+	result := score(child, *measurement)
+	return &result, nil
+}
+
+// GetMeasurements retrieves the scored measurements of a child, oldest first
+func (u *GrowthUseCase) GetMeasurements(childID int64, userID string) ([]domain.MeasurementResult, error) {
+	child, err := u.childrenUseCase.GetChild(childID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	measurements, err := u.growthRepo.GetByChildID(child.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.MeasurementResult, 0, len(measurements))
+	for _, measurement := range measurements {
+		results = append(results, score(child, measurement))
+	}
+	return results, nil
+}
+
+// DeleteMeasurement removes a measurement of a child owned by the user
+func (u *GrowthUseCase) DeleteMeasurement(childID, id int64, userID string) error {
+	if _, err := u.childrenUseCase.GetChild(childID, userID); err != nil {
+		return err
+	}
+
+	measurement, err := u.growthRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if measurement == nil || measurement.ChildID != childID {
+		return ErrMeasurementNotFound
+	}
+
+	if err := u.growthRepo.Delete(id); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrMeasurementNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// GetSeries returns the measurements of one indicator with the WHO reference
+// curves, ready to be drawn as a growth chart
+func (u *GrowthUseCase) GetSeries(childID int64, userID, indicator string) (*domain.SeriesResponse, error) {
+	unit, ok := indicatorUnits[indicator]
+	if !ok {
+		return nil, ErrInvalidIndicator
+	}
+
+	child, err := u.childrenUseCase.GetChild(childID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if child.DateOfBirth == nil || child.Sex == "" {
+		return nil, ErrIncompleteBirthProfile
+	}
+
+	measurements, err := u.growthRepo.GetByChildID(child.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	series := &domain.SeriesResponse{
+		ChildID:   child.ID,
+		Indicator: indicator,
+		Unit:      unit,
+		Sex:       child.Sex,
+		Corrected: child.IsPremature(),
+		Points:    []domain.SeriesPoint{},
+		Curves:    referenceCurves(indicator, child.Sex),
+	}
+
+	for _, measurement := range measurements {
+		value := indicatorValue(&measurement, indicator)
+		if value == nil {
+			continue
+		}
+		ageDays, ok := scoringAgeDays(&child.BirthProfile, measurement.MeasuredOn)
+		if !ok {
+			continue
+		}
+
+		point := domain.SeriesPoint{
+			MeasurementID: measurement.ID,
+			MeasuredOn:    measurement.MeasuredOn,
+			AgeDays:       ageDays,
+			Value:         *value,
+		}
+		if s := scoreValue(indicator, child.Sex, ageDays, *value); s != nil {
+			point.ZScore = &s.ZScore
+			point.Percentile = &s.Percentile
+		}
+		series.Points = append(series.Points, point)
+	}
+
+	return series, nil
+}
+
+// score attaches the age and WHO scores to a measurement
+func score(child *childrenDomain.Child, measurement domain.Measurement) domain.MeasurementResult {
+	result := domain.MeasurementResult{Measurement: measurement}
+
+	ageDays, ok := scoringAgeDays(&child.BirthProfile, measurement.MeasuredOn)
+	if !ok {
+		return result
+	}
+	result.AgeDays = &ageDays
+
+	scores := domain.Scores{}
+	if measurement.WeightKg != nil {
+		scores.WeightForAge = scoreValue(standards.WeightForAge, child.Sex, ageDays, *measurement.WeightKg)
+	}
+	if measurement.LengthCm != nil {
+		scores.LengthForAge = scoreValue(standards.LengthForAge, child.Sex, ageDays, *measurement.LengthCm)
+	}
+	if measurement.HeadCircumferenceCm != nil {
+		scores.HeadCircumferenceForAge = scoreValue(standards.HeadCircumferenceForAge, child.Sex, ageDays, *measurement.HeadCircumferenceCm)
+	}
+	if scores != (domain.Scores{}) {
+		result.Scores = &scores
+	}
+	return result
+}
+
+// scoringAgeDays returns the age in days on the measurement date. Premature
+// babies are scored at their corrected age during the first two years. It
+// reports false when the date of birth is unknown or the measurement was
+// taken before birth.
+func scoringAgeDays(profile *childrenDomain.BirthProfile, measuredOn childrenDomain.Date) (int, bool) {
+	if profile.DateOfBirth == nil {
+		return 0, false
+	}
+
+	dob := *profile.DateOfBirth
+	days := dob.DaysUntil(measuredOn)
+	if days < 0 {
+		return 0, false
+	}
+
+	if profile.IsPremature() && measuredOn.Before(dob.AddDate(0, childrenDomain.CorrectedAgeMaxMonths, 0)) {
+		gestationalAge, _ := profile.GestationalAgeAtBirthDays()
+		days -= childrenDomain.TermGestationalAgeDays - gestationalAge
+	}
+	return days, true
+}
+
+// scoreValue returns the WHO score of a value, or nil when the tables do not
+// cover the sex or age
+func scoreValue(indicator, sex string, ageDays int, value float64) *domain.Score {
+	lms, ok := standards.Lookup(indicator, sex, ageDays)
+	if !ok || value <= 0 {
+		return nil
+	}
+
+	z := lms.ZScore(value)
+	return &domain.Score{
+		ZScore:     round(z, 2),
+		Percentile: round(standards.Percentile(z), 1),
+	}
+}
+
+// referenceCurves returns the monthly WHO percentile curves of an indicator
+func referenceCurves(indicator, sex string) []domain.ReferenceCurve {
+	curves := make([]domain.ReferenceCurve, 0, len(chartPercentiles))
+	for _, percentile := range chartPercentiles {
+		z := standards.ZScoreForPercentile(percentile)
+		curve := domain.ReferenceCurve{Percentile: percentile, Points: []domain.CurvePoint{}}
+		for month := 0; ; month++ {
+			lms, ok := standards.MonthlyLMS(indicator, sex, month)
+			if !ok {
+				break
+			}
+			curve.Points = append(curve.Points, domain.CurvePoint{
+				AgeMonths: month,
+				AgeDays:   int(math.Round(float64(month) * standards.DaysPerMonth)),
+				Value:     round(lms.Value(z), 2),
+			})
+		}
+		curves = append(curves, curve)
+	}
+	return curves
+}
+
+// indicatorValue returns the measured value of an indicator, or nil when it
+// was not measured
+func indicatorValue(measurement *domain.Measurement, indicator string) *float64 {
+	switch indicator {
+	case standards.WeightForAge:
+		return measurement.WeightKg
+	case standards.LengthForAge:
+		return measurement.LengthCm
+	case standards.HeadCircumferenceForAge:
+		return measurement.HeadCircumferenceCm
+	}
+	return nil
+}
+
+func round(value float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"dailyalu-server/internal/module/children/childrentest"
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	"dailyalu-server/internal/module/growth/domain"
+	"dailyalu-server/internal/module/growth/standards"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockGrowthRepository is a mock implementation of the growth repository
+type MockGrowthRepository struct {
+	CreateFunc       func(measurement *domain.Measurement) error
+	GetByIDFunc      func(id int64) (*domain.Measurement, error)
+	GetByChildIDFunc func(childID int64) ([]domain.Measurement, error)
+	DeleteFunc       func(id int64) error
+}
+
+func (m *MockGrowthRepository) Create(measurement *domain.Measurement) error {
+	return m.CreateFunc(measurement)
+}
+
+func (m *MockGrowthRepository) GetByID(id int64) (*domain.Measurement, error) {
+	return m.GetByIDFunc(id)
+}
+
+func (m *MockGrowthRepository) GetByChildID(childID int64) ([]domain.Measurement, error) {
+	return m.GetByChildIDFunc(childID)
+}
+
+func (m *MockGrowthRepository) Delete(id int64) error {
+	return m.DeleteFunc(id)
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func newTestChild(profile childrenDomain.BirthProfile) *childrenDomain.Child {
+	return &childrenDomain.Child{ID: 1, UserID: "user-1", Name: "Baby", BirthProfile: profile}
+}
+
+func newTestUseCase(repo *MockGrowthRepository, child *childrenDomain.Child) *GrowthUseCase {
+	return &GrowthUseCase{
+		growthRepo:      repo,
+		childrenUseCase: &childrentest.UseCase{Child: child},
+		now:             childrentest.Clock(time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)),
+	}
+}
+
+func TestCreateMeasurement(t *testing.T) {
+	dob := childrentest.MustDate(t, "2025-01-01")
+	child := newTestChild(childrenDomain.BirthProfile{DateOfBirth: &dob, Sex: childrenDomain.SexMale})
+
+	repo := &MockGrowthRepository{
+		CreateFunc: func(measurement *domain.Measurement) error {
+			measurement.ID = 7
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo, child)
+
+	// The WHO median weight of a boy at birth scores the 50th percentile
+	measuredOn := childrentest.MustDate(t, "2025-01-01")
+	result, err := uc.CreateMeasurement(&domain.CreateMeasurementRequest{
+		ChildID:    1,
+		UserID:     "user-1",
+		MeasuredOn: &measuredOn,
+		WeightKg:   floatPtr(3.3464),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ID != 7 || result.AgeDays == nil || *result.AgeDays != 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Scores == nil || result.Scores.WeightForAge == nil {
+		t.Fatal("expected a weight-for-age score")
+	}
+	if result.Scores.WeightForAge.ZScore != 0 || result.Scores.WeightForAge.Percentile != 50 {
+		t.Errorf("expected median score, got %+v", result.Scores.WeightForAge)
+	}
+	if result.Scores.LengthForAge != nil {
+		t.Error("expected no length-for-age score without a length")
+	}
+}
+
+func TestCreateMeasurementValidation(t *testing.T) {
+	dob := childrentest.MustDate(t, "2025-01-01")
+	child := newTestChild(childrenDomain.BirthProfile{DateOfBirth: &dob, Sex: childrenDomain.SexFemale})
+	repo := &MockGrowthRepository{
+		CreateFunc: func(measurement *domain.Measurement) error {
+			t.Fatal("Create should not be called")
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo, child)
+
+	testCases := []struct {
+		name       string
+		userID     string
+		measuredOn string
+		weight     *float64
+		expected   error
+	}{
+		{"no values", "user-1", "2025-02-01", nil, ErrEmptyMeasurement},
+		{"before birth", "user-1", "2024-12-31", floatPtr(3), ErrInvalidMeasurementDate},
+		{"in the future", "user-1", "2025-06-02", floatPtr(3), ErrInvalidMeasurementDate},
+		{"other user's child", "user-2", "2025-02-01", floatPtr(3), childrenUsecase.ErrUnauthorizedAccess},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			measuredOn := childrentest.MustDate(t, tc.measuredOn)
+			_, err := uc.CreateMeasurement(&domain.CreateMeasurementRequest{
+				ChildID:    1,
+				UserID:     tc.userID,
+				MeasuredOn: &measuredOn,
+				WeightKg:   tc.weight,
+			})
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestScoringAgeDaysCorrectsPrematureBabies(t *testing.T) {
+	dob := childrentest.MustDate(t, "2025-01-01")
+	profile := childrenDomain.BirthProfile{
+		DateOfBirth:         &dob,
+		GestationalAgeWeeks: childrentest.IntPtr(32),
+	}
+
+	// Born 8 weeks early
+	age, ok := scoringAgeDays(&profile, childrentest.MustDate(t, "2025-04-01"))
+	if !ok || age != 90-56 {
+		t.Errorf("expected corrected age 34, got %d (%v)", age, ok)
+	}
+
+	// Chronological age from two years on
+	age, ok = scoringAgeDays(&profile, childrentest.MustDate(t, "2027-01-01"))
+	if !ok || age != 730 {
+		t.Errorf("expected chronological age 730, got %d (%v)", age, ok)
+	}
+
+	if _, ok := scoringAgeDays(&childrenDomain.BirthProfile{}, childrentest.MustDate(t, "2025-04-01")); ok {
+		t.Error("expected no age without a date of birth")
+	}
+}
+
+func TestGetSeries(t *testing.T) {
+	dob := childrentest.MustDate(t, "2025-01-01")
+	child := newTestChild(childrenDomain.BirthProfile{DateOfBirth: &dob, Sex: childrenDomain.SexFemale})
+
+	repo := &MockGrowthRepository{
+		GetByChildIDFunc: func(childID int64) ([]domain.Measurement, error) {
+			return []domain.Measurement{
+				{ID: 1, ChildID: 1, MeasuredOn: childrentest.MustDate(t, "2025-01-01"), WeightKg: floatPtr(3.2), LengthCm: floatPtr(49.1)},
+				{ID: 2, ChildID: 1, MeasuredOn: childrentest.MustDate(t, "2025-02-01"), HeadCircumferenceCm: floatPtr(36.5)},
+				{ID: 3, ChildID: 1, MeasuredOn: childrentest.MustDate(t, "2025-03-01"), WeightKg: floatPtr(5.1)},
+			}, nil
+		},
+	}
+	uc := newTestUseCase(repo, child)
+
+	series, err := uc.GetSeries(1, "user-1", standards.WeightForAge)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if series.Unit != "kg" || series.Sex != childrenDomain.SexFemale || series.Corrected {
+		t.Errorf("unexpected series header: %+v", series)
+	}
+	if len(series.Points) != 2 || series.Points[0].MeasurementID != 1 || series.Points[1].MeasurementID != 3 {
+		t.Fatalf("expected the two weight measurements, got %+v", series.Points)
+	}
+	for _, point := range series.Points {
+		if point.Percentile == nil {
+			t.Errorf("expected a percentile for measurement %d", point.MeasurementID)
+		}
+	}
+
+	if len(series.Curves) != len(chartPercentiles) {
+		t.Fatalf("expected %d curves, got %d", len(chartPercentiles), len(series.Curves))
+	}
+	median := series.Curves[2]
+	if median.Percentile != 50 || len(median.Points) != 25 || median.Points[0].Value != 3.23 {
+		t.Errorf("unexpected median curve: %+v", median.Points[0])
+	}
+
+	if _, err := uc.GetSeries(1, "user-1", "bmi_for_age"); !errors.Is(err, ErrInvalidIndicator) {
+		t.Errorf("expected ErrInvalidIndicator, got %v", err)
+	}
+
+	child.Sex = ""
+	if _, err := uc.GetSeries(1, "user-1", standards.WeightForAge); !errors.Is(err, ErrIncompleteBirthProfile) {
+		t.Errorf("expected ErrIncompleteBirthProfile, got %v", err)
+	}
+}
+
+func TestDeleteMeasurementOfAnotherChild(t *testing.T) {
+	child := newTestChild(childrenDomain.BirthProfile{})
+	repo := &MockGrowthRepository{
+		GetByIDFunc: func(id int64) (*domain.Measurement, error) {
+			return &domain.Measurement{ID: id, ChildID: 2}, nil
+		},
+		DeleteFunc: func(id int64) error {
+			t.Fatal("Delete should not be called")
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo, child)
+
+	if err := uc.DeleteMeasurement(1, 5, "user-1"); !errors.Is(err, ErrMeasurementNotFound) {
+		t.Errorf("expected ErrMeasurementNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"dailyalu-server/internal/module/growth/domain"
+)
+
+// IGrowthUseCase defines the interface for growth business logic
+type IGrowthUseCase interface {
+	CreateMeasurement(req *domain.CreateMeasurementRequest) (*domain.MeasurementResult, error)
+	GetMeasurements(childID int64, userID string) ([]domain.MeasurementResult, error)
+	DeleteMeasurement(childID, id int64, userID string) error
+	GetSeries(childID int64, userID, indicator string) (*domain.SeriesResponse, error)
+}
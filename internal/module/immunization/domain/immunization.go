@@ -0,0 +1,60 @@
+package domain
+
+import (
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	"time"
+)
+
+// Schedule item statuses
+const (
+	StatusCompleted = "completed"
+	StatusOverdue   = "overdue"
+	StatusDue       = "due"
+	StatusUpcoming  = "upcoming"
+)
+
+// DoseRecord is a vaccine dose given to a child
+type DoseRecord struct {
+	ID          int64               `json:"id"`
+	ChildID     int64               `json:"child_id"`
+	UserID      string              `json:"user_id"`
+	VaccineCode string              `json:"vaccine_code"`
+	DoseNumber  int                 `json:"dose_number"`
+	GivenOn     childrenDomain.Date `json:"given_on"`
+	LotNumber   string              `json:"lot_number,omitempty"`
+	Provider    string              `json:"provider,omitempty"`
+	Note        string              `json:"note,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// RecordDoseRequest represents the request to record a given dose
+type RecordDoseRequest struct {
+	ChildID     int64                `json:"-"`
+	UserID      string               `json:"-"`
+	VaccineCode string               `json:"vaccine_code" validate:"required,max=64"`
+	DoseNumber  int                  `json:"dose_number" validate:"required,min=1"`
+	GivenOn     *childrenDomain.Date `json:"given_on" validate:"required"`
+	LotNumber   string               `json:"lot_number,omitempty" validate:"max=64"`
+	Provider    string               `json:"provider,omitempty" validate:"max=255"`
+	Note        string               `json:"note,omitempty" validate:"max=500"`
+}
+
+// ScheduleItem is a dose of the national schedule placed on a child's
+// calendar. Record is set once the dose was given.
+type ScheduleItem struct {
+	VaccineCode  string              `json:"vaccine_code"`
+	VaccineName  string              `json:"vaccine_name"`
+	DoseNumber   int                 `json:"dose_number"`
+	DueOn        childrenDomain.Date `json:"due_on"`
+	OverdueAfter childrenDomain.Date `json:"overdue_after"`
+	Status       string              `json:"status"`
+	Record       *DoseRecord         `json:"record,omitempty"`
+}
+
+// ChildScheduleResponse is a child's immunization schedule
+type ChildScheduleResponse struct {
+	ChildID  int64          `json:"child_id"`
+	Schedule string         `json:"schedule"`
+	Items    []ScheduleItem `json:"items"`
+}
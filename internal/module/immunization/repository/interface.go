@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/immunization/domain"
+)
+
+// IImmunizationRepository defines the interface for immunization data access
+type IImmunizationRepository interface {
+	Create(record *domain.DoseRecord) error
+	GetByID(id int64) (*domain.DoseRecord, error)
+	GetByChildID(childID int64) ([]domain.DoseRecord, error)
+	Delete(id int64) error
+}
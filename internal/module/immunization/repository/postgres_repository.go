@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/immunization/domain"
+	"database/sql"
+	"time"
+)
+
+// PostgresImmunizationRepository implements the immunization repository interface using PostgreSQL
+type PostgresImmunizationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresImmunizationRepository creates a new PostgreSQL immunization repository
+func NewPostgresImmunizationRepository(db *sql.DB) IImmunizationRepository {
+	return &PostgresImmunizationRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new dose record into the database
+func (r *PostgresImmunizationRepository) Create(record *domain.DoseRecord) error {
+	query := `
+		INSERT INTO immunization_records (child_id, user_id, vaccine_code, dose_number, given_on, lot_number,
+			provider, note, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+
+	return r.db.QueryRow(
+		query,
+		record.ChildID,
+		record.UserID,
+		record.VaccineCode,
+		record.DoseNumber,
+		record.GivenOn,
+		record.LotNumber,
+		record.Provider,
+		record.Note,
+		record.CreatedAt,
+		record.UpdatedAt,
+	).Scan(&record.ID)
+}
+
+// GetByID retrieves a dose record by ID
+func (r *PostgresImmunizationRepository) GetByID(id int64) (*domain.DoseRecord, error) {
+	query := `
+		SELECT id, child_id, user_id, vaccine_code, dose_number, given_on, lot_number, provider, note,
+			created_at, updated_at
+		FROM immunization_records
+		WHERE id = $1
+	`
+
+	var record domain.DoseRecord
+	err := scanDoseRecord(r.db.QueryRow(query, id), &record)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// GetByChildID retrieves all dose records of a child, oldest first
+func (r *PostgresImmunizationRepository) GetByChildID(childID int64) ([]domain.DoseRecord, error) {
+	query := `
+		SELECT id, child_id, user_id, vaccine_code, dose_number, given_on, lot_number, provider, note,
+			created_at, updated_at
+		FROM immunization_records
+		WHERE child_id = $1
+		ORDER BY given_on, id
+	`
+
+	rows, err := r.db.Query(query, childID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []domain.DoseRecord{}
+	for rows.Next() {
+		var record domain.DoseRecord
+		if err := scanDoseRecord(rows, &record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// Delete removes a dose record
+func (r *PostgresImmunizationRepository) Delete(id int64) error {
+	result, err := r.db.Exec(`DELETE FROM immunization_records WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDoseRecord(row rowScanner, record *domain.DoseRecord) error {
+	return row.Scan(
+		&record.ID,
+		&record.ChildID,
+		&record.UserID,
+		&record.VaccineCode,
+		&record.DoseNumber,
+		&record.GivenOn,
+		&record.LotNumber,
+		&record.Provider,
+		&record.Note,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+	)
+}
@@ -0,0 +1,235 @@
+// Package schedule loads national vaccine schedules. Schedules are YAML or
+// JSON documents; the IDAI (Indonesia) and CDC (United States) schedules are
+// bundled and a custom file can be configured instead.
+package schedule
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed data/*.yaml
+var builtin embed.FS
+
+// DefaultGracePeriod is how long after the recommended age a dose stays due
+// when neither the dose nor the schedule sets a limit
+const DefaultGracePeriod = "4w"
+
+// ErrUnknownSchedule is returned for a bundled schedule name that does not exist
+var ErrUnknownSchedule = errors.New("unknown immunization schedule")
+
+// Age is an offset from the date of birth such as "6w" or "18m"
+type Age struct {
+	Amount int
+	Unit   byte
+}
+
+// ParseAge parses an age made of a number and a unit: d (days), w (weeks),
+// m (months) or y (years)
+func ParseAge(value string) (Age, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 {
+		return Age{}, fmt.Errorf("invalid age %q", value)
+	}
+
+	unit := value[len(value)-1]
+	if !strings.ContainsRune("dwmy", rune(unit)) {
+		return Age{}, fmt.Errorf("invalid age %q: unit must be d, w, m or y", value)
+	}
+	amount, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || amount < 0 {
+		return Age{}, fmt.Errorf("invalid age %q", value)
+	}
+	return Age{Amount: amount, Unit: unit}, nil
+}
+
+// AddTo returns the date at this age for a child born on birth
+func (a Age) AddTo(birth time.Time) time.Time {
+	switch a.Unit {
+	case 'w':
+		return birth.AddDate(0, 0, a.Amount*7)
+	case 'm':
+		return birth.AddDate(0, a.Amount, 0)
+	case 'y':
+		return birth.AddDate(a.Amount, 0, 0)
+	}
+	return birth.AddDate(0, 0, a.Amount)
+}
+
+func (a Age) String() string {
+	return strconv.Itoa(a.Amount) + string(a.Unit)
+}
+
+func (a Age) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// Dose is a recommended dose of a vaccine. It is due from Age and overdue
+// after Until, or after the schedule's grace period when Until is not set.
+type Dose struct {
+	Number int  `json:"number"`
+	Age    Age  `json:"age"`
+	Until  *Age `json:"until,omitempty"`
+}
+
+// Vaccine lists the recommended doses of one vaccine
+type Vaccine struct {
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Doses []Dose `json:"doses"`
+}
+
+// Schedule is a national vaccine schedule
+type Schedule struct {
+	Name        string    `json:"name"`
+	Country     string    `json:"country"`
+	Source      string    `json:"source,omitempty"`
+	GracePeriod Age       `json:"grace_period"`
+	Vaccines    []Vaccine `json:"vaccines"`
+}
+
+// DueOn returns the date a dose is recommended for a child born on birth
+func (s *Schedule) DueOn(dose Dose, birth time.Time) time.Time {
+	return dose.Age.AddTo(birth)
+}
+
+// OverdueAfter returns the last day a dose is given on time
+func (s *Schedule) OverdueAfter(dose Dose, birth time.Time) time.Time {
+	if dose.Until != nil {
+		return dose.Until.AddTo(birth)
+	}
+	return s.GracePeriod.AddTo(s.DueOn(dose, birth))
+}
+
+// Dose returns a dose of a vaccine by its 1-based number
+func (s *Schedule) Dose(code string, number int) (Vaccine, Dose, bool) {
+	for _, vaccine := range s.Vaccines {
+		if vaccine.Code == code {
+			if number < 1 || number > len(vaccine.Doses) {
+				return Vaccine{}, Dose{}, false
+			}
+			return vaccine, vaccine.Doses[number-1], true
+		}
+	}
+	return Vaccine{}, Dose{}, false
+}
+
+// document is the file format shared by YAML and JSON schedules
+type document struct {
+	Name        string `yaml:"name" json:"name"`
+	Country     string `yaml:"country" json:"country"`
+	Source      string `yaml:"source" json:"source"`
+	GracePeriod string `yaml:"grace_period" json:"grace_period"`
+	Vaccines    []struct {
+		Code  string `yaml:"code" json:"code"`
+		Name  string `yaml:"name" json:"name"`
+		Doses []struct {
+			Age   string `yaml:"age" json:"age"`
+			Until string `yaml:"until" json:"until"`
+		} `yaml:"doses" json:"doses"`
+	} `yaml:"vaccines" json:"vaccines"`
+}
+
+// Parse reads a schedule document in "yaml" or "json" format
+func Parse(data []byte, format string) (*Schedule, error) {
+	var doc document
+	var err error
+	if format == "json" {
+		err = json.Unmarshal(data, &doc)
+	} else {
+		err = yaml.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse immunization schedule: %w", err)
+	}
+
+	if doc.Name == "" {
+		return nil, errors.New("immunization schedule has no name")
+	}
+	if doc.GracePeriod == "" {
+		doc.GracePeriod = DefaultGracePeriod
+	}
+	grace, err := ParseAge(doc.GracePeriod)
+	if err != nil {
+		return nil, fmt.Errorf("grace_period: %w", err)
+	}
+
+	schedule := &Schedule{Name: doc.Name, Country: doc.Country, Source: doc.Source, GracePeriod: grace}
+	seen := make(map[string]bool)
+	reference := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, v := range doc.Vaccines {
+		if v.Code == "" || seen[v.Code] {
+			return nil, fmt.Errorf("vaccine code %q is empty or duplicated", v.Code)
+		}
+		if len(v.Doses) == 0 {
+			return nil, fmt.Errorf("vaccine %s has no doses", v.Code)
+		}
+		seen[v.Code] = true
+
+		vaccine := Vaccine{Code: v.Code, Name: v.Name}
+		for i, d := range v.Doses {
+			age, err := ParseAge(d.Age)
+			if err != nil {
+				return nil, fmt.Errorf("vaccine %s dose %d: %w", v.Code, i+1, err)
+			}
+
+			dose := Dose{Number: i + 1, Age: age}
+			if d.Until != "" {
+				until, err := ParseAge(d.Until)
+				if err != nil {
+					return nil, fmt.Errorf("vaccine %s dose %d: %w", v.Code, i+1, err)
+				}
+				if until.AddTo(reference).Before(age.AddTo(reference)) {
+					return nil, fmt.Errorf("vaccine %s dose %d: until is before age", v.Code, i+1)
+				}
+				dose.Until = &until
+			}
+			vaccine.Doses = append(vaccine.Doses, dose)
+		}
+		schedule.Vaccines = append(schedule.Vaccines, vaccine)
+	}
+
+	return schedule, nil
+}
+
+// LoadFile reads a schedule from a .yaml, .yml or .json file
+func LoadFile(path string) (*Schedule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	format := "yaml"
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		format = "json"
+	}
+	return Parse(data, format)
+}
+
+// Builtin returns a bundled schedule by name, "idai" or "cdc"
+func Builtin(name string) (*Schedule, error) {
+	data, err := builtin.ReadFile("data/" + strings.ToLower(name) + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSchedule, name)
+	}
+	return Parse(data, "yaml")
+}
+
+// NewFromConfig loads immunization.schedule_file when set, otherwise the
+// bundled schedule named by immunization.schedule
+func NewFromConfig() (*Schedule, error) {
+	if path := viper.GetString("immunization.schedule_file"); path != "" {
+		return LoadFile(path)
+	}
+	return Builtin(viper.GetString("immunization.schedule"))
+}
@@ -0,0 +1,110 @@
+package schedule
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBuiltinSchedules(t *testing.T) {
+	for _, name := range []string{"idai", "CDC"} {
+		schedule, err := Builtin(name)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if len(schedule.Vaccines) == 0 {
+			t.Errorf("%s: expected vaccines", name)
+		}
+	}
+
+	if _, err := Builtin("narnia"); !errors.Is(err, ErrUnknownSchedule) {
+		t.Errorf("expected ErrUnknownSchedule, got %v", err)
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	birth := time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"0d", birth},
+		{"6w", birth.AddDate(0, 0, 42)},
+		{"2m", birth.AddDate(0, 2, 0)},
+		{"5y", birth.AddDate(5, 0, 0)},
+	}
+	for _, tc := range testCases {
+		age, err := ParseAge(tc.input)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.input, err)
+		}
+		if got := age.AddTo(birth); !got.Equal(tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.input, tc.expected, got)
+		}
+		if age.String() != tc.input {
+			t.Errorf("expected %s, got %s", tc.input, age.String())
+		}
+	}
+
+	for _, input := range []string{"", "m", "2h", "-1m", "twom"} {
+		if _, err := ParseAge(input); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
+
+func TestLoadJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.json")
+	document := `{
+		"name": "Clinic",
+		"grace_period": "2w",
+		"vaccines": [
+			{"code": "bcg", "name": "BCG", "doses": [{"age": "0d", "until": "1m"}]},
+			{"code": "mmr", "name": "MMR", "doses": [{"age": "12m"}, {"age": "4y"}]}
+		]
+	}`
+	if err := os.WriteFile(path, []byte(document), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	schedule, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	birth := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	_, bcg, ok := schedule.Dose("bcg", 1)
+	if !ok || !schedule.OverdueAfter(bcg, birth).Equal(birth.AddDate(0, 1, 0)) {
+		t.Errorf("expected BCG overdue after one month, got %+v", bcg)
+	}
+
+	_, mmr, ok := schedule.Dose("mmr", 2)
+	if !ok || mmr.Number != 2 {
+		t.Fatalf("expected the second MMR dose, got %+v", mmr)
+	}
+	if got := schedule.OverdueAfter(mmr, birth); !got.Equal(birth.AddDate(4, 0, 14)) {
+		t.Errorf("expected the grace period after the due date, got %v", got)
+	}
+
+	if _, _, ok := schedule.Dose("mmr", 3); ok {
+		t.Error("expected no third MMR dose")
+	}
+}
+
+func TestParseRejectsInvalidSchedules(t *testing.T) {
+	documents := map[string]string{
+		"no name":          `vaccines: []`,
+		"duplicate code":   "name: X\nvaccines:\n  - {code: a, doses: [{age: 1m}]}\n  - {code: a, doses: [{age: 2m}]}",
+		"no doses":         "name: X\nvaccines:\n  - {code: a, doses: []}",
+		"until before age": "name: X\nvaccines:\n  - {code: a, doses: [{age: 2m, until: 1m}]}",
+	}
+	for name, document := range documents {
+		if _, err := Parse([]byte(document), "yaml"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import "errors"
+
+// Domain errors for immunization module
+var (
+	ErrDoseRecordNotFound  = errors.New("dose record not found")
+	ErrUnknownVaccineDose  = errors.New("vaccine dose is not part of the immunization schedule")
+	ErrDoseAlreadyRecorded = errors.New("vaccine dose has already been recorded")
+	ErrInvalidDoseDate     = errors.New("dose date must be between the date of birth and today")
+	ErrInvalidStatusFilter = errors.New("invalid immunization status filter")
+	ErrDateOfBirthRequired = errors.New("child date of birth is required for the immunization schedule")
+)
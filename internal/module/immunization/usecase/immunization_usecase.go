@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	"dailyalu-server/internal/module/immunization/domain"
+	"dailyalu-server/internal/module/immunization/repository"
+	"dailyalu-server/internal/module/immunization/schedule"
+	"database/sql"
+	"time"
+)
+
+// ImmunizationUseCase implements the immunization business logic
+type ImmunizationUseCase struct {
+	immunizationRepo repository.IImmunizationRepository
+	childrenUseCase  childrenUsecase.IChildrenUseCase
+	schedule         *schedule.Schedule
+	now              func() time.Time
+}
+
+// NewImmunizationUseCase creates a new immunization use case for a national schedule
+func NewImmunizationUseCase(immunizationRepo repository.IImmunizationRepository, childrenUseCase childrenUsecase.IChildrenUseCase, schedule *schedule.Schedule) IImmunizationUseCase {
+	return &ImmunizationUseCase{
+		immunizationRepo: immunizationRepo,
+		childrenUseCase:  childrenUseCase,
+		schedule:         schedule,
+		now:              time.Now,
+	}
+}
+
+// GetSchedule returns the configured national schedule
+func (u *ImmunizationUseCase) GetSchedule() *schedule.Schedule {
+	return u.schedule
+}
+
+// RecordDose records a dose of the schedule given to a child owned by the user
+func (u *ImmunizationUseCase) RecordDose(req *domain.RecordDoseRequest) (*domain.DoseRecord, error) {
+	if _, _, ok := u.schedule.Dose(req.VaccineCode, req.DoseNumber); !ok {
+		return nil, ErrUnknownVaccineDose
+	}
+
+	child, err := u.childrenUseCase.GetChild(req.ChildID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	givenOn := *req.GivenOn
+	if givenOn.After(u.today().Time) || (child.DateOfBirth != nil && givenOn.Before(child.DateOfBirth.Time)) {
+		return nil, ErrInvalidDoseDate
+	}
+
+	records, err := u.immunizationRepo.GetByChildID(child.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if record.VaccineCode == req.VaccineCode && record.DoseNumber == req.DoseNumber {
+			return nil, ErrDoseAlreadyRecorded
+		}
+	}
+
+	record := &domain.DoseRecord{
+		ChildID:     child.ID,
+		UserID:      req.UserID,
+		VaccineCode: req.VaccineCode,
+		DoseNumber:  req.DoseNumber,
+		GivenOn:     givenOn,
+		LotNumber:   req.LotNumber,
+		Provider:    req.Provider,
+		Note:        req.Note,
+	}
+
+	if err := u.immunizationRepo.Create(record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// GetRecords retrieves the doses given to a child, oldest first
+func (u *ImmunizationUseCase) GetRecords(childID int64, userID string) ([]domain.DoseRecord, error) {
+	child, err := u.childrenUseCase.GetChild(childID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.immunizationRepo.GetByChildID(child.ID)
+}
+
+// DeleteRecord removes a dose record of a child owned by the user
+func (u *ImmunizationUseCase) DeleteRecord(childID, id int64, userID string) error {
+	if _, err := u.childrenUseCase.GetChild(childID, userID); err != nil {
+		return err
+	}
+
+	record, err := u.immunizationRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if record == nil || record.ChildID != childID {
+		return ErrDoseRecordNotFound
+	}
+
+	if err := u.immunizationRepo.Delete(id); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrDoseRecordNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// GetChildSchedule places every dose of the schedule on the child's calendar
+// from the date of birth. An empty status returns all doses, otherwise only
+// those with that status.
+func (u *ImmunizationUseCase) GetChildSchedule(childID int64, userID, status string) (*domain.ChildScheduleResponse, error) {
+	switch status {
+	case "", domain.StatusCompleted, domain.StatusOverdue, domain.StatusDue, domain.StatusUpcoming:
+	default:
+		return nil, ErrInvalidStatusFilter
+	}
+
+	child, err := u.childrenUseCase.GetChild(childID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if child.DateOfBirth == nil {
+		return nil, ErrDateOfBirthRequired
+	}
+
+	records, err := u.immunizationRepo.GetByChildID(child.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	type doseKey struct {
+		code   string
+		number int
+	}
+	given := make(map[doseKey]*domain.DoseRecord, len(records))
+	for i := range records {
+		given[doseKey{records[i].VaccineCode, records[i].DoseNumber}] = &records[i]
+	}
+
+	today := u.today()
+	birth := child.DateOfBirth.Time
+	response := &domain.ChildScheduleResponse{
+		ChildID:  child.ID,
+		Schedule: u.schedule.Name,
+		Items:    []domain.ScheduleItem{},
+	}
+
+	for _, vaccine := range u.schedule.Vaccines {
+		for _, dose := range vaccine.Doses {
+			item := domain.ScheduleItem{
+				VaccineCode:  vaccine.Code,
+				VaccineName:  vaccine.Name,
+				DoseNumber:   dose.Number,
+				DueOn:        childrenDomain.NewDate(u.schedule.DueOn(dose, birth)),
+				OverdueAfter: childrenDomain.NewDate(u.schedule.OverdueAfter(dose, birth)),
+				Record:       given[doseKey{vaccine.Code, dose.Number}],
+			}
+
+			switch {
+			case item.Record != nil:
+				item.Status = domain.StatusCompleted
+			case today.Before(item.DueOn.Time):
+				item.Status = domain.StatusUpcoming
+			case today.After(item.OverdueAfter.Time):
+				item.Status = domain.StatusOverdue
+			default:
+				item.Status = domain.StatusDue
+			}
+
+			if status == "" || item.Status == status {
+				response.Items = append(response.Items, item)
+			}
+		}
+	}
+
+	return response, nil
+}
+
+func (u *ImmunizationUseCase) today() childrenDomain.Date {
+	return childrenDomain.NewDate(u.now())
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"dailyalu-server/internal/module/children/childrentest"
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	"dailyalu-server/internal/module/immunization/domain"
+	"dailyalu-server/internal/module/immunization/schedule"
+	"errors"
+	"testing"
+	"time"
+)
+
+const testSchedule = `
+name: Test
+grace_period: 4w
+vaccines:
+  - code: hepatitis_b
+    name: Hepatitis B
+    doses:
+      - age: 0d
+        until: 7d
+  - code: dtp
+    name: DTP
+    doses:
+      - age: 2m
+      - age: 3m
+      - age: 4m
+`
+
+// MockImmunizationRepository is a mock implementation of the immunization repository
+type MockImmunizationRepository struct {
+	CreateFunc       func(record *domain.DoseRecord) error
+	GetByIDFunc      func(id int64) (*domain.DoseRecord, error)
+	GetByChildIDFunc func(childID int64) ([]domain.DoseRecord, error)
+	DeleteFunc       func(id int64) error
+}
+
+func (m *MockImmunizationRepository) Create(record *domain.DoseRecord) error {
+	return m.CreateFunc(record)
+}
+
+func (m *MockImmunizationRepository) GetByID(id int64) (*domain.DoseRecord, error) {
+	return m.GetByIDFunc(id)
+}
+
+func (m *MockImmunizationRepository) GetByChildID(childID int64) ([]domain.DoseRecord, error) {
+	return m.GetByChildIDFunc(childID)
+}
+
+func (m *MockImmunizationRepository) Delete(id int64) error {
+	return m.DeleteFunc(id)
+}
+
+func newTestUseCase(t *testing.T, repo *MockImmunizationRepository, child *childrenDomain.Child) *ImmunizationUseCase {
+	parsed, err := schedule.Parse([]byte(testSchedule), "yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &ImmunizationUseCase{
+		immunizationRepo: repo,
+		childrenUseCase:  &childrentest.UseCase{Child: child},
+		schedule:         parsed,
+		now:              childrentest.Clock(time.Date(2025, 3, 20, 10, 0, 0, 0, time.UTC)),
+	}
+}
+
+func TestGetChildSchedule(t *testing.T) {
+	dob := childrentest.MustDate(t, "2025-01-01")
+	child := &childrenDomain.Child{ID: 1, UserID: "user-1", BirthProfile: childrenDomain.BirthProfile{DateOfBirth: &dob}}
+
+	repo := &MockImmunizationRepository{
+		GetByChildIDFunc: func(childID int64) ([]domain.DoseRecord, error) {
+			return []domain.DoseRecord{
+				{ID: 9, ChildID: 1, VaccineCode: "dtp", DoseNumber: 1, GivenOn: childrentest.MustDate(t, "2025-03-01"), LotNumber: "A123"},
+			}, nil
+		},
+	}
+	uc := newTestUseCase(t, repo, child)
+
+	result, err := uc.GetChildSchedule(1, "user-1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Schedule != "Test" || len(result.Items) != 4 {
+		t.Fatalf("unexpected schedule: %+v", result)
+	}
+
+	expected := []struct {
+		code    string
+		number  int
+		dueOn   string
+		overdue string
+		status  string
+	}{
+		{"hepatitis_b", 1, "2025-01-01", "2025-01-08", domain.StatusOverdue},
+		{"dtp", 1, "2025-03-01", "2025-03-29", domain.StatusCompleted},
+		{"dtp", 2, "2025-04-01", "2025-04-29", domain.StatusUpcoming},
+		{"dtp", 3, "2025-05-01", "2025-05-29", domain.StatusUpcoming},
+	}
+	for i, e := range expected {
+		item := result.Items[i]
+		if item.VaccineCode != e.code || item.DoseNumber != e.number || item.DueOn.String() != e.dueOn ||
+			item.OverdueAfter.String() != e.overdue || item.Status != e.status {
+			t.Errorf("item %d: expected %+v, got %+v", i, e, item)
+		}
+	}
+	if result.Items[1].Record == nil || result.Items[1].Record.LotNumber != "A123" {
+		t.Error("expected the dose record on the completed item")
+	}
+
+	// On the due date the next dose becomes due
+	uc.now = childrentest.Clock(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC))
+	due, err := uc.GetChildSchedule(1, "user-1", domain.StatusDue)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(due.Items) != 1 || due.Items[0].VaccineCode != "dtp" || due.Items[0].DoseNumber != 2 {
+		t.Errorf("expected the second DTP dose to be due, got %+v", due.Items)
+	}
+
+	if _, err := uc.GetChildSchedule(1, "user-1", "late"); !errors.Is(err, ErrInvalidStatusFilter) {
+		t.Errorf("expected ErrInvalidStatusFilter, got %v", err)
+	}
+
+	child.DateOfBirth = nil
+	if _, err := uc.GetChildSchedule(1, "user-1", ""); !errors.Is(err, ErrDateOfBirthRequired) {
+		t.Errorf("expected ErrDateOfBirthRequired, got %v", err)
+	}
+}
+
+func TestRecordDose(t *testing.T) {
+	dob := childrentest.MustDate(t, "2025-01-01")
+	child := &childrenDomain.Child{ID: 1, UserID: "user-1", BirthProfile: childrenDomain.BirthProfile{DateOfBirth: &dob}}
+
+	created := 0
+	repo := &MockImmunizationRepository{
+		GetByChildIDFunc: func(childID int64) ([]domain.DoseRecord, error) {
+			return []domain.DoseRecord{{ChildID: 1, VaccineCode: "hepatitis_b", DoseNumber: 1}}, nil
+		},
+		CreateFunc: func(record *domain.DoseRecord) error {
+			created++
+			record.ID = 5
+			return nil
+		},
+	}
+	uc := newTestUseCase(t, repo, child)
+
+	givenOn := childrentest.MustDate(t, "2025-03-02")
+	record, err := uc.RecordDose(&domain.RecordDoseRequest{
+		ChildID:     1,
+		UserID:      "user-1",
+		VaccineCode: "dtp",
+		DoseNumber:  1,
+		GivenOn:     &givenOn,
+		LotNumber:   "A123",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record.ID != 5 || record.LotNumber != "A123" || created != 1 {
+		t.Errorf("unexpected record: %+v", record)
+	}
+
+	testCases := []struct {
+		name     string
+		code     string
+		number   int
+		givenOn  string
+		expected error
+	}{
+		{"unknown vaccine", "bcg", 1, "2025-03-02", ErrUnknownVaccineDose},
+		{"unknown dose", "dtp", 4, "2025-03-02", ErrUnknownVaccineDose},
+		{"already recorded", "hepatitis_b", 1, "2025-01-01", ErrDoseAlreadyRecorded},
+		{"before birth", "dtp", 2, "2024-12-31", ErrInvalidDoseDate},
+		{"in the future", "dtp", 2, "2025-03-21", ErrInvalidDoseDate},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			givenOn := childrentest.MustDate(t, tc.givenOn)
+			_, err := uc.RecordDose(&domain.RecordDoseRequest{
+				ChildID:     1,
+				UserID:      "user-1",
+				VaccineCode: tc.code,
+				DoseNumber:  tc.number,
+				GivenOn:     &givenOn,
+			})
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
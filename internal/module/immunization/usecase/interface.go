@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"dailyalu-server/internal/module/immunization/domain"
+	"dailyalu-server/internal/module/immunization/schedule"
+)
+
+// IImmunizationUseCase defines the interface for immunization business logic
+type IImmunizationUseCase interface {
+	GetSchedule() *schedule.Schedule
+	RecordDose(req *domain.RecordDoseRequest) (*domain.DoseRecord, error)
+	GetRecords(childID int64, userID string) ([]domain.DoseRecord, error)
+	DeleteRecord(childID, id int64, userID string) error
+	GetChildSchedule(childID int64, userID, status string) (*domain.ChildScheduleResponse, error)
+}
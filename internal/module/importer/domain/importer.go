@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// Apps whose exports can be imported
+const (
+	SourceHuckleberry = "huckleberry"
+	SourceBabyTracker = "baby_tracker"
+	SourceBabyConnect = "baby_connect"
+)
+
+// Sources lists the apps whose exports can be imported
+var Sources = []string{SourceHuckleberry, SourceBabyTracker, SourceBabyConnect}
+
+// Activity types imports map rows to
+const (
+	ActivityTypeSleep   = "sleep"
+	ActivityTypeFeeding = "feeding"
+	ActivityTypeDiaper  = "diaper"
+)
+
+// Import statuses. An import is previewed when its file has been parsed,
+// queued once the user commits it, and running while the worker creates its
+// activities.
+const (
+	ImportPreviewed = "previewed"
+	ImportQueued    = "queued"
+	ImportRunning   = "running"
+	ImportCompleted = "completed"
+	// ImportFailed imports can be committed again; rows already imported
+	// are kept
+	ImportFailed = "failed"
+)
+
+// Row statuses
+const (
+	// RowMapped rows are waiting to be imported
+	RowMapped = "mapped"
+	// RowUnmapped rows could not be mapped to an activity and are skipped
+	RowUnmapped = "unmapped"
+	// RowDuplicate rows match an existing activity or an earlier row of the
+	// file and are skipped
+	RowDuplicate = "duplicate"
+	RowImported  = "imported"
+)
+
+// MaxRows is the largest number of rows a file may have
+const MaxRows = 20000
+
+// PreviewSampleSize is the number of mapped and of unmapped rows returned
+// with a preview
+const PreviewSampleSize = 20
+
+// Import is an export file of another app being imported for a child
+type Import struct {
+	ID       int64  `json:"id"`
+	UserID   string `json:"user_id"`
+	ChildID  int64  `json:"child_id"`
+	Source   string `json:"source"`
+	Filename string `json:"filename"`
+	Status   string `json:"status"`
+	// TotalRows counts the data rows of the file
+	TotalRows    int `json:"total_rows"`
+	MappedRows   int `json:"mapped_rows"`
+	UnmappedRows int `json:"unmapped_rows"`
+	// DuplicateRows counts the rows found to be duplicates, during the
+	// preview or when committing
+	DuplicateRows int `json:"duplicate_rows"`
+	// ProcessedRows counts the mapped rows handled by the commit so far,
+	// whether imported or found to be duplicates
+	ProcessedRows int        `json:"processed_rows"`
+	ImportedRows  int        `json:"imported_rows"`
+	Progress      int        `json:"progress"`
+	Error         string     `json:"error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	// LockedUntil is the lease held by the worker importing the rows
+	LockedUntil time.Time `json:"-"`
+}
+
+// SetProgress computes the percentage of the mapped rows the commit handled
+func (i *Import) SetProgress() {
+	switch {
+	case i.Status == ImportCompleted:
+		i.Progress = 100
+	case i.MappedRows > 0:
+		i.Progress = i.ProcessedRows * 100 / i.MappedRows
+	default:
+		i.Progress = 0
+	}
+}
+
+// Row is one data row of an imported file
+type Row struct {
+	// ID is zero in previews, whose rows are not read back
+	ID       int64  `json:"id,omitempty"`
+	ImportID int64  `json:"import_id"`
+	Line     int    `json:"line"`
+	Status   string `json:"status"`
+	// Type, Details and HappensAt describe the activity the row maps to.
+	// They are empty for unmapped rows.
+	Type      string          `json:"type,omitempty"`
+	Details   json.RawMessage `json:"details,omitempty"`
+	HappensAt *time.Time      `json:"happens_at,omitempty"`
+	// Reason explains why the row is unmapped or a duplicate
+	Reason string `json:"reason,omitempty"`
+	// Fields holds the row as found in the file, by column name
+	Fields     map[string]string `json:"fields"`
+	ActivityID *int              `json:"activity_id,omitempty"`
+}
+
+// Key identifies the activity of a row for duplicate detection: exports keep
+// times to the minute, so activities of the same type starting in the same
+// minute are considered the same
+func Key(activityType string, happensAt time.Time) string {
+	return activityType + "@" + happensAt.UTC().Truncate(time.Minute).Format(time.RFC3339)
+}
+
+// MarkDuplicates marks the mapped rows whose key is among existing, or that
+// repeat an earlier row, as duplicates. It returns the number of rows
+// marked.
+func MarkDuplicates(rows []Row, existing map[string]bool) int {
+	marked := 0
+	seen := map[string]bool{}
+	for i := range rows {
+		row := &rows[i]
+		if row.Status != RowMapped {
+			continue
+		}
+
+		key := Key(row.Type, *row.HappensAt)
+		switch {
+		case existing[key]:
+			row.Reason = "an activity of this type already exists at this time"
+		case seen[key]:
+			row.Reason = "repeats an earlier row of the file"
+		default:
+			seen[key] = true
+			continue
+		}
+		row.Status = RowDuplicate
+		marked++
+	}
+	return marked
+}
+
+// TimeRange returns the earliest and latest time of the mapped rows. It
+// reports false when no row is mapped.
+func TimeRange(rows []Row) (from, to time.Time, ok bool) {
+	for _, row := range rows {
+		if row.Status != RowMapped {
+			continue
+		}
+		if !ok || row.HappensAt.Before(from) {
+			from = *row.HappensAt
+		}
+		if !ok || row.HappensAt.After(to) {
+			to = *row.HappensAt
+		}
+		ok = true
+	}
+	return from, to, ok
+}
+
+// PreviewImportRequest represents an uploaded export file to preview
+type PreviewImportRequest struct {
+	UserID   string `json:"-"`
+	ChildID  int64  `json:"-"`
+	Source   string `json:"source" validate:"required,oneof=huckleberry baby_tracker baby_connect"`
+	Filename string `json:"-"`
+	Content  []byte `json:"-"`
+}
+
+// ReasonCount is the number of unmapped rows sharing a reason
+type ReasonCount struct {
+	Reason string `json:"reason"`
+	Rows   int    `json:"rows"`
+}
+
+// Preview is the result of a dry run: what committing the import would do
+type Preview struct {
+	Import *Import `json:"import"`
+	// UnmappedReasons groups the unmapped rows by reason, most frequent
+	// first
+	UnmappedReasons []ReasonCount `json:"unmapped_reasons"`
+	// Unmapped lists the first unmapped rows
+	Unmapped []Row `json:"unmapped"`
+	// Sample lists the first rows that would be imported
+	Sample []Row `json:"sample"`
+}
+
+// CountReasons groups rows by their reason, most frequent first
+func CountReasons(rows []Row) []ReasonCount {
+	counts := map[string]int{}
+	for _, row := range rows {
+		counts[row.Reason]++
+	}
+
+	reasons := make([]ReasonCount, 0, len(counts))
+	for reason, count := range counts {
+		reasons = append(reasons, ReasonCount{Reason: reason, Rows: count})
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if reasons[i].Rows != reasons[j].Rows {
+			return reasons[i].Rows > reasons[j].Rows
+		}
+		return reasons[i].Reason < reasons[j].Reason
+	})
+	return reasons
+}
+
+// GetRowsRequest represents the request to list the rows of an import
+type GetRowsRequest struct {
+	UserID   string `json:"-"`
+	ChildID  int64  `json:"-"`
+	ImportID int64  `json:"-"`
+	Status   string `json:"status" validate:"omitempty,oneof=mapped unmapped duplicate imported"`
+	Page     int    `json:"page" validate:"min=1"`
+	PageSize int    `json:"page_size" validate:"min=1,max=100"`
+}
+
+// RowsResponse represents a page of import rows
+type RowsResponse struct {
+	Rows       []Row      `json:"rows"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// Pagination represents pagination information
+type Pagination struct {
+	Total       int64 `json:"total"`
+	CurrentPage int   `json:"current_page"`
+	PageSize    int   `json:"page_size"`
+	TotalPages  int   `json:"total_pages"`
+}
@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// babyConnectDateLayouts and babyConnectTimeLayouts are the date and time
+// formats of BabyConnect exports, which give them in separate columns
+var (
+	babyConnectDateLayouts = []string{"01/02/2006", "1/2/2006", "2006-01-02"}
+	babyConnectTimeLayouts = []string{"3:04 PM", "3:04PM", "15:04"}
+)
+
+// mapBabyConnect maps a row of a BabyConnect export. Its columns are Date,
+// Time, End Time, Duration, Activity, Quantity, Extra Data, Text and Notes:
+//
+//   - Bottle rows give the amount in Quantity, with its unit in Extra Data
+//     when Quantity is a bare number, and the milk in Text
+//   - Nursing rows give the duration, and the side in Extra Data or Text
+//   - Diaper rows describe the content in Text ("BM and wet diaper")
+//   - Sleep rows give the end time, on the next day when earlier than the
+//     start, or the duration
+func mapBabyConnect(record Record, loc *time.Location) (*Activity, error) {
+	day := record.Get("date")
+	start, err := parseDateTime(day, record.Get("time"), loc)
+	if err != nil {
+		return nil, err
+	}
+
+	var activity *Activity
+	switch kind := strings.ToLower(record.Get("activity")); kind {
+	case "sleep", "nap":
+		var end time.Time
+		if value := record.Get("end time"); value != "" {
+			if end, err = parseDateTime(day, value, loc); err != nil {
+				return nil, err
+			}
+			if end.Before(start) {
+				end = end.AddDate(0, 0, 1)
+			}
+		}
+		activity, err = sleep(start, end, record.Get("duration"))
+	case "bottle":
+		activity, err = bottle(start, record.Get("quantity"), record.Get("extra data"), record.Get("text"))
+	case "nursing", "breastfeeding":
+		side := record.Get("extra data")
+		if side == "" {
+			side = textSide(record.Get("text"))
+		}
+		activity, err = breastfeed(start, side, record.Get("duration"))
+	case "diaper":
+		activity, err = diaper(start, record.Get("text", "extra data"))
+	case "":
+		return nil, fmt.Errorf("missing activity")
+	default:
+		return nil, fmt.Errorf("unsupported activity %q", record.Get("activity"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	activity.Details.setNotes(record.Get("notes"))
+	return activity, nil
+}
+
+// parseDateTime reads a date and a time of day given in separate columns
+func parseDateTime(day, clock string, loc *time.Location) (time.Time, error) {
+	if day == "" || clock == "" {
+		return time.Time{}, fmt.Errorf("missing date or time")
+	}
+
+	var layouts []string
+	for _, dateLayout := range babyConnectDateLayouts {
+		for _, timeLayout := range babyConnectTimeLayouts {
+			layouts = append(layouts, dateLayout+" "+timeLayout)
+		}
+	}
+	return parseTime(day+" "+clock, loc, layouts...)
+}
+
+// textSide finds the breast named in a description such as "Nursed on the
+// left side"
+func textSide(text string) string {
+	text = strings.ToLower(text)
+	left, right := strings.Contains(text, "left"), strings.Contains(text, "right")
+	switch {
+	case left && right:
+		return "both"
+	case left:
+		return "left"
+	case right:
+		return "right"
+	}
+	return ""
+}
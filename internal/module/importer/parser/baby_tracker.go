@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// babyTrackerLayouts are the time formats of Baby Tracker exports
+var babyTrackerLayouts = []string{
+	"1/2/06, 3:04 PM",
+	"1/2/06 3:04 PM",
+	"1/2/2006, 3:04 PM",
+	"1/2/2006 15:04",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+}
+
+// mapBabyTracker maps a row of a Baby Tracker export. The app exports one
+// file per activity, so the kind of a row is told by the file's columns:
+//
+//   - diapers have a Status column ("Wet", "Dirty", "Mixed", "Dry")
+//   - nursing has Start Side and the durations of each side
+//   - bottles (formula or pumped milk) have an Amount column
+//   - sleeps have a duration in minutes
+func mapBabyTracker(record Record, loc *time.Location) (*Activity, error) {
+	start, err := parseTime(record.Get("time"), loc, babyTrackerLayouts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var activity *Activity
+	switch {
+	case record.Has("status"):
+		activity, err = diaper(start, record.Get("status"))
+	case record.Has("start side"), record.Has("left duration"), record.Has("right duration"):
+		duration := record.Get("total duration")
+		if duration == "" {
+			duration, err = sumMinutes(record.Get("left duration"), record.Get("right duration"))
+			if err != nil {
+				return nil, err
+			}
+		}
+		activity, err = breastfeed(start, record.Get("start side"), duration)
+	case record.Has("amount"):
+		activity, err = bottle(start, record.Get("amount"), "", record.Get("type", "milk type", "category"))
+	case record.Has("duration minutes"), record.Has("duration"):
+		duration := record.Get("duration minutes", "duration")
+		if duration == "" {
+			return nil, fmt.Errorf("missing duration")
+		}
+		activity, err = sleep(start, time.Time{}, duration)
+	default:
+		return nil, fmt.Errorf("unsupported Baby Tracker file")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	activity.Details.setNotes(record.Get("note", "notes"))
+	return activity, nil
+}
+
+// sumMinutes adds durations, either of which may be empty. It returns an
+// empty duration when both are.
+func sumMinutes(durations ...string) (string, error) {
+	total, found := 0, false
+	for _, duration := range durations {
+		if duration == "" {
+			continue
+		}
+		minutes, err := parseMinutes(duration)
+		if err != nil {
+			return "", err
+		}
+		total += minutes
+		found = true
+	}
+	if !found {
+		return "", nil
+	}
+	return strconv.Itoa(total), nil
+}
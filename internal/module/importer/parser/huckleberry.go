@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// huckleberryLayouts are the time formats of Huckleberry exports, which
+// follow the phone's region
+var huckleberryLayouts = []string{
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"01/02/2006 15:04",
+	"1/2/2006 3:04 PM",
+	"02/01/2006 15:04",
+}
+
+// mapHuckleberry maps a row of the single file Huckleberry exports. Its
+// columns are Type, Start, End, Duration, Start Condition, Start Location,
+// End Condition and Notes, whose meaning depends on the type:
+//
+//   - Sleep rows give the end or the duration
+//   - Feed rows are bottles when Start Location is "Bottle", with the milk
+//     in Start Condition and the amount in End Condition, and breastfeeds
+//     otherwise, with the side in Start Condition
+//   - Diaper rows give the content in End Condition, or in Start Condition
+//     in older exports
+func mapHuckleberry(record Record, loc *time.Location) (*Activity, error) {
+	start, err := parseTime(record.Get("start"), loc, huckleberryLayouts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var activity *Activity
+	switch kind := strings.ToLower(record.Get("type")); kind {
+	case "sleep", "nap":
+		var end time.Time
+		if value := record.Get("end"); value != "" {
+			if end, err = parseTime(value, loc, huckleberryLayouts...); err != nil {
+				return nil, err
+			}
+		}
+		activity, err = sleep(start, end, record.Get("duration"))
+	case "feed", "feeding":
+		if strings.EqualFold(record.Get("start location"), "bottle") {
+			activity, err = bottle(start, record.Get("end condition"), "", record.Get("start condition"))
+		} else {
+			activity, err = breastfeed(start, record.Get("start condition"), record.Get("duration"))
+		}
+	case "diaper":
+		activity, err = diaper(start, record.Get("end condition", "start condition"))
+	case "":
+		return nil, fmt.Errorf("missing type")
+	default:
+		return nil, fmt.Errorf("unsupported type %q", record.Get("type"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	activity.Details.setNotes(record.Get("notes"))
+	return activity, nil
+}
@@ -0,0 +1,215 @@
+// Package parser reads the CSV exports of other baby tracker apps and maps
+// their rows to activities. Every source knows the columns of its app's
+// export; a row that cannot be mapped is kept with the reason, so users can
+// see what an import leaves out before committing it.
+package parser
+
+import (
+	"bytes"
+	"dailyalu-server/internal/module/importer/domain"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Errors about files that cannot be read at all
+var (
+	ErrUnknownSource = errors.New("unknown import source")
+	ErrInvalidFile   = errors.New("import file is not a valid CSV file")
+	ErrEmptyFile     = errors.New("import file has no rows")
+	ErrTooManyRows   = errors.New("import file has too many rows")
+)
+
+// MissingColumnsError reports a file lacking columns its source always
+// exports, usually because it comes from another app
+type MissingColumnsError struct {
+	Source  string
+	Columns []string
+}
+
+func (e *MissingColumnsError) Error() string {
+	return fmt.Sprintf("%s export is missing the columns %s", e.Source, strings.Join(e.Columns, ", "))
+}
+
+// Activity is the activity a row maps to
+type Activity struct {
+	Type      string
+	HappensAt time.Time
+	Details   Details
+}
+
+// Details are the activity details a row maps to
+type Details map[string]interface{}
+
+// setNotes keeps non-empty notes
+func (d Details) setNotes(notes ...string) {
+	var parts []string
+	for _, note := range notes {
+		if note = strings.TrimSpace(note); note != "" {
+			parts = append(parts, note)
+		}
+	}
+	if len(parts) > 0 {
+		d["notes"] = strings.Join(parts, "\n")
+	}
+}
+
+// Record is a data row of an export, by column name
+type Record struct {
+	Line int
+	// Fields holds the values by column name as found in the file
+	Fields map[string]string
+	// values holds the values by normalized column name
+	values map[string]string
+}
+
+// Get returns the first non-empty value of the columns, whose names are
+// given normalized
+func (r Record) Get(columns ...string) string {
+	for _, column := range columns {
+		if value := strings.TrimSpace(r.values[column]); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Has reports whether the file has the column, even if the record leaves
+// it empty
+func (r Record) Has(column string) bool {
+	_, ok := r.values[column]
+	return ok
+}
+
+// source describes the export of an app
+type source struct {
+	// required lists the normalized columns every export of the app has
+	required []string
+	// mapRecord maps a record with times in loc, or returns why it cannot
+	mapRecord func(record Record, loc *time.Location) (*Activity, error)
+}
+
+var sources = map[string]source{
+	domain.SourceHuckleberry: {required: []string{"type", "start"}, mapRecord: mapHuckleberry},
+	domain.SourceBabyTracker: {required: []string{"time"}, mapRecord: mapBabyTracker},
+	domain.SourceBabyConnect: {required: []string{"date", "time", "activity"}, mapRecord: mapBabyConnect},
+}
+
+// Parse reads an export of the app named by sourceName. Exports hold wall
+// clock times, which are read in loc. Every data row is returned, either
+// mapped or unmapped with the reason.
+func Parse(sourceName string, content []byte, loc *time.Location) ([]domain.Row, error) {
+	src, ok := sources[sourceName]
+	if !ok {
+		return nil, ErrUnknownSource
+	}
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrEmptyFile
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	columns := make([]string, len(header))
+	present := map[string]bool{}
+	for i, name := range header {
+		header[i] = strings.TrimSpace(name)
+		columns[i] = normalize(name)
+		present[columns[i]] = true
+	}
+	var missing []string
+	for _, column := range src.required {
+		if !present[column] {
+			missing = append(missing, column)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, &MissingColumnsError{Source: sourceName, Columns: missing}
+	}
+
+	var rows []domain.Row
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		if isBlank(values) {
+			continue
+		}
+		if len(rows) == domain.MaxRows {
+			return nil, ErrTooManyRows
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := Record{Line: line, Fields: map[string]string{}, values: map[string]string{}}
+		for i, column := range columns {
+			value := ""
+			if i < len(values) {
+				value = values[i]
+			}
+			record.Fields[header[i]] = value
+			record.values[column] = value
+		}
+
+		rows = append(rows, toRow(record, src, loc))
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrEmptyFile
+	}
+	return rows, nil
+}
+
+// toRow maps a record to a mapped or unmapped row
+func toRow(record Record, src source, loc *time.Location) domain.Row {
+	row := domain.Row{Line: record.Line, Status: domain.RowUnmapped, Fields: record.Fields}
+
+	activity, err := src.mapRecord(record, loc)
+	if err != nil {
+		row.Reason = err.Error()
+		return row
+	}
+
+	details, err := json.Marshal(activity.Details)
+	if err != nil {
+		row.Reason = err.Error()
+		return row
+	}
+
+	row.Status = domain.RowMapped
+	row.Type = activity.Type
+	row.Details = details
+	row.HappensAt = &activity.HappensAt
+	return row
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// normalize lowercases a column name and keeps words separated by single
+// spaces, so that "Duration(minutes)" becomes "duration minutes"
+func normalize(column string) string {
+	return strings.TrimSpace(nonAlphanumeric.ReplaceAllString(strings.ToLower(column), " "))
+}
+
+func isBlank(values []string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
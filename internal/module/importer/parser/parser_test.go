@@ -0,0 +1,235 @@
+package parser
+
+import (
+	"dailyalu-server/internal/module/importer/domain"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var jakarta = time.FixedZone("WIB", 7*60*60)
+
+// expectedRow is what a row of a test file should map to
+type expectedRow struct {
+	line      int
+	status    string
+	kind      string
+	happensAt time.Time
+	details   string
+	reason    string
+}
+
+func checkRows(t *testing.T, rows []domain.Row, expected []expectedRow) {
+	t.Helper()
+
+	if len(rows) != len(expected) {
+		t.Fatalf("expected %d rows, got %d: %+v", len(expected), len(rows), rows)
+	}
+	for i, want := range expected {
+		row := rows[i]
+		if row.Line != want.line || row.Status != want.status {
+			t.Errorf("row %d: expected line %d %s, got line %d %s (%s)", i, want.line, want.status, row.Line, row.Status, row.Reason)
+			continue
+		}
+		if want.status == domain.RowUnmapped {
+			if !strings.Contains(row.Reason, want.reason) {
+				t.Errorf("row %d: expected reason containing %q, got %q", i, want.reason, row.Reason)
+			}
+			continue
+		}
+		if row.Type != want.kind || !row.HappensAt.Equal(want.happensAt) {
+			t.Errorf("row %d: expected %s at %s, got %s at %s", i, want.kind, want.happensAt, row.Type, row.HappensAt)
+		}
+		if !sameJSON(t, row.Details, want.details) {
+			t.Errorf("row %d: expected details %s, got %s", i, want.details, row.Details)
+		}
+	}
+}
+
+func sameJSON(t *testing.T, got json.RawMessage, expected string) bool {
+	t.Helper()
+
+	var a, b interface{}
+	if err := json.Unmarshal(got, &a); err != nil {
+		t.Fatalf("invalid JSON %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(expected), &b); err != nil {
+		t.Fatalf("invalid JSON %s: %v", expected, err)
+	}
+	x, _ := json.Marshal(a)
+	y, _ := json.Marshal(b)
+	return string(x) == string(y)
+}
+
+func TestParseHuckleberry(t *testing.T) {
+	content := "\xef\xbb\xbf" + strings.Join([]string{
+		"Type,Start,End,Duration,Start Condition,Start Location,End Condition,Notes",
+		"Sleep,2025-03-01 21:15,2025-03-02 01:45,04:30,,Crib,,",
+		"Feed,2025-03-02 02:00,,00:20,L,,,Sleepy",
+		"Feed,2025-03-02 05:30,,,Formula,Bottle,4oz,",
+		"Diaper,2025-03-02 06:00,,,,,Pee:Poo,",
+		"Pump,2025-03-02 07:00,,00:15,,,,",
+		",,,,,,,",
+		"Diaper,yesterday,,,,,Pee,",
+		"Diaper,2025-03-02 09:00,,,,,Sparkly,",
+	}, "\n")
+
+	rows, err := Parse(domain.SourceHuckleberry, []byte(content), jakarta)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checkRows(t, rows, []expectedRow{
+		{line: 2, status: domain.RowMapped, kind: "sleep", happensAt: time.Date(2025, 3, 1, 21, 15, 0, 0, jakarta),
+			details: `{"ended_at": "2025-03-02T01:45:00+07:00", "duration_minutes": 270}`},
+		{line: 3, status: domain.RowMapped, kind: "feeding", happensAt: time.Date(2025, 3, 2, 2, 0, 0, 0, jakarta),
+			details: `{"method": "breast", "side": "left", "duration_minutes": 20, "notes": "Sleepy"}`},
+		{line: 4, status: domain.RowMapped, kind: "feeding", happensAt: time.Date(2025, 3, 2, 5, 30, 0, 0, jakarta),
+			details: `{"method": "bottle", "amount": 4, "unit": "oz", "milk": "formula"}`},
+		{line: 5, status: domain.RowMapped, kind: "diaper", happensAt: time.Date(2025, 3, 2, 6, 0, 0, 0, jakarta),
+			details: `{"kind": "mixed"}`},
+		{line: 6, status: domain.RowUnmapped, reason: `unsupported type "Pump"`},
+		{line: 8, status: domain.RowUnmapped, reason: "invalid time"},
+		{line: 9, status: domain.RowUnmapped, reason: "unknown diaper content"},
+	})
+
+	if rows[0].Fields["Start Location"] != "Crib" {
+		t.Errorf("expected the original fields to be kept, got %v", rows[0].Fields)
+	}
+}
+
+func TestParseBabyTracker(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []expectedRow
+	}{
+		{
+			name:    "nursing",
+			content: "Time,Start Side,Left duration,Right duration,Note\n\"3/1/25, 9:08 PM\",Left,10,5,\n3/2/25 1:00 AM,Right,,,",
+			expected: []expectedRow{
+				{line: 2, status: domain.RowMapped, kind: "feeding", happensAt: time.Date(2025, 3, 1, 21, 8, 0, 0, jakarta),
+					details: `{"method": "breast", "side": "left", "duration_minutes": 15}`},
+				{line: 3, status: domain.RowMapped, kind: "feeding", happensAt: time.Date(2025, 3, 2, 1, 0, 0, 0, jakarta),
+					details: `{"method": "breast", "side": "right"}`},
+			},
+		},
+		{
+			name:    "formula",
+			content: "Time,Amount,Note\n2025-03-01 10:00,120 ml,Warm\n2025-03-01 13:00,four,",
+			expected: []expectedRow{
+				{line: 2, status: domain.RowMapped, kind: "feeding", happensAt: time.Date(2025, 3, 1, 10, 0, 0, 0, jakarta),
+					details: `{"method": "bottle", "amount": 120, "unit": "ml", "notes": "Warm"}`},
+				{line: 3, status: domain.RowUnmapped, reason: "invalid amount"},
+			},
+		},
+		{
+			name:    "diaper",
+			content: "Time,Status,Note\n2025-03-01 10:00,Dirty,",
+			expected: []expectedRow{
+				{line: 2, status: domain.RowMapped, kind: "diaper", happensAt: time.Date(2025, 3, 1, 10, 0, 0, 0, jakarta),
+					details: `{"kind": "dirty"}`},
+			},
+		},
+		{
+			name:    "sleep",
+			content: "Time,Duration(minutes),Note\n2025-03-01 13:00,95,Stroller nap",
+			expected: []expectedRow{
+				{line: 2, status: domain.RowMapped, kind: "sleep", happensAt: time.Date(2025, 3, 1, 13, 0, 0, 0, jakarta),
+					details: `{"duration_minutes": 95, "notes": "Stroller nap"}`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Parse(domain.SourceBabyTracker, []byte(tt.content), jakarta)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkRows(t, rows, tt.expected)
+		})
+	}
+}
+
+func TestParseBabyConnect(t *testing.T) {
+	content := strings.Join([]string{
+		"Date,Time,End Time,Duration,Activity,Quantity,Extra Data,Text,Notes,Caregiver,Child Name",
+		"03/01/2025,10:30 PM,2:00 AM,,Sleep,,,Sleep,,Mom,Alu",
+		"03/02/2025,2:15 AM,,,Bottle,4,oz,Bottle: Breast milk,,Dad,Alu",
+		"03/02/2025,6:00 AM,,12,Nursing,,,Nursed on the right side,,Mom,Alu",
+		"03/02/2025,6:30 AM,,,Diaper,,,BM and wet diaper,Rash,Mom,Alu",
+		"03/02/2025,8:00 AM,,,Bath,,,Bath,,Mom,Alu",
+	}, "\n")
+
+	rows, err := Parse(domain.SourceBabyConnect, []byte(content), jakarta)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checkRows(t, rows, []expectedRow{
+		{line: 2, status: domain.RowMapped, kind: "sleep", happensAt: time.Date(2025, 3, 1, 22, 30, 0, 0, jakarta),
+			details: `{"ended_at": "2025-03-02T02:00:00+07:00", "duration_minutes": 210}`},
+		{line: 3, status: domain.RowMapped, kind: "feeding", happensAt: time.Date(2025, 3, 2, 2, 15, 0, 0, jakarta),
+			details: `{"method": "bottle", "amount": 4, "unit": "oz", "milk": "breast_milk"}`},
+		{line: 4, status: domain.RowMapped, kind: "feeding", happensAt: time.Date(2025, 3, 2, 6, 0, 0, 0, jakarta),
+			details: `{"method": "breast", "side": "right", "duration_minutes": 12}`},
+		{line: 5, status: domain.RowMapped, kind: "diaper", happensAt: time.Date(2025, 3, 2, 6, 30, 0, 0, jakarta),
+			details: `{"kind": "mixed", "notes": "Rash"}`},
+		{line: 6, status: domain.RowUnmapped, reason: `unsupported activity "Bath"`},
+	})
+}
+
+func TestParseRejectsUnreadableFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		content  string
+		expected error
+	}{
+		{"unknown source", "glow", "Type,Start\nSleep,2025-03-01 10:00", ErrUnknownSource},
+		{"empty file", domain.SourceHuckleberry, "", ErrEmptyFile},
+		{"header only", domain.SourceHuckleberry, "Type,Start\n,\n", ErrEmptyFile},
+		{"too many rows", domain.SourceHuckleberry, "Type,Start\n" + strings.Repeat("Sleep,2025-03-01 10:00\n", domain.MaxRows+1), ErrTooManyRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.source, []byte(tt.content), jakarta); !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	// Files of another app lack the columns of the source
+	_, err := Parse(domain.SourceBabyConnect, []byte("Type,Start\nSleep,2025-03-01 10:00"), jakarta)
+	var missing *MissingColumnsError
+	if !errors.As(err, &missing) || strings.Join(missing.Columns, ",") != "date,time,activity" {
+		t.Errorf("expected the missing columns, got %v", err)
+	}
+}
+
+func TestParseMinutes(t *testing.T) {
+	tests := map[string]int{
+		"90":       90,
+		"1:30":     90,
+		"01:30:30": 91,
+		"90 min":   90,
+		"1h 30m":   90,
+		"1.5 hrs":  90,
+		"45 sec":   1,
+	}
+	for value, expected := range tests {
+		if got, err := parseMinutes(value); err != nil || got != expected {
+			t.Errorf("parseMinutes(%q) = %d, %v; expected %d", value, got, err, expected)
+		}
+	}
+
+	for _, value := range []string{"", "soon", "1:2:3:4", "10 days"} {
+		if _, err := parseMinutes(value); err == nil {
+			t.Errorf("expected parseMinutes(%q) to fail", value)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package parser
+
+import (
+	"dailyalu-server/internal/module/importer/domain"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseTime reads a wall clock time in loc with the first matching layout
+func parseTime(value string, loc *time.Location, layouts ...string) (time.Time, error) {
+	value = strings.Join(strings.Fields(value), " ")
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
+
+var durationPart = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([a-z]*)`)
+
+// parseMinutes reads a duration as "1:30" (hours and minutes), "1:30:00",
+// "90", "90 min" or "1h 30m" and returns it in whole minutes
+func parseMinutes(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if strings.Contains(value, ":") {
+		parts := strings.Split(value, ":")
+		if len(parts) > 3 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		seconds := 0.0
+		for i, part := range parts {
+			n, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			seconds += float64(n) * math.Pow(60, float64(2-i))
+		}
+		return int(math.Round(seconds / 60)), nil
+	}
+
+	matches := durationPart.FindAllStringSubmatch(value, -1)
+	if len(matches) == 0 || strings.TrimSpace(durationPart.ReplaceAllString(value, "")) != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	minutes := 0.0
+	for _, match := range matches {
+		n, _ := strconv.ParseFloat(match[1], 64)
+		switch match[2] {
+		case "", "m", "min", "mins", "minute", "minutes":
+			minutes += n
+		case "h", "hr", "hrs", "hour", "hours":
+			minutes += n * 60
+		case "s", "sec", "secs", "second", "seconds":
+			minutes += n / 60
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+	}
+	return int(math.Round(minutes)), nil
+}
+
+var amountPattern = regexp.MustCompile(`^(\d+(?:[.,]\d+)?)\s*([a-z. ]*)$`)
+
+// parseAmount reads a volume such as "120ml", "4 oz" or "4.5 fl. oz". A
+// bare number is in defaultUnit.
+func parseAmount(value, defaultUnit string) (float64, string, error) {
+	match := amountPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if match == nil {
+		return 0, "", fmt.Errorf("invalid amount %q", value)
+	}
+
+	amount, err := strconv.ParseFloat(strings.Replace(match[1], ",", ".", 1), 64)
+	if err != nil || amount <= 0 {
+		return 0, "", fmt.Errorf("invalid amount %q", value)
+	}
+
+	unit := match[2]
+	if strings.TrimSpace(unit) == "" {
+		unit = defaultUnit
+	}
+	switch strings.NewReplacer(".", "", " ", "").Replace(strings.ToLower(unit)) {
+	case "":
+		return 0, "", fmt.Errorf("amount %q has no unit", value)
+	case "ml", "mls", "milliliter", "milliliters", "cc":
+		return amount, "ml", nil
+	case "oz", "floz", "ounce", "ounces":
+		return amount, "oz", nil
+	}
+	return 0, "", fmt.Errorf("unsupported amount unit %q", unit)
+}
+
+// parseSide reads the breast a feed started on or used
+func parseSide(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "l", "left":
+		return "left"
+	case "r", "right":
+		return "right"
+	case "both", "l/r", "r/l", "left/right", "right/left":
+		return "both"
+	}
+	return ""
+}
+
+// parseMilk reads the kind of milk of a bottle
+func parseMilk(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case strings.Contains(value, "formula"):
+		return "formula"
+	case strings.Contains(value, "breast"), strings.Contains(value, "pumped"):
+		return "breast_milk"
+	}
+	return ""
+}
+
+// parseDiaper reads the content of a diaper, as any combination of words
+// such as "Pee", "Wet", "Poo", "BM" or "Both"
+func parseDiaper(value string) (string, error) {
+	words := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z')
+	})
+
+	wet, dirty, dry := false, false, false
+	for _, word := range words {
+		switch word {
+		case "pee", "wet", "urine":
+			wet = true
+		case "poo", "poop", "bm", "dirty", "soiled", "stool":
+			dirty = true
+		case "both", "mixed":
+			wet, dirty = true, true
+		case "dry", "clean":
+			dry = true
+		}
+	}
+
+	switch {
+	case wet && dirty:
+		return "mixed", nil
+	case wet:
+		return "wet", nil
+	case dirty:
+		return "dirty", nil
+	case dry:
+		return "dry", nil
+	}
+	return "", fmt.Errorf("unknown diaper content %q", value)
+}
+
+// sleep maps a sleep that started at start. It lasted until end, if not
+// zero, or for duration, if not empty.
+func sleep(start, end time.Time, duration string) (*Activity, error) {
+	details := Details{}
+	switch {
+	case !end.IsZero():
+		if end.Before(start) {
+			return nil, fmt.Errorf("sleep ends before it starts")
+		}
+		details["ended_at"] = end.Format(time.RFC3339)
+		details["duration_minutes"] = int(math.Round(end.Sub(start).Minutes()))
+	case duration != "":
+		minutes, err := parseMinutes(duration)
+		if err != nil {
+			return nil, err
+		}
+		details["duration_minutes"] = minutes
+	}
+
+	return &Activity{Type: domain.ActivityTypeSleep, HappensAt: start, Details: details}, nil
+}
+
+// bottle maps a bottle feed of the amount
+func bottle(start time.Time, amount, defaultUnit, milk string) (*Activity, error) {
+	value, unit, err := parseAmount(amount, defaultUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	details := Details{"method": "bottle", "amount": value, "unit": unit}
+	if milk := parseMilk(milk); milk != "" {
+		details["milk"] = milk
+	}
+	return &Activity{Type: domain.ActivityTypeFeeding, HappensAt: start, Details: details}, nil
+}
+
+// breastfeed maps a breastfeed on side lasting duration, either of which may
+// be empty
+func breastfeed(start time.Time, side, duration string) (*Activity, error) {
+	details := Details{"method": "breast"}
+	if side := parseSide(side); side != "" {
+		details["side"] = side
+	}
+	if duration != "" {
+		minutes, err := parseMinutes(duration)
+		if err != nil {
+			return nil, err
+		}
+		details["duration_minutes"] = minutes
+	}
+	return &Activity{Type: domain.ActivityTypeFeeding, HappensAt: start, Details: details}, nil
+}
+
+// diaper maps a diaper change with the content
+func diaper(start time.Time, content string) (*Activity, error) {
+	kind, err := parseDiaper(content)
+	if err != nil {
+		return nil, err
+	}
+	return &Activity{Type: domain.ActivityTypeDiaper, HappensAt: start, Details: Details{"kind": kind}}, nil
+}
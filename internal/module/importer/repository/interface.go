@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"dailyalu-server/internal/module/importer/domain"
+	"time"
+)
+
+// IImportRepository defines the interface for import data access
+type IImportRepository interface {
+	// Create stores an import together with the rows of its file
+	Create(imp *domain.Import, rows []domain.Row) error
+	GetImport(id int64) (*domain.Import, error)
+	// GetImports returns the imports of a child, newest first
+	GetImports(childID int64) ([]domain.Import, error)
+	// GetRows returns a page of the rows of an import in file order, only
+	// those with the status unless it is empty, and the number of such rows
+	GetRows(importID int64, status string, page, pageSize int) ([]domain.Row, int64, error)
+	Delete(id int64) error
+	// Queue hands a previewed or failed import to the worker
+	Queue(id int64, at time.Time) error
+	// GetActivityKeys returns the duplicate detection keys of the child's
+	// activities between from and to
+	GetActivityKeys(childID int64, from, to time.Time) (map[string]bool, error)
+
+	// ClaimQueued leases a queued import, or a running one whose worker
+	// stopped, and marks it running. It returns nil when there is none.
+	// RecordBatch and Finish only apply while the import still holds the
+	// lease in LockedUntil.
+	ClaimQueued(now, lockUntil time.Time) (*domain.Import, error)
+	// GetMappedRows returns the next rows waiting to be imported
+	GetMappedRows(importID int64, limit int) ([]domain.Row, error)
+	// RecordBatch creates the activities of the rows marked imported, stores
+	// the status of every row and the counters of the import, and extends
+	// the lease, all or nothing
+	RecordBatch(ctx context.Context, imp *domain.Import, rows []domain.Row, lockUntil time.Time) error
+	// Finish stores the final status of an import and releases the lease
+	Finish(imp *domain.Import) error
+}
@@ -0,0 +1,419 @@
+package repository
+
+import (
+	"context"
+	activityDomain "dailyalu-server/internal/module/activity/domain"
+	activityRepository "dailyalu-server/internal/module/activity/repository"
+	"dailyalu-server/internal/module/importer/domain"
+	"dailyalu-server/internal/service/worker"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const importColumns = `id, user_id, child_id, source, filename, status, total_rows, mapped_rows, unmapped_rows,
+	duplicate_rows, processed_rows, imported_rows, error, created_at, updated_at, started_at, completed_at`
+
+const rowColumns = `id, import_id, line, status, type, details, happens_at, reason, fields, activity_id`
+
+// PostgresImportRepository implements the import repository interface using PostgreSQL
+type PostgresImportRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresImportRepository creates a new PostgreSQL import repository
+func NewPostgresImportRepository(db *sql.DB) IImportRepository {
+	return &PostgresImportRepository{
+		db: db,
+	}
+}
+
+// Create inserts an import and copies its rows in one transaction
+func (r *PostgresImportRepository) Create(imp *domain.Import, rows []domain.Row) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO imports (user_id, child_id, source, filename, status, total_rows, mapped_rows, unmapped_rows,
+			duplicate_rows, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+
+	now := time.Now()
+	imp.CreatedAt = now
+	imp.UpdatedAt = now
+
+	err = tx.QueryRow(
+		query,
+		imp.UserID,
+		imp.ChildID,
+		imp.Source,
+		imp.Filename,
+		imp.Status,
+		imp.TotalRows,
+		imp.MappedRows,
+		imp.UnmappedRows,
+		imp.DuplicateRows,
+		imp.CreatedAt,
+		imp.UpdatedAt,
+	).Scan(&imp.ID)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn("import_rows", "import_id", "line", "status", "type", "details", "happens_at", "reason", "fields"))
+	if err != nil {
+		return err
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		row.ImportID = imp.ID
+
+		fields, err := json.Marshal(row.Fields)
+		if err != nil {
+			return err
+		}
+		var details interface{}
+		if len(row.Details) > 0 {
+			details = string(row.Details)
+		}
+
+		if _, err := stmt.Exec(row.ImportID, row.Line, row.Status, row.Type, details, row.HappensAt, row.Reason, string(fields)); err != nil {
+			return err
+		}
+	}
+	if _, err := stmt.Exec(); err != nil {
+		return err
+	}
+	if err := stmt.Close(); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetImport retrieves an import by ID
+func (r *PostgresImportRepository) GetImport(id int64) (*domain.Import, error) {
+	query := `SELECT ` + importColumns + ` FROM imports WHERE id = $1`
+
+	var imp domain.Import
+	if err := scanImport(r.db.QueryRow(query, id), &imp); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &imp, nil
+}
+
+// GetImports retrieves the imports of a child
+func (r *PostgresImportRepository) GetImports(childID int64) ([]domain.Import, error) {
+	query := `SELECT ` + importColumns + ` FROM imports WHERE child_id = $1 ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Query(query, childID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	imports := []domain.Import{}
+	for rows.Next() {
+		var imp domain.Import
+		if err := scanImport(rows, &imp); err != nil {
+			return nil, err
+		}
+		imports = append(imports, imp)
+	}
+
+	return imports, rows.Err()
+}
+
+// GetRows retrieves a page of the rows of an import
+func (r *PostgresImportRepository) GetRows(importID int64, status string, page, pageSize int) ([]domain.Row, int64, error) {
+	offset := (page - 1) * pageSize
+	condition := `import_id = $1 AND ($2 = '' OR status = $2)`
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM import_rows WHERE ` + condition
+	if err := r.db.QueryRow(countQuery, importID, status).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + rowColumns + ` FROM import_rows
+		WHERE ` + condition + `
+		ORDER BY line, id
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.Query(query, importID, status, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	importRows, err := scanRows(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return importRows, total, nil
+}
+
+// Delete removes an import and its rows. Activities it created are kept.
+func (r *PostgresImportRepository) Delete(id int64) error {
+	result, err := r.db.Exec(`DELETE FROM imports WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+// Queue hands a previewed or failed import to the worker
+func (r *PostgresImportRepository) Queue(id int64, at time.Time) error {
+	query := `
+		UPDATE imports
+		SET status = $1, error = '', completed_at = NULL, updated_at = $2
+		WHERE id = $3 AND status IN ($4, $5)
+	`
+
+	result, err := r.db.Exec(query, domain.ImportQueued, at, id, domain.ImportPreviewed, domain.ImportFailed)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+// GetActivityKeys retrieves the keys of the child's activities within a
+// period
+func (r *PostgresImportRepository) GetActivityKeys(childID int64, from, to time.Time) (map[string]bool, error) {
+	query := `
+		SELECT type, happens_at
+		FROM activities
+		WHERE child_id = $1 AND happens_at >= $2 AND happens_at <= $3
+	`
+
+	// Keys are compared to the minute, so look at the whole first and last
+	// minutes
+	rows, err := r.db.Query(query, childID, from.Truncate(time.Minute), to.Truncate(time.Minute).Add(time.Minute))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := map[string]bool{}
+	for rows.Next() {
+		var activityType string
+		var happensAt time.Time
+		if err := rows.Scan(&activityType, &happensAt); err != nil {
+			return nil, err
+		}
+		keys[domain.Key(activityType, happensAt)] = true
+	}
+
+	return keys, rows.Err()
+}
+
+// ClaimQueued leases the import committed first. SKIP LOCKED lets
+// concurrent instances claim different imports, and the lease makes an
+// import claimable again if its instance dies while importing it.
+func (r *PostgresImportRepository) ClaimQueued(now, lockUntil time.Time) (*domain.Import, error) {
+	query := `
+		UPDATE imports
+		SET status = $3, locked_until = $2, started_at = COALESCE(started_at, $1), updated_at = $1
+		WHERE id = (
+			SELECT id FROM imports
+			WHERE status = $4 OR (status = $3 AND locked_until <= $1)
+			ORDER BY updated_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + importColumns
+
+	var imp domain.Import
+	err := scanImport(r.db.QueryRow(query, now, lockUntil, domain.ImportRunning, domain.ImportQueued), &imp)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	imp.LockedUntil = lockUntil
+
+	return &imp, nil
+}
+
+// GetMappedRows retrieves the next rows waiting to be imported, in file order
+func (r *PostgresImportRepository) GetMappedRows(importID int64, limit int) ([]domain.Row, error) {
+	query := `SELECT ` + rowColumns + ` FROM import_rows
+		WHERE import_id = $1 AND status = $2
+		ORDER BY id
+		LIMIT $3`
+
+	rows, err := r.db.Query(query, importID, domain.RowMapped, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanRows(rows)
+}
+
+// RecordBatch stores the outcome of a batch of rows. Activities are created
+// in the same transaction as the row updates, so a batch interrupted half
+// way is imported again as a whole. Nothing is stored, and worker.ErrLeaseLost
+// is returned, once another worker may have claimed the import.
+func (r *PostgresImportRepository) RecordBatch(ctx context.Context, imp *domain.Import, rows []domain.Row, lockUntil time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for i := range rows {
+		row := &rows[i]
+
+		if row.Status == domain.RowImported {
+			activity := &activityDomain.Activity{
+				UserID:    imp.UserID,
+				ChildID:   int(imp.ChildID),
+				Type:      row.Type,
+				Details:   row.Details,
+				HappensAt: *row.HappensAt,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			if err := activityRepository.Insert(ctx, tx, activity); err != nil {
+				return fmt.Errorf("failed to import line %d: %w", row.Line, err)
+			}
+			row.ActivityID = &activity.ID
+		}
+
+		query := `UPDATE import_rows SET status = $1, reason = $2, activity_id = $3 WHERE id = $4`
+		if _, err := tx.ExecContext(ctx, query, row.Status, row.Reason, row.ActivityID, row.ID); err != nil {
+			return err
+		}
+	}
+
+	imp.UpdatedAt = now
+	query := `
+		UPDATE imports
+		SET processed_rows = $1, imported_rows = $2, duplicate_rows = $3, locked_until = $4, updated_at = $5
+		WHERE id = $6 AND locked_until = $7
+	`
+	err = worker.CheckLease(tx.ExecContext(ctx, query, imp.ProcessedRows, imp.ImportedRows, imp.DuplicateRows, lockUntil, imp.UpdatedAt, imp.ID, imp.LockedUntil))
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	imp.LockedUntil = lockUntil
+	return nil
+}
+
+// Finish stores the final status of an import and releases the lease. It
+// returns worker.ErrLeaseLost when the lease expired in the meantime.
+func (r *PostgresImportRepository) Finish(imp *domain.Import) error {
+	query := `
+		UPDATE imports
+		SET status = $1, error = $2, completed_at = $3, locked_until = NULL, updated_at = $4
+		WHERE id = $5 AND locked_until = $6
+	`
+
+	imp.UpdatedAt = time.Now()
+
+	return worker.CheckLease(r.db.Exec(query, imp.Status, imp.Error, imp.CompletedAt, imp.UpdatedAt, imp.ID, imp.LockedUntil))
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanImport(row rowScanner, imp *domain.Import) error {
+	err := row.Scan(
+		&imp.ID,
+		&imp.UserID,
+		&imp.ChildID,
+		&imp.Source,
+		&imp.Filename,
+		&imp.Status,
+		&imp.TotalRows,
+		&imp.MappedRows,
+		&imp.UnmappedRows,
+		&imp.DuplicateRows,
+		&imp.ProcessedRows,
+		&imp.ImportedRows,
+		&imp.Error,
+		&imp.CreatedAt,
+		&imp.UpdatedAt,
+		&imp.StartedAt,
+		&imp.CompletedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	imp.SetProgress()
+	return nil
+}
+
+func scanRows(rows *sql.Rows) ([]domain.Row, error) {
+	importRows := []domain.Row{}
+	for rows.Next() {
+		var row domain.Row
+		var details, fields []byte
+		var activityID sql.NullInt64
+
+		if err := rows.Scan(
+			&row.ID,
+			&row.ImportID,
+			&row.Line,
+			&row.Status,
+			&row.Type,
+			&details,
+			&row.HappensAt,
+			&row.Reason,
+			&fields,
+			&activityID,
+		); err != nil {
+			return nil, err
+		}
+
+		if len(details) > 0 {
+			row.Details = details
+		}
+		if err := json.Unmarshal(fields, &row.Fields); err != nil {
+			return nil, err
+		}
+		if activityID.Valid {
+			id := int(activityID.Int64)
+			row.ActivityID = &id
+		}
+		importRows = append(importRows, row)
+	}
+
+	return importRows, rows.Err()
+}
+
+func expectAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import "errors"
+
+// Domain errors for importer module
+var (
+	ErrImportNotFound = errors.New("import not found")
+	// ErrUnreadableFile wraps the reason a file cannot be previewed at all
+	ErrUnreadableFile       = errors.New("import file cannot be read")
+	ErrNothingToImport      = errors.New("import has no rows left to import")
+	ErrImportNotCommittable = errors.New("only previewed or failed imports can be committed")
+	ErrImportInProgress     = errors.New("import is in progress")
+)
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	"dailyalu-server/internal/module/importer/domain"
+	"dailyalu-server/internal/module/importer/parser"
+	"dailyalu-server/internal/module/importer/repository"
+	"dailyalu-server/internal/utils"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ImportUseCase implements the import use case interface
+type ImportUseCase struct {
+	importRepo      repository.IImportRepository
+	childrenUseCase childrenUsecase.IChildrenUseCase
+	now             func() time.Time
+}
+
+// NewImportUseCase creates a new import use case
+func NewImportUseCase(importRepo repository.IImportRepository, childrenUseCase childrenUsecase.IChildrenUseCase) IImportUseCase {
+	return &ImportUseCase{
+		importRepo:      importRepo,
+		childrenUseCase: childrenUseCase,
+		now:             time.Now,
+	}
+}
+
+// Preview maps the rows of an export file, finds those already recorded and
+// stores the import for review
+func (uc *ImportUseCase) Preview(ctx context.Context, req *domain.PreviewImportRequest) (*domain.Preview, error) {
+	child, err := uc.childrenUseCase.GetChild(req.ChildID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := parser.Parse(req.Source, req.Content, utils.LocationFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnreadableFile, err)
+	}
+
+	existing := map[string]bool{}
+	if from, to, ok := domain.TimeRange(rows); ok {
+		if existing, err = uc.importRepo.GetActivityKeys(child.ID, from, to); err != nil {
+			return nil, err
+		}
+	}
+	duplicates := domain.MarkDuplicates(rows, existing)
+
+	imp := &domain.Import{
+		UserID:        req.UserID,
+		ChildID:       child.ID,
+		Source:        req.Source,
+		Filename:      req.Filename,
+		Status:        domain.ImportPreviewed,
+		TotalRows:     len(rows),
+		DuplicateRows: duplicates,
+	}
+
+	var unmapped []domain.Row
+	for _, row := range rows {
+		switch row.Status {
+		case domain.RowMapped:
+			imp.MappedRows++
+		case domain.RowUnmapped:
+			unmapped = append(unmapped, row)
+		}
+	}
+	imp.UnmappedRows = len(unmapped)
+
+	if err := uc.importRepo.Create(imp, rows); err != nil {
+		return nil, err
+	}
+
+	preview := &domain.Preview{
+		Import:          imp,
+		UnmappedReasons: domain.CountReasons(unmapped),
+		Unmapped:        []domain.Row{},
+		Sample:          []domain.Row{},
+	}
+	for _, row := range rows {
+		switch {
+		case row.Status == domain.RowMapped && len(preview.Sample) < domain.PreviewSampleSize:
+			preview.Sample = append(preview.Sample, row)
+		case row.Status == domain.RowUnmapped && len(preview.Unmapped) < domain.PreviewSampleSize:
+			preview.Unmapped = append(preview.Unmapped, row)
+		}
+	}
+	return preview, nil
+}
+
+// GetImports retrieves the imports of a child owned by the user
+func (uc *ImportUseCase) GetImports(childID int64, userID string) ([]domain.Import, error) {
+	if _, err := uc.childrenUseCase.GetChild(childID, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.importRepo.GetImports(childID)
+}
+
+// GetImport retrieves an import of a child owned by the user, with its
+// progress
+func (uc *ImportUseCase) GetImport(childID, id int64, userID string) (*domain.Import, error) {
+	if _, err := uc.childrenUseCase.GetChild(childID, userID); err != nil {
+		return nil, err
+	}
+
+	imp, err := uc.importRepo.GetImport(id)
+	if err != nil {
+		return nil, err
+	}
+	if imp == nil || imp.ChildID != childID {
+		return nil, ErrImportNotFound
+	}
+
+	return imp, nil
+}
+
+// GetRows retrieves a page of the rows of an import
+func (uc *ImportUseCase) GetRows(req *domain.GetRowsRequest) (*domain.RowsResponse, error) {
+	if _, err := uc.GetImport(req.ChildID, req.ImportID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	rows, total, err := uc.importRepo.GetRows(req.ImportID, req.Status, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RowsResponse{
+		Rows: rows,
+		Pagination: domain.Pagination{
+			Total:       total,
+			CurrentPage: req.Page,
+			PageSize:    req.PageSize,
+			TotalPages:  int(math.Ceil(float64(total) / float64(req.PageSize))),
+		},
+	}, nil
+}
+
+// Commit queues an import whose preview the user accepted
+func (uc *ImportUseCase) Commit(childID, id int64, userID string) (*domain.Import, error) {
+	imp, err := uc.GetImport(childID, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if imp.Status != domain.ImportPreviewed && imp.Status != domain.ImportFailed {
+		return nil, ErrImportNotCommittable
+	}
+	if imp.ProcessedRows >= imp.MappedRows {
+		return nil, ErrNothingToImport
+	}
+
+	if err := uc.importRepo.Queue(id, uc.now()); err != nil {
+		// The import was committed by another request in between
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrImportNotCommittable
+		}
+		return nil, err
+	}
+
+	return uc.GetImport(childID, id, userID)
+}
+
+// DeleteImport removes an import that is not being imported
+func (uc *ImportUseCase) DeleteImport(childID, id int64, userID string) error {
+	imp, err := uc.GetImport(childID, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if imp.Status == domain.ImportQueued || imp.Status == domain.ImportRunning {
+		return ErrImportInProgress
+	}
+
+	if err := uc.importRepo.Delete(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrImportNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/children/childrentest"
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	"dailyalu-server/internal/module/importer/domain"
+	"dailyalu-server/internal/module/importer/repository"
+	"dailyalu-server/internal/utils"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockImportRepository keeps imports in memory and knows the keys of the
+// child's existing activities
+type MockImportRepository struct {
+	repository.IImportRepository
+	Imports      map[int64]*domain.Import
+	Rows         map[int64][]domain.Row
+	ActivityKeys map[string]bool
+}
+
+func (m *MockImportRepository) Create(imp *domain.Import, rows []domain.Row) error {
+	imp.ID = int64(len(m.Imports) + 1)
+	m.Imports[imp.ID] = imp
+	m.Rows[imp.ID] = rows
+	return nil
+}
+
+func (m *MockImportRepository) GetImport(id int64) (*domain.Import, error) {
+	imp, ok := m.Imports[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *imp
+	return &copied, nil
+}
+
+func (m *MockImportRepository) Queue(id int64, at time.Time) error {
+	imp, ok := m.Imports[id]
+	if !ok || (imp.Status != domain.ImportPreviewed && imp.Status != domain.ImportFailed) {
+		return sql.ErrNoRows
+	}
+	imp.Status = domain.ImportQueued
+	return nil
+}
+
+func (m *MockImportRepository) Delete(id int64) error {
+	delete(m.Imports, id)
+	return nil
+}
+
+func (m *MockImportRepository) GetActivityKeys(childID int64, from, to time.Time) (map[string]bool, error) {
+	return m.ActivityKeys, nil
+}
+
+var jakarta = time.FixedZone("WIB", 7*60*60)
+
+func newTestUseCase() (*ImportUseCase, *MockImportRepository) {
+	repo := &MockImportRepository{
+		Imports: map[int64]*domain.Import{},
+		Rows:    map[int64][]domain.Row{},
+		// A bottle already logged in the app at 05:30 Jakarta time
+		ActivityKeys: map[string]bool{
+			domain.Key(domain.ActivityTypeFeeding, time.Date(2025, 3, 1, 22, 30, 0, 0, time.UTC)): true,
+		},
+	}
+	children := &childrentest.UseCase{Child: &childrenDomain.Child{ID: 1, UserID: "user-1"}}
+	return NewImportUseCase(repo, children).(*ImportUseCase), repo
+}
+
+const huckleberryExport = `Type,Start,End,Duration,Start Condition,Start Location,End Condition,Notes
+Sleep,2025-03-01 21:15,2025-03-02 01:45,,,,,
+Feed,2025-03-02 02:00,,00:20,L,,,
+Feed,2025-03-02 05:30,,,Formula,Bottle,4oz,
+Diaper,2025-03-02 06:00,,,,,Pee,
+Diaper,2025-03-02 06:00,,,,,Pee,
+Pump,2025-03-02 07:00,,00:15,,,,
+Growth,2025-03-02 08:00,,,,,,
+`
+
+func TestPreview(t *testing.T) {
+	uc, repo := newTestUseCase()
+	ctx := utils.WithLocation(context.Background(), jakarta)
+
+	preview, err := uc.Preview(ctx, &domain.PreviewImportRequest{
+		UserID:   "user-1",
+		ChildID:  1,
+		Source:   domain.SourceHuckleberry,
+		Filename: "huckleberry.csv",
+		Content:  []byte(huckleberryExport),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	imp := preview.Import
+	if imp.ID != 1 || imp.Status != domain.ImportPreviewed || imp.Filename != "huckleberry.csv" {
+		t.Errorf("unexpected import %+v", imp)
+	}
+	// The bottle was logged already and the second diaper repeats the first
+	if imp.TotalRows != 7 || imp.MappedRows != 3 || imp.UnmappedRows != 2 || imp.DuplicateRows != 2 {
+		t.Errorf("expected 7 rows with 3 mapped, 2 unmapped and 2 duplicates, got %+v", imp)
+	}
+	if len(repo.Rows[1]) != 7 {
+		t.Errorf("expected every row to be stored, got %d", len(repo.Rows[1]))
+	}
+
+	if len(preview.Sample) != 3 || preview.Sample[0].Type != domain.ActivityTypeSleep || preview.Sample[2].Type != domain.ActivityTypeDiaper {
+		t.Errorf("unexpected sample %+v", preview.Sample)
+	}
+	if !preview.Sample[0].HappensAt.Equal(time.Date(2025, 3, 1, 21, 15, 0, 0, jakarta)) {
+		t.Errorf("expected times to be read in the time zone of the context, got %s", preview.Sample[0].HappensAt)
+	}
+	if len(preview.Unmapped) != 2 || len(preview.UnmappedReasons) != 2 || preview.UnmappedReasons[0].Reason != `unsupported type "Growth"` {
+		t.Errorf("unexpected unmapped rows %+v, reasons %+v", preview.Unmapped, preview.UnmappedReasons)
+	}
+
+	for _, row := range repo.Rows[1] {
+		if row.Status == domain.RowDuplicate && row.Reason == "" {
+			t.Errorf("expected duplicate row %d to give its reason", row.Line)
+		}
+	}
+}
+
+func TestPreviewRejectsUnreadableFiles(t *testing.T) {
+	uc, repo := newTestUseCase()
+
+	_, err := uc.Preview(context.Background(), &domain.PreviewImportRequest{
+		UserID:  "user-1",
+		ChildID: 1,
+		Source:  domain.SourceBabyConnect,
+		Content: []byte(huckleberryExport),
+	})
+	if !errors.Is(err, ErrUnreadableFile) || !strings.Contains(err.Error(), "missing the columns date") {
+		t.Errorf("expected ErrUnreadableFile naming the missing columns, got %v", err)
+	}
+	if len(repo.Imports) != 0 {
+		t.Errorf("expected no import to be stored")
+	}
+
+	_, err = uc.Preview(context.Background(), &domain.PreviewImportRequest{UserID: "user-2", ChildID: 1, Source: domain.SourceHuckleberry})
+	if !errors.Is(err, childrenUsecase.ErrUnauthorizedAccess) {
+		t.Errorf("expected ErrUnauthorizedAccess, got %v", err)
+	}
+}
+
+func TestCommit(t *testing.T) {
+	uc, repo := newTestUseCase()
+	repo.Imports[1] = &domain.Import{ID: 1, ChildID: 1, Status: domain.ImportPreviewed, MappedRows: 3}
+	repo.Imports[2] = &domain.Import{ID: 2, ChildID: 1, Status: domain.ImportPreviewed}
+	repo.Imports[3] = &domain.Import{ID: 3, ChildID: 2, Status: domain.ImportPreviewed, MappedRows: 3}
+
+	imp, err := uc.Commit(1, 1, "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if imp.Status != domain.ImportQueued {
+		t.Errorf("expected the import to be queued, got %s", imp.Status)
+	}
+
+	if _, err := uc.Commit(1, 1, "user-1"); !errors.Is(err, ErrImportNotCommittable) {
+		t.Errorf("expected ErrImportNotCommittable when committing twice, got %v", err)
+	}
+	if _, err := uc.Commit(1, 2, "user-1"); !errors.Is(err, ErrNothingToImport) {
+		t.Errorf("expected ErrNothingToImport, got %v", err)
+	}
+	if _, err := uc.Commit(1, 3, "user-1"); !errors.Is(err, ErrImportNotFound) {
+		t.Errorf("expected imports of other children to be hidden, got %v", err)
+	}
+
+	// Deleting is refused until the worker is done
+	if err := uc.DeleteImport(1, 1, "user-1"); !errors.Is(err, ErrImportInProgress) {
+		t.Errorf("expected ErrImportInProgress, got %v", err)
+	}
+	repo.Imports[1].Status = domain.ImportFailed
+	if err := uc.DeleteImport(1, 1, "user-1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
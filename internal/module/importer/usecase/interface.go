@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/importer/domain"
+)
+
+// IImportUseCase defines the interface for importing the exports of other
+// baby tracker apps
+type IImportUseCase interface {
+	// Preview parses an export file in the time zone of the context and
+	// stores the result without creating any activity
+	Preview(ctx context.Context, req *domain.PreviewImportRequest) (*domain.Preview, error)
+	GetImports(childID int64, userID string) ([]domain.Import, error)
+	GetImport(childID, id int64, userID string) (*domain.Import, error)
+	GetRows(req *domain.GetRowsRequest) (*domain.RowsResponse, error)
+	// Commit queues a previewed import for the background worker, which
+	// creates its activities
+	Commit(childID, id int64, userID string) (*domain.Import, error)
+	// DeleteImport removes an import and its rows. Activities it created
+	// are kept.
+	DeleteImport(childID, id int64, userID string) error
+}
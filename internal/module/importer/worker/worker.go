@@ -0,0 +1,149 @@
+// Package worker creates the activities of committed imports in the
+// background, using the lease runner of internal/service/worker. Each batch of
+// rows is recorded with its activities in one transaction and extends the
+// lease, so an import interrupted by a crash or shutdown resumes after its
+// last recorded batch.
+package worker
+
+import (
+	"context"
+	"dailyalu-server/internal/module/importer/domain"
+	"dailyalu-server/internal/module/importer/repository"
+	leaseWorker "dailyalu-server/internal/service/worker"
+	"dailyalu-server/pkg/app_log/zap_log"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Config controls polling and batching
+type Config struct {
+	// PollInterval is the time between two looks for committed imports
+	PollInterval time.Duration
+	// BatchSize is the number of rows imported per transaction; progress is
+	// updated after each batch
+	BatchSize int
+	// Lease is how long a claimed import is hidden from other instances
+	// after its last batch
+	Lease time.Duration
+}
+
+// NewConfigFromConfig reads the worker settings under imports
+func NewConfigFromConfig() Config {
+	return Config{
+		PollInterval: viper.GetDuration("imports.poll_interval"),
+		BatchSize:    viper.GetInt("imports.batch_size"),
+		Lease:        viper.GetDuration("imports.lease"),
+	}
+}
+
+// Worker polls for committed imports and imports their rows
+type Worker struct {
+	importRepo repository.IImportRepository
+	runner     *leaseWorker.Runner[domain.Import]
+	config     Config
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewWorker creates an import worker
+func NewWorker(importRepo repository.IImportRepository, config Config) *Worker {
+	logger := zap_log.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	w := &Worker{
+		importRepo: importRepo,
+		config:     config,
+		logger:     logger,
+		now:        time.Now,
+	}
+	// Imports are claimed one at a time and a failed import is not retried;
+	// it can be committed again instead
+	w.runner = leaseWorker.NewRunner("committed imports", leaseWorker.Jobs[domain.Import]{
+		Claim:  w.claim,
+		Handle: w.process,
+		Record: w.record,
+	}, leaseWorker.Config{
+		PollInterval: config.PollInterval,
+		BatchSize:    1,
+		Lease:        config.Lease,
+		MaxAttempts:  1,
+	}, func() time.Time { return w.now() })
+	return w
+}
+
+// Run imports committed imports until the context is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	w.runner.Run(ctx)
+}
+
+// RunOnce claims one committed import and imports its rows. It reports
+// whether an import was claimed.
+func (w *Worker) RunOnce(ctx context.Context) (bool, error) {
+	claimed, err := w.runner.RunOnce(ctx)
+	return claimed > 0, err
+}
+
+func (w *Worker) claim(now, leaseUntil time.Time, _ int) ([]domain.Import, error) {
+	imp, err := w.importRepo.ClaimQueued(now, leaseUntil)
+	if err != nil || imp == nil {
+		return nil, err
+	}
+	return []domain.Import{*imp}, nil
+}
+
+// record marks an import completed or failed
+func (w *Worker) record(imp *domain.Import, attempt leaseWorker.Attempt) {
+	completedAt := w.now()
+	imp.CompletedAt = &completedAt
+	imp.Status = domain.ImportCompleted
+	imp.Error = ""
+	if attempt.Err != nil {
+		imp.Status = domain.ImportFailed
+		imp.Error = attempt.Err.Error()
+		w.logger.Error("failed to import rows", zap.Int64("import_id", imp.ID), zap.Error(attempt.Err))
+	}
+
+	if err := w.importRepo.Finish(imp); err != nil {
+		w.logger.Error("failed to record import", zap.Int64("import_id", imp.ID), zap.Error(err))
+	}
+}
+
+// process imports the mapped rows of an import batch by batch. Rows matching
+// an activity recorded since the preview, including by an earlier batch, are
+// skipped as duplicates.
+func (w *Worker) process(ctx context.Context, imp *domain.Import) error {
+	for ctx.Err() == nil {
+		rows, err := w.importRepo.GetMappedRows(imp.ID, w.config.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		from, to, _ := domain.TimeRange(rows)
+		existing, err := w.importRepo.GetActivityKeys(imp.ChildID, from, to)
+		if err != nil {
+			return err
+		}
+
+		duplicates := domain.MarkDuplicates(rows, existing)
+		for i := range rows {
+			if rows[i].Status == domain.RowMapped {
+				rows[i].Status = domain.RowImported
+			}
+		}
+
+		imp.ProcessedRows += len(rows)
+		imp.ImportedRows += len(rows) - duplicates
+		imp.DuplicateRows += duplicates
+		if err := w.importRepo.RecordBatch(ctx, imp, rows, w.now().Add(w.config.Lease).Truncate(time.Microsecond)); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
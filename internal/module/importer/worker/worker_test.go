@@ -0,0 +1,141 @@
+package worker
+
+import (
+	"context"
+	"dailyalu-server/internal/module/importer/domain"
+	"dailyalu-server/internal/module/importer/repository"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockImportRepository serves one committed import and records the batches
+// the worker imports
+type MockImportRepository struct {
+	repository.IImportRepository
+	Queued       *domain.Import
+	Rows         []domain.Row
+	ActivityKeys map[string]bool
+	Batches      [][]domain.Row
+	Finished     *domain.Import
+	FailBatch    int
+}
+
+func (m *MockImportRepository) ClaimQueued(now, lockUntil time.Time) (*domain.Import, error) {
+	imp := m.Queued
+	m.Queued = nil
+	return imp, nil
+}
+
+func (m *MockImportRepository) GetMappedRows(importID int64, limit int) ([]domain.Row, error) {
+	var rows []domain.Row
+	for _, row := range m.Rows {
+		if row.Status == domain.RowMapped && len(rows) < limit {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (m *MockImportRepository) GetActivityKeys(childID int64, from, to time.Time) (map[string]bool, error) {
+	keys := map[string]bool{}
+	for key := range m.ActivityKeys {
+		keys[key] = true
+	}
+	return keys, nil
+}
+
+func (m *MockImportRepository) RecordBatch(ctx context.Context, imp *domain.Import, rows []domain.Row, lockUntil time.Time) error {
+	if m.FailBatch == len(m.Batches)+1 {
+		return errors.New("connection reset")
+	}
+	m.Batches = append(m.Batches, rows)
+	for _, row := range rows {
+		for i := range m.Rows {
+			if m.Rows[i].ID == row.ID {
+				m.Rows[i] = row
+			}
+		}
+		if row.Status == domain.RowImported {
+			m.ActivityKeys[domain.Key(row.Type, *row.HappensAt)] = true
+		}
+	}
+	return nil
+}
+
+func (m *MockImportRepository) Finish(imp *domain.Import) error {
+	m.Finished = imp
+	return nil
+}
+
+func testRepository() *MockImportRepository {
+	start := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	row := func(id int64, activityType string, at time.Time) domain.Row {
+		return domain.Row{ID: id, ImportID: 1, Line: int(id) + 1, Status: domain.RowMapped, Type: activityType, Details: json.RawMessage(`{}`), HappensAt: &at}
+	}
+
+	return &MockImportRepository{
+		Queued: &domain.Import{ID: 1, UserID: "user-1", ChildID: 1, Status: domain.ImportRunning, MappedRows: 5},
+		Rows: []domain.Row{
+			row(1, domain.ActivityTypeSleep, start),
+			row(2, domain.ActivityTypeFeeding, start.Add(time.Hour)),
+			row(3, domain.ActivityTypeDiaper, start.Add(2*time.Hour)),
+			row(4, domain.ActivityTypeFeeding, start.Add(3*time.Hour)),
+			row(5, domain.ActivityTypeDiaper, start.Add(4*time.Hour)),
+		},
+		// The second feed was logged in the app after the preview
+		ActivityKeys: map[string]bool{domain.Key(domain.ActivityTypeFeeding, start.Add(3*time.Hour)): true},
+	}
+}
+
+func TestRunOnce(t *testing.T) {
+	repo := testRepository()
+	w := NewWorker(repo, Config{BatchSize: 2, Lease: time.Minute})
+
+	claimed, err := w.RunOnce(context.Background())
+	if err != nil || !claimed {
+		t.Fatalf("expected an import to be claimed, got %v, %v", claimed, err)
+	}
+
+	if len(repo.Batches) != 3 {
+		t.Errorf("expected 3 batches of at most 2 rows, got %d", len(repo.Batches))
+	}
+
+	imp := repo.Finished
+	if imp == nil || imp.Status != domain.ImportCompleted || imp.CompletedAt == nil || imp.Error != "" {
+		t.Fatalf("expected the import to be completed, got %+v", imp)
+	}
+	if imp.ProcessedRows != 5 || imp.ImportedRows != 4 || imp.DuplicateRows != 1 {
+		t.Errorf("expected 4 rows imported and 1 duplicate, got %+v", imp)
+	}
+	if repo.Rows[3].Status != domain.RowDuplicate || repo.Rows[4].Status != domain.RowImported {
+		t.Errorf("unexpected rows %+v", repo.Rows)
+	}
+
+	// Nothing left to claim
+	if claimed, err := w.RunOnce(context.Background()); err != nil || claimed {
+		t.Errorf("expected nothing to be claimed, got %v, %v", claimed, err)
+	}
+}
+
+func TestRunOnceFailsImport(t *testing.T) {
+	repo := testRepository()
+	repo.FailBatch = 2
+	w := NewWorker(repo, Config{BatchSize: 2, Lease: time.Minute})
+
+	if _, err := w.RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	imp := repo.Finished
+	if imp.Status != domain.ImportFailed || imp.Error != "connection reset" {
+		t.Errorf("expected the import to fail with the error, got %+v", imp)
+	}
+
+	// The rows of the first batch stay imported, the others wait for the
+	// import to be committed again
+	if repo.Rows[0].Status != domain.RowImported || repo.Rows[2].Status != domain.RowMapped {
+		t.Errorf("unexpected rows %+v", repo.Rows)
+	}
+}
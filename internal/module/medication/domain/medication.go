@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+)
+
+// Plan is a medication prescribed to a child. Doses are medicine activities
+// that reference the plan.
+type Plan struct {
+	ID              int64      `json:"id"`
+	ChildID         int64      `json:"child_id"`
+	UserID          string     `json:"user_id"`
+	DrugName        string     `json:"drug_name"`
+	Dose            float64    `json:"dose"`
+	Unit            string     `json:"unit"`
+	IntervalMinutes int        `json:"interval_minutes"`
+	MaxDosesPer24h  int        `json:"max_doses_per_24h"`
+	StartsAt        time.Time  `json:"starts_at"`
+	EndsAt          *time.Time `json:"ends_at,omitempty"`
+	Note            string     `json:"note,omitempty"`
+	NextDose        *NextDose  `json:"next_dose,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Interval returns the minimum time between two doses
+func (p *Plan) Interval() time.Duration {
+	return time.Duration(p.IntervalMinutes) * time.Minute
+}
+
+// NextDose tells when the next dose of a plan may be given. A dose is allowed
+// once the interval since the last dose has passed and fewer than the maximum
+// doses were given in the previous 24 hours.
+type NextDose struct {
+	AllowedAt      time.Time  `json:"allowed_at"`
+	AllowedNow     bool       `json:"allowed_now"`
+	LastDoseAt     *time.Time `json:"last_dose_at,omitempty"`
+	DosesLast24h   int        `json:"doses_last_24h"`
+	MaxDosesPer24h int        `json:"max_doses_per_24h"`
+	Ended          bool       `json:"ended"`
+}
+
+// CreatePlanRequest represents the request to create a medication plan
+type CreatePlanRequest struct {
+	ChildID         int64   `json:"-"`
+	UserID          string  `json:"-"`
+	DrugName        string  `json:"drug_name" validate:"required,max=255"`
+	Dose            float64 `json:"dose" validate:"required,gt=0"`
+	Unit            string  `json:"unit" validate:"required,max=20"`
+	IntervalMinutes int     `json:"interval_minutes" validate:"required,min=15,max=10080"`
+	MaxDosesPer24h  int     `json:"max_doses_per_24h" validate:"required,min=1,max=96"`
+	StartsAt        string  `json:"starts_at" validate:"required"`
+	EndsAt          string  `json:"ends_at,omitempty"`
+	Note            string  `json:"note,omitempty" validate:"max=500"`
+}
+
+// UpdatePlanRequest represents the request to update a medication plan
+type UpdatePlanRequest struct {
+	ID      int64 `json:"-"`
+	ChildID int64 `json:"-"`
+	CreatePlanRequest
+}
+
+// DoseCheckRequest describes a dose about to be logged against a plan.
+// ExcludeActivityID leaves out the activity being updated.
+type DoseCheckRequest struct {
+	UserID            string
+	ChildID           int64
+	PlanID            int64
+	At                time.Time
+	ExcludeActivityID int64
+	AllowExceedingMax bool
+}
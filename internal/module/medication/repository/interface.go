@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/medication/domain"
+	"time"
+)
+
+// IMedicationRepository defines the interface for medication plan data access
+type IMedicationRepository interface {
+	Create(plan *domain.Plan) error
+	GetByID(id int64) (*domain.Plan, error)
+	GetByChildID(childID int64) ([]domain.Plan, error)
+	Update(plan *domain.Plan) error
+	Delete(id int64) error
+	GetDoseTimes(planID int64, from, to time.Time, excludeActivityID int64) ([]time.Time, error)
+}
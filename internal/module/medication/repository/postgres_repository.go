@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/medication/domain"
+	"database/sql"
+	"time"
+)
+
+// PostgresMedicationRepository implements the medication repository interface using PostgreSQL
+type PostgresMedicationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresMedicationRepository creates a new PostgreSQL medication repository
+func NewPostgresMedicationRepository(db *sql.DB) IMedicationRepository {
+	return &PostgresMedicationRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new medication plan into the database
+func (r *PostgresMedicationRepository) Create(plan *domain.Plan) error {
+	query := `
+		INSERT INTO medication_plans (child_id, user_id, drug_name, dose, unit, interval_minutes, max_doses_per_24h,
+			starts_at, ends_at, note, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id
+	`
+
+	now := time.Now()
+	plan.CreatedAt = now
+	plan.UpdatedAt = now
+
+	return r.db.QueryRow(
+		query,
+		plan.ChildID,
+		plan.UserID,
+		plan.DrugName,
+		plan.Dose,
+		plan.Unit,
+		plan.IntervalMinutes,
+		plan.MaxDosesPer24h,
+		plan.StartsAt,
+		plan.EndsAt,
+		plan.Note,
+		plan.CreatedAt,
+		plan.UpdatedAt,
+	).Scan(&plan.ID)
+}
+
+// GetByID retrieves a medication plan by ID
+func (r *PostgresMedicationRepository) GetByID(id int64) (*domain.Plan, error) {
+	query := `
+		SELECT id, child_id, user_id, drug_name, dose, unit, interval_minutes, max_doses_per_24h,
+			starts_at, ends_at, note, created_at, updated_at
+		FROM medication_plans
+		WHERE id = $1
+	`
+
+	var plan domain.Plan
+	err := scanPlan(r.db.QueryRow(query, id), &plan)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+// GetByChildID retrieves the medication plans of a child, newest first
+func (r *PostgresMedicationRepository) GetByChildID(childID int64) ([]domain.Plan, error) {
+	query := `
+		SELECT id, child_id, user_id, drug_name, dose, unit, interval_minutes, max_doses_per_24h,
+			starts_at, ends_at, note, created_at, updated_at
+		FROM medication_plans
+		WHERE child_id = $1
+		ORDER BY starts_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(query, childID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	plans := []domain.Plan{}
+	for rows.Next() {
+		var plan domain.Plan
+		if err := scanPlan(rows, &plan); err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+
+	return plans, rows.Err()
+}
+
+// Update updates an existing medication plan
+func (r *PostgresMedicationRepository) Update(plan *domain.Plan) error {
+	query := `
+		UPDATE medication_plans
+		SET drug_name = $1, dose = $2, unit = $3, interval_minutes = $4, max_doses_per_24h = $5,
+			starts_at = $6, ends_at = $7, note = $8, updated_at = $9
+		WHERE id = $10
+	`
+
+	plan.UpdatedAt = time.Now()
+
+	result, err := r.db.Exec(
+		query,
+		plan.DrugName,
+		plan.Dose,
+		plan.Unit,
+		plan.IntervalMinutes,
+		plan.MaxDosesPer24h,
+		plan.StartsAt,
+		plan.EndsAt,
+		plan.Note,
+		plan.UpdatedAt,
+		plan.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+// Delete removes a medication plan. Dose activities keep their details and
+// lose the reference.
+func (r *PostgresMedicationRepository) Delete(id int64) error {
+	result, err := r.db.Exec(`DELETE FROM medication_plans WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+// GetDoseTimes returns the times of the doses logged against a plan between
+// from and to, oldest first
+func (r *PostgresMedicationRepository) GetDoseTimes(planID int64, from, to time.Time, excludeActivityID int64) ([]time.Time, error) {
+	query := `
+		SELECT happens_at
+		FROM activities
+		WHERE medication_plan_id = $1 AND happens_at BETWEEN $2 AND $3 AND id <> $4
+		ORDER BY happens_at
+	`
+
+	rows, err := r.db.Query(query, planID, from, to, excludeActivityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var times []time.Time
+	for rows.Next() {
+		var happensAt time.Time
+		if err := rows.Scan(&happensAt); err != nil {
+			return nil, err
+		}
+		times = append(times, happensAt)
+	}
+
+	return times, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPlan(row rowScanner, plan *domain.Plan) error {
+	return row.Scan(
+		&plan.ID,
+		&plan.ChildID,
+		&plan.UserID,
+		&plan.DrugName,
+		&plan.Dose,
+		&plan.Unit,
+		&plan.IntervalMinutes,
+		&plan.MaxDosesPer24h,
+		&plan.StartsAt,
+		&plan.EndsAt,
+		&plan.Note,
+		&plan.CreatedAt,
+		&plan.UpdatedAt,
+	)
+}
+
+func expectAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
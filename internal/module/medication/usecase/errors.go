@@ -0,0 +1,11 @@
+package usecase
+
+import "errors"
+
+// Domain errors for medication module
+var (
+	ErrPlanNotFound      = errors.New("medication plan not found")
+	ErrInvalidPlanPeriod = errors.New("medication plan must end after it starts")
+	ErrInvalidPlanTime   = errors.New("invalid medication plan time")
+	ErrDailyMaxExceeded  = errors.New("dose would exceed the maximum doses per 24 hours")
+)
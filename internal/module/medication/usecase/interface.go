@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/medication/domain"
+)
+
+// IMedicationUseCase defines the interface for medication plan business logic
+type IMedicationUseCase interface {
+	CreatePlan(ctx context.Context, req *domain.CreatePlanRequest) (*domain.Plan, error)
+	GetPlan(ctx context.Context, childID, id int64, userID string) (*domain.Plan, error)
+	GetPlans(ctx context.Context, childID int64, userID string) ([]domain.Plan, error)
+	UpdatePlan(ctx context.Context, req *domain.UpdatePlanRequest) (*domain.Plan, error)
+	DeletePlan(ctx context.Context, childID, id int64, userID string) error
+	CheckDose(ctx context.Context, req *domain.DoseCheckRequest) ([]string, error)
+}
@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"context"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	"dailyalu-server/internal/module/medication/domain"
+	"dailyalu-server/internal/module/medication/repository"
+	"dailyalu-server/internal/utils"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// doseWindow is the rolling period the daily maximum applies to
+const doseWindow = 24 * time.Hour
+
+// MedicationUseCase implements the medication use case interface
+type MedicationUseCase struct {
+	medicationRepo  repository.IMedicationRepository
+	childrenUseCase childrenUsecase.IChildrenUseCase
+	now             func() time.Time
+}
+
+// NewMedicationUseCase creates a new medication use case
+func NewMedicationUseCase(medicationRepo repository.IMedicationRepository, childrenUseCase childrenUsecase.IChildrenUseCase) IMedicationUseCase {
+	return &MedicationUseCase{
+		medicationRepo:  medicationRepo,
+		childrenUseCase: childrenUseCase,
+		now:             time.Now,
+	}
+}
+
+// CreatePlan adds a medication plan for a child
+func (uc *MedicationUseCase) CreatePlan(ctx context.Context, req *domain.CreatePlanRequest) (*domain.Plan, error) {
+	if _, err := uc.childrenUseCase.GetChild(req.ChildID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	plan := &domain.Plan{
+		ChildID: req.ChildID,
+		UserID:  req.UserID,
+	}
+	if err := applyPlanRequest(ctx, plan, req); err != nil {
+		return nil, err
+	}
+
+	if err := uc.medicationRepo.Create(plan); err != nil {
+		return nil, err
+	}
+
+	return uc.present(ctx, plan)
+}
+
+// GetPlan retrieves a medication plan with its next allowed dose
+func (uc *MedicationUseCase) GetPlan(ctx context.Context, childID, id int64, userID string) (*domain.Plan, error) {
+	plan, err := uc.getOwnedPlan(childID, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.present(ctx, plan)
+}
+
+// GetPlans retrieves the medication plans of a child
+func (uc *MedicationUseCase) GetPlans(ctx context.Context, childID int64, userID string) ([]domain.Plan, error) {
+	if _, err := uc.childrenUseCase.GetChild(childID, userID); err != nil {
+		return nil, err
+	}
+
+	plans, err := uc.medicationRepo.GetByChildID(childID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range plans {
+		if _, err := uc.present(ctx, &plans[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return plans, nil
+}
+
+// UpdatePlan replaces the details of a medication plan
+func (uc *MedicationUseCase) UpdatePlan(ctx context.Context, req *domain.UpdatePlanRequest) (*domain.Plan, error) {
+	plan, err := uc.getOwnedPlan(req.ChildID, req.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyPlanRequest(ctx, plan, &req.CreatePlanRequest); err != nil {
+		return nil, err
+	}
+
+	if err := uc.medicationRepo.Update(plan); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlanNotFound
+		}
+		return nil, err
+	}
+
+	return uc.present(ctx, plan)
+}
+
+// DeletePlan removes a medication plan
+func (uc *MedicationUseCase) DeletePlan(ctx context.Context, childID, id int64, userID string) error {
+	if _, err := uc.getOwnedPlan(childID, id, userID); err != nil {
+		return err
+	}
+
+	if err := uc.medicationRepo.Delete(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlanNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// CheckDose validates a dose about to be logged against a plan. A dose that
+// would exceed the maximum per 24 hours is rejected unless the caller allows
+// it; softer problems are returned as warnings.
+func (uc *MedicationUseCase) CheckDose(ctx context.Context, req *domain.DoseCheckRequest) ([]string, error) {
+	plan, err := uc.getOwnedPlan(req.ChildID, req.PlanID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	span := doseWindow
+	if plan.Interval() > span {
+		span = plan.Interval()
+	}
+
+	times, err := uc.medicationRepo.GetDoseTimes(plan.ID, req.At.Add(-span), req.At.Add(span), req.ExcludeActivityID)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	if req.At.Before(plan.StartsAt) || (plan.EndsAt != nil && req.At.After(*plan.EndsAt)) {
+		warnings = append(warnings, "dose is outside the period of the medication plan")
+	}
+
+	for _, t := range times {
+		if absDuration(req.At.Sub(t)) < plan.Interval() {
+			warnings = append(warnings, fmt.Sprintf("dose is less than %s after another dose", formatInterval(plan.Interval())))
+			break
+		}
+	}
+
+	if exceedsDailyMax(times, req.At, plan.MaxDosesPer24h) {
+		if !req.AllowExceedingMax {
+			return nil, ErrDailyMaxExceeded
+		}
+		warnings = append(warnings, fmt.Sprintf("dose exceeds the maximum of %d doses per 24 hours", plan.MaxDosesPer24h))
+	}
+
+	return warnings, nil
+}
+
+// present moves the plan times to the user's time zone and computes the next
+// allowed dose
+func (uc *MedicationUseCase) present(ctx context.Context, plan *domain.Plan) (*domain.Plan, error) {
+	now := uc.now()
+
+	span := doseWindow
+	if plan.Interval() > span {
+		span = plan.Interval()
+	}
+
+	times, err := uc.medicationRepo.GetDoseTimes(plan.ID, now.Add(-span), now, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := utils.LocationFromContext(ctx)
+	next := nextDose(plan, times, now)
+	next.AllowedAt = next.AllowedAt.In(loc)
+	if next.LastDoseAt != nil {
+		last := next.LastDoseAt.In(loc)
+		next.LastDoseAt = &last
+	}
+	plan.NextDose = next
+
+	plan.StartsAt = plan.StartsAt.In(loc)
+	if plan.EndsAt != nil {
+		endsAt := plan.EndsAt.In(loc)
+		plan.EndsAt = &endsAt
+	}
+	plan.CreatedAt = plan.CreatedAt.In(loc)
+	plan.UpdatedAt = plan.UpdatedAt.In(loc)
+
+	return plan, nil
+}
+
+func (uc *MedicationUseCase) getOwnedPlan(childID, id int64, userID string) (*domain.Plan, error) {
+	if _, err := uc.childrenUseCase.GetChild(childID, userID); err != nil {
+		return nil, err
+	}
+
+	plan, err := uc.medicationRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil || plan.ChildID != childID {
+		return nil, ErrPlanNotFound
+	}
+
+	return plan, nil
+}
+
+func applyPlanRequest(ctx context.Context, plan *domain.Plan, req *domain.CreatePlanRequest) error {
+	startsAt, err := utils.TimeLocationParsing(ctx, req.StartsAt)
+	if err != nil {
+		return ErrInvalidPlanTime
+	}
+
+	var endsAt *time.Time
+	if req.EndsAt != "" {
+		parsed, err := utils.TimeLocationParsing(ctx, req.EndsAt)
+		if err != nil {
+			return ErrInvalidPlanTime
+		}
+		if !parsed.After(startsAt) {
+			return ErrInvalidPlanPeriod
+		}
+		endsAt = &parsed
+	}
+
+	plan.DrugName = req.DrugName
+	plan.Dose = req.Dose
+	plan.Unit = req.Unit
+	plan.IntervalMinutes = req.IntervalMinutes
+	plan.MaxDosesPer24h = req.MaxDosesPer24h
+	plan.StartsAt = startsAt
+	plan.EndsAt = endsAt
+	plan.Note = req.Note
+
+	return nil
+}
+
+// nextDose computes when the next dose is allowed given the doses logged up
+// to now, oldest first. The dose must wait for the interval since the last
+// dose and, when the daily maximum is reached, for the oldest dose of the
+// last 24 hours to leave the window.
+func nextDose(plan *domain.Plan, times []time.Time, now time.Time) *domain.NextDose {
+	next := &domain.NextDose{
+		AllowedAt:      plan.StartsAt,
+		MaxDosesPer24h: plan.MaxDosesPer24h,
+	}
+
+	if len(times) > 0 {
+		last := times[len(times)-1]
+		next.LastDoseAt = &last
+		if after := last.Add(plan.Interval()); after.After(next.AllowedAt) {
+			next.AllowedAt = after
+		}
+	}
+
+	var window []time.Time
+	for _, t := range times {
+		if t.After(now.Add(-doseWindow)) {
+			window = append(window, t)
+		}
+	}
+	next.DosesLast24h = len(window)
+
+	if len(window) >= plan.MaxDosesPer24h {
+		if after := window[len(window)-plan.MaxDosesPer24h].Add(doseWindow); after.After(next.AllowedAt) {
+			next.AllowedAt = after
+		}
+	}
+
+	next.Ended = plan.EndsAt != nil && (now.After(*plan.EndsAt) || next.AllowedAt.After(*plan.EndsAt))
+	next.AllowedNow = !next.Ended && !next.AllowedAt.After(now)
+
+	return next
+}
+
+// exceedsDailyMax reports whether adding a dose at the given time puts more
+// than max doses in any 24 hour window containing it
+func exceedsDailyMax(times []time.Time, at time.Time, max int) bool {
+	all := append([]time.Time{at}, times...)
+	sort.Slice(all, func(i, j int) bool { return all[i].Before(all[j]) })
+
+	for i, start := range all {
+		end := start.Add(doseWindow)
+		if at.Before(start) || !at.Before(end) {
+			continue
+		}
+		count := 0
+		for _, t := range all[i:] {
+			if !t.Before(end) {
+				break
+			}
+			count++
+		}
+		if count > max {
+			return true
+		}
+	}
+
+	return false
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+func formatInterval(d time.Duration) string {
+	if d == time.Hour {
+		return "1 hour"
+	}
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%d hours", int(d.Hours()))
+	}
+	return fmt.Sprintf("%d minutes", int(d.Minutes()))
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/children/childrentest"
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	"dailyalu-server/internal/module/medication/domain"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockMedicationRepository is a mock implementation of the medication repository
+type MockMedicationRepository struct {
+	CreateFunc       func(plan *domain.Plan) error
+	GetByIDFunc      func(id int64) (*domain.Plan, error)
+	GetByChildIDFunc func(childID int64) ([]domain.Plan, error)
+	UpdateFunc       func(plan *domain.Plan) error
+	DeleteFunc       func(id int64) error
+	Doses            []time.Time
+}
+
+func (m *MockMedicationRepository) Create(plan *domain.Plan) error {
+	return m.CreateFunc(plan)
+}
+
+func (m *MockMedicationRepository) GetByID(id int64) (*domain.Plan, error) {
+	return m.GetByIDFunc(id)
+}
+
+func (m *MockMedicationRepository) GetByChildID(childID int64) ([]domain.Plan, error) {
+	return m.GetByChildIDFunc(childID)
+}
+
+func (m *MockMedicationRepository) Update(plan *domain.Plan) error {
+	return m.UpdateFunc(plan)
+}
+
+func (m *MockMedicationRepository) Delete(id int64) error {
+	return m.DeleteFunc(id)
+}
+
+// GetDoseTimes filters the mock's doses like the database query does
+func (m *MockMedicationRepository) GetDoseTimes(planID int64, from, to time.Time, excludeActivityID int64) ([]time.Time, error) {
+	var times []time.Time
+	for _, t := range m.Doses {
+		if !t.Before(from) && !t.After(to) {
+			times = append(times, t)
+		}
+	}
+	return times, nil
+}
+
+var testNow = time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)
+
+// newTestUseCase serves a paracetamol plan of at most four doses per day,
+// every four hours, to child 1
+func newTestUseCase(repo *MockMedicationRepository) *MedicationUseCase {
+	repo.GetByIDFunc = func(id int64) (*domain.Plan, error) {
+		if id != 3 {
+			return nil, nil
+		}
+		return &domain.Plan{
+			ID:              3,
+			ChildID:         1,
+			DrugName:        "Paracetamol",
+			Dose:            2.5,
+			Unit:            "ml",
+			IntervalMinutes: 240,
+			MaxDosesPer24h:  4,
+			StartsAt:        testNow.Add(-72 * time.Hour),
+		}, nil
+	}
+	return &MedicationUseCase{
+		medicationRepo:  repo,
+		childrenUseCase: &childrentest.UseCase{Child: &childrenDomain.Child{ID: 1, UserID: "user-1"}},
+		now:             childrentest.Clock(testNow),
+	}
+}
+
+func hoursAgo(hours ...float64) []time.Time {
+	times := make([]time.Time, len(hours))
+	for i, h := range hours {
+		times[i] = testNow.Add(-time.Duration(h * float64(time.Hour)))
+	}
+	return times
+}
+
+func TestGetPlanNextDose(t *testing.T) {
+	testCases := []struct {
+		name       string
+		doses      []time.Time
+		allowedAt  time.Time
+		allowedNow bool
+		last24h    int
+	}{
+		{"no doses", nil, testNow.Add(-72 * time.Hour), true, 0},
+		{"interval passed", hoursAgo(5), testNow.Add(-time.Hour), true, 1},
+		{"within interval", hoursAgo(1), testNow.Add(3 * time.Hour), false, 1},
+		{"daily maximum reached", hoursAgo(20, 15, 10, 5), testNow.Add(4 * time.Hour), false, 4},
+		{"old doses leave the window", hoursAgo(26, 20, 15, 5), testNow.Add(-time.Hour), true, 3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := newTestUseCase(&MockMedicationRepository{Doses: tc.doses})
+
+			plan, err := uc.GetPlan(context.Background(), 1, 3, "user-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			next := plan.NextDose
+			if !next.AllowedAt.Equal(tc.allowedAt) || next.AllowedNow != tc.allowedNow || next.DosesLast24h != tc.last24h {
+				t.Errorf("expected allowed at %v (%v, %d doses), got %+v", tc.allowedAt, tc.allowedNow, tc.last24h, next)
+			}
+		})
+	}
+}
+
+func TestCheckDose(t *testing.T) {
+	repo := &MockMedicationRepository{Doses: hoursAgo(20, 15, 10, 5)}
+	uc := newTestUseCase(repo)
+	ctx := context.Background()
+
+	req := &domain.DoseCheckRequest{UserID: "user-1", ChildID: 1, PlanID: 3, At: testNow}
+	if _, err := uc.CheckDose(ctx, req); !errors.Is(err, ErrDailyMaxExceeded) {
+		t.Fatalf("expected ErrDailyMaxExceeded, got %v", err)
+	}
+
+	req.AllowExceedingMax = true
+	warnings, err := uc.CheckDose(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("expected a warning about the daily maximum, got %v", warnings)
+	}
+
+	// A dose after the oldest one left the window is only early
+	req = &domain.DoseCheckRequest{UserID: "user-1", ChildID: 1, PlanID: 3, At: testNow}
+	repo.Doses = hoursAgo(26, 20, 10, 2)
+	warnings, err = uc.CheckDose(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0] != "dose is less than 4 hours after another dose" {
+		t.Errorf("expected a warning about the interval, got %v", warnings)
+	}
+
+	req.PlanID = 4
+	if _, err := uc.CheckDose(ctx, req); !errors.Is(err, ErrPlanNotFound) {
+		t.Errorf("expected ErrPlanNotFound, got %v", err)
+	}
+
+	req.PlanID = 3
+	req.UserID = "user-2"
+	if _, err := uc.CheckDose(ctx, req); !errors.Is(err, childrenUsecase.ErrUnauthorizedAccess) {
+		t.Errorf("expected ErrUnauthorizedAccess, got %v", err)
+	}
+}
+
+func TestCreatePlanRejectsInvalidPeriod(t *testing.T) {
+	uc := newTestUseCase(&MockMedicationRepository{})
+
+	_, err := uc.CreatePlan(context.Background(), &domain.CreatePlanRequest{
+		ChildID:         1,
+		UserID:          "user-1",
+		DrugName:        "Ibuprofen",
+		Dose:            5,
+		Unit:            "ml",
+		IntervalMinutes: 360,
+		MaxDosesPer24h:  3,
+		StartsAt:        "2025-03-20T08:00:00",
+		EndsAt:          "2025-03-19T08:00:00",
+	})
+	if !errors.Is(err, ErrInvalidPlanPeriod) {
+		t.Errorf("expected ErrInvalidPlanPeriod, got %v", err)
+	}
+}
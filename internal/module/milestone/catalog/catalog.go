@@ -0,0 +1,59 @@
+// Package catalog provides the built-in developmental milestones, based on
+// the CDC "Learn the Signs. Act Early." checklists.
+package catalog
+
+import (
+	"dailyalu-server/internal/module/milestone/domain"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed data/milestones.json
+var milestonesJSON []byte
+
+var (
+	groups = mustParse(milestonesJSON)
+	byID   = index(groups)
+)
+
+func mustParse(data []byte) []domain.MilestoneGroup {
+	var parsed []domain.MilestoneGroup
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		panic(fmt.Sprintf("catalog: invalid milestones: %v", err))
+	}
+	return parsed
+}
+
+func index(groups []domain.MilestoneGroup) map[string]domain.Milestone {
+	milestones := make(map[string]domain.Milestone)
+	for _, group := range groups {
+		for _, milestone := range group.Milestones {
+			if _, exists := milestones[milestone.ID]; exists {
+				panic(fmt.Sprintf("catalog: duplicate milestone %q", milestone.ID))
+			}
+			milestones[milestone.ID] = milestone
+		}
+	}
+	return milestones
+}
+
+// Groups returns the catalog grouped by age range, youngest first
+func Groups() []domain.MilestoneGroup {
+	return groups
+}
+
+// Get returns a milestone by ID
+func Get(id string) (domain.Milestone, bool) {
+	milestone, ok := byID[id]
+	return milestone, ok
+}
+
+// All returns every milestone in catalog order
+func All() []domain.Milestone {
+	milestones := make([]domain.Milestone, 0, len(byID))
+	for _, group := range groups {
+		milestones = append(milestones, group.Milestones...)
+	}
+	return milestones
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	"time"
+)
+
+// Milestone categories
+const (
+	CategorySocial    = "social"
+	CategoryLanguage  = "language"
+	CategoryCognitive = "cognitive"
+	CategoryMotor     = "motor"
+)
+
+// Milestone is a catalog entry with the age range in which most children
+// reach it
+type Milestone struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Category  string `json:"category"`
+	MinMonths int    `json:"min_months"`
+	MaxMonths int    `json:"max_months"`
+}
+
+// MilestoneGroup is a section of the catalog covering an age range
+type MilestoneGroup struct {
+	Label      string      `json:"label"`
+	MinMonths  int         `json:"min_months"`
+	MaxMonths  int         `json:"max_months"`
+	Milestones []Milestone `json:"milestones"`
+}
+
+// Achievement records the day a child reached a milestone
+type Achievement struct {
+	ID          int64               `json:"id"`
+	ChildID     int64               `json:"child_id"`
+	UserID      string              `json:"user_id"`
+	MilestoneID string              `json:"milestone_id"`
+	AchievedOn  childrenDomain.Date `json:"achieved_on"`
+	Note        string              `json:"note,omitempty"`
+	Milestone   *Milestone          `json:"milestone,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// RecordAchievementRequest represents the request to record or update an achievement
+type RecordAchievementRequest struct {
+	ChildID     int64                `json:"-"`
+	UserID      string               `json:"-"`
+	MilestoneID string               `json:"-"`
+	AchievedOn  *childrenDomain.Date `json:"achieved_on" validate:"required"`
+	Note        string               `json:"note,omitempty" validate:"max=500"`
+}
+
+// ProgressResponse lists the milestones a child has not reached yet. Upcoming
+// milestones are due now or within the next few months, overdue ones are past
+// the end of their typical age range.
+type ProgressResponse struct {
+	ChildID   int64       `json:"child_id"`
+	AgeMonths int         `json:"age_months"`
+	Corrected bool        `json:"corrected"`
+	Achieved  int         `json:"achieved"`
+	Upcoming  []Milestone `json:"upcoming"`
+	Overdue   []Milestone `json:"overdue"`
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/milestone/domain"
+)
+
+// IMilestoneRepository defines the interface for milestone achievement data access
+type IMilestoneRepository interface {
+	Upsert(achievement *domain.Achievement) error
+	GetByChildID(childID int64) ([]domain.Achievement, error)
+	Delete(childID int64, milestoneID string) (bool, error)
+}
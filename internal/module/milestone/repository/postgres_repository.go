@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/milestone/domain"
+	"database/sql"
+	"time"
+)
+
+// PostgresMilestoneRepository implements the milestone repository interface using PostgreSQL
+type PostgresMilestoneRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresMilestoneRepository creates a new PostgreSQL milestone repository
+func NewPostgresMilestoneRepository(db *sql.DB) IMilestoneRepository {
+	return &PostgresMilestoneRepository{
+		db: db,
+	}
+}
+
+// Upsert records an achievement, replacing the date and note when the child
+// already reached the milestone
+func (r *PostgresMilestoneRepository) Upsert(achievement *domain.Achievement) error {
+	query := `
+		INSERT INTO milestone_achievements (child_id, user_id, milestone_id, achieved_on, note, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (child_id, milestone_id) DO UPDATE SET
+			achieved_on = EXCLUDED.achieved_on,
+			note = EXCLUDED.note,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at
+	`
+
+	now := time.Now()
+	achievement.UpdatedAt = now
+
+	return r.db.QueryRow(
+		query,
+		achievement.ChildID,
+		achievement.UserID,
+		achievement.MilestoneID,
+		achievement.AchievedOn,
+		achievement.Note,
+		now,
+		achievement.UpdatedAt,
+	).Scan(&achievement.ID, &achievement.CreatedAt)
+}
+
+// GetByChildID retrieves the achievements of a child, oldest first
+func (r *PostgresMilestoneRepository) GetByChildID(childID int64) ([]domain.Achievement, error) {
+	query := `
+		SELECT id, child_id, user_id, milestone_id, achieved_on, note, created_at, updated_at
+		FROM milestone_achievements
+		WHERE child_id = $1
+		ORDER BY achieved_on, id
+	`
+
+	rows, err := r.db.Query(query, childID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	achievements := []domain.Achievement{}
+	for rows.Next() {
+		var achievement domain.Achievement
+		err := rows.Scan(
+			&achievement.ID,
+			&achievement.ChildID,
+			&achievement.UserID,
+			&achievement.MilestoneID,
+			&achievement.AchievedOn,
+			&achievement.Note,
+			&achievement.CreatedAt,
+			&achievement.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		achievements = append(achievements, achievement)
+	}
+
+	return achievements, rows.Err()
+}
+
+// Delete removes an achievement and reports whether it existed
+func (r *PostgresMilestoneRepository) Delete(childID int64, milestoneID string) (bool, error) {
+	result, err := r.db.Exec(`DELETE FROM milestone_achievements WHERE child_id = $1 AND milestone_id = $2`, childID, milestoneID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
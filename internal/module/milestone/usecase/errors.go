@@ -0,0 +1,11 @@
+package usecase
+
+import "errors"
+
+// Domain errors for milestone module
+var (
+	ErrMilestoneNotFound      = errors.New("milestone not found")
+	ErrAchievementNotFound    = errors.New("milestone achievement not found")
+	ErrInvalidAchievementDate = errors.New("achievement date must be between the date of birth and today")
+	ErrDateOfBirthRequired    = errors.New("child date of birth is required for milestone progress")
+)
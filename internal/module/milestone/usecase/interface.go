@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"dailyalu-server/internal/module/milestone/domain"
+)
+
+// IMilestoneUseCase defines the interface for milestone business logic
+type IMilestoneUseCase interface {
+	GetCatalog() []domain.MilestoneGroup
+	RecordAchievement(req *domain.RecordAchievementRequest) (*domain.Achievement, error)
+	GetAchievements(childID int64, userID string) ([]domain.Achievement, error)
+	DeleteAchievement(childID int64, milestoneID, userID string) error
+	GetProgress(childID int64, userID string) (*domain.ProgressResponse, error)
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	"dailyalu-server/internal/module/milestone/catalog"
+	"dailyalu-server/internal/module/milestone/domain"
+	"dailyalu-server/internal/module/milestone/repository"
+	"time"
+)
+
+// upcomingWindowMonths is how far ahead milestones are listed as upcoming
+const upcomingWindowMonths = 2
+
+// MilestoneUseCase implements the milestone business logic
+type MilestoneUseCase struct {
+	milestoneRepo   repository.IMilestoneRepository
+	childrenUseCase childrenUsecase.IChildrenUseCase
+	now             func() time.Time
+}
+
+// NewMilestoneUseCase creates a new milestone use case
+func NewMilestoneUseCase(milestoneRepo repository.IMilestoneRepository, childrenUseCase childrenUsecase.IChildrenUseCase) IMilestoneUseCase {
+	return &MilestoneUseCase{
+		milestoneRepo:   milestoneRepo,
+		childrenUseCase: childrenUseCase,
+		now:             time.Now,
+	}
+}
+
+// GetCatalog returns the built-in milestones grouped by age range
+func (u *MilestoneUseCase) GetCatalog() []domain.MilestoneGroup {
+	return catalog.Groups()
+}
+
+// RecordAchievement records that a child reached a milestone. Recording a
+// milestone again updates its date and note.
+func (u *MilestoneUseCase) RecordAchievement(req *domain.RecordAchievementRequest) (*domain.Achievement, error) {
+	milestone, ok := catalog.Get(req.MilestoneID)
+	if !ok {
+		return nil, ErrMilestoneNotFound
+	}
+
+	child, err := u.childrenUseCase.GetChild(req.ChildID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	achievedOn := *req.AchievedOn
+	if achievedOn.After(childrenDomain.NewDate(u.now()).Time) ||
+		(child.DateOfBirth != nil && achievedOn.Before(child.DateOfBirth.Time)) {
+		return nil, ErrInvalidAchievementDate
+	}
+
+	achievement := &domain.Achievement{
+		ChildID:     child.ID,
+		UserID:      req.UserID,
+		MilestoneID: milestone.ID,
+		AchievedOn:  achievedOn,
+		Note:        req.Note,
+	}
+
+	if err := u.milestoneRepo.Upsert(achievement); err != nil {
+		return nil, err
+	}
+
+	achievement.Milestone = &milestone
+	return achievement, nil
+}
+
+// GetAchievements retrieves the milestones a child has reached, oldest first
+func (u *MilestoneUseCase) GetAchievements(childID int64, userID string) ([]domain.Achievement, error) {
+	child, err := u.childrenUseCase.GetChild(childID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	achievements, err := u.milestoneRepo.GetByChildID(child.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range achievements {
+		// Achievements of milestones removed from the catalog keep their ID only
+		if milestone, ok := catalog.Get(achievements[i].MilestoneID); ok {
+			achievements[i].Milestone = &milestone
+		}
+	}
+	return achievements, nil
+}
+
+// DeleteAchievement removes the achievement of a milestone
+func (u *MilestoneUseCase) DeleteAchievement(childID int64, milestoneID, userID string) error {
+	if _, err := u.childrenUseCase.GetChild(childID, userID); err != nil {
+		return err
+	}
+
+	deleted, err := u.milestoneRepo.Delete(childID, milestoneID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrAchievementNotFound
+	}
+	return nil
+}
+
+// GetProgress lists the milestones a child has not reached yet that are due
+// soon or overdue for their age. Premature babies are compared at their
+// corrected age.
+func (u *MilestoneUseCase) GetProgress(childID int64, userID string) (*domain.ProgressResponse, error) {
+	child, err := u.childrenUseCase.GetChild(childID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if child.Age == nil {
+		return nil, ErrDateOfBirthRequired
+	}
+
+	achievements, err := u.milestoneRepo.GetByChildID(child.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	achieved := make(map[string]bool, len(achievements))
+	for _, achievement := range achievements {
+		achieved[achievement.MilestoneID] = true
+	}
+
+	progress := &domain.ProgressResponse{
+		ChildID:   child.ID,
+		AgeMonths: child.Age.Months,
+		Achieved:  len(achievements),
+		Upcoming:  []domain.Milestone{},
+		Overdue:   []domain.Milestone{},
+	}
+	if child.Age.CorrectedMonths != nil {
+		progress.AgeMonths = *child.Age.CorrectedMonths
+		progress.Corrected = true
+	}
+
+	for _, milestone := range catalog.All() {
+		if achieved[milestone.ID] {
+			continue
+		}
+		switch {
+		case progress.AgeMonths > milestone.MaxMonths:
+			progress.Overdue = append(progress.Overdue, milestone)
+		case milestone.MinMonths <= progress.AgeMonths+upcomingWindowMonths:
+			progress.Upcoming = append(progress.Upcoming, milestone)
+		}
+	}
+
+	return progress, nil
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"dailyalu-server/internal/module/children/childrentest"
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	"dailyalu-server/internal/module/milestone/domain"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockMilestoneRepository is a mock implementation of the milestone repository
+type MockMilestoneRepository struct {
+	UpsertFunc       func(achievement *domain.Achievement) error
+	GetByChildIDFunc func(childID int64) ([]domain.Achievement, error)
+	DeleteFunc       func(childID int64, milestoneID string) (bool, error)
+}
+
+func (m *MockMilestoneRepository) Upsert(achievement *domain.Achievement) error {
+	return m.UpsertFunc(achievement)
+}
+
+func (m *MockMilestoneRepository) GetByChildID(childID int64) ([]domain.Achievement, error) {
+	return m.GetByChildIDFunc(childID)
+}
+
+func (m *MockMilestoneRepository) Delete(childID int64, milestoneID string) (bool, error) {
+	return m.DeleteFunc(childID, milestoneID)
+}
+
+func newTestUseCase(repo *MockMilestoneRepository, child *childrenDomain.Child) *MilestoneUseCase {
+	return &MilestoneUseCase{
+		milestoneRepo:   repo,
+		childrenUseCase: &childrentest.UseCase{Child: child},
+		now:             childrentest.Clock(time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)),
+	}
+}
+
+func milestoneIDs(milestones []domain.Milestone) map[string]bool {
+	ids := make(map[string]bool, len(milestones))
+	for _, milestone := range milestones {
+		ids[milestone.ID] = true
+	}
+	return ids
+}
+
+func TestRecordAchievement(t *testing.T) {
+	dob := childrentest.MustDate(t, "2025-01-01")
+	child := &childrenDomain.Child{ID: 1, UserID: "user-1", BirthProfile: childrenDomain.BirthProfile{DateOfBirth: &dob}}
+
+	var saved *domain.Achievement
+	repo := &MockMilestoneRepository{
+		UpsertFunc: func(achievement *domain.Achievement) error {
+			achievement.ID = 3
+			saved = achievement
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo, child)
+
+	achievedOn := childrentest.MustDate(t, "2025-02-20")
+	achievement, err := uc.RecordAchievement(&domain.RecordAchievementRequest{
+		ChildID:     1,
+		UserID:      "user-1",
+		MilestoneID: "social_smile",
+		AchievedOn:  &achievedOn,
+		Note:        "At grandma's",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved == nil || saved.MilestoneID != "social_smile" || saved.Note != "At grandma's" {
+		t.Fatalf("unexpected saved achievement: %+v", saved)
+	}
+	if achievement.Milestone == nil || achievement.Milestone.Category != domain.CategorySocial {
+		t.Errorf("expected the catalog entry to be attached, got %+v", achievement.Milestone)
+	}
+
+	testCases := []struct {
+		name        string
+		milestoneID string
+		achievedOn  string
+		expected    error
+	}{
+		{"unknown milestone", "juggles", "2025-02-20", ErrMilestoneNotFound},
+		{"before birth", "social_smile", "2024-12-31", ErrInvalidAchievementDate},
+		{"in the future", "social_smile", "2025-06-02", ErrInvalidAchievementDate},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			achievedOn := childrentest.MustDate(t, tc.achievedOn)
+			_, err := uc.RecordAchievement(&domain.RecordAchievementRequest{
+				ChildID:     1,
+				UserID:      "user-1",
+				MilestoneID: tc.milestoneID,
+				AchievedOn:  &achievedOn,
+			})
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetProgress(t *testing.T) {
+	child := &childrenDomain.Child{ID: 1, UserID: "user-1", Age: &childrenDomain.ChildAge{Days: 190, Weeks: 27, Months: 6}}
+
+	repo := &MockMilestoneRepository{
+		GetByChildIDFunc: func(childID int64) ([]domain.Achievement, error) {
+			return []domain.Achievement{
+				{ChildID: 1, MilestoneID: "social_smile"},
+				{ChildID: 1, MilestoneID: "rolls_tummy_to_back"},
+			}, nil
+		},
+	}
+	uc := newTestUseCase(repo, child)
+
+	progress, err := uc.GetProgress(1, "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if progress.AgeMonths != 6 || progress.Corrected || progress.Achieved != 2 {
+		t.Errorf("unexpected progress header: %+v", progress)
+	}
+
+	overdue := milestoneIDs(progress.Overdue)
+	upcoming := milestoneIDs(progress.Upcoming)
+
+	if !overdue["holds_head_up"] || overdue["social_smile"] {
+		t.Errorf("unexpected overdue milestones: %v", overdue)
+	}
+	if !upcoming["babbles"] || !upcoming["crawls"] || upcoming["rolls_tummy_to_back"] {
+		t.Errorf("unexpected upcoming milestones: %v", upcoming)
+	}
+	if upcoming["walks_alone"] || overdue["walks_alone"] {
+		t.Error("expected milestones beyond the window to be left out")
+	}
+}
+
+func TestGetProgressUsesCorrectedAge(t *testing.T) {
+	child := &childrenDomain.Child{ID: 1, UserID: "user-1", Age: &childrenDomain.ChildAge{Months: 6, CorrectedMonths: childrentest.IntPtr(4)}}
+	repo := &MockMilestoneRepository{
+		GetByChildIDFunc: func(childID int64) ([]domain.Achievement, error) {
+			return []domain.Achievement{}, nil
+		},
+	}
+	uc := newTestUseCase(repo, child)
+
+	progress, err := uc.GetProgress(1, "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if progress.AgeMonths != 4 || !progress.Corrected {
+		t.Errorf("expected corrected age 4, got %+v", progress)
+	}
+	if milestoneIDs(progress.Overdue)["laughs"] {
+		t.Error("expected laughs not to be overdue at a corrected age of 4 months")
+	}
+
+	child.Age = nil
+	if _, err := uc.GetProgress(1, "user-1"); !errors.Is(err, ErrDateOfBirthRequired) {
+		t.Errorf("expected ErrDateOfBirthRequired, got %v", err)
+	}
+}
+
+func TestDeleteAchievementNotRecorded(t *testing.T) {
+	child := &childrenDomain.Child{ID: 1, UserID: "user-1"}
+	repo := &MockMilestoneRepository{
+		DeleteFunc: func(childID int64, milestoneID string) (bool, error) {
+			return false, nil
+		},
+	}
+	uc := newTestUseCase(repo, child)
+
+	if err := uc.DeleteAchievement(1, "crawls", "user-1"); !errors.Is(err, ErrAchievementNotFound) {
+		t.Errorf("expected ErrAchievementNotFound, got %v", err)
+	}
+	if err := uc.DeleteAchievement(1, "crawls", "user-2"); !errors.Is(err, childrenUsecase.ErrUnauthorizedAccess) {
+		t.Errorf("expected ErrUnauthorizedAccess, got %v", err)
+	}
+}
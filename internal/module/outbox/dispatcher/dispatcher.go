@@ -0,0 +1,122 @@
+// Package dispatcher hands outbox messages to their handlers in the
+// background, using the lease and retry runner of package worker.
+package dispatcher
+
+import (
+	"context"
+	"dailyalu-server/internal/module/outbox/domain"
+	"dailyalu-server/internal/module/outbox/repository"
+	"dailyalu-server/internal/service/worker"
+	"dailyalu-server/pkg/app_log/zap_log"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Handler performs the work a message asks for. Returning an error retries
+// the message later.
+type Handler func(ctx context.Context, message *domain.Message) error
+
+// ErrPermanent marks errors that retrying cannot fix, such as a payload that
+// does not decode. Handlers wrap it to dead-letter a message at once.
+var ErrPermanent = worker.ErrPermanent
+
+// Config controls polling and retries. Timeout limits each attempt, e.g. one
+// SMTP send.
+type Config = worker.Config
+
+// NewConfigFromConfig reads the dispatcher settings under outbox
+func NewConfigFromConfig() Config {
+	return worker.NewConfigFromConfig("outbox")
+}
+
+// Dispatcher polls for due messages and passes them to the handler of their
+// topic
+type Dispatcher struct {
+	outboxRepo repository.IOutboxRepository
+	handlers   map[string]Handler
+	runner     *worker.Runner[domain.Message]
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewDispatcher creates an outbox dispatcher. Messages whose topic has no
+// handler are dead-lettered.
+func NewDispatcher(outboxRepo repository.IOutboxRepository, handlers map[string]Handler, config Config) *Dispatcher {
+	logger := zap_log.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	d := &Dispatcher{
+		outboxRepo: outboxRepo,
+		handlers:   handlers,
+		logger:     logger,
+		now:        time.Now,
+	}
+	d.runner = worker.NewRunner("outbox messages", worker.Jobs[domain.Message]{
+		Claim:    outboxRepo.ClaimDue,
+		Attempts: func(message *domain.Message) int { return message.Attempts },
+		Handle:   d.handle,
+		Record:   d.record,
+	}, config, func() time.Time { return d.now() })
+	return d
+}
+
+// Run handles due messages until the context is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	d.runner.Run(ctx)
+}
+
+// RunOnce claims one batch of due messages and handles them. It returns the
+// number of messages claimed.
+func (d *Dispatcher) RunOnce(ctx context.Context) (int, error) {
+	return d.runner.RunOnce(ctx)
+}
+
+// record stores the outcome of an attempt at a message
+func (d *Dispatcher) record(message *domain.Message, attempt worker.Attempt) {
+	message.Attempts = attempt.Number
+	message.LastAttemptAt = &attempt.StartedAt
+	message.NextAttemptAt = attempt.RetryAt
+	message.LastError = ""
+
+	switch {
+	case attempt.Err == nil:
+		message.Status = domain.MessageSent
+	case attempt.GaveUp():
+		message.Status = domain.MessageDead
+		message.LastError = attempt.Err.Error()
+	default:
+		message.Status = domain.MessagePending
+		message.LastError = attempt.Err.Error()
+	}
+
+	if attempt.Err != nil {
+		log := d.logger.Warn
+		if attempt.GaveUp() {
+			log = d.logger.Error
+		}
+		log("failed to handle outbox message",
+			zap.Int64("message_id", message.ID),
+			zap.String("topic", message.Topic),
+			zap.Int("attempt", message.Attempts),
+			zap.String("status", message.Status),
+			zap.Error(attempt.Err),
+		)
+	}
+
+	if err := d.outboxRepo.RecordAttempt(message, attempt.LeasedUntil); err != nil {
+		d.logger.Error("failed to record outbox message", zap.Int64("message_id", message.ID), zap.Error(err))
+	}
+}
+
+func (d *Dispatcher) handle(ctx context.Context, message *domain.Message) error {
+	handler, ok := d.handlers[message.Topic]
+	if !ok {
+		return fmt.Errorf("%w: no handler for topic %q", ErrPermanent, message.Topic)
+	}
+
+	return handler(ctx, message)
+}
@@ -0,0 +1,123 @@
+package dispatcher
+
+import (
+	"context"
+	"dailyalu-server/internal/module/outbox/domain"
+	mailerDomain "dailyalu-server/internal/service/mailer/domain"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockOutboxRepository serves claimed messages and records attempts
+type MockOutboxRepository struct {
+	Due      []domain.Message
+	Recorded map[int64]domain.Message
+}
+
+func (m *MockOutboxRepository) Create(message *domain.Message) error { return nil }
+
+func (m *MockOutboxRepository) GetMessage(id int64) (*domain.Message, error) { return nil, nil }
+
+func (m *MockOutboxRepository) GetMessages(status string, page, pageSize int) ([]domain.Message, int64, error) {
+	return nil, 0, nil
+}
+
+func (m *MockOutboxRepository) Requeue(id int64, at time.Time) error { return nil }
+
+func (m *MockOutboxRepository) Delete(id int64) error { return nil }
+
+func (m *MockOutboxRepository) ClaimDue(now, lockUntil time.Time, limit int) ([]domain.Message, error) {
+	due := m.Due[:min(limit, len(m.Due))]
+	m.Due = m.Due[len(due):]
+	return due, nil
+}
+
+func (m *MockOutboxRepository) RecordAttempt(message *domain.Message, lockedUntil time.Time) error {
+	m.Recorded[message.ID] = *message
+	return nil
+}
+
+// MockMailerService fails verification emails to unreachable addresses
+type MockMailerService struct {
+	Sent []*mailerDomain.EmailVerificationData
+}
+
+func (m *MockMailerService) SendVerificationEmail(ctx context.Context, data *mailerDomain.EmailVerificationData) error {
+	if data.To == "bounce@example.com" {
+		return errors.New("smtp: connection refused")
+	}
+	m.Sent = append(m.Sent, data)
+	return nil
+}
+
+func (m *MockMailerService) SendMagicLinkEmail(ctx context.Context, data *mailerDomain.MagicLinkEmailData) error {
+	return nil
+}
+
+func (m *MockMailerService) SendNotificationEmail(ctx context.Context, data *mailerDomain.NotificationEmailData) error {
+	return nil
+}
+
+func (m *MockMailerService) SendWeeklyDigestEmail(ctx context.Context, data *mailerDomain.WeeklyDigestEmailData) error {
+	return nil
+}
+
+func (m *MockMailerService) SendReportEmail(ctx context.Context, data *mailerDomain.ReportEmailData) error {
+	return nil
+}
+
+func TestRunOnce(t *testing.T) {
+	now := time.Date(2025, 3, 20, 8, 0, 0, 0, time.UTC)
+
+	message := func(id int64, topic string, payload string, attempts int) domain.Message {
+		return domain.Message{ID: id, Topic: topic, Payload: []byte(payload), Status: domain.MessagePending, Attempts: attempts}
+	}
+
+	repo := &MockOutboxRepository{
+		Due: []domain.Message{
+			message(1, domain.TopicVerificationEmail, `{"To":"ani@example.com","Name":"Ani","VerificationURL":"https://dailyalu.mom/verify/abc"}`, 0),
+			message(2, domain.TopicVerificationEmail, `{"To":"bounce@example.com"}`, 1),
+			message(3, domain.TopicVerificationEmail, `{"To":"bounce@example.com"}`, 2),
+			message(4, domain.TopicVerificationEmail, `"not an email"`, 0),
+			message(5, "sms.verification", `{}`, 0),
+		},
+		Recorded: map[int64]domain.Message{},
+	}
+	mailer := &MockMailerService{}
+
+	d := NewDispatcher(repo, MailHandlers(mailer), Config{BatchSize: 10, Lease: time.Minute, MaxAttempts: 3, RetryBackoff: time.Minute})
+	d.now = func() time.Time { return now }
+
+	claimed, err := d.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claimed != 5 {
+		t.Errorf("expected 5 claimed messages, got %d", claimed)
+	}
+
+	if len(mailer.Sent) != 1 || mailer.Sent[0].To != "ani@example.com" || mailer.Sent[0].VerificationURL != "https://dailyalu.mom/verify/abc" {
+		t.Errorf("expected the verification email to be sent, got %+v", mailer.Sent)
+	}
+	if sent := repo.Recorded[1]; sent.Status != domain.MessageSent || sent.Attempts != 1 || sent.NextAttemptAt != nil {
+		t.Errorf("expected a sent message, got %+v", sent)
+	}
+
+	// The second failure is retried after twice the backoff
+	retried := repo.Recorded[2]
+	if retried.Status != domain.MessagePending || !retried.NextAttemptAt.Equal(now.Add(2*time.Minute)) || retried.LastError == "" {
+		t.Errorf("expected a retry in 2 minutes, got %+v", retried)
+	}
+
+	// The last attempt, an undecodable payload and an unknown topic dead-letter
+	// the message
+	for _, id := range []int64{3, 4, 5} {
+		if dead := repo.Recorded[id]; dead.Status != domain.MessageDead || dead.NextAttemptAt != nil || dead.LastError == "" {
+			t.Errorf("expected message %d to be dead-lettered, got %+v", id, dead)
+		}
+	}
+	if attempts := repo.Recorded[4].Attempts; attempts != 1 {
+		t.Errorf("expected permanent failures not to be retried, got %d attempts", attempts)
+	}
+}
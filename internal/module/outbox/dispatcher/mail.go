@@ -0,0 +1,37 @@
+package dispatcher
+
+import (
+	"context"
+	"dailyalu-server/internal/module/outbox/domain"
+	mailerDomain "dailyalu-server/internal/service/mailer/domain"
+	"encoding/json"
+	"fmt"
+)
+
+// MailHandlers returns the handlers that send queued emails through the
+// mailer
+func MailHandlers(mailerService mailerDomain.IMailerService) map[string]Handler {
+	return map[string]Handler{
+		domain.TopicVerificationEmail: func(ctx context.Context, message *domain.Message) error {
+			var data mailerDomain.EmailVerificationData
+			if err := json.Unmarshal(message.Payload, &data); err != nil {
+				return fmt.Errorf("%w: invalid verification email payload: %v", ErrPermanent, err)
+			}
+			return mailerService.SendVerificationEmail(ctx, &data)
+		},
+		domain.TopicMagicLinkEmail: func(ctx context.Context, message *domain.Message) error {
+			var data mailerDomain.MagicLinkEmailData
+			if err := json.Unmarshal(message.Payload, &data); err != nil {
+				return fmt.Errorf("%w: invalid magic link email payload: %v", ErrPermanent, err)
+			}
+			return mailerService.SendMagicLinkEmail(ctx, &data)
+		},
+		domain.TopicWeeklyDigestEmail: func(ctx context.Context, message *domain.Message) error {
+			var data mailerDomain.WeeklyDigestEmailData
+			if err := json.Unmarshal(message.Payload, &data); err != nil {
+				return fmt.Errorf("%w: invalid weekly digest email payload: %v", ErrPermanent, err)
+			}
+			return mailerService.SendWeeklyDigestEmail(ctx, &data)
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Message statuses
+const (
+	MessagePending = "pending"
+	MessageSent    = "sent"
+	// MessageDead marks messages that failed every attempt or that no handler
+	// accepts. They are kept for admins to inspect and retry.
+	MessageDead = "dead"
+)
+
+// Topics name what a message asks for and select its handler
+const (
+	TopicVerificationEmail = "email.verification"
+	TopicWeeklyDigestEmail = "email.weekly_digest"
+	TopicReportEmail       = "email.report"
+	TopicMagicLinkEmail    = "email.magic_link"
+)
+
+// Message is a unit of work recorded together with the change that caused it
+type Message struct {
+	ID            int64           `json:"id"`
+	Topic         string          `json:"topic"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt *time.Time      `json:"next_attempt_at,omitempty"`
+	LastAttemptAt *time.Time      `json:"last_attempt_at,omitempty"`
+	LastError     string          `json:"last_error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// NewMessage creates a pending message due immediately, with the payload
+// encoded as JSON
+func NewMessage(topic string, payload interface{}) (*Message, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Message{
+		Topic:         topic,
+		Payload:       encoded,
+		Status:        MessagePending,
+		NextAttemptAt: &now,
+	}, nil
+}
+
+// GetMessagesRequest represents the request to list messages with a status
+type GetMessagesRequest struct {
+	Status   string `json:"status" validate:"required,oneof=pending sent dead"`
+	Page     int    `json:"page" validate:"min=1"`
+	PageSize int    `json:"page_size" validate:"min=1,max=100"`
+}
+
+// MessagesResponse represents a page of messages
+type MessagesResponse struct {
+	Messages   []Message  `json:"messages"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// Pagination represents pagination information
+type Pagination struct {
+	Total       int64 `json:"total"`
+	CurrentPage int   `json:"current_page"`
+	PageSize    int   `json:"page_size"`
+	TotalPages  int   `json:"total_pages"`
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/outbox/domain"
+	"time"
+)
+
+// IOutboxRepository defines the interface for outbox data access
+type IOutboxRepository interface {
+	Create(message *domain.Message) error
+	GetMessage(id int64) (*domain.Message, error)
+	// GetMessages returns a page of messages with the status, most recently
+	// changed first, and the number of such messages
+	GetMessages(status string, page, pageSize int) ([]domain.Message, int64, error)
+	// Requeue makes a dead message pending again with a fresh set of attempts
+	Requeue(id int64, at time.Time) error
+	Delete(id int64) error
+	// ClaimDue leases pending messages whose next attempt is due
+	ClaimDue(now, lockUntil time.Time, limit int) ([]domain.Message, error)
+	// RecordAttempt stores the outcome of an attempt and releases the lease,
+	// provided the message still holds the lease lockedUntil
+	RecordAttempt(message *domain.Message, lockedUntil time.Time) error
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/outbox/domain"
+	"dailyalu-server/internal/service/worker"
+	"database/sql"
+	"time"
+)
+
+const messageColumns = `id, topic, payload, status, attempts, next_attempt_at, last_attempt_at, last_error, created_at, updated_at`
+
+// Queryer is implemented by both *sql.DB and *sql.Tx
+type Queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// Insert writes a message with q. Other repositories pass their transaction
+// so the message is only recorded if their change commits.
+func Insert(q Queryer, message *domain.Message) error {
+	query := `
+		INSERT INTO outbox_messages (topic, payload, status, attempts, next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	now := time.Now()
+	message.CreatedAt = now
+	message.UpdatedAt = now
+
+	return q.QueryRow(
+		query,
+		message.Topic,
+		[]byte(message.Payload),
+		message.Status,
+		message.Attempts,
+		message.NextAttemptAt,
+		message.CreatedAt,
+		message.UpdatedAt,
+	).Scan(&message.ID)
+}
+
+// PostgresOutboxRepository implements the outbox repository interface using PostgreSQL
+type PostgresOutboxRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresOutboxRepository creates a new PostgreSQL outbox repository
+func NewPostgresOutboxRepository(db *sql.DB) IOutboxRepository {
+	return &PostgresOutboxRepository{
+		db: db,
+	}
+}
+
+// Create inserts a message on its own
+func (r *PostgresOutboxRepository) Create(message *domain.Message) error {
+	return Insert(r.db, message)
+}
+
+// GetMessage retrieves a message by ID
+func (r *PostgresOutboxRepository) GetMessage(id int64) (*domain.Message, error) {
+	query := `SELECT ` + messageColumns + ` FROM outbox_messages WHERE id = $1`
+
+	var message domain.Message
+	err := scanMessage(r.db.QueryRow(query, id), &message)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+// GetMessages retrieves a page of messages with the status
+func (r *PostgresOutboxRepository) GetMessages(status string, page, pageSize int) ([]domain.Message, int64, error) {
+	offset := (page - 1) * pageSize
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM outbox_messages WHERE status = $1`
+	if err := r.db.QueryRow(countQuery, status).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + messageColumns + ` FROM outbox_messages
+		WHERE status = $1
+		ORDER BY updated_at DESC, id DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(query, status, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	messages, err := scanMessages(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return messages, total, nil
+}
+
+// Requeue makes a dead message pending again
+func (r *PostgresOutboxRepository) Requeue(id int64, at time.Time) error {
+	query := `
+		UPDATE outbox_messages
+		SET status = $1, attempts = 0, next_attempt_at = $2, locked_until = NULL, updated_at = $3
+		WHERE id = $4 AND status = $5
+	`
+
+	result, err := r.db.Exec(query, domain.MessagePending, at, time.Now(), id, domain.MessageDead)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+// Delete removes a message
+func (r *PostgresOutboxRepository) Delete(id int64) error {
+	result, err := r.db.Exec(`DELETE FROM outbox_messages WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+// ClaimDue leases due messages to the caller. SKIP LOCKED lets concurrent
+// instances claim disjoint rows, and the lease makes a message claimable
+// again if its instance dies before recording the attempt.
+func (r *PostgresOutboxRepository) ClaimDue(now, lockUntil time.Time, limit int) ([]domain.Message, error) {
+	query := `
+		UPDATE outbox_messages
+		SET locked_until = $2
+		WHERE id IN (
+			SELECT id FROM outbox_messages
+			WHERE status = 'pending' AND next_attempt_at <= $1 AND (locked_until IS NULL OR locked_until <= $1)
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + messageColumns
+
+	rows, err := r.db.Query(query, now, lockUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMessages(rows)
+}
+
+// RecordAttempt stores the outcome of an attempt and releases the lease. It
+// returns worker.ErrLeaseLost when the lease expired in the meantime.
+func (r *PostgresOutboxRepository) RecordAttempt(message *domain.Message, lockedUntil time.Time) error {
+	query := `
+		UPDATE outbox_messages
+		SET status = $1, attempts = $2, next_attempt_at = $3, last_attempt_at = $4, last_error = $5,
+			locked_until = NULL, updated_at = $6
+		WHERE id = $7 AND locked_until = $8
+	`
+
+	message.UpdatedAt = time.Now()
+
+	return worker.CheckLease(r.db.Exec(
+		query,
+		message.Status,
+		message.Attempts,
+		message.NextAttemptAt,
+		message.LastAttemptAt,
+		message.LastError,
+		message.UpdatedAt,
+		message.ID,
+		lockedUntil,
+	))
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMessage(row rowScanner, message *domain.Message) error {
+	var payload []byte
+
+	err := row.Scan(
+		&message.ID,
+		&message.Topic,
+		&payload,
+		&message.Status,
+		&message.Attempts,
+		&message.NextAttemptAt,
+		&message.LastAttemptAt,
+		&message.LastError,
+		&message.CreatedAt,
+		&message.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	message.Payload = payload
+	return nil
+}
+
+func scanMessages(rows *sql.Rows) ([]domain.Message, error) {
+	messages := []domain.Message{}
+	for rows.Next() {
+		var message domain.Message
+		if err := scanMessage(rows, &message); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+func expectAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package usecase
+
+import "errors"
+
+// Domain errors for outbox module
+var (
+	ErrMessageNotFound = errors.New("outbox message not found")
+	ErrMessageNotDead  = errors.New("only dead outbox messages can be retried")
+)
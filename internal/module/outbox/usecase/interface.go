@@ -0,0 +1,12 @@
+package usecase
+
+import "dailyalu-server/internal/module/outbox/domain"
+
+// IOutboxUseCase defines the admin operations on outbox messages
+type IOutboxUseCase interface {
+	GetMessages(req *domain.GetMessagesRequest) (*domain.MessagesResponse, error)
+	GetMessage(id int64) (*domain.Message, error)
+	// RetryMessage queues a dead message again with a fresh set of attempts
+	RetryMessage(id int64) (*domain.Message, error)
+	DeleteMessage(id int64) error
+}
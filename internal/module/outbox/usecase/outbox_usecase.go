@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"dailyalu-server/internal/module/outbox/domain"
+	"dailyalu-server/internal/module/outbox/repository"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+)
+
+// OutboxUseCase implements the outbox use case interface
+type OutboxUseCase struct {
+	outboxRepo repository.IOutboxRepository
+	now        func() time.Time
+}
+
+// NewOutboxUseCase creates a new outbox use case
+func NewOutboxUseCase(outboxRepo repository.IOutboxRepository) IOutboxUseCase {
+	return &OutboxUseCase{
+		outboxRepo: outboxRepo,
+		now:        time.Now,
+	}
+}
+
+// GetMessages retrieves a page of messages with the status
+func (uc *OutboxUseCase) GetMessages(req *domain.GetMessagesRequest) (*domain.MessagesResponse, error) {
+	messages, total, err := uc.outboxRepo.GetMessages(req.Status, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.MessagesResponse{
+		Messages: messages,
+		Pagination: domain.Pagination{
+			Total:       total,
+			CurrentPage: req.Page,
+			PageSize:    req.PageSize,
+			TotalPages:  int(math.Ceil(float64(total) / float64(req.PageSize))),
+		},
+	}, nil
+}
+
+// GetMessage retrieves a message by ID
+func (uc *OutboxUseCase) GetMessage(id int64) (*domain.Message, error) {
+	message, err := uc.outboxRepo.GetMessage(id)
+	if err != nil {
+		return nil, err
+	}
+	if message == nil {
+		return nil, ErrMessageNotFound
+	}
+
+	return message, nil
+}
+
+// RetryMessage queues a dead message again
+func (uc *OutboxUseCase) RetryMessage(id int64) (*domain.Message, error) {
+	message, err := uc.GetMessage(id)
+	if err != nil {
+		return nil, err
+	}
+	if message.Status != domain.MessageDead {
+		return nil, ErrMessageNotDead
+	}
+
+	if err := uc.outboxRepo.Requeue(id, uc.now()); err != nil {
+		// The dispatcher or another admin changed the message in between
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMessageNotDead
+		}
+		return nil, err
+	}
+
+	return uc.GetMessage(id)
+}
+
+// DeleteMessage removes a message, e.g. a dead one that should not be retried
+func (uc *OutboxUseCase) DeleteMessage(id int64) error {
+	if err := uc.outboxRepo.Delete(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMessageNotFound
+		}
+		return err
+	}
+
+	return nil
+}
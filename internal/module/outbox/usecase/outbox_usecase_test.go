@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"dailyalu-server/internal/module/outbox/domain"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockOutboxRepository keeps messages in memory
+type MockOutboxRepository struct {
+	Messages map[int64]*domain.Message
+}
+
+func (m *MockOutboxRepository) Create(message *domain.Message) error { return nil }
+
+func (m *MockOutboxRepository) GetMessage(id int64) (*domain.Message, error) {
+	if message, ok := m.Messages[id]; ok {
+		copied := *message
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (m *MockOutboxRepository) GetMessages(status string, page, pageSize int) ([]domain.Message, int64, error) {
+	messages := []domain.Message{}
+	for _, message := range m.Messages {
+		if message.Status == status {
+			messages = append(messages, *message)
+		}
+	}
+	return messages, int64(len(messages)), nil
+}
+
+func (m *MockOutboxRepository) Requeue(id int64, at time.Time) error {
+	message, ok := m.Messages[id]
+	if !ok || message.Status != domain.MessageDead {
+		return sql.ErrNoRows
+	}
+	message.Status = domain.MessagePending
+	message.Attempts = 0
+	message.NextAttemptAt = &at
+	return nil
+}
+
+func (m *MockOutboxRepository) Delete(id int64) error {
+	if _, ok := m.Messages[id]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(m.Messages, id)
+	return nil
+}
+
+func (m *MockOutboxRepository) ClaimDue(now, lockUntil time.Time, limit int) ([]domain.Message, error) {
+	return nil, nil
+}
+
+func (m *MockOutboxRepository) RecordAttempt(message *domain.Message, lockedUntil time.Time) error {
+	return nil
+}
+
+func TestRetryMessage(t *testing.T) {
+	now := time.Date(2025, 3, 20, 8, 0, 0, 0, time.UTC)
+	repo := &MockOutboxRepository{Messages: map[int64]*domain.Message{
+		1: {ID: 1, Topic: domain.TopicVerificationEmail, Status: domain.MessageDead, Attempts: 10, LastError: "smtp: connection refused"},
+		2: {ID: 2, Topic: domain.TopicVerificationEmail, Status: domain.MessageSent, Attempts: 1},
+	}}
+	uc := &OutboxUseCase{outboxRepo: repo, now: func() time.Time { return now }}
+
+	dead, err := uc.GetMessages(&domain.GetMessagesRequest{Status: domain.MessageDead, Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dead.Messages) != 1 || dead.Pagination.Total != 1 || dead.Pagination.TotalPages != 1 {
+		t.Errorf("expected one dead message, got %+v", dead)
+	}
+
+	message, err := uc.RetryMessage(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if message.Status != domain.MessagePending || message.Attempts != 0 || !message.NextAttemptAt.Equal(now) {
+		t.Errorf("expected the message to be due again, got %+v", message)
+	}
+
+	testCases := []struct {
+		name     string
+		id       int64
+		expected error
+	}{
+		{"already queued", 1, ErrMessageNotDead},
+		{"sent", 2, ErrMessageNotDead},
+		{"missing", 3, ErrMessageNotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := uc.RetryMessage(tc.id); !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+
+	if err := uc.DeleteMessage(3); !errors.Is(err, ErrMessageNotFound) {
+		t.Errorf("expected ErrMessageNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"dailyalu-server/internal/module/reminder/rule"
+	"dailyalu-server/internal/utils"
+	"time"
+)
+
+// Reminder notifies a user once at FireAt or repeatedly following Rule.
+// Rules are evaluated in the reminder's time zone.
+type Reminder struct {
+	ID            int64      `json:"id"`
+	UserID        string     `json:"user_id"`
+	ChildID       *int64     `json:"child_id,omitempty"`
+	Title         string     `json:"title"`
+	Message       string     `json:"message,omitempty"`
+	Channel       string     `json:"channel"`
+	WebhookURL    string     `json:"webhook_url,omitempty"`
+	WebhookSecret string     `json:"webhook_secret,omitempty"`
+	FireAt        *time.Time `json:"fire_at,omitempty"`
+	Rule          string     `json:"rule,omitempty"`
+	Timezone      string     `json:"timezone"`
+	Active        bool       `json:"active"`
+	NextRunAt     *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt     *time.Time `json:"last_run_at,omitempty"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// NextOccurrence returns the first occurrence of a recurring reminder after
+// the given time, or nil for one-off reminders and rules that never match
+// again
+func (r *Reminder) NextOccurrence(after time.Time) (*time.Time, error) {
+	if r.Rule == "" {
+		return nil, nil
+	}
+
+	parsed, err := rule.Parse(r.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := utils.LoadLocation(r.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	next := parsed.Next(after.In(loc))
+	if next.IsZero() {
+		return nil, nil
+	}
+	return &next, nil
+}
+
+// CreateReminderRequest represents the request to create a reminder. Exactly
+// one of FireAt and Rule is set.
+type CreateReminderRequest struct {
+	UserID     string `json:"-"`
+	ChildID    *int64 `json:"child_id,omitempty"`
+	Title      string `json:"title" validate:"required,max=255"`
+	Message    string `json:"message,omitempty" validate:"max=1000"`
+	Channel    string `json:"channel" validate:"required,oneof=email webhook push"`
+	WebhookURL string `json:"webhook_url,omitempty" validate:"required_if=Channel webhook,omitempty,url,max=2048"`
+	FireAt     string `json:"fire_at,omitempty"`
+	Rule       string `json:"rule,omitempty" validate:"max=100"`
+}
+
+// UpdateReminderRequest represents the request to update a reminder
+type UpdateReminderRequest struct {
+	ID int64 `json:"-"`
+	CreateReminderRequest
+	Active *bool `json:"active,omitempty"`
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/reminder/domain"
+	"time"
+)
+
+// IReminderRepository defines the interface for reminder data access
+type IReminderRepository interface {
+	Create(reminder *domain.Reminder) error
+	GetByID(id int64) (*domain.Reminder, error)
+	GetByUserID(userID string) ([]domain.Reminder, error)
+	Update(reminder *domain.Reminder) error
+	Delete(id int64) error
+
+	// ClaimDue locks up to limit active reminders due at now until lockUntil.
+	// Instances claiming concurrently never get the same reminder.
+	ClaimDue(now, lockUntil time.Time, limit int) ([]domain.Reminder, error)
+	// MarkSent records a delivery and schedules the next run; a nil nextRunAt
+	// deactivates the reminder. Like MarkFailed, it only applies while the
+	// reminder still holds the lease lockedUntil.
+	MarkSent(id int64, lockedUntil, sentAt time.Time, nextRunAt *time.Time) error
+	// MarkFailed records a failed delivery and schedules the retry or next
+	// run; a nil nextRunAt deactivates the reminder
+	MarkFailed(id int64, lockedUntil time.Time, attempts int, lastError string, nextRunAt *time.Time) error
+}
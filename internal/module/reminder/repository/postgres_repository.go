@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/reminder/domain"
+	"dailyalu-server/internal/service/worker"
+	"database/sql"
+	"time"
+)
+
+const reminderColumns = `id, user_id, child_id, title, message, channel, webhook_url, webhook_secret, fire_at, rule, timezone,
+	active, next_run_at, last_run_at, attempts, last_error, created_at, updated_at`
+
+// PostgresReminderRepository implements the reminder repository interface using PostgreSQL
+type PostgresReminderRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresReminderRepository creates a new PostgreSQL reminder repository
+func NewPostgresReminderRepository(db *sql.DB) IReminderRepository {
+	return &PostgresReminderRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new reminder into the database
+func (r *PostgresReminderRepository) Create(reminder *domain.Reminder) error {
+	query := `
+		INSERT INTO reminders (user_id, child_id, title, message, channel, webhook_url, webhook_secret, fire_at, rule,
+			timezone, active, next_run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id
+	`
+
+	now := time.Now()
+	reminder.CreatedAt = now
+	reminder.UpdatedAt = now
+
+	return r.db.QueryRow(
+		query,
+		reminder.UserID,
+		reminder.ChildID,
+		reminder.Title,
+		reminder.Message,
+		reminder.Channel,
+		reminder.WebhookURL,
+		reminder.WebhookSecret,
+		reminder.FireAt,
+		reminder.Rule,
+		reminder.Timezone,
+		reminder.Active,
+		reminder.NextRunAt,
+		reminder.CreatedAt,
+		reminder.UpdatedAt,
+	).Scan(&reminder.ID)
+}
+
+// GetByID retrieves a reminder by ID
+func (r *PostgresReminderRepository) GetByID(id int64) (*domain.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM reminders WHERE id = $1`
+
+	var reminder domain.Reminder
+	err := scanReminder(r.db.QueryRow(query, id), &reminder)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &reminder, nil
+}
+
+// GetByUserID retrieves the reminders of a user, soonest first
+func (r *PostgresReminderRepository) GetByUserID(userID string) ([]domain.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM reminders WHERE user_id = $1 ORDER BY active DESC, next_run_at, id`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanReminders(rows)
+}
+
+// Update updates the user editable fields of a reminder and its schedule
+func (r *PostgresReminderRepository) Update(reminder *domain.Reminder) error {
+	query := `
+		UPDATE reminders
+		SET child_id = $1, title = $2, message = $3, channel = $4, webhook_url = $5, webhook_secret = $6, fire_at = $7,
+			rule = $8, timezone = $9, active = $10, next_run_at = $11, attempts = $12, last_error = $13, updated_at = $14
+		WHERE id = $15
+	`
+
+	reminder.UpdatedAt = time.Now()
+
+	result, err := r.db.Exec(
+		query,
+		reminder.ChildID,
+		reminder.Title,
+		reminder.Message,
+		reminder.Channel,
+		reminder.WebhookURL,
+		reminder.WebhookSecret,
+		reminder.FireAt,
+		reminder.Rule,
+		reminder.Timezone,
+		reminder.Active,
+		reminder.NextRunAt,
+		reminder.Attempts,
+		reminder.LastError,
+		reminder.UpdatedAt,
+		reminder.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+// Delete removes a reminder
+func (r *PostgresReminderRepository) Delete(id int64) error {
+	result, err := r.db.Exec(`DELETE FROM reminders WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+// ClaimDue leases due reminders to the caller. SKIP LOCKED lets concurrent
+// instances claim disjoint rows, and the lease makes a reminder claimable
+// again if its instance dies before recording the delivery.
+func (r *PostgresReminderRepository) ClaimDue(now, lockUntil time.Time, limit int) ([]domain.Reminder, error) {
+	query := `
+		UPDATE reminders
+		SET locked_until = $2
+		WHERE id IN (
+			SELECT id FROM reminders
+			WHERE active AND next_run_at <= $1 AND (locked_until IS NULL OR locked_until <= $1)
+			ORDER BY next_run_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + reminderColumns
+
+	rows, err := r.db.Query(query, now, lockUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanReminders(rows)
+}
+
+// MarkSent records a successful delivery and releases the lease. It returns
+// worker.ErrLeaseLost when the lease expired in the meantime.
+func (r *PostgresReminderRepository) MarkSent(id int64, lockedUntil, sentAt time.Time, nextRunAt *time.Time) error {
+	query := `
+		UPDATE reminders
+		SET last_run_at = $1, next_run_at = $2, active = $3, attempts = 0, last_error = '',
+			locked_until = NULL, updated_at = $4
+		WHERE id = $5 AND locked_until = $6
+	`
+
+	return worker.CheckLease(r.db.Exec(query, sentAt, nextRunAt, nextRunAt != nil, time.Now(), id, lockedUntil))
+}
+
+// MarkFailed records a failed delivery and releases the lease. It returns
+// worker.ErrLeaseLost when the lease expired in the meantime.
+func (r *PostgresReminderRepository) MarkFailed(id int64, lockedUntil time.Time, attempts int, lastError string, nextRunAt *time.Time) error {
+	query := `
+		UPDATE reminders
+		SET attempts = $1, last_error = $2, next_run_at = $3, active = $4, locked_until = NULL, updated_at = $5
+		WHERE id = $6 AND locked_until = $7
+	`
+
+	return worker.CheckLease(r.db.Exec(query, attempts, lastError, nextRunAt, nextRunAt != nil, time.Now(), id, lockedUntil))
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReminder(row rowScanner, reminder *domain.Reminder) error {
+	return row.Scan(
+		&reminder.ID,
+		&reminder.UserID,
+		&reminder.ChildID,
+		&reminder.Title,
+		&reminder.Message,
+		&reminder.Channel,
+		&reminder.WebhookURL,
+		&reminder.WebhookSecret,
+		&reminder.FireAt,
+		&reminder.Rule,
+		&reminder.Timezone,
+		&reminder.Active,
+		&reminder.NextRunAt,
+		&reminder.LastRunAt,
+		&reminder.Attempts,
+		&reminder.LastError,
+		&reminder.CreatedAt,
+		&reminder.UpdatedAt,
+	)
+}
+
+func scanReminders(rows *sql.Rows) ([]domain.Reminder, error) {
+	reminders := []domain.Reminder{}
+	for rows.Next() {
+		var reminder domain.Reminder
+		if err := scanReminder(rows, &reminder); err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	return reminders, rows.Err()
+}
+
+func expectAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+// Package rule parses the recurrence rules of reminders: five field cron
+// expressions ("minute hour day-of-month month day-of-week"), the
+// descriptors @hourly, @daily, @weekly, @monthly and @yearly, and fixed
+// intervals written as "@every 3h".
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinEvery is the shortest interval accepted by @every
+const MinEvery = time.Minute
+
+// ErrInvalidRule is returned for expressions that cannot be parsed
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+var descriptors = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+// field describes the allowed range of a cron field
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Rule is a parsed recurrence rule. Cron fields are bit sets of the
+// matching values.
+type Rule struct {
+	expr  string
+	every time.Duration
+
+	minute, hour, dom, month, dow uint64
+
+	// Like cron, a day matches either restricted day field when both are set
+	domRestricted, dowRestricted bool
+}
+
+// Parse parses a recurrence rule
+func Parse(expr string) (*Rule, error) {
+	expr = strings.TrimSpace(expr)
+	r := &Rule{expr: expr}
+
+	if rest, ok := strings.CutPrefix(expr, "@every "); ok {
+		every, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+		if every < MinEvery {
+			return nil, fmt.Errorf("%w: interval must be at least %s", ErrInvalidRule, MinEvery)
+		}
+		r.every = every
+		return r, nil
+	}
+
+	spec := expr
+	if strings.HasPrefix(spec, "@") {
+		var ok bool
+		if spec, ok = descriptors[spec]; !ok {
+			return nil, fmt.Errorf("%w: unknown descriptor %q", ErrInvalidRule, expr)
+		}
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("%w: expected %d fields, got %d", ErrInvalidRule, len(fields), len(parts))
+	}
+
+	sets := make([]uint64, len(fields))
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+
+	r.minute, r.hour, r.dom, r.month, r.dow = sets[0], sets[1], sets[2], sets[3], sets[4]
+	// Sunday may be written as 0 or 7
+	if r.dow&(1<<7) != 0 {
+		r.dow |= 1
+	}
+	r.domRestricted = parts[2] != "*"
+	r.dowRestricted = parts[4] != "*"
+
+	return r, nil
+}
+
+// parseField parses a comma separated list of values, ranges ("1-5"), steps
+// ("*/15", "8-18/2") and wildcards into a bit set
+func parseField(part string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(part, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step < 1 {
+				return 0, fmt.Errorf("%w: invalid step %q in %s", ErrInvalidRule, stepPart, f.name)
+			}
+		}
+
+		low, high := f.min, f.max
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = strconv.Atoi(lowPart); err != nil {
+				return 0, fmt.Errorf("%w: invalid %s %q", ErrInvalidRule, f.name, item)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highPart); err != nil {
+					return 0, fmt.Errorf("%w: invalid %s %q", ErrInvalidRule, f.name, item)
+				}
+			} else if hasStep {
+				high = f.max
+			}
+		}
+
+		if low < f.min || high > f.max || low > high {
+			return 0, fmt.Errorf("%w: %s %q out of range %d-%d", ErrInvalidRule, f.name, item, f.min, f.max)
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// String returns the expression the rule was parsed from
+func (r *Rule) String() string {
+	return r.expr
+}
+
+// Next returns the first occurrence strictly after the given time. Cron
+// fields are matched against the wall clock in the location of after. A zero
+// time is returned when the rule never matches, e.g. "0 0 30 2 *".
+func (r *Rule) Next(after time.Time) time.Time {
+	if r.every > 0 {
+		return after.Add(r.every)
+	}
+
+	loc := after.Location()
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute(), 0, 0, loc).Add(time.Minute)
+
+	// Rules matching a date at all do so within a leap year cycle
+	limit := t.Year() + 5
+
+wrap:
+	for t.Year() <= limit {
+		for !has(r.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			if t.Year() > limit {
+				return time.Time{}
+			}
+		}
+
+		for !r.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			if t.Day() == 1 {
+				continue wrap
+			}
+		}
+
+		for !has(r.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if t.Hour() == 0 {
+				continue wrap
+			}
+		}
+
+		for !has(r.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			if t.Minute() == 0 {
+				continue wrap
+			}
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (r *Rule) dayMatches(t time.Time) bool {
+	dom := has(r.dom, t.Day())
+	dow := has(r.dow, int(t.Weekday()))
+	if r.domRestricted && r.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
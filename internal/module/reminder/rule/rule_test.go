@@ -0,0 +1,54 @@
+package rule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	// Thursday 20 March 2025, 10:30 in Jakarta
+	after := time.Date(2025, 3, 20, 10, 30, 0, 0, jakarta)
+
+	testCases := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, 3, 20, 10, 45, 0, 0, jakarta)},
+		{"0 8,20 * * *", time.Date(2025, 3, 20, 20, 0, 0, 0, jakarta)},
+		{"30 10 * * *", time.Date(2025, 3, 21, 10, 30, 0, 0, jakarta)},
+		{"0 9 * * 1-5", time.Date(2025, 3, 21, 9, 0, 0, 0, jakarta)},
+		{"0 9 * * 7", time.Date(2025, 3, 23, 9, 0, 0, 0, jakarta)},
+		{"0 0 1 * *", time.Date(2025, 4, 1, 0, 0, 0, 0, jakarta)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, jakarta)},
+		{"0 9 15 * 1", time.Date(2025, 3, 24, 9, 0, 0, 0, jakarta)},
+		{"@daily", time.Date(2025, 3, 21, 0, 0, 0, 0, jakarta)},
+		{"@every 3h", after.Add(3 * time.Hour)},
+	}
+	for _, tc := range testCases {
+		r, err := Parse(tc.expr)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.expr, err)
+		}
+		if got := r.Next(after); !got.Equal(tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.expr, tc.expected, got)
+		}
+	}
+
+	r, _ := Parse("0 0 30 2 *")
+	if got := r.Next(after); !got.IsZero() {
+		t.Errorf("expected no occurrence on 30 February, got %v", got)
+	}
+}
+
+func TestParseRejectsInvalidRules(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "*/0 * * * *", "5-1 * * * *", "@fortnightly", "@every 10s", "@every soon"} {
+		if _, err := Parse(expr); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("%q: expected ErrInvalidRule, got %v", expr, err)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Package scheduler delivers due reminders in the background, using the
+// lease and retry runner of package worker.
+package scheduler
+
+import (
+	"context"
+	"dailyalu-server/internal/module/reminder/domain"
+	"dailyalu-server/internal/module/reminder/repository"
+	notifierDomain "dailyalu-server/internal/service/notifier/domain"
+	"dailyalu-server/internal/service/notifier/push"
+	"dailyalu-server/internal/service/worker"
+	"dailyalu-server/pkg/app_log/zap_log"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// errRemindersOff skips an occurrence the recipient does not want on the
+// reminder's channel
+var errRemindersOff = errors.New("reminders are turned off for the channel")
+
+// RecipientResolver returns the user a reminder is delivered to
+type RecipientResolver func(userID string) (*notifierDomain.Recipient, error)
+
+// Config controls polling and retries. MaxAttempts is the number of
+// deliveries tried per occurrence.
+type Config = worker.Config
+
+// NewConfigFromConfig reads the scheduler settings under reminders
+func NewConfigFromConfig() Config {
+	return worker.NewConfigFromConfig("reminders")
+}
+
+// Scheduler polls for due reminders and delivers them through the notifier
+// of their channel
+type Scheduler struct {
+	reminderRepo     repository.IReminderRepository
+	notifiers        map[string]notifierDomain.INotifier
+	resolveRecipient RecipientResolver
+	runner           *worker.Runner[domain.Reminder]
+	logger           *zap.Logger
+	now              func() time.Time
+}
+
+// NewScheduler creates a reminder scheduler
+func NewScheduler(reminderRepo repository.IReminderRepository, notifiers map[string]notifierDomain.INotifier, resolveRecipient RecipientResolver, config Config) *Scheduler {
+	logger := zap_log.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	s := &Scheduler{
+		reminderRepo:     reminderRepo,
+		notifiers:        notifiers,
+		resolveRecipient: resolveRecipient,
+		logger:           logger,
+		now:              time.Now,
+	}
+	s.runner = worker.NewRunner("reminders", worker.Jobs[domain.Reminder]{
+		Claim:    reminderRepo.ClaimDue,
+		Attempts: func(reminder *domain.Reminder) int { return reminder.Attempts },
+		Handle:   s.deliver,
+		Record:   s.record,
+	}, config, func() time.Time { return s.now() })
+	return s
+}
+
+// Run delivers due reminders until the context is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	s.runner.Run(ctx)
+}
+
+// RunOnce claims one batch of due reminders and delivers them. It returns
+// the number of reminders claimed.
+func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
+	return s.runner.RunOnce(ctx)
+}
+
+// record stores the outcome of a delivery and schedules the reminder again
+func (s *Scheduler) record(reminder *domain.Reminder, attempt worker.Attempt) {
+	if errors.Is(attempt.Err, errRemindersOff) {
+		// Not a failure: move on to the next occurrence, which is delivered
+		// if the user turns reminders back on by then
+		if err := s.reminderRepo.MarkFailed(reminder.ID, attempt.LeasedUntil, 0, "", s.nextRun(reminder, attempt.StartedAt)); err != nil {
+			s.logger.Error("failed to record skipped reminder", zap.Int64("reminder_id", reminder.ID), zap.Error(err))
+		}
+		return
+	}
+
+	if attempt.Err == nil {
+		if err := s.reminderRepo.MarkSent(reminder.ID, attempt.LeasedUntil, attempt.StartedAt, s.nextRun(reminder, attempt.StartedAt)); err != nil {
+			s.logger.Error("failed to record reminder delivery", zap.Int64("reminder_id", reminder.ID), zap.Error(err))
+		}
+		return
+	}
+
+	s.logger.Warn("failed to deliver reminder",
+		zap.Int64("reminder_id", reminder.ID),
+		zap.String("channel", reminder.Channel),
+		zap.Int("attempt", attempt.Number),
+		zap.Error(attempt.Err),
+	)
+
+	attempts, next := attempt.Number, attempt.RetryAt
+	if attempt.GaveUp() {
+		// Give up on this occurrence and move on to the next one
+		attempts = 0
+		next = s.nextRun(reminder, attempt.StartedAt)
+	}
+
+	if err := s.reminderRepo.MarkFailed(reminder.ID, attempt.LeasedUntil, attempts, attempt.Err.Error(), next); err != nil {
+		s.logger.Error("failed to record reminder failure", zap.Int64("reminder_id", reminder.ID), zap.Error(err))
+	}
+}
+
+func (s *Scheduler) deliver(ctx context.Context, reminder *domain.Reminder) error {
+	notifier, ok := s.notifiers[reminder.Channel]
+	if !ok {
+		return fmt.Errorf("no notifier for channel %q", reminder.Channel)
+	}
+
+	recipient, err := s.resolveRecipient(reminder.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve recipient: %w", err)
+	}
+	if recipient.RemindersOff[reminder.Channel] {
+		return errRemindersOff
+	}
+
+	data := map[string]interface{}{
+		"reminder_id": reminder.ID,
+	}
+	if reminder.ChildID != nil {
+		data["child_id"] = *reminder.ChildID
+	}
+	if reminder.NextRunAt != nil {
+		data["scheduled_at"] = *reminder.NextRunAt
+	}
+
+	return notifier.Notify(ctx, &notifierDomain.Notification{
+		Recipient:     *recipient,
+		Title:         reminder.Title,
+		Message:       reminder.Message,
+		WebhookURL:    reminder.WebhookURL,
+		WebhookSecret: reminder.WebhookSecret,
+		Template:      push.TemplateReminder,
+		Data:          data,
+	})
+}
+
+// nextRun returns the next occurrence after now. Occurrences missed while no
+// scheduler ran are skipped rather than delivered in a burst.
+func (s *Scheduler) nextRun(reminder *domain.Reminder, now time.Time) *time.Time {
+	next, err := reminder.NextOccurrence(now)
+	if err != nil {
+		s.logger.Error("failed to schedule reminder", zap.Int64("reminder_id", reminder.ID), zap.Error(err))
+		return nil
+	}
+	return next
+}
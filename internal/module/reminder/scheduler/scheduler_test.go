@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"context"
+	"dailyalu-server/internal/module/reminder/domain"
+	notifierDomain "dailyalu-server/internal/service/notifier/domain"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockReminderRepository records the outcome of claimed reminders
+type MockReminderRepository struct {
+	Due     []domain.Reminder
+	Sent    map[int64]*time.Time
+	Failed  map[int64]*time.Time
+	Attempt map[int64]int
+}
+
+func (m *MockReminderRepository) Create(reminder *domain.Reminder) error { return nil }
+
+func (m *MockReminderRepository) GetByID(id int64) (*domain.Reminder, error) { return nil, nil }
+
+func (m *MockReminderRepository) GetByUserID(userID string) ([]domain.Reminder, error) {
+	return nil, nil
+}
+
+func (m *MockReminderRepository) Update(reminder *domain.Reminder) error { return nil }
+
+func (m *MockReminderRepository) Delete(id int64) error { return nil }
+
+func (m *MockReminderRepository) ClaimDue(now, lockUntil time.Time, limit int) ([]domain.Reminder, error) {
+	due := m.Due[:min(limit, len(m.Due))]
+	m.Due = m.Due[len(due):]
+	return due, nil
+}
+
+func (m *MockReminderRepository) MarkSent(id int64, lockedUntil, sentAt time.Time, nextRunAt *time.Time) error {
+	m.Sent[id] = nextRunAt
+	return nil
+}
+
+func (m *MockReminderRepository) MarkFailed(id int64, lockedUntil time.Time, attempts int, lastError string, nextRunAt *time.Time) error {
+	m.Failed[id] = nextRunAt
+	m.Attempt[id] = attempts
+	return nil
+}
+
+// MockNotifier fails for the titles listed in FailFor
+type MockNotifier struct {
+	Delivered []string
+	FailFor   map[string]bool
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, notification *notifierDomain.Notification) error {
+	if m.FailFor[notification.Title] {
+		return errors.New("connection refused")
+	}
+	m.Delivered = append(m.Delivered, notification.Title+" to "+notification.Recipient.Email)
+	return nil
+}
+
+func TestRunOnce(t *testing.T) {
+	now := time.Date(2025, 3, 20, 8, 0, 0, 0, time.UTC)
+	fireAt := now.Add(-time.Minute)
+
+	repo := &MockReminderRepository{
+		Due: []domain.Reminder{
+			{ID: 1, UserID: "user-1", Title: "Feed again", Channel: "email", FireAt: &fireAt, NextRunAt: &fireAt, Timezone: "UTC"},
+			{ID: 2, UserID: "user-1", Title: "Vitamin D", Channel: "email", Rule: "0 8 * * *", NextRunAt: &now, Timezone: "UTC"},
+			{ID: 3, UserID: "user-1", Title: "Flaky", Channel: "email", Rule: "0 8 * * *", NextRunAt: &now, Timezone: "UTC", Attempts: 1},
+			{ID: 4, UserID: "user-1", Title: "Flaky", Channel: "email", Rule: "0 8 * * *", NextRunAt: &now, Timezone: "UTC", Attempts: 2},
+			{ID: 5, UserID: "user-1", Title: "No push yet", Channel: "push", FireAt: &fireAt, NextRunAt: &fireAt, Timezone: "UTC", Attempts: 2},
+			{ID: 6, UserID: "user-2", Title: "Vitamin D", Channel: "email", Rule: "0 8 * * *", NextRunAt: &now, Timezone: "UTC", Attempts: 1},
+		},
+		Sent:    map[int64]*time.Time{},
+		Failed:  map[int64]*time.Time{},
+		Attempt: map[int64]int{},
+	}
+	email := &MockNotifier{FailFor: map[string]bool{"Flaky": true}}
+
+	s := NewScheduler(repo, map[string]notifierDomain.INotifier{"email": email}, func(userID string) (*notifierDomain.Recipient, error) {
+		// user-2 turned email reminders off
+		return &notifierDomain.Recipient{UserID: userID, Email: "parent@example.com", RemindersOff: map[string]bool{"email": userID == "user-2"}}, nil
+	}, Config{BatchSize: 10, Lease: time.Minute, MaxAttempts: 3, RetryBackoff: time.Minute})
+	s.now = func() time.Time { return now }
+
+	claimed, err := s.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claimed != 6 {
+		t.Errorf("expected 6 claimed reminders, got %d", claimed)
+	}
+	if len(email.Delivered) != 2 || email.Delivered[0] != "Feed again to parent@example.com" {
+		t.Errorf("unexpected deliveries: %v", email.Delivered)
+	}
+
+	tomorrow := now.AddDate(0, 0, 1)
+
+	// One-off reminders are deactivated once sent, recurring ones rescheduled
+	if next, ok := repo.Sent[1]; !ok || next != nil {
+		t.Errorf("expected the one-off reminder to be deactivated, got %v", next)
+	}
+	if next := repo.Sent[2]; next == nil || !next.Equal(tomorrow) {
+		t.Errorf("expected the next run tomorrow, got %v", next)
+	}
+
+	// The second failure is retried after twice the backoff
+	if next := repo.Failed[3]; next == nil || !next.Equal(now.Add(2*time.Minute)) || repo.Attempt[3] != 2 {
+		t.Errorf("expected a retry in 2 minutes, got %v after %d attempts", next, repo.Attempt[3])
+	}
+
+	// The last attempt gives up on the occurrence
+	if next := repo.Failed[4]; next == nil || !next.Equal(tomorrow) || repo.Attempt[4] != 0 {
+		t.Errorf("expected the next occurrence tomorrow, got %v after %d attempts", next, repo.Attempt[4])
+	}
+	if next, ok := repo.Failed[5]; !ok || next != nil {
+		t.Errorf("expected the undeliverable one-off reminder to be deactivated, got %v", next)
+	}
+
+	// Opted out occurrences are skipped without counting as a failed attempt
+	if next := repo.Failed[6]; next == nil || !next.Equal(tomorrow) || repo.Attempt[6] != 0 {
+		t.Errorf("expected the opted out occurrence to be skipped to tomorrow, got %v after %d attempts", next, repo.Attempt[6])
+	}
+}
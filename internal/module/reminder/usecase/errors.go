@@ -0,0 +1,15 @@
+package usecase
+
+import "errors"
+
+// Domain errors for reminder module
+var (
+	ErrReminderNotFound        = errors.New("reminder not found")
+	ErrInvalidReminderSchedule = errors.New("reminder needs either fire_at or rule")
+	ErrInvalidRule             = errors.New("invalid reminder rule")
+	ErrInvalidReminderTime     = errors.New("invalid reminder time")
+	ErrFireAtInPast            = errors.New("reminder time is in the past")
+	ErrRuleNeverMatches        = errors.New("reminder rule has no upcoming occurrence")
+	ErrChannelUnavailable      = errors.New("notification channel is not available")
+	ErrInvalidWebhookURL       = errors.New("invalid reminder webhook URL")
+)
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/reminder/domain"
+)
+
+// IReminderUseCase defines the interface for reminder business logic
+type IReminderUseCase interface {
+	CreateReminder(ctx context.Context, req *domain.CreateReminderRequest) (*domain.Reminder, error)
+	GetReminder(ctx context.Context, id int64, userID string) (*domain.Reminder, error)
+	GetReminders(ctx context.Context, userID string) ([]domain.Reminder, error)
+	UpdateReminder(ctx context.Context, req *domain.UpdateReminderRequest) (*domain.Reminder, error)
+	DeleteReminder(ctx context.Context, id int64, userID string) error
+}
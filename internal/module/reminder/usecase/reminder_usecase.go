@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	"dailyalu-server/internal/module/reminder/domain"
+	"dailyalu-server/internal/module/reminder/repository"
+	"dailyalu-server/internal/module/reminder/rule"
+	"dailyalu-server/internal/security/apikey"
+	"dailyalu-server/internal/security/outbound"
+	notifierDomain "dailyalu-server/internal/service/notifier/domain"
+	"dailyalu-server/internal/utils"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ReminderUseCase implements the reminder use case interface
+type ReminderUseCase struct {
+	reminderRepo    repository.IReminderRepository
+	childrenUseCase childrenUsecase.IChildrenUseCase
+	channels        map[string]bool
+	urlPolicy       outbound.Policy
+	now             func() time.Time
+}
+
+// NewReminderUseCase creates a new reminder use case. channels lists the
+// notification channels reminders can be delivered through, and webhook
+// URLs must be allowed by urlPolicy.
+func NewReminderUseCase(reminderRepo repository.IReminderRepository, childrenUseCase childrenUsecase.IChildrenUseCase, channels []string, urlPolicy outbound.Policy) IReminderUseCase {
+	available := make(map[string]bool, len(channels))
+	for _, channel := range channels {
+		available[channel] = true
+	}
+
+	return &ReminderUseCase{
+		reminderRepo:    reminderRepo,
+		childrenUseCase: childrenUseCase,
+		channels:        available,
+		urlPolicy:       urlPolicy,
+		now:             time.Now,
+	}
+}
+
+// CreateReminder schedules a new reminder
+func (uc *ReminderUseCase) CreateReminder(ctx context.Context, req *domain.CreateReminderRequest) (*domain.Reminder, error) {
+	reminder := &domain.Reminder{
+		UserID: req.UserID,
+		Active: true,
+	}
+	if err := uc.apply(ctx, reminder, req); err != nil {
+		return nil, err
+	}
+
+	if err := uc.reminderRepo.Create(reminder); err != nil {
+		return nil, err
+	}
+
+	return present(ctx, reminder), nil
+}
+
+// GetReminder retrieves a reminder owned by the user
+func (uc *ReminderUseCase) GetReminder(ctx context.Context, id int64, userID string) (*domain.Reminder, error) {
+	reminder, err := uc.getOwnedReminder(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return present(ctx, reminder), nil
+}
+
+// GetReminders retrieves the reminders of a user
+func (uc *ReminderUseCase) GetReminders(ctx context.Context, userID string) ([]domain.Reminder, error) {
+	reminders, err := uc.reminderRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range reminders {
+		present(ctx, &reminders[i])
+	}
+
+	return reminders, nil
+}
+
+// UpdateReminder replaces a reminder and reschedules it, reactivating it
+// unless active is false. Failed attempts are forgotten.
+func (uc *ReminderUseCase) UpdateReminder(ctx context.Context, req *domain.UpdateReminderRequest) (*domain.Reminder, error) {
+	reminder, err := uc.getOwnedReminder(req.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder.Active = req.Active == nil || *req.Active
+	if err := uc.apply(ctx, reminder, &req.CreateReminderRequest); err != nil {
+		return nil, err
+	}
+	reminder.Attempts = 0
+	reminder.LastError = ""
+
+	if err := uc.reminderRepo.Update(reminder); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReminderNotFound
+		}
+		return nil, err
+	}
+
+	return present(ctx, reminder), nil
+}
+
+// DeleteReminder removes a reminder
+func (uc *ReminderUseCase) DeleteReminder(ctx context.Context, id int64, userID string) error {
+	if _, err := uc.getOwnedReminder(id, userID); err != nil {
+		return err
+	}
+
+	if err := uc.reminderRepo.Delete(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrReminderNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (uc *ReminderUseCase) getOwnedReminder(id int64, userID string) (*domain.Reminder, error) {
+	reminder, err := uc.reminderRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if reminder == nil || reminder.UserID != userID {
+		return nil, ErrReminderNotFound
+	}
+
+	return reminder, nil
+}
+
+// apply validates the request and copies it onto the reminder, computing
+// its first run. Rules are bound to the time zone of the request.
+func (uc *ReminderUseCase) apply(ctx context.Context, reminder *domain.Reminder, req *domain.CreateReminderRequest) error {
+	if !uc.channels[req.Channel] {
+		return ErrChannelUnavailable
+	}
+
+	if (req.FireAt == "") == (req.Rule == "") {
+		return ErrInvalidReminderSchedule
+	}
+
+	if req.ChildID != nil {
+		if _, err := uc.childrenUseCase.GetChild(*req.ChildID, reminder.UserID); err != nil {
+			return err
+		}
+	}
+
+	// Webhooks are signed like webhook subscriptions. The secret is kept
+	// when the reminder changes so receivers need not be reconfigured.
+	if req.Channel == notifierDomain.ChannelWebhook {
+		if err := uc.urlPolicy.ValidateURL(req.WebhookURL); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidWebhookURL, err)
+		}
+		if reminder.WebhookSecret == "" {
+			secret, err := apikey.GenerateKey("whsec")
+			if err != nil {
+				return fmt.Errorf("failed to generate webhook secret: %w", err)
+			}
+			reminder.WebhookSecret = secret
+		}
+	}
+
+	now := uc.now()
+	reminder.ChildID = req.ChildID
+	reminder.Title = req.Title
+	reminder.Message = req.Message
+	reminder.Channel = req.Channel
+	reminder.WebhookURL = req.WebhookURL
+	reminder.Timezone = utils.LocationFromContext(ctx).String()
+	reminder.FireAt = nil
+	reminder.Rule = ""
+
+	if req.FireAt != "" {
+		fireAt, err := utils.TimeLocationParsing(ctx, req.FireAt)
+		if err != nil {
+			return ErrInvalidReminderTime
+		}
+		if !fireAt.After(now) && reminder.Active {
+			return ErrFireAtInPast
+		}
+		reminder.FireAt = &fireAt
+		reminder.NextRunAt = &fireAt
+		return nil
+	}
+
+	if _, err := rule.Parse(req.Rule); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+	reminder.Rule = req.Rule
+
+	next, err := reminder.NextOccurrence(now)
+	if err != nil {
+		return err
+	}
+	if next == nil {
+		return ErrRuleNeverMatches
+	}
+	reminder.NextRunAt = next
+
+	return nil
+}
+
+// present moves the reminder times to the user's time zone
+func present(ctx context.Context, reminder *domain.Reminder) *domain.Reminder {
+	loc := utils.LocationFromContext(ctx)
+	for _, t := range []**time.Time{&reminder.FireAt, &reminder.NextRunAt, &reminder.LastRunAt} {
+		if *t != nil {
+			local := (*t).In(loc)
+			*t = &local
+		}
+	}
+	reminder.CreatedAt = reminder.CreatedAt.In(loc)
+	reminder.UpdatedAt = reminder.UpdatedAt.In(loc)
+
+	return reminder
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/children/childrentest"
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	"dailyalu-server/internal/module/reminder/domain"
+	"dailyalu-server/internal/utils"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockReminderRepository is a mock implementation of the reminder repository
+type MockReminderRepository struct {
+	CreateFunc  func(reminder *domain.Reminder) error
+	GetByIDFunc func(id int64) (*domain.Reminder, error)
+	UpdateFunc  func(reminder *domain.Reminder) error
+}
+
+func (m *MockReminderRepository) Create(reminder *domain.Reminder) error {
+	return m.CreateFunc(reminder)
+}
+
+func (m *MockReminderRepository) GetByID(id int64) (*domain.Reminder, error) {
+	return m.GetByIDFunc(id)
+}
+
+func (m *MockReminderRepository) GetByUserID(userID string) ([]domain.Reminder, error) {
+	return nil, nil
+}
+
+func (m *MockReminderRepository) Update(reminder *domain.Reminder) error {
+	return m.UpdateFunc(reminder)
+}
+
+func (m *MockReminderRepository) Delete(id int64) error {
+	return nil
+}
+
+func (m *MockReminderRepository) ClaimDue(now, lockUntil time.Time, limit int) ([]domain.Reminder, error) {
+	return nil, nil
+}
+
+func (m *MockReminderRepository) MarkSent(id int64, lockedUntil, sentAt time.Time, nextRunAt *time.Time) error {
+	return nil
+}
+
+func (m *MockReminderRepository) MarkFailed(id int64, lockedUntil time.Time, attempts int, lastError string, nextRunAt *time.Time) error {
+	return nil
+}
+
+func newTestUseCase(repo *MockReminderRepository) *ReminderUseCase {
+	return &ReminderUseCase{
+		reminderRepo:    repo,
+		childrenUseCase: &childrentest.UseCase{Child: &childrenDomain.Child{ID: 1, UserID: "user-1"}},
+		channels:        map[string]bool{"email": true, "webhook": true},
+		now:             childrentest.Clock(time.Date(2025, 3, 20, 3, 30, 0, 0, time.UTC)),
+	}
+}
+
+func TestCreateReminder(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	ctx := utils.WithLocation(context.Background(), jakarta)
+
+	repo := &MockReminderRepository{
+		CreateFunc: func(reminder *domain.Reminder) error {
+			reminder.ID = 7
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo)
+
+	// 03:30 UTC is 10:30 in Jakarta, so the 08:00 dose is due tomorrow
+	childID := int64(1)
+	reminder, err := uc.CreateReminder(ctx, &domain.CreateReminderRequest{
+		UserID:  "user-1",
+		ChildID: &childID,
+		Title:   "Vitamin D",
+		Channel: "email",
+		Rule:    "0 8 * * *",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := time.Date(2025, 3, 21, 8, 0, 0, 0, jakarta)
+	if reminder.ID != 7 || !reminder.Active || reminder.Timezone != "Asia/Jakarta" || !reminder.NextRunAt.Equal(expected) {
+		t.Errorf("unexpected reminder: %+v", reminder)
+	}
+
+	reminder, err = uc.CreateReminder(ctx, &domain.CreateReminderRequest{
+		UserID:  "user-1",
+		Title:   "Feed again",
+		Channel: "email",
+		FireAt:  "2025-03-20T13:30:00",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reminder.NextRunAt.Equal(time.Date(2025, 3, 20, 6, 30, 0, 0, time.UTC)) {
+		t.Errorf("expected the reminder at 13:30 in Jakarta, got %v", reminder.NextRunAt)
+	}
+
+	testCases := []struct {
+		name     string
+		req      domain.CreateReminderRequest
+		expected error
+	}{
+		{"both fire_at and rule", domain.CreateReminderRequest{Channel: "email", FireAt: "2025-03-21T08:00:00", Rule: "@daily"}, ErrInvalidReminderSchedule},
+		{"neither fire_at nor rule", domain.CreateReminderRequest{Channel: "email"}, ErrInvalidReminderSchedule},
+		{"invalid rule", domain.CreateReminderRequest{Channel: "email", Rule: "every day"}, ErrInvalidRule},
+		{"rule never matches", domain.CreateReminderRequest{Channel: "email", Rule: "0 0 31 2 *"}, ErrRuleNeverMatches},
+		{"in the past", domain.CreateReminderRequest{Channel: "email", FireAt: "2025-03-20T09:00:00"}, ErrFireAtInPast},
+		{"unavailable channel", domain.CreateReminderRequest{Channel: "push", Rule: "@daily"}, ErrChannelUnavailable},
+		{"unknown child", domain.CreateReminderRequest{Channel: "email", Rule: "@daily", ChildID: new(int64)}, childrenUsecase.ErrChildNotFound},
+		{"plain http webhook", domain.CreateReminderRequest{Channel: "webhook", WebhookURL: "http://203.0.113.10/hooks", Rule: "@daily"}, ErrInvalidWebhookURL},
+		{"cloud metadata webhook", domain.CreateReminderRequest{Channel: "webhook", WebhookURL: "https://169.254.169.254/latest/meta-data", Rule: "@daily"}, ErrInvalidWebhookURL},
+		{"loopback webhook", domain.CreateReminderRequest{Channel: "webhook", WebhookURL: "https://127.0.0.1:6379/", Rule: "@daily"}, ErrInvalidWebhookURL},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := tc.req
+			req.UserID = "user-1"
+			req.Title = "Reminder"
+			if _, err := uc.CreateReminder(ctx, &req); !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestUpdateReminderReactivates(t *testing.T) {
+	sentAt := time.Date(2025, 3, 19, 8, 0, 0, 0, time.UTC)
+	repo := &MockReminderRepository{
+		GetByIDFunc: func(id int64) (*domain.Reminder, error) {
+			return &domain.Reminder{ID: id, UserID: "user-1", Channel: "email", FireAt: &sentAt, Attempts: 5, LastError: "timeout"}, nil
+		},
+		UpdateFunc: func(reminder *domain.Reminder) error {
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo)
+
+	req := &domain.UpdateReminderRequest{ID: 7}
+	req.UserID = "user-1"
+	req.Title = "Feed again"
+	req.Channel = "email"
+	req.FireAt = "2025-03-20T06:00:00Z"
+
+	reminder, err := uc.UpdateReminder(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reminder.Active || reminder.Attempts != 0 || reminder.LastError != "" {
+		t.Errorf("expected an active reminder without failures, got %+v", reminder)
+	}
+
+	req.UserID = "user-2"
+	if _, err := uc.UpdateReminder(context.Background(), req); !errors.Is(err, ErrReminderNotFound) {
+		t.Errorf("expected ErrReminderNotFound, got %v", err)
+	}
+}
+
+func TestWebhookReminderSecret(t *testing.T) {
+	var saved *domain.Reminder
+	repo := &MockReminderRepository{
+		CreateFunc: func(reminder *domain.Reminder) error {
+			saved = reminder
+			return nil
+		},
+		GetByIDFunc: func(id int64) (*domain.Reminder, error) {
+			copied := *saved
+			return &copied, nil
+		},
+		UpdateFunc: func(reminder *domain.Reminder) error {
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo)
+
+	req := domain.CreateReminderRequest{UserID: "user-1", Title: "Pump", Channel: "webhook", WebhookURL: "https://203.0.113.10/hooks", Rule: "@daily"}
+	reminder, err := uc.CreateReminder(context.Background(), &req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(reminder.WebhookSecret, "whsec_") {
+		t.Fatalf("expected a webhook secret, got %q", reminder.WebhookSecret)
+	}
+
+	// Changing the reminder keeps the secret receivers already know
+	update := &domain.UpdateReminderRequest{ID: reminder.ID, CreateReminderRequest: req}
+	update.WebhookURL = "https://203.0.113.11/hooks"
+	updated, err := uc.UpdateReminder(context.Background(), update)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.WebhookSecret != reminder.WebhookSecret {
+		t.Errorf("expected the secret to be kept, got %q", updated.WebhookSecret)
+	}
+}
@@ -0,0 +1,241 @@
+package domain
+
+import (
+	activityDomain "dailyalu-server/internal/module/activity/domain"
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	growthDomain "dailyalu-server/internal/module/growth/domain"
+	"dailyalu-server/internal/utils"
+	"encoding/json"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Activity types summarized in reports
+const (
+	ActivityTypeSleep   = "sleep"
+	ActivityTypeFeeding = "feeding"
+	ActivityTypeDiaper  = "diaper"
+)
+
+// MaxPeriodDays is the longest period a report may cover
+const MaxPeriodDays = 93
+
+// ContentType is the media type of generated reports
+const ContentType = "application/pdf"
+
+// ReportRequest represents the request to generate the report of a child.
+// From and To are the first and last day of the period, inclusive.
+type ReportRequest struct {
+	ChildID int64  `json:"-"`
+	UserID  string `json:"-"`
+	From    string `json:"from" validate:"required,datetime=2006-01-02"`
+	To      string `json:"to" validate:"required,datetime=2006-01-02"`
+}
+
+// EmailReportRequest represents the request to email a report. Email
+// defaults to the address of the account.
+type EmailReportRequest struct {
+	ReportRequest
+	Email string `json:"email,omitempty" validate:"omitempty,email"`
+}
+
+// ReportEmailJob is a report email waiting in the outbox. The report is
+// generated when the job is handled, counting days in Timezone.
+type ReportEmailJob struct {
+	ChildID  int64  `json:"child_id"`
+	UserID   string `json:"user_id"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Email    string `json:"email,omitempty"`
+	Timezone string `json:"timezone"`
+}
+
+// Document is a generated report file
+type Document struct {
+	Filename string
+	Content  []byte
+}
+
+// DaySummary holds the totals of one day of the period
+type DaySummary struct {
+	Date  childrenDomain.Date
+	Feeds int
+	// FeedVolumeMl sums the feeds whose amount has a volume unit
+	FeedVolumeMl float64
+	// SleepMinutes counts sleeps on the day they started
+	SleepMinutes int
+	Diapers      int
+}
+
+// Note is a free text note recorded with an activity or a measurement
+type Note struct {
+	At   time.Time
+	Type string
+	Text string
+}
+
+// Report holds everything printed in the report of a child over a period
+type Report struct {
+	Child *childrenDomain.Child
+	From  childrenDomain.Date
+	To    childrenDomain.Date
+	// Location is the time zone days are counted in
+	Location    *time.Location
+	GeneratedAt time.Time
+	Days        []DaySummary
+	Notes       []Note
+	// Measurements lists the measurements taken during the period
+	Measurements []growthDomain.MeasurementResult
+	// WeightChart is nil when the child's date of birth or sex is unknown
+	WeightChart *growthDomain.SeriesResponse
+}
+
+// NewReport creates an empty report with one day summary per day of the
+// period
+func NewReport(child *childrenDomain.Child, from, to childrenDomain.Date, loc *time.Location) *Report {
+	report := &Report{
+		Child:    child,
+		From:     from,
+		To:       to,
+		Location: loc,
+	}
+	for day := from.Time; !day.After(to.Time); day = day.AddDate(0, 0, 1) {
+		report.Days = append(report.Days, DaySummary{Date: childrenDomain.Date{Time: day}})
+	}
+	return report
+}
+
+// PeriodDays returns the number of days between two dates, both included
+func PeriodDays(from, to childrenDomain.Date) int {
+	return from.DaysUntil(to) + 1
+}
+
+// Start returns the first instant of the period in the report's time zone
+func (r *Report) Start() time.Time {
+	return time.Date(r.From.Year(), r.From.Month(), r.From.Day(), 0, 0, 0, 0, r.Location)
+}
+
+// End returns the last instant of the period in the report's time zone
+func (r *Report) End() time.Time {
+	return time.Date(r.To.Year(), r.To.Month(), r.To.Day()+1, 0, 0, 0, 0, r.Location).Add(-time.Nanosecond)
+}
+
+// AddActivity counts an activity in the summary of its day and keeps its
+// notes. Activities outside the period are ignored.
+func (r *Report) AddActivity(activity *activityDomain.Activity) {
+	happensAt := activity.HappensAt.In(r.Location)
+	index := r.From.DaysUntil(childrenDomain.NewDate(happensAt))
+	if index < 0 || index >= len(r.Days) {
+		return
+	}
+
+	day := &r.Days[index]
+	switch activity.Type {
+	case ActivityTypeFeeding:
+		day.Feeds++
+		day.FeedVolumeMl = math.Round((day.FeedVolumeMl+FeedVolumeMl(activity.Details))*100) / 100
+	case ActivityTypeSleep:
+		day.SleepMinutes += int(math.Round(activity.Duration().Minutes()))
+	case ActivityTypeDiaper:
+		day.Diapers++
+	}
+
+	// The notes of the activity and those kept in its details, once each
+	notes := strings.TrimSpace(activity.Notes)
+	if notes != "" {
+		r.Notes = append(r.Notes, Note{At: happensAt, Type: activity.Type, Text: notes})
+	}
+	if text := DetailsNote(activity.Details); text != "" && text != notes {
+		r.Notes = append(r.Notes, Note{At: happensAt, Type: activity.Type, Text: text})
+	}
+}
+
+// AddMeasurements keeps the measurements taken during the period and their
+// notes
+func (r *Report) AddMeasurements(measurements []growthDomain.MeasurementResult) {
+	for _, measurement := range measurements {
+		if measurement.MeasuredOn.Before(r.From.Time) || measurement.MeasuredOn.After(r.To.Time) {
+			continue
+		}
+		r.Measurements = append(r.Measurements, measurement)
+
+		if text := strings.TrimSpace(measurement.Note); text != "" {
+			on := measurement.MeasuredOn
+			r.Notes = append(r.Notes, Note{
+				At:   time.Date(on.Year(), on.Month(), on.Day(), 0, 0, 0, 0, r.Location),
+				Type: "measurement",
+				Text: text,
+			})
+		}
+	}
+
+	sort.SliceStable(r.Notes, func(i, j int) bool { return r.Notes[i].At.Before(r.Notes[j].At) })
+}
+
+// Totals sums the day summaries
+func (r *Report) Totals() DaySummary {
+	var totals DaySummary
+	for _, day := range r.Days {
+		totals.Feeds += day.Feeds
+		totals.FeedVolumeMl += day.FeedVolumeMl
+		totals.SleepMinutes += day.SleepMinutes
+		totals.Diapers += day.Diapers
+	}
+	return totals
+}
+
+// Filename returns the name the report is downloaded or attached as
+func (r *Report) Filename() string {
+	name := strings.Trim(unsafeFilenameChars.ReplaceAllString(strings.ToLower(r.Child.Name), "-"), "-")
+	if name == "" {
+		name = "child"
+	}
+	return name + "-report-" + r.From.String() + "-" + r.To.String() + ".pdf"
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// FeedVolumeMl returns the amount of a feed in milliliters, or zero when
+// the details give no amount in a volume unit
+func FeedVolumeMl(details json.RawMessage) float64 {
+	var fields struct {
+		Amount *float64 `json:"amount"`
+		Volume *float64 `json:"volume"`
+		Unit   string   `json:"unit"`
+	}
+	if err := json.Unmarshal(details, &fields); err != nil {
+		return 0
+	}
+
+	amount := fields.Amount
+	if amount == nil {
+		amount = fields.Volume
+	}
+	if amount == nil || *amount <= 0 {
+		return 0
+	}
+
+	ml, ok := utils.ConvertUnit(*amount, fields.Unit, "ml")
+	if !ok {
+		return 0
+	}
+	return ml
+}
+
+// DetailsNote returns the "notes" or "note" text of activity details
+func DetailsNote(details json.RawMessage) string {
+	var fields struct {
+		Notes string `json:"notes"`
+		Note  string `json:"note"`
+	}
+	if err := json.Unmarshal(details, &fields); err != nil {
+		return ""
+	}
+	if text := strings.TrimSpace(fields.Notes); text != "" {
+		return text
+	}
+	return strings.TrimSpace(fields.Note)
+}
@@ -0,0 +1,189 @@
+package render
+
+import (
+	"dailyalu-server/internal/module/growth/standards"
+	"dailyalu-server/internal/module/report/domain"
+	"dailyalu-server/pkg/pdf"
+	"math"
+	"strconv"
+)
+
+// Chart layout in points
+const (
+	chartHeight     = 230.0
+	chartAxisWidth  = 32.0
+	chartLabelWidth = 28.0
+	// chartMinMonths is the narrowest age range drawn, so that the curves of
+	// newborns still show their trend
+	chartMinMonths = 6
+)
+
+var (
+	curveColor  = pdf.RGB(173, 181, 189)
+	medianColor = pdf.RGB(108, 117, 125)
+	gridColor   = pdf.RGB(233, 236, 239)
+)
+
+// growthChart draws the child's weights up to the end of the period against
+// the WHO percentile curves, from birth to shortly after the child's age
+func growthChart(l *layout, report *domain.Report) {
+	l.heading("Weight-for-age")
+
+	chart := report.WeightChart
+	if chart == nil || len(chart.Curves) == 0 {
+		l.text(pdf.Helvetica, 10, mutedColor, "The growth chart needs the child's date of birth and sex.")
+		return
+	}
+
+	// The age range ends the month after the last day of the period or
+	// measurement drawn
+	endDays := 0
+	if report.Child.DateOfBirth != nil {
+		endDays = report.Child.DateOfBirth.DaysUntil(report.To)
+	}
+	var points []pdf.Point
+	for _, point := range chart.Points {
+		if point.MeasuredOn.After(report.To.Time) {
+			continue
+		}
+		endDays = max(endDays, point.AgeDays)
+		points = append(points, pdf.Point{X: float64(point.AgeDays), Y: point.Value})
+	}
+	endMonths := max(chartMinMonths, int(math.Ceil(float64(endDays)/standards.DaysPerMonth))+1)
+
+	// Keep the curves within the range, and the range within the curves
+	curves := make([][]pdf.Point, len(chart.Curves))
+	xMax, yMin, yMax := 0.0, math.Inf(1), math.Inf(-1)
+	for i, curve := range chart.Curves {
+		for _, point := range curve.Points {
+			if point.AgeMonths > endMonths {
+				break
+			}
+			curves[i] = append(curves[i], pdf.Point{X: float64(point.AgeDays), Y: point.Value})
+			xMax = max(xMax, float64(point.AgeDays))
+			yMin, yMax = min(yMin, point.Value), max(yMax, point.Value)
+		}
+	}
+	var drawn []pdf.Point
+	for _, point := range points {
+		if point.X <= xMax {
+			drawn = append(drawn, point)
+			yMin, yMax = min(yMin, point.Y), max(yMax, point.Y)
+		}
+	}
+	if xMax == 0 || yMax <= yMin {
+		l.text(pdf.Helvetica, 10, mutedColor, "No reference curves are available for the child's age.")
+		return
+	}
+
+	yStep := niceStep(yMax-yMin, 8)
+	yMin = math.Floor(yMin/yStep) * yStep
+	yMax = math.Ceil(yMax/yStep) * yStep
+
+	l.reserve(chartHeight + 30)
+	left := margin + chartAxisWidth
+	width := l.width() - chartAxisWidth - chartLabelWidth
+	top := l.y + 6
+	bottom := top + chartHeight
+
+	toPage := func(p pdf.Point) pdf.Point {
+		return pdf.Point{
+			X: left + p.X/xMax*width,
+			Y: bottom - (p.Y-yMin)/(yMax-yMin)*chartHeight,
+		}
+	}
+
+	// Grid with values on the left and ages in months below
+	for value := yMin; value <= yMax+yStep/2; value += yStep {
+		y := toPage(pdf.Point{Y: value}).Y
+		l.page.Line(left, y, left+width, y, 0.5, gridColor)
+		label := strconv.FormatFloat(value, 'f', decimalsOf(yStep), 64)
+		l.page.Text(left-6-pdf.TextWidth(pdf.Helvetica, 7, label), y+2.5, pdf.Helvetica, 7, mutedColor, label)
+	}
+	monthStep := niceMonthStep(endMonths)
+	for month := 0; month <= endMonths; month += monthStep {
+		// Curve points fall on whole days, up to a day before the month
+		days := float64(month) * standards.DaysPerMonth
+		if days > xMax+1 {
+			break
+		}
+		x := toPage(pdf.Point{X: min(days, xMax)}).X
+		l.page.Line(x, top, x, bottom, 0.5, gridColor)
+		label := strconv.Itoa(month)
+		l.page.Text(x-pdf.TextWidth(pdf.Helvetica, 7, label)/2, bottom+10, pdf.Helvetica, 7, mutedColor, label)
+	}
+	l.page.Line(left, bottom, left+width, bottom, 0.75, medianColor)
+	l.page.Line(left, top, left, bottom, 0.75, medianColor)
+
+	// Percentile curves, labelled at their end
+	for i, curve := range curves {
+		if len(curve) == 0 {
+			continue
+		}
+		page := make([]pdf.Point, len(curve))
+		for j, point := range curve {
+			page[j] = toPage(point)
+		}
+
+		color, lineWidth := curveColor, 0.75
+		if chart.Curves[i].Percentile == 50 {
+			color, lineWidth = medianColor, 1.25
+		}
+		l.page.Polyline(page, lineWidth, color)
+
+		end := page[len(page)-1]
+		l.page.Text(end.X+4, end.Y+2.5, pdf.Helvetica, 7, color, "P"+strconv.FormatFloat(chart.Curves[i].Percentile, 'f', -1, 64))
+	}
+
+	// The child's weights
+	page := make([]pdf.Point, len(drawn))
+	for i, point := range drawn {
+		page[i] = toPage(point)
+	}
+	l.page.Polyline(page, 1.5, accentColor)
+	for _, point := range page {
+		l.page.Circle(point.X, point.Y, 2.5, accentColor)
+	}
+
+	l.y = bottom + 24
+	caption := "Weight (" + chart.Unit + ") by age in months against the WHO child growth standards"
+	if chart.Corrected {
+		caption += ", at corrected age"
+	}
+	if len(drawn) == 0 {
+		caption += ". No weight has been recorded yet"
+	}
+	l.text(pdf.Helvetica, 8, mutedColor, caption+".")
+}
+
+// niceStep returns a round step dividing span into at most count intervals
+func niceStep(span float64, count int) float64 {
+	magnitude := math.Pow(10, math.Floor(math.Log10(span/float64(count))))
+	for _, factor := range []float64{1, 2, 2.5, 5, 10} {
+		if step := factor * magnitude; span/step <= float64(count) {
+			return step
+		}
+	}
+	return 10 * magnitude
+}
+
+// decimalsOf returns the decimals needed to print multiples of step
+func decimalsOf(step float64) int {
+	for decimals := 0; decimals < 3; decimals++ {
+		scaled := step * math.Pow(10, float64(decimals))
+		if math.Abs(scaled-math.Round(scaled)) < 1e-9 {
+			return decimals
+		}
+	}
+	return 3
+}
+
+// niceMonthStep returns the interval of the age labels for a range of months
+func niceMonthStep(months int) int {
+	for _, step := range []int{1, 2, 3, 6, 12} {
+		if months/step <= 12 {
+			return step
+		}
+	}
+	return 12
+}
@@ -0,0 +1,390 @@
+// Package render lays out child reports as printable A4 PDF documents
+package render
+
+import (
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	"dailyalu-server/internal/module/report/domain"
+	"dailyalu-server/pkg/pdf"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Page layout in points
+const (
+	margin       = 48.0
+	footerHeight = 28.0
+	lineHeight   = 14.0
+	rowHeight    = 16.0
+)
+
+var (
+	textColor   = pdf.RGB(33, 37, 41)
+	mutedColor  = pdf.RGB(108, 117, 125)
+	ruleColor   = pdf.RGB(206, 212, 218)
+	shadeColor  = pdf.RGB(241, 243, 245)
+	accentColor = pdf.RGB(214, 51, 132)
+)
+
+// Render lays out the report: the child's profile, the weight chart, the
+// measurements of the period, the daily summaries and the notes
+func Render(report *domain.Report) ([]byte, error) {
+	doc := pdf.New()
+	doc.SetTitle(report.Child.Name + " - report " + report.From.String() + " to " + report.To.String())
+
+	l := &layout{doc: doc}
+	l.newPage()
+
+	header(l, report)
+	profile(l, report)
+	summary(l, report)
+	growthChart(l, report)
+	measurements(l, report)
+	days(l, report)
+	notes(l, report)
+
+	l.footers(report.Child.Name + " - DailyAlu report, " + report.From.String() + " to " + report.To.String())
+
+	return doc.Bytes()
+}
+
+// layout places content from the top of the page down, starting a new page
+// when the next block does not fit
+type layout struct {
+	doc   *pdf.Document
+	pages []*pdf.Page
+	page  *pdf.Page
+	y     float64
+}
+
+func (l *layout) newPage() {
+	l.page = l.doc.AddPage()
+	l.pages = append(l.pages, l.page)
+	l.y = margin
+}
+
+func (l *layout) width() float64 {
+	return l.doc.Width() - 2*margin
+}
+
+// fits reports whether a block of the height fits on the current page
+func (l *layout) fits(height float64) bool {
+	return l.y+height <= l.doc.Height()-margin-footerHeight
+}
+
+// reserve moves to a new page unless a block of the height fits
+func (l *layout) reserve(height float64) bool {
+	if l.fits(height) {
+		return false
+	}
+	l.newPage()
+	return true
+}
+
+// text writes a line at the left margin and moves below it
+func (l *layout) text(font pdf.Font, size float64, color pdf.Color, text string) {
+	l.page.Text(margin, l.y+size, font, size, color, pdf.Truncate(font, size, l.width(), text))
+	l.y += size + 4
+}
+
+// heading starts a section, keeping it together with the next lines
+func (l *layout) heading(title string) {
+	l.y += 10
+	l.reserve(60)
+	l.text(pdf.HelveticaBold, 13, textColor, title)
+	l.page.Line(margin, l.y, margin+l.width(), l.y, 0.75, accentColor)
+	l.y += 8
+}
+
+// footers numbers every page
+func (l *layout) footers(title string) {
+	for i, page := range l.pages {
+		y := l.doc.Height() - margin + 8
+		page.Line(margin, y-12, margin+l.width(), y-12, 0.5, ruleColor)
+		page.Text(margin, y, pdf.Helvetica, 8, mutedColor, title)
+		number := fmt.Sprintf("Page %d of %d", i+1, len(l.pages))
+		page.Text(margin+l.width()-pdf.TextWidth(pdf.Helvetica, 8, number), y, pdf.Helvetica, 8, mutedColor, number)
+	}
+}
+
+// column is a table column. Numeric columns are right aligned.
+type column struct {
+	title   string
+	width   float64
+	numeric bool
+}
+
+// table draws rows under a header, repeating the header on every page. With
+// boldLast, the last row is printed in bold, e.g. for totals.
+func (l *layout) table(columns []column, rows [][]string, boldLast bool) {
+	drawHeader := func() {
+		l.page.Rect(margin, l.y, l.width(), rowHeight+2, shadeColor)
+		l.row(columns, titles(columns), pdf.HelveticaBold)
+		l.y += 2
+	}
+
+	l.reserve(2 * rowHeight)
+	drawHeader()
+	for i, row := range rows {
+		if l.reserve(rowHeight) {
+			drawHeader()
+		}
+		font := pdf.Helvetica
+		if boldLast && i == len(rows)-1 {
+			font = pdf.HelveticaBold
+			l.page.Line(margin, l.y, margin+l.width(), l.y, 0.5, ruleColor)
+		}
+		l.row(columns, row, font)
+	}
+}
+
+func (l *layout) row(columns []column, cells []string, font pdf.Font) {
+	const size, padding = 9.0, 4.0
+
+	x := margin
+	for i, c := range columns {
+		text := pdf.Truncate(font, size, c.width-2*padding, cells[i])
+		textX := x + padding
+		if c.numeric {
+			textX = x + c.width - padding - pdf.TextWidth(font, size, text)
+		}
+		l.page.Text(textX, l.y+rowHeight-5, font, size, textColor, text)
+		x += c.width
+	}
+	l.y += rowHeight
+}
+
+func titles(columns []column) []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.title
+	}
+	return names
+}
+
+func header(l *layout, report *domain.Report) {
+	l.text(pdf.HelveticaBold, 9, accentColor, "DAILYALU")
+	l.text(pdf.HelveticaBold, 20, textColor, report.Child.Name)
+	l.text(pdf.Helvetica, 11, textColor, fmt.Sprintf("Report from %s to %s (%d days)",
+		report.From.String(), report.To.String(), len(report.Days)))
+	l.text(pdf.Helvetica, 8, mutedColor, fmt.Sprintf("Generated on %s, times in %s",
+		report.GeneratedAt.Format("2006-01-02 15:04"), report.Location.String()))
+}
+
+func profile(l *layout, report *domain.Report) {
+	child := report.Child
+	l.heading("Profile")
+
+	var fields [][2]string
+	if child.DateOfBirth != nil {
+		fields = append(fields,
+			[2]string{"Date of birth", child.DateOfBirth.String()},
+			[2]string{"Age at end of period", age(*child.DateOfBirth, report.To)},
+		)
+	}
+	if child.Sex != "" {
+		fields = append(fields, [2]string{"Sex", titleCase(child.Sex)})
+	}
+	if days, ok := child.GestationalAgeAtBirthDays(); ok {
+		value := fmt.Sprintf("%d weeks %d days", days/7, days%7)
+		if child.IsPremature() {
+			value += " (premature)"
+		}
+		fields = append(fields, [2]string{"Gestational age at birth", value})
+	}
+	if child.BirthWeightGrams != nil {
+		fields = append(fields, [2]string{"Birth weight", strconv.Itoa(*child.BirthWeightGrams) + " g"})
+	}
+	if child.BirthLengthCm != nil {
+		fields = append(fields, [2]string{"Birth length", decimal(*child.BirthLengthCm, 1) + " cm"})
+	}
+	if child.BirthHeadCircumferenceCm != nil {
+		fields = append(fields, [2]string{"Birth head circumference", decimal(*child.BirthHeadCircumferenceCm, 1) + " cm"})
+	}
+
+	if len(fields) == 0 {
+		l.text(pdf.Helvetica, 10, mutedColor, "No birth profile recorded.")
+		return
+	}
+
+	// Two columns of label and value pairs
+	half := l.width() / 2
+	for i := 0; i < len(fields); i += 2 {
+		l.reserve(lineHeight)
+		for j := 0; j < 2 && i+j < len(fields); j++ {
+			x := margin + float64(j)*half
+			l.page.Text(x, l.y+10, pdf.Helvetica, 9, mutedColor, fields[i+j][0])
+			l.page.Text(x+125, l.y+10, pdf.HelveticaBold, 10, textColor, pdf.Truncate(pdf.HelveticaBold, 10, half-130, fields[i+j][1]))
+		}
+		l.y += lineHeight
+	}
+}
+
+func summary(l *layout, report *domain.Report) {
+	l.heading("Daily averages")
+
+	totals := report.Totals()
+	count := float64(len(report.Days))
+	stats := [][2]string{
+		{"Feeds", decimal(float64(totals.Feeds)/count, 1)},
+		{"Feed volume", decimal(totals.FeedVolumeMl/count, 0) + " ml"},
+		{"Sleep", hours(float64(totals.SleepMinutes) / count)},
+		{"Diapers", decimal(float64(totals.Diapers)/count, 1)},
+	}
+
+	l.reserve(36)
+	width := l.width() / float64(len(stats))
+	for i, stat := range stats {
+		x := margin + float64(i)*width
+		l.page.Rect(x+2, l.y, width-4, 34, shadeColor)
+		l.page.Text(x+10, l.y+13, pdf.Helvetica, 8, mutedColor, stat[0])
+		l.page.Text(x+10, l.y+28, pdf.HelveticaBold, 13, textColor, stat[1])
+	}
+	l.y += 36
+}
+
+func measurements(l *layout, report *domain.Report) {
+	l.heading("Measurements")
+
+	if len(report.Measurements) == 0 {
+		l.text(pdf.Helvetica, 10, mutedColor, "No measurements were taken during this period.")
+		return
+	}
+
+	w := l.width()
+	columns := []column{
+		{"Date", w * 0.2, false},
+		{"Weight (kg)", w * 0.16, true},
+		{"Length (cm)", w * 0.16, true},
+		{"Head (cm)", w * 0.16, true},
+		{"Weight percentile", w * 0.32, true},
+	}
+
+	rows := make([][]string, 0, len(report.Measurements))
+	for _, m := range report.Measurements {
+		percentile := "-"
+		if m.Scores != nil && m.Scores.WeightForAge != nil {
+			percentile = "P" + decimal(m.Scores.WeightForAge.Percentile, 1)
+		}
+		rows = append(rows, []string{
+			m.MeasuredOn.String(),
+			optional(m.WeightKg, 2),
+			optional(m.LengthCm, 1),
+			optional(m.HeadCircumferenceCm, 1),
+			percentile,
+		})
+	}
+	l.table(columns, rows, false)
+}
+
+func days(l *layout, report *domain.Report) {
+	l.heading("Daily log")
+
+	w := l.width()
+	columns := []column{
+		{"Date", w * 0.28, false},
+		{"Feeds", w * 0.14, true},
+		{"Volume (ml)", w * 0.2, true},
+		{"Sleep", w * 0.2, true},
+		{"Diapers", w * 0.18, true},
+	}
+
+	rows := make([][]string, 0, len(report.Days)+1)
+	for _, day := range report.Days {
+		rows = append(rows, []string{
+			day.Date.Format("Mon 2006-01-02"),
+			strconv.Itoa(day.Feeds),
+			decimal(day.FeedVolumeMl, 0),
+			hours(float64(day.SleepMinutes)),
+			strconv.Itoa(day.Diapers),
+		})
+	}
+	totals := report.Totals()
+	rows = append(rows, []string{
+		"Total",
+		strconv.Itoa(totals.Feeds),
+		decimal(totals.FeedVolumeMl, 0),
+		hours(float64(totals.SleepMinutes)),
+		strconv.Itoa(totals.Diapers),
+	})
+	l.table(columns, rows, true)
+}
+
+func notes(l *layout, report *domain.Report) {
+	l.heading("Notes")
+
+	if len(report.Notes) == 0 {
+		l.text(pdf.Helvetica, 10, mutedColor, "No notes were recorded during this period.")
+		return
+	}
+
+	const size = 9.0
+	textX := margin + 150
+	for _, note := range report.Notes {
+		when := note.At.Format("2006-01-02 15:04")
+		if note.Type == "measurement" {
+			when = note.At.Format("2006-01-02")
+		}
+
+		lines := pdf.Wrap(pdf.Helvetica, size, margin+l.width()-textX, note.Text)
+		l.reserve(float64(len(lines))*lineHeight + 4)
+		l.page.Text(margin, l.y+10, pdf.Helvetica, 8, mutedColor, when)
+		l.page.Text(margin+80, l.y+10, pdf.HelveticaBold, 8, textColor, titleCase(note.Type))
+		for _, line := range lines {
+			l.page.Text(textX, l.y+10, pdf.Helvetica, size, textColor, line)
+			l.y += lineHeight
+		}
+		l.y += 4
+	}
+}
+
+// age formats the age on a day as months and days, or years and months
+// from two years
+func age(dob, on childrenDomain.Date) string {
+	if on.Before(dob.Time) {
+		return "-"
+	}
+
+	months := (on.Year()-dob.Year())*12 + int(on.Month()) - int(dob.Month())
+	if on.Day() < dob.Day() {
+		months--
+	}
+	days := childrenDomain.Date{Time: dob.AddDate(0, months, 0)}.DaysUntil(on)
+
+	if months >= 24 {
+		return plural(months/12, "year") + " " + plural(months%12, "month")
+	}
+	return plural(months, "month") + " " + plural(days, "day")
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return strconv.Itoa(n) + " " + unit + "s"
+}
+
+// hours formats minutes as hours and minutes, e.g. "13h 05m"
+func hours(minutes float64) string {
+	total := int(minutes + 0.5)
+	return fmt.Sprintf("%dh %02dm", total/60, total%60)
+}
+
+func decimal(value float64, decimals int) string {
+	return strconv.FormatFloat(value, 'f', decimals, 64)
+}
+
+func optional(value *float64, decimals int) string {
+	if value == nil {
+		return "-"
+	}
+	return decimal(*value, decimals)
+}
+
+func titleCase(s string) string {
+	s = strings.ReplaceAll(s, "_", " ")
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
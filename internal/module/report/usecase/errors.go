@@ -0,0 +1,9 @@
+package usecase
+
+import "errors"
+
+// Domain errors for report module
+var (
+	ErrInvalidReportPeriod = errors.New("report must end on or after the day it starts")
+	ErrReportPeriodTooLong = errors.New("report period is too long")
+)
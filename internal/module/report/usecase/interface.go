@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/report/domain"
+)
+
+// IReportUseCase defines the interface for child report business logic
+type IReportUseCase interface {
+	Generate(ctx context.Context, req *domain.ReportRequest) (*domain.Document, error)
+	// Email checks the request and queues the report email in the outbox
+	Email(ctx context.Context, req *domain.EmailReportRequest) error
+	// SendEmail generates and sends a queued report email
+	SendEmail(ctx context.Context, job *domain.ReportEmailJob) error
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	activityDomain "dailyalu-server/internal/module/activity/domain"
+	activityRepo "dailyalu-server/internal/module/activity/repository"
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	"dailyalu-server/internal/module/growth/standards"
+	growthUsecase "dailyalu-server/internal/module/growth/usecase"
+	outboxDomain "dailyalu-server/internal/module/outbox/domain"
+	outboxRepo "dailyalu-server/internal/module/outbox/repository"
+	"dailyalu-server/internal/module/report/domain"
+	"dailyalu-server/internal/module/report/render"
+	mailerDomain "dailyalu-server/internal/service/mailer/domain"
+	notifierDomain "dailyalu-server/internal/service/notifier/domain"
+	"dailyalu-server/internal/utils"
+	"errors"
+	"time"
+)
+
+// RecipientResolver returns the account a report is emailed to by default
+type RecipientResolver func(userID string) (*notifierDomain.Recipient, error)
+
+// ReportUseCase implements the report use case interface
+type ReportUseCase struct {
+	activityRepo     activityRepo.IActivityRepository
+	childrenUseCase  childrenUsecase.IChildrenUseCase
+	growthUseCase    growthUsecase.IGrowthUseCase
+	mailerService    mailerDomain.IMailerService
+	outboxRepo       outboxRepo.IOutboxRepository
+	resolveRecipient RecipientResolver
+	now              func() time.Time
+}
+
+// NewReportUseCase creates a new report use case
+func NewReportUseCase(activityRepo activityRepo.IActivityRepository, childrenUseCase childrenUsecase.IChildrenUseCase, growthUseCase growthUsecase.IGrowthUseCase, mailerService mailerDomain.IMailerService, outboxRepo outboxRepo.IOutboxRepository, resolveRecipient RecipientResolver) IReportUseCase {
+	return &ReportUseCase{
+		activityRepo:     activityRepo,
+		childrenUseCase:  childrenUseCase,
+		growthUseCase:    growthUseCase,
+		mailerService:    mailerService,
+		outboxRepo:       outboxRepo,
+		resolveRecipient: resolveRecipient,
+		now:              time.Now,
+	}
+}
+
+// Generate renders the PDF report of a child owned by the user. Days are
+// counted in the time zone of the context.
+func (uc *ReportUseCase) Generate(ctx context.Context, req *domain.ReportRequest) (*domain.Document, error) {
+	report, err := uc.build(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := render.Render(report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Document{Filename: report.Filename(), Content: content}, nil
+}
+
+// Email checks the period and access to the child, then queues the report
+// email in the outbox. Rendering and sending happen in the background.
+func (uc *ReportUseCase) Email(ctx context.Context, req *domain.EmailReportRequest) error {
+	if _, _, err := parsePeriod(&req.ReportRequest); err != nil {
+		return err
+	}
+	if _, err := uc.childrenUseCase.GetChild(req.ChildID, req.UserID); err != nil {
+		return err
+	}
+
+	message, err := outboxDomain.NewMessage(outboxDomain.TopicReportEmail, &domain.ReportEmailJob{
+		ChildID:  req.ChildID,
+		UserID:   req.UserID,
+		From:     req.From,
+		To:       req.To,
+		Email:    req.Email,
+		Timezone: utils.LocationFromContext(ctx).String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return uc.outboxRepo.Create(message)
+}
+
+// SendEmail generates a queued report and sends it as an attachment, to the
+// account's address unless the job names another one
+func (uc *ReportUseCase) SendEmail(ctx context.Context, job *domain.ReportEmailJob) error {
+	loc, err := utils.LoadLocation(job.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	ctx = utils.WithLocation(ctx, loc)
+
+	recipient, err := uc.resolveRecipient(job.UserID)
+	if err != nil {
+		return err
+	}
+
+	req := &domain.ReportRequest{ChildID: job.ChildID, UserID: job.UserID, From: job.From, To: job.To}
+	report, err := uc.build(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	content, err := render.Render(report)
+	if err != nil {
+		return err
+	}
+
+	to := recipient.Email
+	if job.Email != "" {
+		to = job.Email
+	}
+
+	return uc.mailerService.SendReportEmail(ctx, &mailerDomain.ReportEmailData{
+		Name:        recipient.Name,
+		ChildName:   report.Child.Name,
+		PeriodStart: req.From,
+		PeriodEnd:   req.To,
+		Report: mailerDomain.Attachment{
+			Filename:    report.Filename(),
+			ContentType: domain.ContentType,
+			Data:        content,
+		},
+		To:     to,
+		Locale: recipient.Locale,
+	})
+}
+
+// build gathers the data of a report: the child's profile, the activities of
+// the period summarized by day and the growth measurements
+func (uc *ReportUseCase) build(ctx context.Context, req *domain.ReportRequest) (*domain.Report, error) {
+	from, to, err := parsePeriod(req)
+	if err != nil {
+		return nil, err
+	}
+
+	child, err := uc.childrenUseCase.GetChild(req.ChildID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := domain.NewReport(child, from, to, utils.LocationFromContext(ctx))
+	report.GeneratedAt = uc.now().In(report.Location)
+
+	search := &activityDomain.SearchActivityRequest{
+		UserID:    req.UserID,
+		ChildID:   int(child.ID),
+		StartDate: report.Start(),
+		EndDate:   report.End(),
+	}
+	err = uc.activityRepo.Export(ctx, search, func(activity *activityDomain.Activity) error {
+		report.AddActivity(activity)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	measurements, err := uc.growthUseCase.GetMeasurements(child.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	report.AddMeasurements(measurements)
+
+	// The chart needs the date of birth and sex; the rest of the report
+	// does not
+	chart, err := uc.growthUseCase.GetSeries(child.ID, req.UserID, standards.WeightForAge)
+	if err != nil && !errors.Is(err, growthUsecase.ErrIncompleteBirthProfile) {
+		return nil, err
+	}
+	report.WeightChart = chart
+
+	return report, nil
+}
+
+// parsePeriod returns the first and last day of a report, which may not be
+// more than MaxPeriodDays apart
+func parsePeriod(req *domain.ReportRequest) (childrenDomain.Date, childrenDomain.Date, error) {
+	from, err := childrenDomain.ParseDate(req.From)
+	if err != nil {
+		return childrenDomain.Date{}, childrenDomain.Date{}, ErrInvalidReportPeriod
+	}
+	to, err := childrenDomain.ParseDate(req.To)
+	if err != nil {
+		return childrenDomain.Date{}, childrenDomain.Date{}, ErrInvalidReportPeriod
+	}
+	if to.Before(from.Time) {
+		return childrenDomain.Date{}, childrenDomain.Date{}, ErrInvalidReportPeriod
+	}
+	if domain.PeriodDays(from, to) > domain.MaxPeriodDays {
+		return childrenDomain.Date{}, childrenDomain.Date{}, ErrReportPeriodTooLong
+	}
+	return from, to, nil
+}
@@ -0,0 +1,378 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	activityDomain "dailyalu-server/internal/module/activity/domain"
+	activityRepo "dailyalu-server/internal/module/activity/repository"
+	"dailyalu-server/internal/module/children/childrentest"
+	childrenDomain "dailyalu-server/internal/module/children/domain"
+	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	growthDomain "dailyalu-server/internal/module/growth/domain"
+	growthUsecase "dailyalu-server/internal/module/growth/usecase"
+	outboxDomain "dailyalu-server/internal/module/outbox/domain"
+	outboxRepo "dailyalu-server/internal/module/outbox/repository"
+	"dailyalu-server/internal/module/report/domain"
+	mailerDomain "dailyalu-server/internal/service/mailer/domain"
+	notifierDomain "dailyalu-server/internal/service/notifier/domain"
+	"dailyalu-server/internal/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// MockActivityRepository exports the activities within the search period
+type MockActivityRepository struct {
+	activityRepo.IActivityRepository
+	Activities []activityDomain.Activity
+	LastSearch *activityDomain.SearchActivityRequest
+}
+
+func (m *MockActivityRepository) Export(ctx context.Context, req *activityDomain.SearchActivityRequest, fn func(activity *activityDomain.Activity) error) error {
+	m.LastSearch = req
+	for i := range m.Activities {
+		activity := m.Activities[i]
+		if activity.HappensAt.Before(req.StartDate) || activity.HappensAt.After(req.EndDate) {
+			continue
+		}
+		if err := fn(&activity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MockGrowthUseCase serves the measurements of the child and a weight chart
+// when its birth profile allows one
+type MockGrowthUseCase struct {
+	growthUsecase.IGrowthUseCase
+	Child        *childrenDomain.Child
+	Measurements []growthDomain.MeasurementResult
+}
+
+func (m *MockGrowthUseCase) GetMeasurements(childID int64, userID string) ([]growthDomain.MeasurementResult, error) {
+	return m.Measurements, nil
+}
+
+func (m *MockGrowthUseCase) GetSeries(childID int64, userID, indicator string) (*growthDomain.SeriesResponse, error) {
+	if m.Child.DateOfBirth == nil || m.Child.Sex == "" {
+		return nil, growthUsecase.ErrIncompleteBirthProfile
+	}
+
+	series := &growthDomain.SeriesResponse{ChildID: childID, Indicator: indicator, Unit: "kg", Sex: m.Child.Sex}
+	for _, measurement := range m.Measurements {
+		if measurement.WeightKg != nil {
+			series.Points = append(series.Points, growthDomain.SeriesPoint{
+				MeasuredOn: measurement.MeasuredOn,
+				AgeDays:    m.Child.DateOfBirth.DaysUntil(measurement.MeasuredOn),
+				Value:      *measurement.WeightKg,
+			})
+		}
+	}
+	for _, percentile := range []float64{3, 50, 97} {
+		curve := growthDomain.ReferenceCurve{Percentile: percentile}
+		for month := 0; month <= 24; month++ {
+			curve.Points = append(curve.Points, growthDomain.CurvePoint{
+				AgeMonths: month,
+				AgeDays:   int(float64(month) * 30.4375),
+				Value:     3 + float64(month)*0.4 + (percentile-50)/50,
+			})
+		}
+		series.Curves = append(series.Curves, curve)
+	}
+	return series, nil
+}
+
+// MockMailerService records the reports it sends
+type MockMailerService struct {
+	mailerDomain.IMailerService
+	Sent []*mailerDomain.ReportEmailData
+}
+
+func (m *MockMailerService) SendReportEmail(ctx context.Context, data *mailerDomain.ReportEmailData) error {
+	m.Sent = append(m.Sent, data)
+	return nil
+}
+
+// MockOutboxRepository records the messages it is given
+type MockOutboxRepository struct {
+	outboxRepo.IOutboxRepository
+	Created []*outboxDomain.Message
+}
+
+func (m *MockOutboxRepository) Create(message *outboxDomain.Message) error {
+	m.Created = append(m.Created, message)
+	return nil
+}
+
+var jakarta = time.FixedZone("WIB", 7*60*60)
+
+func date(value string) childrenDomain.Date {
+	d, _ := childrenDomain.ParseDate(value)
+	return d
+}
+
+func value(v float64) *float64 {
+	return &v
+}
+
+// newTestUseCase serves Budi, born on 2024-11-15, to "user-1", with
+// activities around the first days of March 2025 in Jakarta
+func newTestUseCase() (*ReportUseCase, *MockActivityRepository, *MockMailerService) {
+	dob := date("2024-11-15")
+	child := &childrenDomain.Child{
+		ID:     1,
+		UserID: "user-1",
+		Name:   "Budi",
+		BirthProfile: childrenDomain.BirthProfile{
+			DateOfBirth: &dob,
+			Sex:         childrenDomain.SexMale,
+		},
+	}
+
+	activity := func(activityType, details string, happensAt time.Time) activityDomain.Activity {
+		return activityDomain.Activity{ChildID: 1, UserID: "user-1", Type: activityType, Details: json.RawMessage(details), HappensAt: happensAt}
+	}
+	activities := &MockActivityRepository{Activities: []activityDomain.Activity{
+		// The last evening of February in Jakarta is outside the period
+		activity(domain.ActivityTypeFeeding, `{"amount": 100, "unit": "ml"}`, time.Date(2025, 2, 28, 23, 30, 0, 0, jakarta)),
+		activity(domain.ActivityTypeFeeding, `{"amount": 120, "unit": "ml", "notes": "Formula milk"}`, time.Date(2025, 3, 1, 7, 0, 0, 0, jakarta)),
+		activity(domain.ActivityTypeFeeding, `{"amount": 4, "unit": "oz"}`, time.Date(2025, 3, 1, 23, 30, 0, 0, jakarta)),
+		activity(domain.ActivityTypeSleep, `{"duration_minutes": 90}`, time.Date(2025, 3, 1, 13, 0, 0, 0, jakarta)),
+		activity(domain.ActivityTypeSleep, `{"ended_at": "2025-03-02T06:00:00+07:00"}`, time.Date(2025, 3, 1, 20, 0, 0, 0, jakarta)),
+		activity(domain.ActivityTypeDiaper, `{"kind": "wet"}`, time.Date(2025, 3, 1, 9, 0, 0, 0, jakarta)),
+		// 00:30 in Jakarta is still the previous day in UTC
+		activity(domain.ActivityTypeDiaper, `{"kind": "dirty", "note": "Greenish"}`, time.Date(2025, 3, 2, 17, 30, 0, 0, time.UTC)),
+		activity(domain.ActivityTypeFeeding, `{"kind": "breast"}`, time.Date(2025, 3, 3, 10, 0, 0, 0, jakarta)),
+		// Notes may be written on the activity as well as in its details
+		{ChildID: 1, UserID: "user-1", Type: "medicine", Notes: "After the vaccination", Details: json.RawMessage(`{"name": "Paracetamol", "notes": "Half a dose"}`), HappensAt: time.Date(2025, 3, 2, 10, 0, 0, 0, jakarta)},
+		{ChildID: 1, UserID: "user-1", Type: "medicine", Notes: "Before bed", Details: json.RawMessage(`{"notes": "Before bed"}`), HappensAt: time.Date(2025, 3, 2, 19, 0, 0, 0, jakarta)},
+	}}
+
+	growth := &MockGrowthUseCase{
+		Child: child,
+		Measurements: []growthDomain.MeasurementResult{
+			{Measurement: growthDomain.Measurement{MeasuredOn: date("2025-02-15"), WeightKg: value(6.1)}},
+			{Measurement: growthDomain.Measurement{MeasuredOn: date("2025-03-02"), WeightKg: value(6.4), LengthCm: value(63), Note: "Clinic visit"}},
+		},
+	}
+
+	mailer := &MockMailerService{}
+	resolveRecipient := func(userID string) (*notifierDomain.Recipient, error) {
+		return &notifierDomain.Recipient{UserID: userID, Email: "ani@example.com", Name: "Ani", Locale: "id-ID"}, nil
+	}
+
+	uc := NewReportUseCase(activities, &childrentest.UseCase{Child: child}, growth, mailer, &MockOutboxRepository{}, resolveRecipient).(*ReportUseCase)
+	uc.now = childrentest.Clock(time.Date(2025, 3, 20, 3, 0, 0, 0, time.UTC))
+	return uc, activities, mailer
+}
+
+func TestBuildSummarizesDaysInTheUserTimezone(t *testing.T) {
+	uc, activities, _ := newTestUseCase()
+	ctx := utils.WithLocation(context.Background(), jakarta)
+
+	report, err := uc.build(ctx, &domain.ReportRequest{ChildID: 1, UserID: "user-1", From: "2025-03-01", To: "2025-03-03"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !activities.LastSearch.StartDate.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, jakarta)) || activities.LastSearch.ChildID != 1 || activities.LastSearch.UserID != "user-1" {
+		t.Errorf("unexpected search %+v", activities.LastSearch)
+	}
+
+	expected := []domain.DaySummary{
+		{Date: date("2025-03-01"), Feeds: 2, FeedVolumeMl: 120 + 118.29, SleepMinutes: 90 + 600, Diapers: 1},
+		{Date: date("2025-03-02"), Feeds: 0, Diapers: 0},
+		{Date: date("2025-03-03"), Feeds: 1, Diapers: 1},
+	}
+	if len(report.Days) != len(expected) {
+		t.Fatalf("expected %d days, got %d", len(expected), len(report.Days))
+	}
+	for i, day := range report.Days {
+		if day != expected[i] {
+			t.Errorf("expected day %+v, got %+v", expected[i], day)
+		}
+	}
+
+	// Notes of activities and measurements, in chronological order
+	if len(report.Notes) != 6 ||
+		report.Notes[0].Text != "Formula milk" ||
+		report.Notes[1].Text != "Clinic visit" || report.Notes[1].Type != "measurement" ||
+		report.Notes[2].Text != "After the vaccination" || report.Notes[2].Type != "medicine" ||
+		report.Notes[3].Text != "Half a dose" ||
+		report.Notes[4].Text != "Before bed" ||
+		report.Notes[5].Text != "Greenish" {
+		t.Errorf("unexpected notes %+v", report.Notes)
+	}
+
+	if len(report.Measurements) != 1 || *report.Measurements[0].WeightKg != 6.4 {
+		t.Errorf("expected the measurement of the period only, got %+v", report.Measurements)
+	}
+	if report.WeightChart == nil || len(report.WeightChart.Points) != 2 {
+		t.Errorf("expected the weight chart, got %+v", report.WeightChart)
+	}
+}
+
+func TestBuildValidatesThePeriod(t *testing.T) {
+	uc, _, _ := newTestUseCase()
+
+	testCases := []struct {
+		name     string
+		req      *domain.ReportRequest
+		expected error
+	}{
+		{"ends before it starts", &domain.ReportRequest{ChildID: 1, UserID: "user-1", From: "2025-03-02", To: "2025-03-01"}, ErrInvalidReportPeriod},
+		{"too long", &domain.ReportRequest{ChildID: 1, UserID: "user-1", From: "2025-01-01", To: "2025-04-04"}, ErrReportPeriodTooLong},
+		{"another user's child", &domain.ReportRequest{ChildID: 1, UserID: "user-2", From: "2025-03-01", To: "2025-03-01"}, childrenUsecase.ErrUnauthorizedAccess},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := uc.build(context.Background(), tc.req); !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+
+	// The longest period is accepted
+	if _, err := uc.build(context.Background(), &domain.ReportRequest{ChildID: 1, UserID: "user-1", From: "2025-01-01", To: "2025-04-03"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	uc, _, _ := newTestUseCase()
+	ctx := utils.WithLocation(context.Background(), jakarta)
+
+	document, err := uc.Generate(ctx, &domain.ReportRequest{ChildID: 1, UserID: "user-1", From: "2025-03-01", To: "2025-03-03"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if document.Filename != "budi-report-2025-03-01-2025-03-03.pdf" {
+		t.Errorf("unexpected filename %q", document.Filename)
+	}
+	if !bytes.HasPrefix(document.Content, []byte("%PDF-")) || pageCount(document.Content) == 0 {
+		t.Errorf("expected a PDF, got %d pages", pageCount(document.Content))
+	}
+
+	// A long period with many notes flows onto more pages, and a child
+	// without a birth profile still gets a report without the chart
+	uc.childrenUseCase.(*childrentest.UseCase).Child.BirthProfile = childrenDomain.BirthProfile{}
+	activities := uc.activityRepo.(*MockActivityRepository)
+	for i := 0; i < 90; i++ {
+		activities.Activities = append(activities.Activities, activityDomain.Activity{
+			ChildID:   1,
+			Type:      domain.ActivityTypeFeeding,
+			Details:   json.RawMessage(fmt.Sprintf(`{"amount": 90, "unit": "ml", "notes": "Feed %d went well, with a short burp break halfway and a calm nap right after"}`, i)),
+			HappensAt: time.Date(2025, 1, 1, 8, 0, 0, 0, jakarta).AddDate(0, 0, i),
+		})
+	}
+
+	document, err = uc.Generate(ctx, &domain.ReportRequest{ChildID: 1, UserID: "user-1", From: "2025-01-01", To: "2025-04-03"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pages := pageCount(document.Content); pages < 4 {
+		t.Errorf("expected the report to span several pages, got %d", pages)
+	}
+}
+
+func TestEmail(t *testing.T) {
+	uc, _, mailer := newTestUseCase()
+	outbox := uc.outboxRepo.(*MockOutboxRepository)
+	jakartaZone, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	ctx := utils.WithLocation(context.Background(), jakartaZone)
+
+	err = uc.Email(ctx, &domain.EmailReportRequest{
+		ReportRequest: domain.ReportRequest{ChildID: 1, UserID: "user-1", From: "2025-03-01", To: "2025-03-03"},
+		Email:         "dr.sari@example.com",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The email is queued, not sent during the request
+	if len(mailer.Sent) != 0 || len(outbox.Created) != 1 || outbox.Created[0].Topic != outboxDomain.TopicReportEmail {
+		t.Fatalf("expected one queued report email, got %d queued and %d sent", len(outbox.Created), len(mailer.Sent))
+	}
+	var job domain.ReportEmailJob
+	if err := json.Unmarshal(outbox.Created[0].Payload, &job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := domain.ReportEmailJob{ChildID: 1, UserID: "user-1", From: "2025-03-01", To: "2025-03-03", Email: "dr.sari@example.com", Timezone: "Asia/Jakarta"}
+	if job != expected {
+		t.Errorf("expected job %+v, got %+v", expected, job)
+	}
+
+	// Nothing is queued for another user's child or an invalid period
+	err = uc.Email(ctx, &domain.EmailReportRequest{
+		ReportRequest: domain.ReportRequest{ChildID: 1, UserID: "user-2", From: "2025-03-01", To: "2025-03-03"},
+	})
+	if !errors.Is(err, childrenUsecase.ErrUnauthorizedAccess) {
+		t.Errorf("expected the request to be refused, got %v", err)
+	}
+	err = uc.Email(ctx, &domain.EmailReportRequest{
+		ReportRequest: domain.ReportRequest{ChildID: 1, UserID: "user-1", From: "2025-01-01", To: "2025-04-04"},
+	})
+	if !errors.Is(err, ErrReportPeriodTooLong) {
+		t.Errorf("expected %v, got %v", ErrReportPeriodTooLong, err)
+	}
+	if len(outbox.Created) != 1 {
+		t.Errorf("expected refused requests not to be queued, got %d messages", len(outbox.Created))
+	}
+}
+
+func TestSendEmail(t *testing.T) {
+	uc, activities, mailer := newTestUseCase()
+
+	err := uc.SendEmail(context.Background(), &domain.ReportEmailJob{ChildID: 1, UserID: "user-1", From: "2025-03-01", To: "2025-03-03", Timezone: "UTC"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = uc.SendEmail(context.Background(), &domain.ReportEmailJob{ChildID: 1, UserID: "user-1", From: "2025-03-01", To: "2025-03-03", Email: "dr.sari@example.com", Timezone: "Asia/Jakarta"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(mailer.Sent) != 2 {
+		t.Fatalf("expected two emails, got %d", len(mailer.Sent))
+	}
+	sent := mailer.Sent[0]
+	if sent.To != "ani@example.com" || sent.Name != "Ani" || sent.Locale != "id-ID" || sent.ChildName != "Budi" ||
+		sent.PeriodStart != "2025-03-01" || sent.PeriodEnd != "2025-03-03" {
+		t.Errorf("unexpected email %+v", sent)
+	}
+	if sent.Report.Filename != "budi-report-2025-03-01-2025-03-03.pdf" || sent.Report.ContentType != "application/pdf" || !bytes.HasPrefix(sent.Report.Data, []byte("%PDF-")) {
+		t.Errorf("unexpected attachment %s (%s)", sent.Report.Filename, sent.Report.ContentType)
+	}
+	if mailer.Sent[1].To != "dr.sari@example.com" {
+		t.Errorf("expected the requested address, got %q", mailer.Sent[1].To)
+	}
+
+	// Days are counted in the time zone of the request
+	if zone, _ := activities.LastSearch.StartDate.Zone(); zone != "WIB" {
+		t.Errorf("expected the period to start in Jakarta, got %v", activities.LastSearch.StartDate)
+	}
+
+	// Nothing is sent for a child the user can no longer access
+	err = uc.SendEmail(context.Background(), &domain.ReportEmailJob{ChildID: 1, UserID: "user-2", From: "2025-03-01", To: "2025-03-03", Timezone: "UTC"})
+	if !errors.Is(err, childrenUsecase.ErrUnauthorizedAccess) || len(mailer.Sent) != 2 {
+		t.Errorf("expected the job to be refused, got %v", err)
+	}
+}
+
+func pageCount(content []byte) int {
+	match := regexp.MustCompile(`/Type /Pages /Kids \[[^\]]*\] /Count (\d+)`).FindSubmatch(content)
+	if match == nil {
+		return 0
+	}
+	count, _ := strconv.Atoi(string(match[1]))
+	return count
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// UserIdentity links a user to an account at an external OIDC provider
+type UserIdentity struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"-"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"-"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OIDCAuthRequest is an in-flight authorization request. UserID is set when
+// the request links a provider to an already signed-in user.
+type OIDCAuthRequest struct {
+	State        string
+	Provider     string
+	CodeVerifier string
+	Nonce        string
+	UserID       string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
+
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+type OIDCCallbackRequest struct {
+	Provider string `json:"-"`
+	Code     string `json:"code" validate:"required"`
+	State    string `json:"state" validate:"required"`
+}
@@ -0,0 +1,81 @@
+package domain
+
+import "time"
+
+// Measurement units
+const (
+	VolumeUnitMilliliter = "ml"
+	VolumeUnitOunce      = "oz"
+	WeightUnitKilogram   = "kg"
+	WeightUnitPound      = "lb"
+	LengthUnitCentimeter = "cm"
+	LengthUnitInch       = "in"
+)
+
+// Clock formats
+const (
+	ClockFormat12h = "12h"
+	ClockFormat24h = "24h"
+)
+
+// NotificationPreferences holds the user's notification opt-ins
+type NotificationPreferences struct {
+	EmailReminders bool `json:"email_reminders"`
+	PushReminders  bool `json:"push_reminders"`
+	WeeklyDigest   bool `json:"weekly_digest"`
+	ProductUpdates bool `json:"product_updates"`
+}
+
+// UserPreferences holds display and notification settings of a user
+type UserPreferences struct {
+	UserID         string                  `json:"-"`
+	VolumeUnit     string                  `json:"volume_unit"`
+	WeightUnit     string                  `json:"weight_unit"`
+	LengthUnit     string                  `json:"length_unit"`
+	Locale         string                  `json:"locale"`
+	FirstDayOfWeek string                  `json:"first_day_of_week"`
+	ClockFormat    string                  `json:"clock_format"`
+	Notifications  NotificationPreferences `json:"notifications"`
+	CreatedAt      time.Time               `json:"created_at"`
+	UpdatedAt      time.Time               `json:"updated_at"`
+}
+
+// DefaultUserPreferences returns the preferences of a newly registered user
+func DefaultUserPreferences(userID string) *UserPreferences {
+	now := time.Now()
+	return &UserPreferences{
+		UserID:         userID,
+		VolumeUnit:     VolumeUnitMilliliter,
+		WeightUnit:     WeightUnitKilogram,
+		LengthUnit:     LengthUnitCentimeter,
+		Locale:         "en",
+		FirstDayOfWeek: "monday",
+		ClockFormat:    ClockFormat24h,
+		Notifications: NotificationPreferences{
+			EmailReminders: true,
+			PushReminders:  true,
+			WeeklyDigest:   true,
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// UpdateNotificationPreferencesRequest changes only the opt-ins that are set
+type UpdateNotificationPreferencesRequest struct {
+	EmailReminders *bool `json:"email_reminders"`
+	PushReminders  *bool `json:"push_reminders"`
+	WeeklyDigest   *bool `json:"weekly_digest"`
+	ProductUpdates *bool `json:"product_updates"`
+}
+
+// UpdatePreferencesRequest is a partial update, omitted fields are unchanged
+type UpdatePreferencesRequest struct {
+	VolumeUnit     *string                               `json:"volume_unit" validate:"omitempty,oneof=ml oz"`
+	WeightUnit     *string                               `json:"weight_unit" validate:"omitempty,oneof=kg lb"`
+	LengthUnit     *string                               `json:"length_unit" validate:"omitempty,oneof=cm in"`
+	Locale         *string                               `json:"locale" validate:"omitempty,bcp47_language_tag"`
+	FirstDayOfWeek *string                               `json:"first_day_of_week" validate:"omitempty,oneof=monday sunday saturday"`
+	ClockFormat    *string                               `json:"clock_format" validate:"omitempty,oneof=12h 24h"`
+	Notifications  *UpdateNotificationPreferencesRequest `json:"notifications"`
+}
@@ -11,6 +11,13 @@ const (
 	UserStatusBlocked   = 20
 )
 
+// Events published when users change
+const (
+	EventUpdated  = "user.updated"
+	EventVerified = "user.verified"
+	EventDeleted  = "user.deleted"
+)
+
 type User struct {
 	ID                            string     `json:"id"`
 	Email                         string     `json:"email"`
@@ -21,6 +28,7 @@ type User struct {
 	ResetPasswordToken            string     `json:"-"`
 	ResetPasswordTokenRequestedAt time.Time  `json:"-"`
 	Role                          string     `json:"-"`
+	Timezone                      string     `json:"timezone"`
 	LastLogin                     *time.Time `json:"last_login,omitempty"`
 	CreatedAt                     time.Time  `json:"created_at"`
 	UpdatedAt                     time.Time  `json:"updated_at"`
@@ -70,6 +78,9 @@ type UpdateUserRequest struct {
 	ID    string `json:"id"`
 	Email string `json:"email" validate:"required,email"`
 	Name  string `json:"name" validate:"required"`
+	// Timezone is an IANA name such as "Asia/Jakarta", omit to keep the
+	// current value or send "" to use the server default
+	Timezone *string `json:"timezone" validate:"omitempty,timezone"`
 }
 
 type ForgotPasswordRequest struct {
@@ -81,3 +92,23 @@ type ResetPasswordRequest struct {
 	NewPassword     string `json:"new_password" validate:"required,min=8"`
 	ConfirmPassword string `json:"confirm_password" validate:"required"`
 }
+
+// MagicLinkToken is a single-use sign-in token sent by email. Only the
+// SHA-256 hash of the token is persisted.
+type MagicLinkToken struct {
+	ID        string
+	UserID    string
+	Email     string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type MagicLinkRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type MagicLinkLoginRequest struct {
+	Token string `json:"token" validate:"required"`
+}
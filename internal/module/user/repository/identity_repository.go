@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/user/domain"
+	"database/sql"
+)
+
+type postgresIdentityRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresIdentityRepository creates a new PostgreSQL identity repository
+func NewPostgresIdentityRepository(db *sql.DB) IIdentityRepository {
+	return &postgresIdentityRepository{db: db}
+}
+
+func (r *postgresIdentityRepository) Create(identity *domain.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (id, user_id, provider, subject, email, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.db.Exec(query, identity.ID, identity.UserID, identity.Provider,
+		identity.Subject, identity.Email, identity.CreatedAt)
+	return err
+}
+
+func (r *postgresIdentityRepository) GetByProviderSubject(provider, subject string) (*domain.UserIdentity, error) {
+	identity := &domain.UserIdentity{}
+	var email sql.NullString
+	query := `
+		SELECT id, user_id, provider, subject, email, created_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+	err := r.db.QueryRow(query, provider, subject).Scan(
+		&identity.ID, &identity.UserID, &identity.Provider,
+		&identity.Subject, &email, &identity.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	identity.Email = email.String
+	return identity, nil
+}
+
+func (r *postgresIdentityRepository) GetByUserID(userID string) ([]domain.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, created_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY created_at
+	`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	identities := []domain.UserIdentity{}
+	for rows.Next() {
+		var identity domain.UserIdentity
+		var email sql.NullString
+		if err := rows.Scan(
+			&identity.ID, &identity.UserID, &identity.Provider,
+			&identity.Subject, &email, &identity.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		identity.Email = email.String
+		identities = append(identities, identity)
+	}
+
+	return identities, rows.Err()
+}
+
+func (r *postgresIdentityRepository) Delete(userID, provider string) (bool, error) {
+	query := `DELETE FROM user_identities WHERE user_id = $1 AND provider = $2`
+	result, err := r.db.Exec(query, userID, provider)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *postgresIdentityRepository) CreateAuthRequest(req *domain.OIDCAuthRequest) error {
+	query := `
+		INSERT INTO oidc_auth_requests (state, provider, code_verifier, nonce, user_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	var userID sql.NullString
+	if req.UserID != "" {
+		userID = sql.NullString{String: req.UserID, Valid: true}
+	}
+	_, err := r.db.Exec(query, req.State, req.Provider, req.CodeVerifier, req.Nonce,
+		userID, req.ExpiresAt, req.CreatedAt)
+	return err
+}
+
+// ConsumeAuthRequest deletes and returns the authorization request for state,
+// so every state value can be redeemed at most once
+func (r *postgresIdentityRepository) ConsumeAuthRequest(state string) (*domain.OIDCAuthRequest, error) {
+	req := &domain.OIDCAuthRequest{}
+	var userID sql.NullString
+	query := `
+		DELETE FROM oidc_auth_requests
+		WHERE state = $1
+		RETURNING state, provider, code_verifier, nonce, user_id, expires_at, created_at
+	`
+	err := r.db.QueryRow(query, state).Scan(
+		&req.State, &req.Provider, &req.CodeVerifier, &req.Nonce,
+		&userID, &req.ExpiresAt, &req.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	req.UserID = userID.String
+	return req, nil
+}
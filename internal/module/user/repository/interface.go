@@ -1,13 +1,15 @@
 package repository
 
 import (
+	outboxDomain "dailyalu-server/internal/module/outbox/domain"
 	"dailyalu-server/internal/module/user/domain"
 	"time"
 )
 
 // UserRepository defines the interface for user data access
 type IUserRepository interface {
-	Create(user *domain.User) error
+	// Create inserts the user and the outbox messages in one transaction
+	Create(user *domain.User, messages ...*outboxDomain.Message) error
 	GetByID(id string) (*domain.User, error)
 	GetByEmail(email string) (*domain.User, error)
 	Update(user *domain.User) error
@@ -17,4 +19,29 @@ type IUserRepository interface {
 	UpdatePassword(id, password string) error
 	GetByResetPasswordToken(token string) (*domain.User, error)
 	UpdateForgotPasswordToken(id, token string) error
+	// CreateMagicLinkToken stores a token together with the outbox messages
+	// that send it
+	CreateMagicLinkToken(token *domain.MagicLinkToken, messages ...*outboxDomain.Message) error
+	GetMagicLinkTokenByHash(tokenHash string) (*domain.MagicLinkToken, error)
+	ConsumeMagicLinkToken(id string, usedAt time.Time) (bool, error)
+	CountMagicLinkTokensSince(email string, since time.Time) (int, error)
+	AddPasswordHistory(id, passwordHash string) error
+	GetPasswordHistory(id string, limit int) ([]string, error)
+}
+
+
+// IIdentityRepository defines the interface for linked external identities
+type IIdentityRepository interface {
+	Create(identity *domain.UserIdentity) error
+	GetByProviderSubject(provider, subject string) (*domain.UserIdentity, error)
+	GetByUserID(userID string) ([]domain.UserIdentity, error)
+	Delete(userID, provider string) (bool, error)
+	CreateAuthRequest(req *domain.OIDCAuthRequest) error
+	ConsumeAuthRequest(state string) (*domain.OIDCAuthRequest, error)
+}
+
+// IPreferencesRepository defines the interface for user preferences
+type IPreferencesRepository interface {
+	GetByUserID(userID string) (*domain.UserPreferences, error)
+	Upsert(preferences *domain.UserPreferences) error
 }
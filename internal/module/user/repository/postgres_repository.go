@@ -1,6 +1,8 @@
 package repository
 
 import (
+	outboxDomain "dailyalu-server/internal/module/outbox/domain"
+	outboxRepository "dailyalu-server/internal/module/outbox/repository"
 	"dailyalu-server/internal/module/user/domain"
 	"database/sql"
 	"time"
@@ -16,26 +18,43 @@ func NewPostgresUserRepository(db *sql.DB) IUserRepository {
 }
 
 // Implementation of UserRepository interface
-func (r *postgresUserRepository) Create(user *domain.User) error {
+func (r *postgresUserRepository) Create(user *domain.User, messages ...*outboxDomain.Message) error {
 	query := `
-		INSERT INTO users (id, email, name, password_hash, status, email_verification_token, role, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO users (id, email, name, password_hash, status, email_verification_token, role, timezone, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
-	_, err := r.db.Exec(query, user.ID, user.Email, user.Name, user.PasswordHash, 
-		user.Status, user.EmailVerificationToken, user.Role, user.CreatedAt, user.UpdatedAt)
-	return err
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(query, user.ID, user.Email, user.Name, user.PasswordHash, 
+		user.Status, user.EmailVerificationToken, user.Role, user.Timezone, user.CreatedAt, user.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		if err := outboxRepository.Insert(tx, message); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 func (r *postgresUserRepository) GetByID(id string) (*domain.User, error) {
 	user := &domain.User{}
 	query := `
-		SELECT id, email, name, password_hash, status, email_verification_token, role, last_login, created_at, updated_at
+		SELECT id, email, name, password_hash, status, email_verification_token, role, timezone, last_login, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
 	err := r.db.QueryRow(query, id).Scan(
 		&user.ID, &user.Email, &user.Name, &user.PasswordHash, 
-		&user.Status, &user.EmailVerificationToken, &user.Role,
+		&user.Status, &user.EmailVerificationToken, &user.Role, &user.Timezone,
 		&user.LastLogin, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -47,13 +66,13 @@ func (r *postgresUserRepository) GetByID(id string) (*domain.User, error) {
 func (r *postgresUserRepository) GetByEmail(email string) (*domain.User, error) {
 	user := &domain.User{}
 	query := `
-		SELECT id, email, name, password_hash, status, email_verification_token, role, last_login, created_at, updated_at
+		SELECT id, email, name, password_hash, status, email_verification_token, role, timezone, last_login, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
 	err := r.db.QueryRow(query, email).Scan(
 		&user.ID, &user.Email, &user.Name, &user.PasswordHash, 
-		&user.Status, &user.EmailVerificationToken, &user.Role,
+		&user.Status, &user.EmailVerificationToken, &user.Role, &user.Timezone,
 		&user.LastLogin, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -65,13 +84,13 @@ func (r *postgresUserRepository) GetByEmail(email string) (*domain.User, error)
 func (r *postgresUserRepository) GetByVerificationToken(token string) (*domain.User, error) {
 	user := &domain.User{}
 	query := `
-		SELECT id, email, name, password_hash, status, email_verification_token, role, last_login, created_at, updated_at
+		SELECT id, email, name, password_hash, status, email_verification_token, role, timezone, last_login, created_at, updated_at
 		FROM users
 		WHERE email_verification_token = $1
 	`
 	err := r.db.QueryRow(query, token).Scan(
 		&user.ID, &user.Email, &user.Name, &user.PasswordHash, 
-		&user.Status, &user.EmailVerificationToken, &user.Role,
+		&user.Status, &user.EmailVerificationToken, &user.Role, &user.Timezone,
 		&user.LastLogin, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -83,11 +102,11 @@ func (r *postgresUserRepository) GetByVerificationToken(token string) (*domain.U
 func (r *postgresUserRepository) Update(user *domain.User) error {
 	query := `
 		UPDATE users
-		SET email = $2, name = $3, status = $4, email_verification_token = $5, role = $6, updated_at = $7
+		SET email = $2, name = $3, status = $4, email_verification_token = $5, role = $6, timezone = $7, updated_at = $8
 		WHERE id = $1
 	`
 	_, err := r.db.Exec(query, user.ID, user.Email, user.Name, 
-		user.Status, user.EmailVerificationToken, user.Role, user.UpdatedAt)
+		user.Status, user.EmailVerificationToken, user.Role, user.Timezone, user.UpdatedAt)
 	return err
 }
 
@@ -130,13 +149,13 @@ func (r *postgresUserRepository) Delete(id string) error {
 func (r *postgresUserRepository) GetByResetPasswordToken(token string) (*domain.User, error) {
 	user := &domain.User{}
 	query := `
-		SELECT id, email, name, password_hash, status, reset_password_token, reset_password_token_requested_at, role, last_login, created_at, updated_at
+		SELECT id, email, name, password_hash, status, reset_password_token, reset_password_requested_at, role, timezone, last_login, created_at, updated_at
 		FROM users
-		WHERE email_verification_token = $1
+		WHERE reset_password_token = $1
 	`
 	err := r.db.QueryRow(query, token).Scan(
 		&user.ID, &user.Email, &user.Name, &user.PasswordHash, 
-		&user.Status, &user.ResetPasswordToken, &user.ResetPasswordTokenRequestedAt, &user.Role,
+		&user.Status, &user.ResetPasswordToken, &user.ResetPasswordTokenRequestedAt, &user.Role, &user.Timezone,
 		&user.LastLogin, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -144,3 +163,111 @@ func (r *postgresUserRepository) GetByResetPasswordToken(token string) (*domain.
 	}
 	return user, err
 }
+
+func (r *postgresUserRepository) CreateMagicLinkToken(token *domain.MagicLinkToken, messages ...*outboxDomain.Message) error {
+	query := `
+		INSERT INTO magic_link_tokens (id, user_id, email, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(query, token.ID, token.UserID, token.Email, token.TokenHash,
+		token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		if err := outboxRepository.Insert(tx, message); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *postgresUserRepository) GetMagicLinkTokenByHash(tokenHash string) (*domain.MagicLinkToken, error) {
+	token := &domain.MagicLinkToken{}
+	query := `
+		SELECT id, user_id, email, token_hash, expires_at, used_at, created_at
+		FROM magic_link_tokens
+		WHERE token_hash = $1
+	`
+	err := r.db.QueryRow(query, tokenHash).Scan(
+		&token.ID, &token.UserID, &token.Email, &token.TokenHash,
+		&token.ExpiresAt, &token.UsedAt, &token.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return token, err
+}
+
+// ConsumeMagicLinkToken marks the token as used. It reports false when the
+// token was already consumed, so concurrent redemptions cannot both succeed.
+func (r *postgresUserRepository) ConsumeMagicLinkToken(id string, usedAt time.Time) (bool, error) {
+	query := `
+		UPDATE magic_link_tokens
+		SET used_at = $2
+		WHERE id = $1 AND used_at IS NULL
+	`
+	result, err := r.db.Exec(query, id, usedAt)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+func (r *postgresUserRepository) CountMagicLinkTokensSince(email string, since time.Time) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM magic_link_tokens WHERE email = $1 AND created_at >= $2`
+	err := r.db.QueryRow(query, email, since).Scan(&count)
+	return count, err
+}
+
+func (r *postgresUserRepository) AddPasswordHistory(id, passwordHash string) error {
+	query := `
+		INSERT INTO password_history (user_id, password_hash, created_at)
+		VALUES ($1, $2, $3)
+	`
+	_, err := r.db.Exec(query, id, passwordHash, time.Now())
+	return err
+}
+
+// GetPasswordHistory returns the most recent previous password hashes, newest first
+func (r *postgresUserRepository) GetPasswordHistory(id string, limit int) ([]string, error) {
+	query := `
+		SELECT password_hash
+		FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+	rows, err := r.db.Query(query, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, rows.Err()
+}
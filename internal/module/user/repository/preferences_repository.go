@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/user/domain"
+	"database/sql"
+)
+
+type postgresPreferencesRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPreferencesRepository creates a new PostgreSQL preferences repository
+func NewPostgresPreferencesRepository(db *sql.DB) IPreferencesRepository {
+	return &postgresPreferencesRepository{db: db}
+}
+
+func (r *postgresPreferencesRepository) GetByUserID(userID string) (*domain.UserPreferences, error) {
+	preferences := &domain.UserPreferences{}
+	query := `
+		SELECT user_id, volume_unit, weight_unit, length_unit, locale, first_day_of_week, clock_format,
+			notify_email_reminders, notify_push_reminders, notify_weekly_digest, notify_product_updates,
+			created_at, updated_at
+		FROM user_preferences
+		WHERE user_id = $1
+	`
+	err := r.db.QueryRow(query, userID).Scan(
+		&preferences.UserID, &preferences.VolumeUnit, &preferences.WeightUnit, &preferences.LengthUnit,
+		&preferences.Locale, &preferences.FirstDayOfWeek, &preferences.ClockFormat,
+		&preferences.Notifications.EmailReminders, &preferences.Notifications.PushReminders,
+		&preferences.Notifications.WeeklyDigest, &preferences.Notifications.ProductUpdates,
+		&preferences.CreatedAt, &preferences.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return preferences, err
+}
+
+func (r *postgresPreferencesRepository) Upsert(preferences *domain.UserPreferences) error {
+	query := `
+		INSERT INTO user_preferences (user_id, volume_unit, weight_unit, length_unit, locale, first_day_of_week, clock_format,
+			notify_email_reminders, notify_push_reminders, notify_weekly_digest, notify_product_updates,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (user_id) DO UPDATE SET
+			volume_unit = EXCLUDED.volume_unit,
+			weight_unit = EXCLUDED.weight_unit,
+			length_unit = EXCLUDED.length_unit,
+			locale = EXCLUDED.locale,
+			first_day_of_week = EXCLUDED.first_day_of_week,
+			clock_format = EXCLUDED.clock_format,
+			notify_email_reminders = EXCLUDED.notify_email_reminders,
+			notify_push_reminders = EXCLUDED.notify_push_reminders,
+			notify_weekly_digest = EXCLUDED.notify_weekly_digest,
+			notify_product_updates = EXCLUDED.notify_product_updates,
+			updated_at = EXCLUDED.updated_at
+	`
+	_, err := r.db.Exec(query, preferences.UserID, preferences.VolumeUnit, preferences.WeightUnit,
+		preferences.LengthUnit, preferences.Locale, preferences.FirstDayOfWeek, preferences.ClockFormat,
+		preferences.Notifications.EmailReminders, preferences.Notifications.PushReminders,
+		preferences.Notifications.WeeklyDigest, preferences.Notifications.ProductUpdates,
+		preferences.CreatedAt, preferences.UpdatedAt)
+	return err
+}
@@ -12,4 +12,16 @@ var (
 	ErrInvalidResetToken             = errors.New("invalid password reset token")
 	ErrResetTokenExpired             = errors.New("password reset token has expired")
 	ErrInvalidOldPassword            = errors.New("invalid old password")
+	ErrUserBlocked                   = errors.New("user is blocked")
+	ErrEmailNotVerified              = errors.New("email address has not been verified")
+	ErrInvalidMagicLinkToken         = errors.New("invalid magic link token")
+	ErrMagicLinkTokenExpired         = errors.New("magic link token has expired")
+	ErrUnsupportedProvider           = errors.New("unsupported identity provider")
+	ErrInvalidOIDCState              = errors.New("invalid or expired sign-in state")
+	ErrOIDCAuthenticationFailed      = errors.New("identity provider authentication failed")
+	ErrOIDCEmailNotVerified          = errors.New("identity provider did not return a verified email")
+	ErrIdentityAlreadyLinked         = errors.New("identity is already linked to another account")
+	ErrIdentityNotFound              = errors.New("linked identity not found")
+	ErrCannotUnlinkLastLogin         = errors.New("cannot unlink the only sign-in method")
+	ErrInvalidTimezone               = errors.New("invalid timezone")
 )
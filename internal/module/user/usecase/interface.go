@@ -17,4 +17,21 @@ type IUserUseCase interface {
 	UpdatePassword(request *domain.UpdatePasswordRequest) error
 	ForgotPassword(req *domain.ForgotPasswordRequest) error
 	ResetPassword(req *domain.ResetPasswordRequest) error
-}
\ No newline at end of file
+	RequestMagicLink(ctx context.Context, req *domain.MagicLinkRequest) error
+	LoginWithMagicLink(ctx context.Context, req *domain.MagicLinkLoginRequest) (*domain.LoginResponse, error)
+}
+
+// ISocialLoginUseCase defines sign-in and account linking through external OIDC providers
+type ISocialLoginUseCase interface {
+	Authorize(ctx context.Context, provider, userID string) (*domain.OIDCAuthorizeResponse, error)
+	Login(ctx context.Context, req *domain.OIDCCallbackRequest) (*domain.LoginResponse, error)
+	Link(ctx context.Context, userID string, req *domain.OIDCCallbackRequest) (*domain.UserIdentity, error)
+	Unlink(ctx context.Context, userID, provider string) error
+	ListIdentities(ctx context.Context, userID string) ([]domain.UserIdentity, error)
+}
+
+// IPreferencesUseCase defines reading and updating user preferences
+type IPreferencesUseCase interface {
+	Get(ctx context.Context, userID string) (*domain.UserPreferences, error)
+	Update(ctx context.Context, userID string, req *domain.UpdatePreferencesRequest) (*domain.UserPreferences, error)
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/user/domain"
+	"dailyalu-server/internal/module/user/repository"
+	"fmt"
+	"time"
+)
+
+type preferencesUseCase struct {
+	repo repository.IPreferencesRepository
+}
+
+// NewPreferencesUseCase creates a new user preferences use case
+func NewPreferencesUseCase(repo repository.IPreferencesRepository) IPreferencesUseCase {
+	return &preferencesUseCase{
+		repo: repo,
+	}
+}
+
+// Get returns the user's preferences, or the defaults when none are stored
+func (uc *preferencesUseCase) Get(ctx context.Context, userID string) (*domain.UserPreferences, error) {
+	preferences, err := uc.repo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	if preferences == nil {
+		return domain.DefaultUserPreferences(userID), nil
+	}
+	return preferences, nil
+}
+
+func (uc *preferencesUseCase) Update(ctx context.Context, userID string, req *domain.UpdatePreferencesRequest) (*domain.UserPreferences, error) {
+	preferences, err := uc.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	setString(&preferences.VolumeUnit, req.VolumeUnit)
+	setString(&preferences.WeightUnit, req.WeightUnit)
+	setString(&preferences.LengthUnit, req.LengthUnit)
+	setString(&preferences.Locale, req.Locale)
+	setString(&preferences.FirstDayOfWeek, req.FirstDayOfWeek)
+	setString(&preferences.ClockFormat, req.ClockFormat)
+
+	if n := req.Notifications; n != nil {
+		setBool(&preferences.Notifications.EmailReminders, n.EmailReminders)
+		setBool(&preferences.Notifications.PushReminders, n.PushReminders)
+		setBool(&preferences.Notifications.WeeklyDigest, n.WeeklyDigest)
+		setBool(&preferences.Notifications.ProductUpdates, n.ProductUpdates)
+	}
+
+	preferences.UpdatedAt = time.Now()
+
+	if err := uc.repo.Upsert(preferences); err != nil {
+		return nil, fmt.Errorf("failed to update preferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+func setString(target *string, value *string) {
+	if value != nil {
+		*target = *value
+	}
+}
+
+func setBool(target *bool, value *bool) {
+	if value != nil {
+		*target = *value
+	}
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/user/domain"
+	"testing"
+)
+
+// inMemoryPreferencesRepository implements the preferences repository interface for testing
+type inMemoryPreferencesRepository struct {
+	preferences map[string]domain.UserPreferences
+}
+
+func newInMemoryPreferencesRepository() *inMemoryPreferencesRepository {
+	return &inMemoryPreferencesRepository{preferences: map[string]domain.UserPreferences{}}
+}
+
+func (r *inMemoryPreferencesRepository) GetByUserID(userID string) (*domain.UserPreferences, error) {
+	preferences, ok := r.preferences[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &preferences, nil
+}
+
+func (r *inMemoryPreferencesRepository) Upsert(preferences *domain.UserPreferences) error {
+	r.preferences[preferences.UserID] = *preferences
+	return nil
+}
+
+func TestPreferencesUseCase_Get(t *testing.T) {
+	uc := NewPreferencesUseCase(newInMemoryPreferencesRepository())
+
+	preferences, err := uc.Get(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if preferences.VolumeUnit != domain.VolumeUnitMilliliter || preferences.ClockFormat != domain.ClockFormat24h || !preferences.Notifications.WeeklyDigest {
+		t.Errorf("expected default preferences, got %+v", preferences)
+	}
+}
+
+func TestPreferencesUseCase_Update(t *testing.T) {
+	repo := newInMemoryPreferencesRepository()
+	uc := NewPreferencesUseCase(repo)
+
+	ounce := domain.VolumeUnitOunce
+	locale := "id-ID"
+	disabled := false
+
+	preferences, err := uc.Update(context.Background(), "user-1", &domain.UpdatePreferencesRequest{
+		VolumeUnit: &ounce,
+		Locale:     &locale,
+		Notifications: &domain.UpdateNotificationPreferencesRequest{
+			WeeklyDigest: &disabled,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if preferences.VolumeUnit != domain.VolumeUnitOunce || preferences.Locale != "id-ID" || preferences.Notifications.WeeklyDigest {
+		t.Errorf("expected fields to be updated, got %+v", preferences)
+	}
+
+	// Omitted fields keep their value
+	if preferences.WeightUnit != domain.WeightUnitKilogram || !preferences.Notifications.EmailReminders {
+		t.Errorf("expected omitted fields to be unchanged, got %+v", preferences)
+	}
+
+	stored, _ := repo.GetByUserID("user-1")
+	if stored == nil || stored.VolumeUnit != domain.VolumeUnitOunce {
+		t.Errorf("expected preferences to be stored, got %+v", stored)
+	}
+}
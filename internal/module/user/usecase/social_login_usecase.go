@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/user/domain"
+	"dailyalu-server/internal/module/user/repository"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/security/oidc"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// oidcAuthRequestExpiry is how long a user has to complete the provider sign-in
+const oidcAuthRequestExpiry = 10 * time.Minute
+
+type socialLoginUseCase struct {
+	userRepo     repository.IUserRepository
+	identityRepo repository.IIdentityRepository
+	providers    *oidc.Providers
+	jwtManager   *jwt.JWTManager
+}
+
+// NewSocialLoginUseCase creates a new social login use case
+func NewSocialLoginUseCase(userRepo repository.IUserRepository, identityRepo repository.IIdentityRepository, providers *oidc.Providers, jwtManager *jwt.JWTManager) ISocialLoginUseCase {
+	return &socialLoginUseCase{
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		providers:    providers,
+		jwtManager:   jwtManager,
+	}
+}
+
+// Authorize starts an authorization code flow with PKCE. When userID is set
+// the resulting callback links the provider account to that user instead of
+// signing in.
+func (uc *socialLoginUseCase) Authorize(ctx context.Context, providerName, userID string) (*domain.OIDCAuthorizeResponse, error) {
+	provider, err := uc.providers.Get(providerName)
+	if err != nil {
+		return nil, ErrUnsupportedProvider
+	}
+
+	state, err := oidc.GenerateRandomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := oidc.GenerateRandomString()
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, err := oidc.GenerateRandomString()
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build authorization url: %w", err)
+	}
+
+	now := time.Now()
+	authRequest := &domain.OIDCAuthRequest{
+		State:        state,
+		Provider:     provider.Name(),
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		UserID:       userID,
+		ExpiresAt:    now.Add(oidcAuthRequestExpiry),
+		CreatedAt:    now,
+	}
+
+	if err := uc.identityRepo.CreateAuthRequest(authRequest); err != nil {
+		return nil, fmt.Errorf("failed to store authorization request: %w", err)
+	}
+
+	return &domain.OIDCAuthorizeResponse{
+		AuthorizationURL: authURL,
+		State:            state,
+	}, nil
+}
+
+// Login completes a sign-in flow. Unknown identities with a verified email
+// are linked to the account with that email, or provisioned a new account.
+func (uc *socialLoginUseCase) Login(ctx context.Context, req *domain.OIDCCallbackRequest) (*domain.LoginResponse, error) {
+	authRequest, claims, err := uc.completeAuthorization(ctx, req, "")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.resolveUser(authRequest.Provider, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkLoginAllowed(user); err != nil {
+		return nil, err
+	}
+
+	return newLoginResponse(uc.jwtManager, user)
+}
+
+// Link completes a linking flow started by userID
+func (uc *socialLoginUseCase) Link(ctx context.Context, userID string, req *domain.OIDCCallbackRequest) (*domain.UserIdentity, error) {
+	authRequest, claims, err := uc.completeAuthorization(ctx, req, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.identityRepo.GetByProviderSubject(authRequest.Provider, claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+	if existing != nil {
+		if existing.UserID != userID {
+			return nil, ErrIdentityAlreadyLinked
+		}
+		return existing, nil
+	}
+
+	identities, err := uc.identityRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identities: %w", err)
+	}
+	for _, identity := range identities {
+		if identity.Provider == authRequest.Provider {
+			// A different account at the same provider is already linked
+			return nil, ErrIdentityAlreadyLinked
+		}
+	}
+
+	return uc.createIdentity(userID, authRequest.Provider, claims)
+}
+
+func (uc *socialLoginUseCase) Unlink(ctx context.Context, userID, providerName string) error {
+	providerName = strings.ToLower(providerName)
+
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	identities, err := uc.identityRepo.GetByUserID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get identities: %w", err)
+	}
+
+	linked := false
+	for _, identity := range identities {
+		if identity.Provider == providerName {
+			linked = true
+		}
+	}
+	if !linked {
+		return ErrIdentityNotFound
+	}
+
+	// Accounts provisioned through a provider have no password, so they must
+	// keep at least one linked identity
+	if user.PasswordHash == "" && len(identities) == 1 {
+		return ErrCannotUnlinkLastLogin
+	}
+
+	if _, err := uc.identityRepo.Delete(userID, providerName); err != nil {
+		return fmt.Errorf("failed to unlink identity: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *socialLoginUseCase) ListIdentities(ctx context.Context, userID string) ([]domain.UserIdentity, error) {
+	return uc.identityRepo.GetByUserID(userID)
+}
+
+// completeAuthorization redeems the state, exchanges the code and verifies
+// the ID token. userID must match the user that started the flow.
+func (uc *socialLoginUseCase) completeAuthorization(ctx context.Context, req *domain.OIDCCallbackRequest, userID string) (*domain.OIDCAuthRequest, *oidc.IDTokenClaims, error) {
+	provider, err := uc.providers.Get(req.Provider)
+	if err != nil {
+		return nil, nil, ErrUnsupportedProvider
+	}
+
+	authRequest, err := uc.identityRepo.ConsumeAuthRequest(req.State)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get authorization request: %w", err)
+	}
+	if authRequest == nil ||
+		authRequest.Provider != provider.Name() ||
+		authRequest.UserID != userID ||
+		time.Now().After(authRequest.ExpiresAt) {
+		return nil, nil, ErrInvalidOIDCState
+	}
+
+	rawIDToken, err := provider.Exchange(ctx, req.Code, authRequest.CodeVerifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrOIDCAuthenticationFailed, err)
+	}
+
+	claims, err := provider.VerifyIDToken(ctx, rawIDToken, authRequest.Nonce)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrOIDCAuthenticationFailed, err)
+	}
+
+	return authRequest, claims, nil
+}
+
+func (uc *socialLoginUseCase) resolveUser(providerName string, claims *oidc.IDTokenClaims) (*domain.User, error) {
+	identity, err := uc.identityRepo.GetByProviderSubject(providerName, claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	if identity != nil {
+		user, err := uc.userRepo.GetByID(identity.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user == nil {
+			return nil, ErrUserNotFound
+		}
+		return user, nil
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, ErrOIDCEmailNotVerified
+	}
+
+	user, err := uc.userRepo.GetByEmail(claims.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	now := time.Now()
+	if user == nil {
+		user = &domain.User{
+			ID:        uuid.New().String(),
+			Email:     claims.Email,
+			Name:      displayName(claims),
+			Status:    domain.UserStatusActive,
+			Role:      "user",
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := uc.userRepo.Create(user); err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+	} else if user.Status == domain.UserStatusNotActive {
+		// The provider has verified ownership of the address, but whoever
+		// registered it unverified may not own it. Their password and pending
+		// tokens are dropped so they cannot sign in to the activated account.
+		// The account is activated last, so a failure leaves it unverified.
+		if err := uc.userRepo.UpdatePassword(user.ID, ""); err != nil {
+			return nil, fmt.Errorf("failed to clear password: %w", err)
+		}
+		if err := uc.userRepo.UpdateForgotPasswordToken(user.ID, ""); err != nil {
+			return nil, fmt.Errorf("failed to clear password reset token: %w", err)
+		}
+		user.PasswordHash = ""
+		user.ResetPasswordToken = ""
+		user.EmailVerificationToken = ""
+		user.Status = domain.UserStatusActive
+		user.UpdatedAt = now
+		if err := uc.userRepo.Update(user); err != nil {
+			return nil, fmt.Errorf("failed to activate user: %w", err)
+		}
+	}
+
+	if _, err := uc.createIdentity(user.ID, providerName, claims); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (uc *socialLoginUseCase) createIdentity(userID, providerName string, claims *oidc.IDTokenClaims) (*domain.UserIdentity, error) {
+	identity := &domain.UserIdentity{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Provider:  providerName,
+		Subject:   claims.Subject,
+		Email:     claims.Email,
+		CreatedAt: time.Now(),
+	}
+
+	if err := uc.identityRepo.Create(identity); err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+func displayName(claims *oidc.IDTokenClaims) string {
+	if claims.Name != "" {
+		return claims.Name
+	}
+	if at := strings.Index(claims.Email, "@"); at > 0 {
+		return claims.Email[:at]
+	}
+	return claims.Email
+}
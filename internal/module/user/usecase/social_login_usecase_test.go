@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/user/domain"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/security/oidc"
+	"dailyalu-server/internal/security/oidc/oidctest"
+	"dailyalu-server/internal/security/password"
+	"errors"
+	"testing"
+	"time"
+)
+
+// inMemoryIdentityRepository implements the identity repository interface for testing
+type inMemoryIdentityRepository struct {
+	identities   []domain.UserIdentity
+	authRequests map[string]*domain.OIDCAuthRequest
+}
+
+func newInMemoryIdentityRepository() *inMemoryIdentityRepository {
+	return &inMemoryIdentityRepository{authRequests: map[string]*domain.OIDCAuthRequest{}}
+}
+
+func (r *inMemoryIdentityRepository) Create(identity *domain.UserIdentity) error {
+	r.identities = append(r.identities, *identity)
+	return nil
+}
+
+func (r *inMemoryIdentityRepository) GetByProviderSubject(provider, subject string) (*domain.UserIdentity, error) {
+	for _, identity := range r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return &identity, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *inMemoryIdentityRepository) GetByUserID(userID string) ([]domain.UserIdentity, error) {
+	var identities []domain.UserIdentity
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			identities = append(identities, identity)
+		}
+	}
+	return identities, nil
+}
+
+func (r *inMemoryIdentityRepository) Delete(userID, provider string) (bool, error) {
+	for i, identity := range r.identities {
+		if identity.UserID == userID && identity.Provider == provider {
+			r.identities = append(r.identities[:i], r.identities[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *inMemoryIdentityRepository) CreateAuthRequest(req *domain.OIDCAuthRequest) error {
+	r.authRequests[req.State] = req
+	return nil
+}
+
+func (r *inMemoryIdentityRepository) ConsumeAuthRequest(state string) (*domain.OIDCAuthRequest, error) {
+	req := r.authRequests[state]
+	delete(r.authRequests, state)
+	return req, nil
+}
+
+type socialLoginFixture struct {
+	server       *oidctest.Server
+	uc           *socialLoginUseCase
+	identityRepo *inMemoryIdentityRepository
+	userRepo     *MockUserRepository
+	users        map[string]*domain.User
+}
+
+func newSocialLoginFixture(t *testing.T) *socialLoginFixture {
+	server := oidctest.NewServer("client-id")
+	t.Cleanup(server.Close)
+
+	f := &socialLoginFixture{
+		server:       server,
+		identityRepo: newInMemoryIdentityRepository(),
+		users:        map[string]*domain.User{},
+	}
+
+	userRepo := &MockUserRepository{
+		GetByIDFunc: func(id string) (*domain.User, error) {
+			return f.users[id], nil
+		},
+		GetByEmailFunc: func(email string) (*domain.User, error) {
+			for _, user := range f.users {
+				if user.Email == email {
+					return user, nil
+				}
+			}
+			return nil, nil
+		},
+		CreateFunc: func(user *domain.User) error {
+			f.users[user.ID] = user
+			return nil
+		},
+		UpdateFunc: func(user *domain.User) error {
+			f.users[user.ID] = user
+			return nil
+		},
+		UpdatePasswordFunc: func(id, hash string) error {
+			f.users[id].PasswordHash = hash
+			return nil
+		},
+		UpdateForgotPasswordTokenFunc: func(id, token string) error {
+			f.users[id].ResetPasswordToken = token
+			return nil
+		},
+	}
+	f.userRepo = userRepo
+
+	providers := oidc.NewProviders([]oidc.ProviderConfig{{
+		Name:        "google",
+		Issuer:      server.Issuer(),
+		ClientID:    "client-id",
+		RedirectURL: "https://dailyalu.mom/oidc/callback",
+	}}, server.Client())
+
+	f.uc = &socialLoginUseCase{
+		userRepo:     userRepo,
+		identityRepo: f.identityRepo,
+		providers:    providers,
+		jwtManager:   jwt.NewJWTManager("secret", "refresh-secret", time.Hour, time.Hour),
+	}
+
+	return f
+}
+
+// signIn runs the provider side of the flow and returns the callback request
+func (f *socialLoginFixture) signIn(t *testing.T, userID string, identity oidctest.Identity) *domain.OIDCCallbackRequest {
+	authorize, err := f.uc.Authorize(context.Background(), "google", userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	code, state, err := f.server.Authorize(authorize.AuthorizationURL, identity)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return &domain.OIDCCallbackRequest{Provider: "google", Code: code, State: state}
+}
+
+func TestSocialLoginUseCase_Login(t *testing.T) {
+	verified := oidctest.Identity{Subject: "sub-1", Email: "parent@example.com", EmailVerified: true, Name: "Parent"}
+
+	t.Run("provisions a new account for a verified email", func(t *testing.T) {
+		f := newSocialLoginFixture(t)
+
+		result, err := f.uc.Login(context.Background(), f.signIn(t, "", verified))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.AccessToken == "" || result.User.Email != verified.Email || !result.User.IsActive() {
+			t.Errorf("unexpected login result: %+v", result)
+		}
+		if len(f.identityRepo.identities) != 1 {
+			t.Errorf("expected identity to be linked, got %d", len(f.identityRepo.identities))
+		}
+
+		// Signing in again reuses the linked account
+		again, err := f.uc.Login(context.Background(), f.signIn(t, "", verified))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if again.User.ID != result.User.ID || len(f.users) != 1 {
+			t.Error("expected existing account to be reused")
+		}
+	})
+
+	t.Run("links to an existing account with the same email", func(t *testing.T) {
+		f := newSocialLoginFixture(t)
+		f.users["user-1"] = &domain.User{ID: "user-1", Email: verified.Email, Status: domain.UserStatusNotActive, PasswordHash: "hash"}
+
+		result, err := f.uc.Login(context.Background(), f.signIn(t, "", verified))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.User.ID != "user-1" || !result.User.IsActive() {
+			t.Errorf("expected existing user to be activated and signed in, got %+v", result.User)
+		}
+	})
+
+	t.Run("drops the password of an unverified account it activates", func(t *testing.T) {
+		f := newSocialLoginFixture(t)
+		// Someone else registered the address before its owner signed in
+		attackerHash, err := password.Hash("Attacker-Pass-42")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		f.users["user-1"] = &domain.User{
+			ID:                     "user-1",
+			Email:                  verified.Email,
+			Status:                 domain.UserStatusNotActive,
+			PasswordHash:           attackerHash,
+			EmailVerificationToken: "verification-token",
+			ResetPasswordToken:     "reset-token",
+		}
+
+		if _, err := f.uc.Login(context.Background(), f.signIn(t, "", verified)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		user := f.users["user-1"]
+		if user.PasswordHash != "" || user.EmailVerificationToken != "" || user.ResetPasswordToken != "" {
+			t.Errorf("expected password and pending tokens to be cleared, got %+v", user)
+		}
+
+		passwordLogin := &userUseCase{
+			repo:       f.userRepo,
+			jwtManager: jwt.NewJWTManager("secret", "refresh-secret", time.Hour, time.Hour),
+		}
+		_, err = passwordLogin.Login(&domain.LoginRequest{Email: verified.Email, Password: "Attacker-Pass-42"})
+		if !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("expected %v for the old password, got %v", ErrInvalidCredentials, err)
+		}
+	})
+
+	t.Run("rejects unverified email", func(t *testing.T) {
+		f := newSocialLoginFixture(t)
+		unverified := verified
+		unverified.EmailVerified = false
+
+		_, err := f.uc.Login(context.Background(), f.signIn(t, "", unverified))
+		if !errors.Is(err, ErrOIDCEmailNotVerified) {
+			t.Errorf("expected %v, got %v", ErrOIDCEmailNotVerified, err)
+		}
+	})
+
+	t.Run("rejects blocked user", func(t *testing.T) {
+		f := newSocialLoginFixture(t)
+		f.users["user-1"] = &domain.User{ID: "user-1", Email: verified.Email, Status: domain.UserStatusBlocked}
+
+		_, err := f.uc.Login(context.Background(), f.signIn(t, "", verified))
+		if !errors.Is(err, ErrUserBlocked) {
+			t.Errorf("expected %v, got %v", ErrUserBlocked, err)
+		}
+	})
+
+	t.Run("rejects replayed state", func(t *testing.T) {
+		f := newSocialLoginFixture(t)
+		req := f.signIn(t, "", verified)
+
+		if _, err := f.uc.Login(context.Background(), req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := f.uc.Login(context.Background(), req); !errors.Is(err, ErrInvalidOIDCState) {
+			t.Errorf("expected %v, got %v", ErrInvalidOIDCState, err)
+		}
+	})
+
+	t.Run("rejects link state used for login", func(t *testing.T) {
+		f := newSocialLoginFixture(t)
+
+		_, err := f.uc.Login(context.Background(), f.signIn(t, "user-1", verified))
+		if !errors.Is(err, ErrInvalidOIDCState) {
+			t.Errorf("expected %v, got %v", ErrInvalidOIDCState, err)
+		}
+	})
+}
+
+func TestSocialLoginUseCase_LinkAndUnlink(t *testing.T) {
+	identity := oidctest.Identity{Subject: "sub-1", Email: "parent@gmail.com", EmailVerified: true}
+
+	t.Run("links and unlinks provider", func(t *testing.T) {
+		f := newSocialLoginFixture(t)
+		f.users["user-1"] = &domain.User{ID: "user-1", Email: "parent@example.com", PasswordHash: "hash"}
+
+		linked, err := f.uc.Link(context.Background(), "user-1", f.signIn(t, "user-1", identity))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if linked.UserID != "user-1" || linked.Provider != "google" {
+			t.Errorf("unexpected identity: %+v", linked)
+		}
+
+		if err := f.uc.Unlink(context.Background(), "user-1", "google"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := f.uc.Unlink(context.Background(), "user-1", "google"); !errors.Is(err, ErrIdentityNotFound) {
+			t.Errorf("expected %v, got %v", ErrIdentityNotFound, err)
+		}
+	})
+
+	t.Run("rejects identity linked to another user", func(t *testing.T) {
+		f := newSocialLoginFixture(t)
+		f.users["user-1"] = &domain.User{ID: "user-1", PasswordHash: "hash"}
+		f.users["user-2"] = &domain.User{ID: "user-2", PasswordHash: "hash"}
+
+		if _, err := f.uc.Link(context.Background(), "user-1", f.signIn(t, "user-1", identity)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err := f.uc.Link(context.Background(), "user-2", f.signIn(t, "user-2", identity))
+		if !errors.Is(err, ErrIdentityAlreadyLinked) {
+			t.Errorf("expected %v, got %v", ErrIdentityAlreadyLinked, err)
+		}
+	})
+
+	t.Run("keeps the only sign-in method of passwordless accounts", func(t *testing.T) {
+		f := newSocialLoginFixture(t)
+
+		result, err := f.uc.Login(context.Background(), f.signIn(t, "", identity))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = f.uc.Unlink(context.Background(), result.User.ID, "google")
+		if !errors.Is(err, ErrCannotUnlinkLastLogin) {
+			t.Errorf("expected %v, got %v", ErrCannotUnlinkLastLogin, err)
+		}
+	})
+}
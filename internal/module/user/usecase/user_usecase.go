@@ -2,32 +2,62 @@ package usecase
 
 import (
 	"context"
+	outboxDomain "dailyalu-server/internal/module/outbox/domain"
 	"dailyalu-server/internal/module/user/domain"
 	"dailyalu-server/internal/module/user/repository"
 	"dailyalu-server/internal/security/jwt"
 	"dailyalu-server/internal/security/password"
 	"dailyalu-server/internal/security/token"
 	mailerDomain "dailyalu-server/internal/service/mailer/domain"
+	"dailyalu-server/internal/utils"
+	"dailyalu-server/pkg/app_log/zap_log"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
+const (
+	// frontendBaseURL is the base URL used to build links sent by email
+	frontendBaseURL = "https://dailyalu.mom"
+
+	// magicLinkMaxRequests is the number of sign-in links an email address
+	// may request within magicLinkRateWindow
+	magicLinkMaxRequests = 3
+	magicLinkRateWindow  = 15 * time.Minute
+)
+
+// EventPublisher notifies subscribers that a user changed
+type EventPublisher func(ctx context.Context, event string, user *domain.User)
+
 type userUseCase struct {
-	repo          repository.IUserRepository
-	jwtManager    *jwt.JWTManager
-	tokenService  *token.TokenService
-	mailerService mailerDomain.IMailerService
+	repo            repository.IUserRepository
+	preferencesRepo repository.IPreferencesRepository
+	jwtManager      *jwt.JWTManager
+	tokenService    *token.TokenService
+	mailerService   mailerDomain.IMailerService
+	passwordPolicy  *password.Policy
+	publish         EventPublisher
+	logger          *zap.Logger
 }
 
 // NewUserUseCase creates a new user use case
-func NewUserUseCase(repo repository.IUserRepository, jwtManager *jwt.JWTManager, tokenService *token.TokenService, mailerService mailerDomain.IMailerService) IUserUseCase {
+func NewUserUseCase(repo repository.IUserRepository, preferencesRepo repository.IPreferencesRepository, jwtManager *jwt.JWTManager, tokenService *token.TokenService, mailerService mailerDomain.IMailerService, passwordPolicy *password.Policy, publish EventPublisher) IUserUseCase {
+	logger := zap_log.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
 	return &userUseCase{
-		repo:          repo,
-		jwtManager:    jwtManager,
-		tokenService:  tokenService,
-		mailerService: mailerService,
+		repo:            repo,
+		preferencesRepo: preferencesRepo,
+		jwtManager:      jwtManager,
+		tokenService:    tokenService,
+		mailerService:   mailerService,
+		passwordPolicy:  passwordPolicy,
+		publish:         publish,
+		logger:          logger,
 	}
 }
 
@@ -46,6 +76,10 @@ func (uc *userUseCase) Register(ctx context.Context, req *domain.RegisterRequest
 		return nil, ErrEmailAlreadyExists
 	}
 
+	if err := uc.checkPasswordPolicy("password", req.Password, req.Email, req.Name, nil); err != nil {
+		return nil, err
+	}
+
 	// Hash password
 	hashedPassword, err := password.Hash(req.Password)
 	if err != nil {
@@ -71,27 +105,28 @@ func (uc *userUseCase) Register(ctx context.Context, req *domain.RegisterRequest
 		UpdatedAt:              now,
 	}
 
-	if err := uc.repo.Create(user); err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
-	}
+	verificationLink := uc.tokenService.GenerateVerificationLink(frontendBaseURL, verificationToken)
+	preferences := domain.DefaultUserPreferences(user.ID)
 
-	verificationLink := uc.tokenService.GenerateVerificationLink("https://dailyalu.mom", verificationToken)
-	//verificationLink := uc.tokenService.GenerateVerificationLink("https://dailyalu.mom/verify-email", verificationToken)
-
-	// Implement email sending logic here
-	go func() {
-		emailVerificationData := mailerDomain.EmailVerificationData{
-			To:              user.Email,
-			Name:            user.Name,
-			VerificationURL: verificationLink,
-		}
+	// The email is queued with the user so it is sent even if the process
+	// stops right after the response, and never for a rolled back user
+	verificationEmail, err := outboxDomain.NewMessage(outboxDomain.TopicVerificationEmail, &mailerDomain.EmailVerificationData{
+		To:              user.Email,
+		Name:            user.Name,
+		VerificationURL: verificationLink,
+		Locale:          preferences.Locale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue verification email: %w", err)
+	}
 
-		err = uc.mailerService.SendVerificationEmail(ctx, &emailVerificationData)
+	if err := uc.repo.Create(user, verificationEmail); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
 
-		if err != nil {
-			fmt.Println("failed to send email, " + err.Error())
-		}
-	}()
+	// Missing preferences fall back to the defaults on read, so a failure
+	// here does not fail the registration
+	_ = uc.preferencesRepo.Upsert(preferences)
 
 	return user, nil
 }
@@ -117,6 +152,7 @@ func (uc *userUseCase) VerifyEmail(ctx context.Context, token string) error {
 		return fmt.Errorf("failed to update user status: %w", err)
 	}
 
+	uc.publishEvent(ctx, domain.EventVerified, user)
 	return nil
 }
 
@@ -135,13 +171,43 @@ func (uc *userUseCase) Login(req *domain.LoginRequest) (*domain.LoginResponse, e
 		return nil, ErrInvalidCredentials
 	}
 
-	// Generate token pair
-	accessToken, refreshToken, err := uc.jwtManager.GenerateTokenPair(user.ID, user.Email, user.Role)
+	if err := checkLoginAllowed(user); err != nil {
+		return nil, err
+	}
+
+	// Upgrade hashes created with an older algorithm or weaker parameters
+	// while the plain text password is available. A failed upgrade must not
+	// block the login, it is retried on the next one.
+	if password.NeedsRehash(user.PasswordHash) {
+		if hashedPassword, err := password.Hash(req.Password); err == nil {
+			if err := uc.repo.UpdatePassword(user.ID, hashedPassword); err == nil {
+				user.PasswordHash = hashedPassword
+			}
+		}
+	}
+
+	return newLoginResponse(uc.jwtManager, user)
+}
+
+// checkLoginAllowed rejects users that may not sign in regardless of the
+// authentication method used
+func checkLoginAllowed(user *domain.User) error {
+	if user.IsBlocked() {
+		return ErrUserBlocked
+	}
+	if !user.IsActive() {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// newLoginResponse generates a token pair for an authenticated user
+func newLoginResponse(jwtManager *jwt.JWTManager, user *domain.User) (*domain.LoginResponse, error) {
+	accessToken, refreshToken, err := jwtManager.GenerateTokenPair(user.ID, user.Email, user.Role)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tokens: %w", err)
 	}
 
-	// Return login response
 	return &domain.LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -149,6 +215,107 @@ func (uc *userUseCase) Login(req *domain.LoginRequest) (*domain.LoginResponse, e
 	}, nil
 }
 
+func (uc *userUseCase) RequestMagicLink(ctx context.Context, req *domain.MagicLinkRequest) error {
+	user, err := uc.repo.GetByEmail(req.Email)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		// For security, don't reveal if email exists
+		return nil
+	}
+
+	// From here on failures are logged rather than returned, so the response
+	// stays identical for registered and unregistered addresses
+	if err := uc.queueMagicLink(user); err != nil {
+		uc.logger.Error("Failed to queue magic link", zap.String("user_id", user.ID), zap.Error(err))
+	}
+	return nil
+}
+
+// queueMagicLink stores a new sign-in token and queues the email carrying
+// it in the same transaction. Requests beyond the rate limit are dropped.
+func (uc *userUseCase) queueMagicLink(user *domain.User) error {
+	now := time.Now()
+	requested, err := uc.repo.CountMagicLinkTokensSince(user.Email, now.Add(-magicLinkRateWindow))
+	if err != nil {
+		return fmt.Errorf("failed to count magic link requests: %w", err)
+	}
+	if requested >= magicLinkMaxRequests {
+		// Silently drop the request so the response stays identical
+		// for registered and unregistered addresses
+		return nil
+	}
+
+	rawToken, err := uc.tokenService.GenerateToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate magic link token: %w", err)
+	}
+
+	magicLinkToken := &domain.MagicLinkToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: uc.tokenService.HashToken(rawToken),
+		ExpiresAt: uc.tokenService.ExpiresAt(token.MagicLink, now),
+		CreatedAt: now,
+	}
+
+	magicLinkEmail, err := outboxDomain.NewMessage(outboxDomain.TopicMagicLinkEmail, &mailerDomain.MagicLinkEmailData{
+		To:               user.Email,
+		Name:             user.Name,
+		MagicLinkURL:     uc.tokenService.GenerateMagicLink(frontendBaseURL, rawToken),
+		ExpiresInMinutes: int(magicLinkToken.ExpiresAt.Sub(now).Minutes()),
+		Locale:           uc.preferredLocale(user.ID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue magic link email: %w", err)
+	}
+
+	if err := uc.repo.CreateMagicLinkToken(magicLinkToken, magicLinkEmail); err != nil {
+		return fmt.Errorf("failed to create magic link token: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *userUseCase) LoginWithMagicLink(ctx context.Context, req *domain.MagicLinkLoginRequest) (*domain.LoginResponse, error) {
+	magicLinkToken, err := uc.repo.GetMagicLinkTokenByHash(uc.tokenService.HashToken(req.Token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get magic link token: %w", err)
+	}
+	if magicLinkToken == nil || magicLinkToken.UsedAt != nil {
+		return nil, ErrInvalidMagicLinkToken
+	}
+
+	now := time.Now()
+	if now.After(magicLinkToken.ExpiresAt) {
+		return nil, ErrMagicLinkTokenExpired
+	}
+
+	consumed, err := uc.repo.ConsumeMagicLinkToken(magicLinkToken.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume magic link token: %w", err)
+	}
+	if !consumed {
+		return nil, ErrInvalidMagicLinkToken
+	}
+
+	user, err := uc.repo.GetByID(magicLinkToken.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrInvalidMagicLinkToken
+	}
+
+	if err := checkLoginAllowed(user); err != nil {
+		return nil, err
+	}
+
+	return newLoginResponse(uc.jwtManager, user)
+}
+
 func (uc *userUseCase) RefreshToken(refreshToken string) (string, string, error) {
 	// Use JWT manager to validate and generate new tokens
 	accessToken, newRefreshToken, err := uc.jwtManager.RefreshToken(refreshToken)
@@ -174,12 +341,21 @@ func (uc *userUseCase) UpdateUser(request *domain.UpdateUserRequest) (*domain.Us
 
 	user.Email = request.Email
 	user.Name = request.Name
+	if request.Timezone != nil {
+		if *request.Timezone != "" {
+			if _, err := utils.LoadLocation(*request.Timezone); err != nil {
+				return nil, ErrInvalidTimezone
+			}
+		}
+		user.Timezone = *request.Timezone
+	}
 	user.UpdatedAt = time.Now()
 
 	if err := uc.repo.Update(user); err != nil {
 		return nil, err
 	}
 
+	uc.publishEvent(context.Background(), domain.EventUpdated, user)
 	return user, nil
 }
 
@@ -201,21 +377,94 @@ func (uc *userUseCase) UpdatePassword(request *domain.UpdatePasswordRequest) err
 		return ErrInvalidOldPassword
 	}
 
+	if err := uc.checkPasswordPolicy("new_password", request.NewPassword, user.Email, user.Name, user); err != nil {
+		return err
+	}
+
 	hashedPassword, err := password.Hash(request.NewPassword)
 	if err != nil {
 		return fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	err = uc.repo.UpdatePassword(request.ID, hashedPassword)
-	if err != nil {
+	return uc.changePassword(user, hashedPassword)
+}
+
+// checkPasswordPolicy validates a new password against the configured policy.
+// user is nil during registration, when there is no password history yet.
+func (uc *userUseCase) checkPasswordPolicy(field, newPassword, email, name string, user *domain.User) error {
+	if uc.passwordPolicy == nil {
+		return nil
+	}
+
+	input := password.PolicyInput{
+		Field: field,
+		Email: email,
+		Name:  name,
+	}
+
+	if user != nil && uc.passwordPolicy.HistorySize > 0 {
+		input.PreviousHashes = append(input.PreviousHashes, user.PasswordHash)
+
+		if uc.passwordPolicy.HistorySize > 1 {
+			history, err := uc.repo.GetPasswordHistory(user.ID, uc.passwordPolicy.HistorySize-1)
+			if err != nil {
+				return fmt.Errorf("failed to get password history: %w", err)
+			}
+			input.PreviousHashes = append(input.PreviousHashes, history...)
+		}
+	}
+
+	return uc.passwordPolicy.Validate(newPassword, input)
+}
+
+// changePassword stores the new password hash and keeps the replaced one in
+// the password history
+func (uc *userUseCase) changePassword(user *domain.User, hashedPassword string) error {
+	if user.PasswordHash != "" {
+		if err := uc.repo.AddPasswordHistory(user.ID, user.PasswordHash); err != nil {
+			return fmt.Errorf("failed to record password history: %w", err)
+		}
+	}
+
+	if err := uc.repo.UpdatePassword(user.ID, hashedPassword); err != nil {
 		return err
 	}
 
+	user.PasswordHash = hashedPassword
 	return nil
 }
 
 func (uc *userUseCase) DeleteUser(id string) error {
-	return uc.repo.Delete(id)
+	// Load the user first so subscribers learn who was deleted
+	user, err := uc.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.Delete(id); err != nil {
+		return err
+	}
+
+	if user != nil {
+		uc.publishEvent(context.Background(), domain.EventDeleted, user)
+	}
+	return nil
+}
+
+// preferredLocale returns the locale the user reads emails in
+func (uc *userUseCase) preferredLocale(userID string) string {
+	preferences, err := uc.preferencesRepo.GetByUserID(userID)
+	if err != nil || preferences == nil {
+		return domain.DefaultUserPreferences(userID).Locale
+	}
+	return preferences.Locale
+}
+
+// publishEvent notifies subscribers of a change, if publishing is set up
+func (uc *userUseCase) publishEvent(ctx context.Context, event string, user *domain.User) {
+	if uc.publish != nil {
+		uc.publish(ctx, event, user)
+	}
 }
 
 func (uc *userUseCase) ForgotPassword(req *domain.ForgotPasswordRequest) error {
@@ -268,6 +517,10 @@ func (uc *userUseCase) ResetPassword(req *domain.ResetPasswordRequest) error {
 		return ErrResetTokenExpired
 	}
 
+	if err := uc.checkPasswordPolicy("new_password", req.NewPassword, user.Email, user.Name, user); err != nil {
+		return err
+	}
+
 	// Hash new password
 	hashedPassword, err := password.Hash(req.NewPassword)
 	if err != nil {
@@ -275,13 +528,13 @@ func (uc *userUseCase) ResetPassword(req *domain.ResetPasswordRequest) error {
 	}
 
 	// Update user password and clear reset token
-	user.PasswordHash = hashedPassword
-	user.ResetPasswordToken = ""
-	user.UpdatedAt = time.Now()
-
-	if err := uc.repo.Update(user); err != nil {
+	if err := uc.changePassword(user, hashedPassword); err != nil {
 		return fmt.Errorf("failed to update password: %w", err)
 	}
 
+	if err := uc.repo.UpdateForgotPasswordToken(user.ID, ""); err != nil {
+		return fmt.Errorf("failed to clear reset token: %w", err)
+	}
+
 	return nil
 }
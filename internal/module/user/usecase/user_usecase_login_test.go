@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"dailyalu-server/internal/module/user/domain"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/security/password"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestUserUseCase_Login(t *testing.T) {
+	legacyHash, err := bcrypt.GenerateFromPassword([]byte("Giraffe-Lamp-93"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	currentHash, err := password.Hash("Giraffe-Lamp-93")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		passwordHash  string
+		status        int16
+		password      string
+		expectRehash  bool
+		expectedError error
+	}{
+		{
+			name:         "upgrades legacy bcrypt hash",
+			passwordHash: string(legacyHash),
+			status:       domain.UserStatusActive,
+			password:     "Giraffe-Lamp-93",
+			expectRehash: true,
+		},
+		{
+			name:         "keeps current hash",
+			passwordHash: currentHash,
+			status:       domain.UserStatusActive,
+			password:     "Giraffe-Lamp-93",
+		},
+		{
+			name:          "wrong password does not rehash",
+			passwordHash:  string(legacyHash),
+			status:        domain.UserStatusActive,
+			password:      "Wrong-Password-1",
+			expectedError: ErrInvalidCredentials,
+		},
+		{
+			name:          "blocked user does not rehash",
+			passwordHash:  string(legacyHash),
+			status:        domain.UserStatusBlocked,
+			password:      "Giraffe-Lamp-93",
+			expectedError: ErrUserBlocked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var updatedHash string
+			mockRepo := &MockUserRepository{
+				GetByEmailFunc: func(email string) (*domain.User, error) {
+					return &domain.User{ID: "user-1", Email: email, Status: tc.status, PasswordHash: tc.passwordHash}, nil
+				},
+				UpdatePasswordFunc: func(id, hash string) error {
+					updatedHash = hash
+					return nil
+				},
+			}
+
+			uc := &userUseCase{
+				repo:       mockRepo,
+				jwtManager: jwt.NewJWTManager("secret", "refresh-secret", time.Hour, time.Hour),
+			}
+
+			_, err := uc.Login(&domain.LoginRequest{Email: "parent@example.com", Password: tc.password})
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if tc.expectRehash {
+				if !strings.HasPrefix(updatedHash, "$argon2id$") || !password.Verify(tc.password, updatedHash) {
+					t.Errorf("expected password to be rehashed with argon2id, got %q", updatedHash)
+				}
+			} else if updatedHash != "" {
+				t.Errorf("expected no rehash, got %q", updatedHash)
+			}
+		})
+	}
+}
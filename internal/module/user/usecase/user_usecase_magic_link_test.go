@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	outboxDomain "dailyalu-server/internal/module/outbox/domain"
+	"dailyalu-server/internal/module/user/domain"
+	"dailyalu-server/internal/security/jwt"
+	"dailyalu-server/internal/security/token"
+	mailerDomain "dailyalu-server/internal/service/mailer/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestUserUseCase_RequestMagicLink(t *testing.T) {
+	activeUser := func(email string) (*domain.User, error) {
+		return &domain.User{
+			ID:     "user-1",
+			Email:  email,
+			Name:   "Test User",
+			Status: domain.UserStatusActive,
+		}, nil
+	}
+
+	testCases := []struct {
+		name          string
+		mockRepo      *MockUserRepository
+		expectEmail   bool
+		expectedError error
+	}{
+		{
+			name: "queues sign-in link",
+			mockRepo: &MockUserRepository{
+				GetByEmailFunc: activeUser,
+				CountMagicLinkTokensSinceFunc: func(email string, since time.Time) (int, error) {
+					return 0, nil
+				},
+				CreateMagicLinkTokenFunc: func(token *domain.MagicLinkToken) error {
+					return nil
+				},
+			},
+			expectEmail: true,
+		},
+		{
+			name: "unknown email is silently ignored",
+			mockRepo: &MockUserRepository{
+				GetByEmailFunc: func(email string) (*domain.User, error) {
+					return nil, nil
+				},
+			},
+		},
+		{
+			name: "rate limited email is silently ignored",
+			mockRepo: &MockUserRepository{
+				GetByEmailFunc: activeUser,
+				CountMagicLinkTokensSinceFunc: func(email string, since time.Time) (int, error) {
+					return magicLinkMaxRequests, nil
+				},
+			},
+		},
+		{
+			// A failure for a known address must look like an unknown one
+			name: "storage failure is not reported",
+			mockRepo: &MockUserRepository{
+				GetByEmailFunc: activeUser,
+				CountMagicLinkTokensSinceFunc: func(email string, since time.Time) (int, error) {
+					return 0, nil
+				},
+				CreateMagicLinkTokenFunc: func(token *domain.MagicLinkToken) error {
+					return errors.New("connection refused")
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var created *domain.MagicLinkToken
+			if create := tc.mockRepo.CreateMagicLinkTokenFunc; create != nil {
+				tc.mockRepo.CreateMagicLinkTokenFunc = func(token *domain.MagicLinkToken) error {
+					created = token
+					return create(token)
+				}
+			}
+
+			// The email is written in the user's language
+			preferencesRepo := newInMemoryPreferencesRepository()
+			preferences := domain.DefaultUserPreferences("user-1")
+			preferences.Locale = "id-ID"
+			preferencesRepo.Upsert(preferences)
+
+			uc := &userUseCase{
+				repo:            tc.mockRepo,
+				preferencesRepo: preferencesRepo,
+				tokenService:    token.NewTokenService(),
+				logger:          zap.NewNop(),
+			}
+
+			err := uc.RequestMagicLink(context.Background(), &domain.MagicLinkRequest{Email: "test@example.com"})
+			if err != tc.expectedError {
+				t.Fatalf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if !tc.expectEmail {
+				if len(tc.mockRepo.Enqueued) != 0 {
+					t.Error("expected no email to be queued")
+				}
+				return
+			}
+
+			// The email is queued with the token rather than sent in the request
+			if created == nil || len(tc.mockRepo.Enqueued) != 1 || tc.mockRepo.Enqueued[0].Topic != outboxDomain.TopicMagicLinkEmail {
+				t.Fatalf("expected token to be stored with a queued email, got %+v", tc.mockRepo.Enqueued)
+			}
+			var sent mailerDomain.MagicLinkEmailData
+			if err := json.Unmarshal(tc.mockRepo.Enqueued[0].Payload, &sent); err != nil {
+				t.Fatalf("unexpected payload: %v", err)
+			}
+			if sent.Locale != "id-ID" {
+				t.Errorf("expected the email in the user's locale, got %q", sent.Locale)
+			}
+
+			rawToken := sent.MagicLinkURL[strings.Index(sent.MagicLinkURL, "token=")+len("token="):]
+			if created.TokenHash == rawToken {
+				t.Error("expected token to be stored hashed")
+			}
+			if created.TokenHash != uc.tokenService.HashToken(rawToken) {
+				t.Error("stored hash does not match emailed token")
+			}
+		})
+	}
+}
+
+func TestUserUseCase_LoginWithMagicLink(t *testing.T) {
+	tokenService := token.NewTokenService()
+	validHash := tokenService.HashToken("valid-token")
+
+	storedToken := func(expiresAt time.Time, usedAt *time.Time) func(string) (*domain.MagicLinkToken, error) {
+		return func(tokenHash string) (*domain.MagicLinkToken, error) {
+			if tokenHash != validHash {
+				return nil, nil
+			}
+			return &domain.MagicLinkToken{
+				ID:        "token-1",
+				UserID:    "user-1",
+				TokenHash: validHash,
+				ExpiresAt: expiresAt,
+				UsedAt:    usedAt,
+			}, nil
+		}
+	}
+
+	userWithStatus := func(status int16) func(string) (*domain.User, error) {
+		return func(id string) (*domain.User, error) {
+			return &domain.User{ID: id, Email: "test@example.com", Role: "user", Status: status}, nil
+		}
+	}
+
+	consumed := func(id string, usedAt time.Time) (bool, error) {
+		return true, nil
+	}
+
+	usedAt := time.Now().Add(-time.Minute)
+
+	testCases := []struct {
+		name          string
+		token         string
+		mockRepo      *MockUserRepository
+		expectedError error
+	}{
+		{
+			name:  "successful login",
+			token: "valid-token",
+			mockRepo: &MockUserRepository{
+				GetMagicLinkTokenByHashFunc: storedToken(time.Now().Add(time.Minute), nil),
+				ConsumeMagicLinkTokenFunc:   consumed,
+				GetByIDFunc:                 userWithStatus(domain.UserStatusActive),
+			},
+		},
+		{
+			name:  "unknown token",
+			token: "other-token",
+			mockRepo: &MockUserRepository{
+				GetMagicLinkTokenByHashFunc: storedToken(time.Now().Add(time.Minute), nil),
+			},
+			expectedError: ErrInvalidMagicLinkToken,
+		},
+		{
+			name:  "token already used",
+			token: "valid-token",
+			mockRepo: &MockUserRepository{
+				GetMagicLinkTokenByHashFunc: storedToken(time.Now().Add(time.Minute), &usedAt),
+			},
+			expectedError: ErrInvalidMagicLinkToken,
+		},
+		{
+			name:  "token consumed concurrently",
+			token: "valid-token",
+			mockRepo: &MockUserRepository{
+				GetMagicLinkTokenByHashFunc: storedToken(time.Now().Add(time.Minute), nil),
+				ConsumeMagicLinkTokenFunc: func(id string, usedAt time.Time) (bool, error) {
+					return false, nil
+				},
+			},
+			expectedError: ErrInvalidMagicLinkToken,
+		},
+		{
+			name:  "token expired",
+			token: "valid-token",
+			mockRepo: &MockUserRepository{
+				GetMagicLinkTokenByHashFunc: storedToken(time.Now().Add(-time.Minute), nil),
+			},
+			expectedError: ErrMagicLinkTokenExpired,
+		},
+		{
+			name:  "blocked user",
+			token: "valid-token",
+			mockRepo: &MockUserRepository{
+				GetMagicLinkTokenByHashFunc: storedToken(time.Now().Add(time.Minute), nil),
+				ConsumeMagicLinkTokenFunc:   consumed,
+				GetByIDFunc:                 userWithStatus(domain.UserStatusBlocked),
+			},
+			expectedError: ErrUserBlocked,
+		},
+		{
+			name:  "unverified user",
+			token: "valid-token",
+			mockRepo: &MockUserRepository{
+				GetMagicLinkTokenByHashFunc: storedToken(time.Now().Add(time.Minute), nil),
+				ConsumeMagicLinkTokenFunc:   consumed,
+				GetByIDFunc:                 userWithStatus(domain.UserStatusNotActive),
+			},
+			expectedError: ErrEmailNotVerified,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := &userUseCase{
+				repo:         tc.mockRepo,
+				jwtManager:   jwt.NewJWTManager("secret", "refresh-secret", time.Hour, time.Hour),
+				tokenService: tokenService,
+			}
+
+			result, err := uc.LoginWithMagicLink(context.Background(), &domain.MagicLinkLoginRequest{Token: tc.token})
+
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("expected error %v, got %v", tc.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.AccessToken == "" || result.RefreshToken == "" {
+				t.Error("expected token pair, got empty tokens")
+			}
+		})
+	}
+}
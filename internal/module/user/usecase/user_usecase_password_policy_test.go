@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/user/domain"
+	"dailyalu-server/internal/security/password"
+	"errors"
+	"testing"
+)
+
+func TestUserUseCase_RegisterPasswordPolicy(t *testing.T) {
+	uc := &userUseCase{
+		repo: &MockUserRepository{
+			GetByEmailFunc: func(email string) (*domain.User, error) {
+				return nil, nil
+			},
+		},
+		passwordPolicy: password.DefaultPolicy(),
+	}
+
+	_, err := uc.Register(context.Background(), &domain.RegisterRequest{
+		Email:           "parent@example.com",
+		Name:            "Jane Doe",
+		Password:        "password123",
+		ConfirmPassword: "password123",
+	})
+
+	var policyErr *password.PolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("expected PolicyError, got %v", err)
+	}
+	if policyErr.Violations[0].Field != "password" {
+		t.Errorf("expected violation on password field, got %s", policyErr.Violations[0].Field)
+	}
+}
+
+func TestUserUseCase_UpdatePasswordPolicy(t *testing.T) {
+	currentHash, _ := password.Hash("Current-Secret-1")
+	previousHash, _ := password.Hash("Previous-Secret-2")
+
+	testCases := []struct {
+		name        string
+		newPassword string
+		expectedTag string
+	}{
+		{
+			name:        "rejects current password",
+			newPassword: "Current-Secret-1",
+			expectedTag: password.ViolationReused,
+		},
+		{
+			name:        "rejects password from history",
+			newPassword: "Previous-Secret-2",
+			expectedTag: password.ViolationReused,
+		},
+		{
+			name:        "rejects password containing name",
+			newPassword: "Janet-Secret-3",
+			expectedTag: password.ViolationPersonalInfo,
+		},
+		{
+			name:        "accepts new password and records history",
+			newPassword: "Brand-New-Secret-4",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var recorded, updated string
+			uc := &userUseCase{
+				repo: &MockUserRepository{
+					GetByIDFunc: func(id string) (*domain.User, error) {
+						return &domain.User{ID: id, Email: "parent@example.com", Name: "Janet", PasswordHash: currentHash}, nil
+					},
+					GetPasswordHistoryFunc: func(id string, limit int) ([]string, error) {
+						return []string{previousHash}, nil
+					},
+					AddPasswordHistoryFunc: func(id, passwordHash string) error {
+						recorded = passwordHash
+						return nil
+					},
+					UpdatePasswordFunc: func(id, passwordHash string) error {
+						updated = passwordHash
+						return nil
+					},
+				},
+				passwordPolicy: password.DefaultPolicy(),
+			}
+
+			err := uc.UpdatePassword(&domain.UpdatePasswordRequest{
+				ID:              "user-1",
+				OldPassword:     "Current-Secret-1",
+				NewPassword:     tc.newPassword,
+				ConfirmPassword: tc.newPassword,
+			})
+
+			if tc.expectedTag != "" {
+				var policyErr *password.PolicyError
+				if !errors.As(err, &policyErr) {
+					t.Fatalf("expected PolicyError, got %v", err)
+				}
+				if policyErr.Violations[0].Tag != tc.expectedTag || policyErr.Violations[0].Field != "new_password" {
+					t.Errorf("unexpected violation: %+v", policyErr.Violations[0])
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if recorded != currentHash {
+				t.Error("expected replaced password hash to be recorded in history")
+			}
+			if !password.Verify(tc.newPassword, updated) {
+				t.Error("expected new password hash to be stored")
+			}
+		})
+	}
+}
@@ -2,9 +2,13 @@ package usecase
 
 import (
 	"context"
+	outboxDomain "dailyalu-server/internal/module/outbox/domain"
 	"dailyalu-server/internal/module/user/domain"
 	"dailyalu-server/internal/security/token"
+	mailerDomain "dailyalu-server/internal/service/mailer/domain"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -34,11 +38,6 @@ func TestUserUseCase_Register(t *testing.T) {
 					return nil // successful creation
 				},
 			},
-			mockMailer: &MockMailerService{
-				SendVerificationEmailFunc: func() error {
-					return nil
-				},
-			},
 			expectedUser: &domain.User{
 				Email:                  "test@example.com",
 				Name:                   "Test User",
@@ -141,9 +140,10 @@ func TestUserUseCase_Register(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			uc := &userUseCase{
-				repo:         tc.mockRepo,
-				tokenService: tc.mockToken,
-				mailerService: tc.mockMailer,
+				repo:            tc.mockRepo,
+				preferencesRepo: newInMemoryPreferencesRepository(),
+				tokenService:    tc.mockToken,
+				mailerService:   tc.mockMailer,
 			}
 
 			user, err := uc.Register(context.Background(), tc.req)
@@ -194,6 +194,18 @@ func TestUserUseCase_Register(t *testing.T) {
 			if user.PasswordHash == "" {
 				t.Error("expected password hash, got empty")
 			}
+
+			// The verification email is queued with the user instead of sent
+			if len(tc.mockRepo.Enqueued) != 1 || tc.mockRepo.Enqueued[0].Topic != outboxDomain.TopicVerificationEmail {
+				t.Fatalf("expected a queued verification email, got %+v", tc.mockRepo.Enqueued)
+			}
+			var email mailerDomain.EmailVerificationData
+			if err := json.Unmarshal(tc.mockRepo.Enqueued[0].Payload, &email); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if email.To != tc.req.Email || !strings.Contains(email.VerificationURL, user.EmailVerificationToken) {
+				t.Errorf("unexpected verification email: %+v", email)
+			}
 		})
 	}
 }
\ No newline at end of file
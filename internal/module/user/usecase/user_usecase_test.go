@@ -3,6 +3,7 @@ package usecase
 
 import (
 	"context"
+	outboxDomain "dailyalu-server/internal/module/outbox/domain"
 	"dailyalu-server/internal/module/user/domain"
 	mailerDomain "dailyalu-server/internal/service/mailer/domain"
 	"time"
@@ -20,6 +21,15 @@ type MockUserRepository struct {
 	UpdatePasswordFunc            func(id, password string) error
 	GetByResetPasswordTokenFunc   func(token string) (*domain.User, error)
 	UpdateForgotPasswordTokenFunc func(id, token string) error
+	CreateMagicLinkTokenFunc      func(token *domain.MagicLinkToken) error
+	GetMagicLinkTokenByHashFunc   func(tokenHash string) (*domain.MagicLinkToken, error)
+	ConsumeMagicLinkTokenFunc     func(id string, usedAt time.Time) (bool, error)
+	CountMagicLinkTokensSinceFunc func(email string, since time.Time) (int, error)
+	AddPasswordHistoryFunc        func(id, passwordHash string) error
+	GetPasswordHistoryFunc        func(id string, limit int) ([]string, error)
+
+	// Enqueued collects the outbox messages stored with users and tokens
+	Enqueued []*outboxDomain.Message
 }
 
 func (m *MockUserRepository) GetByID(id string) (*domain.User, error) {
@@ -30,8 +40,12 @@ func (m *MockUserRepository) GetByEmail(email string) (*domain.User, error) {
 	return m.GetByEmailFunc(email)
 }
 
-func (m *MockUserRepository) Create(user *domain.User) error {
-	return m.CreateFunc(user)
+func (m *MockUserRepository) Create(user *domain.User, messages ...*outboxDomain.Message) error {
+	if err := m.CreateFunc(user); err != nil {
+		return err
+	}
+	m.Enqueued = append(m.Enqueued, messages...)
+	return nil
 }
 
 func (m *MockUserRepository) Update(user *domain.User) error {
@@ -62,6 +76,34 @@ func (m *MockUserRepository) UpdateForgotPasswordToken(id, token string) error {
 	return m.UpdateForgotPasswordTokenFunc(id, token)
 }
 
+func (m *MockUserRepository) CreateMagicLinkToken(token *domain.MagicLinkToken, messages ...*outboxDomain.Message) error {
+	if err := m.CreateMagicLinkTokenFunc(token); err != nil {
+		return err
+	}
+	m.Enqueued = append(m.Enqueued, messages...)
+	return nil
+}
+
+func (m *MockUserRepository) GetMagicLinkTokenByHash(tokenHash string) (*domain.MagicLinkToken, error) {
+	return m.GetMagicLinkTokenByHashFunc(tokenHash)
+}
+
+func (m *MockUserRepository) ConsumeMagicLinkToken(id string, usedAt time.Time) (bool, error) {
+	return m.ConsumeMagicLinkTokenFunc(id, usedAt)
+}
+
+func (m *MockUserRepository) CountMagicLinkTokensSince(email string, since time.Time) (int, error) {
+	return m.CountMagicLinkTokensSinceFunc(email, since)
+}
+
+func (m *MockUserRepository) AddPasswordHistory(id, passwordHash string) error {
+	return m.AddPasswordHistoryFunc(id, passwordHash)
+}
+
+func (m *MockUserRepository) GetPasswordHistory(id string, limit int) ([]string, error) {
+	return m.GetPasswordHistoryFunc(id, limit)
+}
+
 // MockTokenService implements the token service interface for testing
 type MockTokenService struct {
 	GenerateTokenFunc            func() (string, error)
@@ -79,8 +121,26 @@ func (m *MockTokenService) GenerateVerificationLink(baseURL, token string) strin
 // MockMailerService implements the mailer service interface for testing
 type MockMailerService struct {
 	SendVerificationEmailFunc func() error
+	SendMagicLinkEmailFunc    func(data *mailerDomain.MagicLinkEmailData) error
+	SendNotificationEmailFunc func(data *mailerDomain.NotificationEmailData) error
 }
 
 func (m *MockMailerService) SendVerificationEmail(ctx context.Context, data *mailerDomain.EmailVerificationData) error {
 	return m.SendVerificationEmailFunc()
 }
+
+func (m *MockMailerService) SendMagicLinkEmail(ctx context.Context, data *mailerDomain.MagicLinkEmailData) error {
+	return m.SendMagicLinkEmailFunc(data)
+}
+
+func (m *MockMailerService) SendNotificationEmail(ctx context.Context, data *mailerDomain.NotificationEmailData) error {
+	return m.SendNotificationEmailFunc(data)
+}
+
+func (m *MockMailerService) SendWeeklyDigestEmail(ctx context.Context, data *mailerDomain.WeeklyDigestEmailData) error {
+	return nil
+}
+
+func (m *MockMailerService) SendReportEmail(ctx context.Context, data *mailerDomain.ReportEmailData) error {
+	return nil
+}
@@ -0,0 +1,159 @@
+// Package dispatcher delivers queued webhook events in the background, using
+// the lease and retry runner of package worker.
+package dispatcher
+
+import (
+	"bytes"
+	"context"
+	"dailyalu-server/internal/module/webhook/domain"
+	"dailyalu-server/internal/module/webhook/repository"
+	"dailyalu-server/internal/security/outbound"
+	"dailyalu-server/internal/service/worker"
+	"dailyalu-server/pkg/app_log/zap_log"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Headers sent with every delivery, besides the signature headers of
+// outbound.SignRequest
+const (
+	HeaderEvent    = "X-DailyAlu-Event"
+	HeaderDelivery = "X-DailyAlu-Delivery"
+)
+
+// errSubscriptionInactive fails deliveries to paused or deleted
+// subscriptions without retrying them
+var errSubscriptionInactive = fmt.Errorf("%w: subscription is inactive", worker.ErrPermanent)
+
+// Config controls polling and retries. Timeout limits each request to a
+// subscriber.
+type Config = worker.Config
+
+// NewConfigFromConfig reads the dispatcher settings under webhooks
+func NewConfigFromConfig() Config {
+	return worker.NewConfigFromConfig("webhooks")
+}
+
+// Dispatcher polls for due deliveries and posts them to their subscription
+type Dispatcher struct {
+	webhookRepo repository.IWebhookRepository
+	client      *http.Client
+	runner      *worker.Runner[domain.Delivery]
+	logger      *zap.Logger
+	now         func() time.Time
+}
+
+// NewDispatcher creates a webhook dispatcher. Requests only connect to
+// addresses urlPolicy allows, and redirects are not followed.
+func NewDispatcher(webhookRepo repository.IWebhookRepository, urlPolicy outbound.Policy, config Config) *Dispatcher {
+	logger := zap_log.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	d := &Dispatcher{
+		webhookRepo: webhookRepo,
+		client:      urlPolicy.NewClient(config.Timeout),
+		logger:      logger,
+		now:         time.Now,
+	}
+	d.runner = worker.NewRunner("webhook deliveries", worker.Jobs[domain.Delivery]{
+		Claim:    webhookRepo.ClaimDue,
+		Attempts: func(delivery *domain.Delivery) int { return delivery.Attempts },
+		Handle:   d.deliver,
+		Record:   d.record,
+	}, config, func() time.Time { return d.now() })
+	return d
+}
+
+// Run delivers due events until the context is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	d.runner.Run(ctx)
+}
+
+// RunOnce claims one batch of due deliveries and attempts them. It returns
+// the number of deliveries claimed.
+func (d *Dispatcher) RunOnce(ctx context.Context) (int, error) {
+	return d.runner.RunOnce(ctx)
+}
+
+// record stores the outcome of an attempt at a delivery
+func (d *Dispatcher) record(delivery *domain.Delivery, attempt worker.Attempt) {
+	delivery.Attempts = attempt.Number
+	delivery.LastAttemptAt = &attempt.StartedAt
+	delivery.NextAttemptAt = attempt.RetryAt
+	delivery.LastError = ""
+
+	switch {
+	case attempt.Err == nil:
+		delivery.Status = domain.DeliverySucceeded
+	case attempt.GaveUp():
+		delivery.Status = domain.DeliveryFailed
+		delivery.LastError = attempt.Err.Error()
+	default:
+		delivery.Status = domain.DeliveryPending
+		delivery.LastError = attempt.Err.Error()
+	}
+
+	if attempt.Err != nil {
+		d.logger.Warn("failed to deliver webhook",
+			zap.Int64("delivery_id", delivery.ID),
+			zap.Int64("subscription_id", delivery.SubscriptionID),
+			zap.Int("attempt", delivery.Attempts),
+			zap.Error(attempt.Err),
+		)
+	}
+
+	if err := d.webhookRepo.RecordAttempt(delivery, attempt.LeasedUntil); err != nil {
+		d.logger.Error("failed to record webhook delivery", zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+	}
+}
+
+// deliver posts the signed payload and keeps the response status, if any, on
+// the delivery. Responses other than 2xx are failures.
+func (d *Dispatcher) deliver(ctx context.Context, delivery *domain.Delivery) error {
+	delivery.ResponseStatus = nil
+
+	subscription, err := d.webhookRepo.GetSubscription(delivery.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to load subscription: %w", err)
+	}
+	if subscription == nil || !subscription.Active {
+		return errSubscriptionInactive
+	}
+
+	timestamp := d.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "DailyAlu-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	outbound.SignRequest(req, subscription.Secret, timestamp, delivery.Payload)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	status := resp.StatusCode
+	delivery.ResponseStatus = &status
+
+	// Drain a little of the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
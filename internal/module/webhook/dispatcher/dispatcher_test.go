@@ -0,0 +1,175 @@
+package dispatcher
+
+import (
+	"context"
+	"dailyalu-server/internal/module/webhook/domain"
+	"dailyalu-server/internal/security/outbound"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockWebhookRepository serves claimed deliveries and records attempts
+type MockWebhookRepository struct {
+	Subscriptions map[int64]*domain.Subscription
+	Due           []domain.Delivery
+	Recorded      map[int64]domain.Delivery
+}
+
+func (m *MockWebhookRepository) CreateSubscription(subscription *domain.Subscription) error {
+	return nil
+}
+
+func (m *MockWebhookRepository) GetSubscription(id int64) (*domain.Subscription, error) {
+	return m.Subscriptions[id], nil
+}
+
+func (m *MockWebhookRepository) GetSubscriptions(userID, apiKeyID string) ([]domain.Subscription, error) {
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) GetSubscribers(userID, eventType string) ([]domain.Subscription, error) {
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) UpdateSubscription(subscription *domain.Subscription) error {
+	return nil
+}
+
+func (m *MockWebhookRepository) DeleteSubscription(id int64) error { return nil }
+
+func (m *MockWebhookRepository) CreateDelivery(delivery *domain.Delivery) error { return nil }
+
+func (m *MockWebhookRepository) GetDelivery(id int64) (*domain.Delivery, error) { return nil, nil }
+
+func (m *MockWebhookRepository) GetDeliveries(subscriptionID int64, limit int) ([]domain.Delivery, error) {
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) ClaimDue(now, lockUntil time.Time, limit int) ([]domain.Delivery, error) {
+	due := m.Due[:min(limit, len(m.Due))]
+	m.Due = m.Due[len(due):]
+	return due, nil
+}
+
+func (m *MockWebhookRepository) RecordAttempt(delivery *domain.Delivery, lockedUntil time.Time) error {
+	m.Recorded[delivery.ID] = *delivery
+	return nil
+}
+
+func TestRunOnce(t *testing.T) {
+	now := time.Date(2025, 3, 20, 8, 0, 0, 0, time.UTC)
+	payload := []byte(`{"id":"event-1","type":"activity.created","data":{"id":42}}`)
+
+	var signature, timestamp string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != string(payload) || r.Header.Get(HeaderEvent) != "activity.created" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			signature = r.Header.Get(outbound.HeaderSignature)
+			timestamp = r.Header.Get(outbound.HeaderTimestamp)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	repo := &MockWebhookRepository{
+		Subscriptions: map[int64]*domain.Subscription{
+			1: {ID: 1, URL: server.URL + "/ok", Secret: "whsec_test", Active: true},
+			2: {ID: 2, URL: server.URL + "/broken", Secret: "whsec_test", Active: true},
+			3: {ID: 3, URL: server.URL + "/ok", Secret: "whsec_test", Active: false},
+		},
+		Due: []domain.Delivery{
+			{ID: 1, SubscriptionID: 1, EventType: "activity.created", Payload: payload, Status: domain.DeliveryPending},
+			{ID: 2, SubscriptionID: 2, EventType: "activity.created", Payload: payload, Status: domain.DeliveryPending, Attempts: 1},
+			{ID: 3, SubscriptionID: 2, EventType: "activity.created", Payload: payload, Status: domain.DeliveryPending, Attempts: 2},
+			{ID: 4, SubscriptionID: 3, EventType: "activity.created", Payload: payload, Status: domain.DeliveryPending},
+		},
+		Recorded: map[int64]domain.Delivery{},
+	}
+
+	d := NewDispatcher(repo, outbound.Policy{AllowHTTP: true, AllowPrivateNetworks: true}, Config{BatchSize: 10, Lease: time.Minute, MaxAttempts: 3, RetryBackoff: time.Minute, Timeout: time.Second})
+	d.now = func() time.Time { return now }
+
+	claimed, err := d.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claimed != 4 {
+		t.Errorf("expected 4 claimed deliveries, got %d", claimed)
+	}
+
+	// The receiver can verify the signature with the shared secret
+	if ts, _ := strconv.ParseInt(timestamp, 10, 64); ts != now.Unix() || signature != outbound.Sign("whsec_test", ts, payload) {
+		t.Errorf("unexpected signature %q at %q", signature, timestamp)
+	}
+	if delivered := repo.Recorded[1]; delivered.Status != domain.DeliverySucceeded || *delivered.ResponseStatus != http.StatusOK || delivered.Attempts != 1 {
+		t.Errorf("expected a successful delivery, got %+v", delivered)
+	}
+
+	// The second failure is retried after twice the backoff
+	retried := repo.Recorded[2]
+	if retried.Status != domain.DeliveryPending || !retried.NextAttemptAt.Equal(now.Add(2*time.Minute)) || *retried.ResponseStatus != http.StatusInternalServerError {
+		t.Errorf("expected a retry in 2 minutes, got %+v", retried)
+	}
+
+	// The last attempt fails the delivery, as does an inactive subscription
+	for _, id := range []int64{3, 4} {
+		if failed := repo.Recorded[id]; failed.Status != domain.DeliveryFailed || failed.NextAttemptAt != nil || failed.LastError == "" {
+			t.Errorf("expected delivery %d to fail, got %+v", id, failed)
+		}
+	}
+}
+
+func TestRunOnceStaysOffPrivateNetworks(t *testing.T) {
+	now := time.Date(2025, 3, 20, 8, 0, 0, 0, time.UTC)
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	}))
+	defer server.Close()
+
+	repo := &MockWebhookRepository{
+		Subscriptions: map[int64]*domain.Subscription{
+			1: {ID: 1, URL: server.URL + "/hooks", Secret: "whsec_test", Active: true},
+		},
+		Due:      []domain.Delivery{{ID: 1, SubscriptionID: 1, EventType: "activity.created", Payload: []byte(`{}`), Status: domain.DeliveryPending}},
+		Recorded: map[int64]domain.Delivery{},
+	}
+	config := Config{BatchSize: 10, Lease: time.Minute, MaxAttempts: 3, RetryBackoff: time.Minute, Timeout: time.Second}
+
+	// The test server listens on loopback, which a subscription saved before
+	// its host resolved there must not reach
+	d := NewDispatcher(repo, outbound.Policy{AllowHTTP: true}, config)
+	d.now = func() time.Time { return now }
+	if _, err := d.RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refused := repo.Recorded[1]; refused.Status != domain.DeliveryPending || refused.ResponseStatus != nil ||
+		!strings.Contains(refused.LastError, outbound.ErrForbiddenAddress.Error()) || len(requests) != 0 {
+		t.Errorf("expected the connection to be refused, got %+v after %d requests", refused, len(requests))
+	}
+
+	// Redirects are failures, not followed
+	repo.Due = []domain.Delivery{{ID: 2, SubscriptionID: 1, EventType: "activity.created", Payload: []byte(`{}`), Status: domain.DeliveryPending}}
+	d = NewDispatcher(repo, outbound.Policy{AllowHTTP: true, AllowPrivateNetworks: true}, config)
+	d.now = func() time.Time { return now }
+	if _, err := d.RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	redirected := repo.Recorded[2]
+	if redirected.Status != domain.DeliveryPending || redirected.ResponseStatus == nil || *redirected.ResponseStatus != http.StatusFound || len(requests) != 1 {
+		t.Errorf("expected the redirect to fail the attempt, got %+v after %d requests", redirected, len(requests))
+	}
+}
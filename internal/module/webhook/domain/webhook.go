@@ -0,0 +1,97 @@
+package domain
+
+import (
+	activityDomain "dailyalu-server/internal/module/activity/domain"
+	userDomain "dailyalu-server/internal/module/user/domain"
+	"encoding/json"
+	"time"
+)
+
+// EventAll subscribes to every event type
+const EventAll = "*"
+
+// EventTypes are the events webhooks can subscribe to
+var EventTypes = []string{
+	activityDomain.EventCreated,
+	activityDomain.EventUpdated,
+	activityDomain.EventDeleted,
+	userDomain.EventUpdated,
+	userDomain.EventVerified,
+	userDomain.EventDeleted,
+}
+
+// Delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// Subscription is an endpoint of a partner app that is sent a user's events.
+// Subscriptions belong to the user and the API key they were created with, so
+// apps only see their own.
+type Subscription struct {
+	ID          int64     `json:"id"`
+	UserID      string    `json:"user_id"`
+	APIKeyID    string    `json:"-"`
+	URL         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Secret      string    `json:"secret,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Subscribes reports whether the subscription wants events of the type
+func (s *Subscription) Subscribes(eventType string) bool {
+	for _, event := range s.Events {
+		if event == eventType || event == EventAll {
+			return true
+		}
+	}
+	return false
+}
+
+// Payload is the body posted to subscriptions
+type Payload struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Delivery is one event sent to one subscription, with the outcome of its
+// latest attempt
+type Delivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// CreateSubscriptionRequest represents the request to subscribe an endpoint
+// to events
+type CreateSubscriptionRequest struct {
+	UserID      string   `json:"-"`
+	APIKeyID    string   `json:"-"`
+	URL         string   `json:"url" validate:"required,url,max=2048"`
+	Events      []string `json:"events" validate:"required,min=1,dive,required"`
+	Description string   `json:"description,omitempty" validate:"max=200"`
+}
+
+// UpdateSubscriptionRequest represents the request to update a subscription
+type UpdateSubscriptionRequest struct {
+	ID int64 `json:"-"`
+	CreateSubscriptionRequest
+	Active *bool `json:"active,omitempty"`
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/webhook/domain"
+	"time"
+)
+
+// IWebhookRepository defines the interface for webhook data access
+type IWebhookRepository interface {
+	CreateSubscription(subscription *domain.Subscription) error
+	GetSubscription(id int64) (*domain.Subscription, error)
+	GetSubscriptions(userID, apiKeyID string) ([]domain.Subscription, error)
+	// GetSubscribers returns the active subscriptions of the user to the event
+	GetSubscribers(userID, eventType string) ([]domain.Subscription, error)
+	UpdateSubscription(subscription *domain.Subscription) error
+	DeleteSubscription(id int64) error
+
+	CreateDelivery(delivery *domain.Delivery) error
+	GetDelivery(id int64) (*domain.Delivery, error)
+	GetDeliveries(subscriptionID int64, limit int) ([]domain.Delivery, error)
+	// ClaimDue leases pending deliveries whose next attempt is due
+	ClaimDue(now, lockUntil time.Time, limit int) ([]domain.Delivery, error)
+	// RecordAttempt stores the outcome of an attempt and releases the lease,
+	// provided the delivery still holds the lease lockedUntil
+	RecordAttempt(delivery *domain.Delivery, lockedUntil time.Time) error
+}
@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"dailyalu-server/internal/module/webhook/domain"
+	"dailyalu-server/internal/service/worker"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const subscriptionColumns = `id, user_id, api_key_id, url, events, secret, description, active, created_at, updated_at`
+
+const deliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	last_attempt_at, response_status, last_error, created_at, updated_at`
+
+// PostgresWebhookRepository implements the webhook repository interface using PostgreSQL
+type PostgresWebhookRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresWebhookRepository creates a new PostgreSQL webhook repository
+func NewPostgresWebhookRepository(db *sql.DB) IWebhookRepository {
+	return &PostgresWebhookRepository{
+		db: db,
+	}
+}
+
+// CreateSubscription inserts a new subscription into the database
+func (r *PostgresWebhookRepository) CreateSubscription(subscription *domain.Subscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (user_id, api_key_id, url, events, secret, description, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	now := time.Now()
+	subscription.CreatedAt = now
+	subscription.UpdatedAt = now
+
+	return r.db.QueryRow(
+		query,
+		subscription.UserID,
+		subscription.APIKeyID,
+		subscription.URL,
+		pq.Array(subscription.Events),
+		subscription.Secret,
+		subscription.Description,
+		subscription.Active,
+		subscription.CreatedAt,
+		subscription.UpdatedAt,
+	).Scan(&subscription.ID)
+}
+
+// GetSubscription retrieves a subscription by ID
+func (r *PostgresWebhookRepository) GetSubscription(id int64) (*domain.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+
+	var subscription domain.Subscription
+	err := scanSubscription(r.db.QueryRow(query, id), &subscription)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// GetSubscriptions retrieves the subscriptions a user created with an API key
+func (r *PostgresWebhookRepository) GetSubscriptions(userID, apiKeyID string) ([]domain.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions
+		WHERE user_id = $1 AND api_key_id = $2
+		ORDER BY created_at`
+
+	return r.querySubscriptions(query, userID, apiKeyID)
+}
+
+// GetSubscribers retrieves the active subscriptions of a user to an event type
+func (r *PostgresWebhookRepository) GetSubscribers(userID, eventType string) ([]domain.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions
+		WHERE user_id = $1 AND active AND ($2 = ANY(events) OR $3 = ANY(events))`
+
+	return r.querySubscriptions(query, userID, eventType, domain.EventAll)
+}
+
+// UpdateSubscription updates an existing subscription
+func (r *PostgresWebhookRepository) UpdateSubscription(subscription *domain.Subscription) error {
+	query := `
+		UPDATE webhook_subscriptions
+		SET url = $1, events = $2, description = $3, active = $4, updated_at = $5
+		WHERE id = $6
+	`
+
+	subscription.UpdatedAt = time.Now()
+
+	result, err := r.db.Exec(
+		query,
+		subscription.URL,
+		pq.Array(subscription.Events),
+		subscription.Description,
+		subscription.Active,
+		subscription.UpdatedAt,
+		subscription.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+// DeleteSubscription removes a subscription and its delivery log
+func (r *PostgresWebhookRepository) DeleteSubscription(id int64) error {
+	result, err := r.db.Exec(`DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result)
+}
+
+// CreateDelivery queues a delivery
+func (r *PostgresWebhookRepository) CreateDelivery(delivery *domain.Delivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, attempts,
+			next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	now := time.Now()
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+
+	return r.db.QueryRow(
+		query,
+		delivery.SubscriptionID,
+		delivery.EventID,
+		delivery.EventType,
+		[]byte(delivery.Payload),
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+		delivery.UpdatedAt,
+	).Scan(&delivery.ID)
+}
+
+// GetDelivery retrieves a delivery by ID
+func (r *PostgresWebhookRepository) GetDelivery(id int64) (*domain.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+
+	var delivery domain.Delivery
+	err := scanDelivery(r.db.QueryRow(query, id), &delivery)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// GetDeliveries retrieves the latest deliveries of a subscription, newest first
+func (r *PostgresWebhookRepository) GetDeliveries(subscriptionID int64, limit int) ([]domain.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDeliveries(rows)
+}
+
+// ClaimDue leases due deliveries to the caller. SKIP LOCKED lets concurrent
+// instances claim disjoint rows, and the lease makes a delivery claimable
+// again if its instance dies before recording the attempt.
+func (r *PostgresWebhookRepository) ClaimDue(now, lockUntil time.Time, limit int) ([]domain.Delivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET locked_until = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1 AND (locked_until IS NULL OR locked_until <= $1)
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns
+
+	rows, err := r.db.Query(query, now, lockUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDeliveries(rows)
+}
+
+// RecordAttempt stores the outcome of a delivery attempt and releases the
+// lease. It returns worker.ErrLeaseLost when the lease expired in the
+// meantime.
+func (r *PostgresWebhookRepository) RecordAttempt(delivery *domain.Delivery, lockedUntil time.Time) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = $2, next_attempt_at = $3, last_attempt_at = $4, response_status = $5,
+			last_error = $6, locked_until = NULL, updated_at = $7
+		WHERE id = $8 AND locked_until = $9
+	`
+
+	delivery.UpdatedAt = time.Now()
+
+	return worker.CheckLease(r.db.Exec(
+		query,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastAttemptAt,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.UpdatedAt,
+		delivery.ID,
+		lockedUntil,
+	))
+}
+
+func (r *PostgresWebhookRepository) querySubscriptions(query string, args ...interface{}) ([]domain.Subscription, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []domain.Subscription{}
+	for rows.Next() {
+		var subscription domain.Subscription
+		if err := scanSubscription(rows, &subscription); err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSubscription(row rowScanner, subscription *domain.Subscription) error {
+	return row.Scan(
+		&subscription.ID,
+		&subscription.UserID,
+		&subscription.APIKeyID,
+		&subscription.URL,
+		pq.Array(&subscription.Events),
+		&subscription.Secret,
+		&subscription.Description,
+		&subscription.Active,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	)
+}
+
+func scanDelivery(row rowScanner, delivery *domain.Delivery) error {
+	var payload []byte
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastAttemptAt,
+		&delivery.ResponseStatus,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	delivery.Payload = payload
+	return nil
+}
+
+func scanDeliveries(rows *sql.Rows) ([]domain.Delivery, error) {
+	deliveries := []domain.Delivery{}
+	for rows.Next() {
+		var delivery domain.Delivery
+		if err := scanDelivery(rows, &delivery); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func expectAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package usecase
+
+import "errors"
+
+// Domain errors for webhook module
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrUnknownEventType     = errors.New("unknown webhook event type")
+	ErrInvalidWebhookURL    = errors.New("invalid webhook URL")
+)
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/webhook/domain"
+)
+
+// IWebhookUseCase defines the interface for webhook business logic
+type IWebhookUseCase interface {
+	CreateSubscription(req *domain.CreateSubscriptionRequest) (*domain.Subscription, error)
+	GetSubscriptions(userID, apiKeyID string) ([]domain.Subscription, error)
+	GetSubscription(id int64, userID, apiKeyID string) (*domain.Subscription, error)
+	UpdateSubscription(req *domain.UpdateSubscriptionRequest) (*domain.Subscription, error)
+	DeleteSubscription(id int64, userID, apiKeyID string) error
+	GetDeliveries(subscriptionID int64, userID, apiKeyID string) ([]domain.Delivery, error)
+	Redeliver(subscriptionID, deliveryID int64, userID, apiKeyID string) (*domain.Delivery, error)
+
+	// Publish queues a delivery of the event to each subscription of the user
+	Publish(ctx context.Context, userID, eventType string, data interface{}) error
+}
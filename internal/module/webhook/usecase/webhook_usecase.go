@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/webhook/domain"
+	"dailyalu-server/internal/module/webhook/repository"
+	"dailyalu-server/internal/security/apikey"
+	"dailyalu-server/internal/security/outbound"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// deliveryLogSize is the number of latest deliveries listed per subscription
+const deliveryLogSize = 100
+
+// WebhookUseCase implements the webhook use case interface
+type WebhookUseCase struct {
+	webhookRepo repository.IWebhookRepository
+	urlPolicy   outbound.Policy
+	now         func() time.Time
+}
+
+// NewWebhookUseCase creates a new webhook use case. Subscription URLs must
+// be allowed by urlPolicy.
+func NewWebhookUseCase(webhookRepo repository.IWebhookRepository, urlPolicy outbound.Policy) IWebhookUseCase {
+	return &WebhookUseCase{
+		webhookRepo: webhookRepo,
+		urlPolicy:   urlPolicy,
+		now:         time.Now,
+	}
+}
+
+// CreateSubscription subscribes an endpoint to events. The signing secret is
+// only returned here.
+func (uc *WebhookUseCase) CreateSubscription(req *domain.CreateSubscriptionRequest) (*domain.Subscription, error) {
+	if err := uc.validateSubscription(req); err != nil {
+		return nil, err
+	}
+
+	secret, err := apikey.GenerateKey("whsec")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	subscription := &domain.Subscription{
+		UserID:      req.UserID,
+		APIKeyID:    req.APIKeyID,
+		URL:         req.URL,
+		Events:      req.Events,
+		Secret:      secret,
+		Description: req.Description,
+		Active:      true,
+	}
+	if err := uc.webhookRepo.CreateSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// GetSubscriptions retrieves the subscriptions of the user and API key
+func (uc *WebhookUseCase) GetSubscriptions(userID, apiKeyID string) ([]domain.Subscription, error) {
+	subscriptions, err := uc.webhookRepo.GetSubscriptions(userID, apiKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+	return subscriptions, nil
+}
+
+// GetSubscription retrieves a subscription of the user and API key
+func (uc *WebhookUseCase) GetSubscription(id int64, userID, apiKeyID string) (*domain.Subscription, error) {
+	subscription, err := uc.getOwnedSubscription(id, userID, apiKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+// UpdateSubscription changes the endpoint, events or state of a subscription
+func (uc *WebhookUseCase) UpdateSubscription(req *domain.UpdateSubscriptionRequest) (*domain.Subscription, error) {
+	if err := uc.validateSubscription(&req.CreateSubscriptionRequest); err != nil {
+		return nil, err
+	}
+
+	subscription, err := uc.getOwnedSubscription(req.ID, req.UserID, req.APIKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.URL = req.URL
+	subscription.Events = req.Events
+	subscription.Description = req.Description
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+
+	if err := uc.webhookRepo.UpdateSubscription(subscription); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+// DeleteSubscription removes a subscription and its delivery log
+func (uc *WebhookUseCase) DeleteSubscription(id int64, userID, apiKeyID string) error {
+	if _, err := uc.getOwnedSubscription(id, userID, apiKeyID); err != nil {
+		return err
+	}
+
+	if err := uc.webhookRepo.DeleteSubscription(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrSubscriptionNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetDeliveries retrieves the latest deliveries of a subscription
+func (uc *WebhookUseCase) GetDeliveries(subscriptionID int64, userID, apiKeyID string) ([]domain.Delivery, error) {
+	if _, err := uc.getOwnedSubscription(subscriptionID, userID, apiKeyID); err != nil {
+		return nil, err
+	}
+
+	return uc.webhookRepo.GetDeliveries(subscriptionID, deliveryLogSize)
+}
+
+// Redeliver queues the event of a past delivery again. The original delivery
+// is kept in the log.
+func (uc *WebhookUseCase) Redeliver(subscriptionID, deliveryID int64, userID, apiKeyID string) (*domain.Delivery, error) {
+	if _, err := uc.getOwnedSubscription(subscriptionID, userID, apiKeyID); err != nil {
+		return nil, err
+	}
+
+	original, err := uc.webhookRepo.GetDelivery(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil || original.SubscriptionID != subscriptionID {
+		return nil, ErrDeliveryNotFound
+	}
+
+	now := uc.now()
+	delivery := &domain.Delivery{
+		SubscriptionID: subscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		Status:         domain.DeliveryPending,
+		NextAttemptAt:  &now,
+	}
+	if err := uc.webhookRepo.CreateDelivery(delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// Publish queues a delivery of the event to each active subscription of the
+// user to its type. The deliveries share the event ID, so receivers can
+// discard duplicates.
+func (uc *WebhookUseCase) Publish(ctx context.Context, userID, eventType string, data interface{}) error {
+	subscriptions, err := uc.webhookRepo.GetSubscribers(userID, eventType)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	now := uc.now()
+	eventID := uuid.New().String()
+	payload, err := json.Marshal(&domain.Payload{
+		ID:        eventID,
+		Type:      eventType,
+		CreatedAt: now.UTC(),
+		Data:      encoded,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		delivery := &domain.Delivery{
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      eventType,
+			Payload:        payload,
+			Status:         domain.DeliveryPending,
+			NextAttemptAt:  &now,
+		}
+		if err := uc.webhookRepo.CreateDelivery(delivery); err != nil {
+			return fmt.Errorf("failed to queue webhook delivery: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *WebhookUseCase) getOwnedSubscription(id int64, userID, apiKeyID string) (*domain.Subscription, error) {
+	subscription, err := uc.webhookRepo.GetSubscription(id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil || subscription.UserID != userID || subscription.APIKeyID != apiKeyID {
+		return nil, ErrSubscriptionNotFound
+	}
+
+	return subscription, nil
+}
+
+// validateSubscription checks the endpoint and the event types. Endpoints
+// on local or private networks are refused.
+func (uc *WebhookUseCase) validateSubscription(req *domain.CreateSubscriptionRequest) error {
+	if err := uc.urlPolicy.ValidateURL(req.URL); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebhookURL, err)
+	}
+
+	for _, event := range req.Events {
+		if !isEventType(event) {
+			return fmt.Errorf("%w: %s", ErrUnknownEventType, event)
+		}
+	}
+
+	return nil
+}
+
+func isEventType(event string) bool {
+	if event == domain.EventAll {
+		return true
+	}
+	for _, eventType := range domain.EventTypes {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
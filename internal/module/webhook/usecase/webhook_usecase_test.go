@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"dailyalu-server/internal/module/webhook/domain"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockWebhookRepository keeps subscriptions and deliveries in memory
+type MockWebhookRepository struct {
+	Subscriptions map[int64]*domain.Subscription
+	Deliveries    []domain.Delivery
+}
+
+func (m *MockWebhookRepository) CreateSubscription(subscription *domain.Subscription) error {
+	subscription.ID = int64(len(m.Subscriptions) + 1)
+	m.Subscriptions[subscription.ID] = subscription
+	return nil
+}
+
+func (m *MockWebhookRepository) GetSubscription(id int64) (*domain.Subscription, error) {
+	if subscription, ok := m.Subscriptions[id]; ok {
+		copied := *subscription
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) GetSubscriptions(userID, apiKeyID string) ([]domain.Subscription, error) {
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) GetSubscribers(userID, eventType string) ([]domain.Subscription, error) {
+	var subscribers []domain.Subscription
+	for _, subscription := range m.Subscriptions {
+		if subscription.UserID == userID && subscription.Active && subscription.Subscribes(eventType) {
+			subscribers = append(subscribers, *subscription)
+		}
+	}
+	return subscribers, nil
+}
+
+func (m *MockWebhookRepository) UpdateSubscription(subscription *domain.Subscription) error {
+	return nil
+}
+
+func (m *MockWebhookRepository) DeleteSubscription(id int64) error {
+	return nil
+}
+
+func (m *MockWebhookRepository) CreateDelivery(delivery *domain.Delivery) error {
+	delivery.ID = int64(len(m.Deliveries) + 1)
+	m.Deliveries = append(m.Deliveries, *delivery)
+	return nil
+}
+
+func (m *MockWebhookRepository) GetDelivery(id int64) (*domain.Delivery, error) {
+	for _, delivery := range m.Deliveries {
+		if delivery.ID == id {
+			return &delivery, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) GetDeliveries(subscriptionID int64, limit int) ([]domain.Delivery, error) {
+	return m.Deliveries, nil
+}
+
+func (m *MockWebhookRepository) ClaimDue(now, lockUntil time.Time, limit int) ([]domain.Delivery, error) {
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) RecordAttempt(delivery *domain.Delivery, lockedUntil time.Time) error {
+	return nil
+}
+
+func newTestUseCase(repo *MockWebhookRepository) *WebhookUseCase {
+	return &WebhookUseCase{
+		webhookRepo: repo,
+		now:         func() time.Time { return time.Date(2025, 3, 20, 3, 30, 0, 0, time.UTC) },
+	}
+}
+
+func TestCreateSubscription(t *testing.T) {
+	repo := &MockWebhookRepository{Subscriptions: map[int64]*domain.Subscription{}}
+	uc := newTestUseCase(repo)
+
+	subscription, err := uc.CreateSubscription(&domain.CreateSubscriptionRequest{
+		UserID:   "user-1",
+		APIKeyID: "scale-app",
+		URL:      "https://203.0.113.10/hooks",
+		Events:   []string{"activity.created"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(subscription.Secret, "whsec_") || !subscription.Active {
+		t.Errorf("expected an active subscription with a secret, got %+v", subscription)
+	}
+
+	// Other API keys of the same user cannot see it, and the secret is not shown again
+	if _, err := uc.GetSubscription(subscription.ID, "user-1", "bottle-app"); !errors.Is(err, ErrSubscriptionNotFound) {
+		t.Errorf("expected ErrSubscriptionNotFound, got %v", err)
+	}
+	if found, err := uc.GetSubscription(subscription.ID, "user-1", "scale-app"); err != nil || found.Secret != "" {
+		t.Errorf("expected the subscription without its secret, got %+v, %v", found, err)
+	}
+
+	testCases := []struct {
+		name     string
+		url      string
+		events   []string
+		expected error
+	}{
+		{"unknown event", "https://203.0.113.10/hooks", []string{"diaper.changed"}, ErrUnknownEventType},
+		{"unsupported scheme", "ftp://203.0.113.10/hooks", []string{"*"}, ErrInvalidWebhookURL},
+		{"plain http", "http://203.0.113.10/hooks", []string{"*"}, ErrInvalidWebhookURL},
+		{"cloud metadata", "https://169.254.169.254/latest/meta-data", []string{"*"}, ErrInvalidWebhookURL},
+		{"loopback", "https://127.0.0.1:5432/", []string{"*"}, ErrInvalidWebhookURL},
+		{"private network", "https://192.168.1.10/hooks", []string{"*"}, ErrInvalidWebhookURL},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := uc.CreateSubscription(&domain.CreateSubscriptionRequest{UserID: "user-1", URL: tc.url, Events: tc.events})
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestPublishAndRedeliver(t *testing.T) {
+	repo := &MockWebhookRepository{Subscriptions: map[int64]*domain.Subscription{
+		1: {ID: 1, UserID: "user-1", APIKeyID: "scale-app", Events: []string{"activity.created"}, Active: true},
+		2: {ID: 2, UserID: "user-1", APIKeyID: "bottle-app", Events: []string{"*"}, Active: true},
+		3: {ID: 3, UserID: "user-1", Events: []string{"*"}, Active: false},
+		4: {ID: 4, UserID: "user-2", Events: []string{"*"}, Active: true},
+	}}
+	uc := newTestUseCase(repo)
+
+	if err := uc.Publish(context.Background(), "user-1", "activity.created", map[string]int{"id": 42}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.Publish(context.Background(), "user-1", "user.updated", map[string]string{"id": "user-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// activity.created reaches both active subscriptions, user.updated only the wildcard one
+	if len(repo.Deliveries) != 3 {
+		t.Fatalf("expected 3 deliveries, got %+v", repo.Deliveries)
+	}
+
+	var payload domain.Payload
+	if err := json.Unmarshal(repo.Deliveries[0].Payload, &payload); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payload.Type != "activity.created" || payload.ID != repo.Deliveries[0].EventID || string(payload.Data) != `{"id":42}` {
+		t.Errorf("unexpected payload: %s", repo.Deliveries[0].Payload)
+	}
+	if repo.Deliveries[0].Status != domain.DeliveryPending || repo.Deliveries[0].NextAttemptAt == nil {
+		t.Errorf("expected a pending delivery, got %+v", repo.Deliveries[0])
+	}
+
+	original := repo.Deliveries[0]
+	redelivery, err := uc.Redeliver(original.SubscriptionID, original.ID, "user-1", repo.Subscriptions[original.SubscriptionID].APIKeyID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if redelivery.ID == original.ID || redelivery.EventID != original.EventID || string(redelivery.Payload) != string(original.Payload) {
+		t.Errorf("expected a new delivery of the same event, got %+v", redelivery)
+	}
+
+	// A delivery can only be redelivered through its own subscription
+	other := int64(1)
+	if original.SubscriptionID == 1 {
+		other = 2
+	}
+	if _, err := uc.Redeliver(other, original.ID, "user-1", repo.Subscriptions[other].APIKeyID); !errors.Is(err, ErrDeliveryNotFound) {
+		t.Errorf("expected ErrDeliveryNotFound, got %v", err)
+	}
+}
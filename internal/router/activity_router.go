@@ -9,7 +9,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupActivityRoutes(app *fiber.App, activityHandler *api.ActivityHandler, securityMiddleware *middleware.SecurityMiddleware) {
+func SetupActivityRoutes(app *fiber.App, activityHandler *api.ActivityHandler, securityMiddleware *middleware.SecurityMiddleware, timezoneMiddleware *middleware.TimezoneMiddleware) {
 	// Initialize API key service and middleware
 	apiKeyService := apikey.NewAPIKeyService()
 	apiKeyMiddleware := middleware.NewAPIKeyMiddleware(apiKeyService)
@@ -20,9 +20,11 @@ func SetupActivityRoutes(app *fiber.App, activityHandler *api.ActivityHandler, s
 
 	// Apply middleware
 	activities.Use(securityMiddleware.JWT())
+	activities.Use(timezoneMiddleware.Handle())
 
 	// Routes
 	activities.Get("/search", activityHandler.Search)
+	activities.Get("/export", activityHandler.Export)
 	activities.Post("/", activityHandler.Create)
 	activities.Get("/:id", activityHandler.Get)
 	activities.Put("/:id", activityHandler.Update)
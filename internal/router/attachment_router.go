@@ -0,0 +1,40 @@
+package router
+
+import (
+	"dailyalu-server/internal/handler/api"
+	"dailyalu-server/internal/middleware"
+	"dailyalu-server/internal/service/storage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupAttachmentRoutes configures the routes for the attachments and
+// profile photos of children
+func SetupAttachmentRoutes(app *fiber.App, handler *api.AttachmentHandler, securityMiddleware *middleware.SecurityMiddleware) {
+	attachments := app.Group("/v1/children/:childId/attachments")
+
+	// Apply middleware
+	attachments.Use(securityMiddleware.JWT())
+
+	// Routes
+	attachments.Post("/", handler.Upload)
+	attachments.Get("/", handler.GetAttachments)
+	attachments.Get("/:id", handler.GetAttachment)
+	attachments.Delete("/:id", handler.DeleteAttachment)
+
+	photo := app.Group("/v1/children/:childId/photo")
+
+	// Apply middleware
+	photo.Use(securityMiddleware.JWT())
+
+	// Routes
+	photo.Put("/", handler.SetPhoto)
+	photo.Get("/", handler.GetPhoto)
+	photo.Delete("/", handler.DeletePhoto)
+}
+
+// SetupFileRoutes configures the public route signed URLs of files kept on
+// the local disk point to
+func SetupFileRoutes(app *fiber.App, handler *api.FileHandler) {
+	app.Get(storage.LocalFilesPath+"*", handler.Download)
+}
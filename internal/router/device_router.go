@@ -0,0 +1,21 @@
+package router
+
+import (
+	"dailyalu-server/internal/handler/api"
+	"dailyalu-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupDeviceRoutes configures the routes for push devices
+func SetupDeviceRoutes(app *fiber.App, handler *api.DeviceHandler, securityMiddleware *middleware.SecurityMiddleware) {
+	devices := app.Group("/v1/devices")
+
+	// Apply middleware
+	devices.Use(securityMiddleware.JWT())
+
+	// Routes
+	devices.Post("/", handler.RegisterDevice)
+	devices.Get("/", handler.GetDevices)
+	devices.Delete("/:id", handler.DeleteDevice)
+}
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"dailyalu-server/internal/handler/api"
+	"dailyalu-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupGrowthRoutes configures the routes for growth measurements
+func SetupGrowthRoutes(app *fiber.App, handler *api.GrowthHandler, securityMiddleware *middleware.SecurityMiddleware) {
+	growth := app.Group("/v1/children/:childId/growth")
+
+	// Apply middleware
+	growth.Use(securityMiddleware.JWT())
+
+	// Routes
+	growth.Get("/series", handler.GetSeries)
+	growth.Post("/measurements", handler.CreateMeasurement)
+	growth.Get("/measurements", handler.GetMeasurements)
+	growth.Delete("/measurements/:id", handler.DeleteMeasurement)
+}
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"dailyalu-server/internal/handler/api"
+	"dailyalu-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupImmunizationRoutes configures the routes for immunizations
+func SetupImmunizationRoutes(app *fiber.App, handler *api.ImmunizationHandler, securityMiddleware *middleware.SecurityMiddleware) {
+	// National schedule
+	app.Get("/v1/immunizations/schedule", securityMiddleware.JWT(), handler.GetSchedule)
+
+	immunizations := app.Group("/v1/children/:childId/immunizations")
+
+	// Apply middleware
+	immunizations.Use(securityMiddleware.JWT())
+
+	// Routes
+	immunizations.Get("/schedule", handler.GetChildSchedule)
+	immunizations.Post("/", handler.RecordDose)
+	immunizations.Get("/", handler.GetRecords)
+	immunizations.Delete("/:id", handler.DeleteRecord)
+}
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"dailyalu-server/internal/handler/api"
+	"dailyalu-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupImportRoutes configures the routes for imports from other apps
+func SetupImportRoutes(app *fiber.App, handler *api.ImportHandler, securityMiddleware *middleware.SecurityMiddleware, timezoneMiddleware *middleware.TimezoneMiddleware) {
+	imports := app.Group("/v1/children/:childId/imports")
+
+	// Apply middleware; exported times are read in the user's time zone
+	imports.Use(securityMiddleware.JWT())
+	imports.Use(timezoneMiddleware.Handle())
+
+	// Routes
+	imports.Post("/", handler.Preview)
+	imports.Get("/", handler.GetImports)
+	imports.Get("/:id", handler.GetImport)
+	imports.Get("/:id/rows", handler.GetRows)
+	imports.Post("/:id/commit", handler.Commit)
+	imports.Delete("/:id", handler.DeleteImport)
+}
@@ -0,0 +1,24 @@
+package router
+
+import (
+	"dailyalu-server/internal/handler/api"
+	"dailyalu-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupMedicationRoutes configures the routes for medication plans
+func SetupMedicationRoutes(app *fiber.App, handler *api.MedicationHandler, securityMiddleware *middleware.SecurityMiddleware, timezoneMiddleware *middleware.TimezoneMiddleware) {
+	medications := app.Group("/v1/children/:childId/medications")
+
+	// Apply middleware
+	medications.Use(securityMiddleware.JWT())
+	medications.Use(timezoneMiddleware.Handle())
+
+	// Routes
+	medications.Post("/", handler.CreatePlan)
+	medications.Get("/", handler.GetPlans)
+	medications.Get("/:id", handler.GetPlan)
+	medications.Put("/:id", handler.UpdatePlan)
+	medications.Delete("/:id", handler.DeletePlan)
+}
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"dailyalu-server/internal/handler/api"
+	"dailyalu-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupMilestoneRoutes configures the routes for developmental milestones
+func SetupMilestoneRoutes(app *fiber.App, handler *api.MilestoneHandler, securityMiddleware *middleware.SecurityMiddleware) {
+	// Built-in catalog
+	app.Get("/v1/milestones", securityMiddleware.JWT(), handler.GetCatalog)
+
+	milestones := app.Group("/v1/children/:childId/milestones")
+
+	// Apply middleware
+	milestones.Use(securityMiddleware.JWT())
+
+	// Routes
+	milestones.Get("/", handler.GetAchievements)
+	milestones.Get("/upcoming", handler.GetProgress)
+	milestones.Put("/:milestoneId", handler.RecordAchievement)
+	milestones.Delete("/:milestoneId", handler.DeleteAchievement)
+}
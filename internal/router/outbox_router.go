@@ -0,0 +1,23 @@
+package router
+
+import (
+	"dailyalu-server/internal/handler/api"
+	"dailyalu-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupOutboxRoutes configures the admin routes for outbox messages
+func SetupOutboxRoutes(app *fiber.App, handler *api.OutboxHandler, securityMiddleware *middleware.SecurityMiddleware) {
+	outbox := app.Group("/v1/admin/outbox")
+
+	// Apply middleware
+	outbox.Use(securityMiddleware.JWT())
+	outbox.Use(securityMiddleware.RoleAuth("admin"))
+
+	// Routes
+	outbox.Get("/", handler.GetMessages)
+	outbox.Get("/:id", handler.GetMessage)
+	outbox.Post("/:id/retry", handler.RetryMessage)
+	outbox.Delete("/:id", handler.DeleteMessage)
+}
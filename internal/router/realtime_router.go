@@ -0,0 +1,20 @@
+package router
+
+import (
+	"dailyalu-server/internal/handler/api"
+	"dailyalu-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupRealtimeRoutes configures the routes for live event streams
+func SetupRealtimeRoutes(app *fiber.App, handler *api.RealtimeHandler, securityMiddleware *middleware.SecurityMiddleware) {
+	realtime := app.Group("/v1/realtime")
+
+	// Apply middleware
+	realtime.Use(securityMiddleware.StreamJWT())
+
+	// Routes
+	realtime.Get("/ws", handler.Upgrade, handler.WebSocket())
+	realtime.Get("/events", handler.Events)
+}
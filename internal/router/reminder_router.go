@@ -0,0 +1,24 @@
+package router
+
+import (
+	"dailyalu-server/internal/handler/api"
+	"dailyalu-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupReminderRoutes configures the routes for reminders
+func SetupReminderRoutes(app *fiber.App, handler *api.ReminderHandler, securityMiddleware *middleware.SecurityMiddleware, timezoneMiddleware *middleware.TimezoneMiddleware) {
+	reminders := app.Group("/v1/reminders")
+
+	// Apply middleware
+	reminders.Use(securityMiddleware.JWT())
+	reminders.Use(timezoneMiddleware.Handle())
+
+	// Routes
+	reminders.Post("/", handler.CreateReminder)
+	reminders.Get("/", handler.GetReminders)
+	reminders.Get("/:id", handler.GetReminder)
+	reminders.Put("/:id", handler.UpdateReminder)
+	reminders.Delete("/:id", handler.DeleteReminder)
+}
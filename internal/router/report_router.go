@@ -0,0 +1,22 @@
+package router
+
+import (
+	"dailyalu-server/internal/handler/api"
+	"dailyalu-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupReportRoutes configures the routes for child reports
+func SetupReportRoutes(app *fiber.App, handler *api.ReportHandler, securityMiddleware *middleware.SecurityMiddleware, timezoneMiddleware *middleware.TimezoneMiddleware) {
+	report := app.Group("/v1/children/:childId/report")
+
+	// Apply middleware; days are counted in the user's time zone
+	report.Use(securityMiddleware.JWT())
+	report.Use(timezoneMiddleware.Handle())
+
+	// Routes
+	report.Get("/", handler.Download)
+	// Emails go to any address the caller names, so they are limited
+	middleware.RateLimitedRoute(report, "POST", "/email", handler.Email)
+}
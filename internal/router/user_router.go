@@ -27,7 +27,13 @@ func SetupUserRoutes(app *fiber.App, userHandler *api.UserHandler, securityMiddl
 	// Apply rate limiters to login and register endpoints
 	middleware.RateLimitedRoute(auth, "POST", "/register", userHandler.Register)
 	middleware.RateLimitedRoute(auth, "POST", "/login", userHandler.Login)
+	middleware.RateLimitedRoute(auth, "POST", "/magic-link", userHandler.RequestMagicLink)
+	middleware.RateLimitedRoute(auth, "POST", "/magic-link/verify", userHandler.MagicLinkLogin)
 	
+	// Sign-in with external identity providers
+	auth.Get("/oidc/:provider/authorize", userHandler.OIDCAuthorize)
+	middleware.RateLimitedRoute(auth, "POST", "/oidc/:provider/callback", userHandler.OIDCCallback)
+
 	// Password recovery routes (don't require authentication)
 	auth.Post("/forgot-password", userHandler.ForgotPassword)
 	auth.Post("/reset-password", userHandler.ResetPassword)
@@ -43,6 +49,14 @@ func SetupUserRoutes(app *fiber.App, userHandler *api.UserHandler, securityMiddl
 	users.Patch("/password", userHandler.UpdatePassword)
 	users.Get("/profile", userHandler.GetUser)
 	users.Put("/profile", userHandler.UpdateUser)
+	users.Get("/preferences", userHandler.GetPreferences)
+	users.Patch("/preferences", userHandler.UpdatePreferences)
+
+	// Linked identity providers
+	users.Get("/identities", userHandler.GetIdentities)
+	users.Post("/identities/:provider/authorize", userHandler.LinkIdentityAuthorize)
+	users.Post("/identities/:provider/callback", userHandler.LinkIdentityCallback)
+	users.Delete("/identities/:provider", userHandler.UnlinkIdentity)
 	
 
 	// Routes accessible only by admins
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"dailyalu-server/internal/handler/api"
+	"dailyalu-server/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupWebhookRoutes configures the routes for webhook subscriptions
+func SetupWebhookRoutes(app *fiber.App, handler *api.WebhookHandler, securityMiddleware *middleware.SecurityMiddleware) {
+	webhooks := app.Group("/v1/webhooks")
+
+	// Apply middleware
+	webhooks.Use(securityMiddleware.JWT())
+
+	// Routes
+	webhooks.Post("/", handler.CreateSubscription)
+	webhooks.Get("/", handler.GetSubscriptions)
+	webhooks.Get("/:id", handler.GetSubscription)
+	webhooks.Put("/:id", handler.UpdateSubscription)
+	webhooks.Delete("/:id", handler.DeleteSubscription)
+	webhooks.Get("/:id/deliveries", handler.GetDeliveries)
+	webhooks.Post("/:id/deliveries/:deliveryId/redeliver", handler.Redeliver)
+}
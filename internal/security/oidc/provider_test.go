@@ -0,0 +1,116 @@
+package oidc_test
+
+import (
+	"context"
+	"dailyalu-server/internal/security/oidc"
+	"dailyalu-server/internal/security/oidc/oidctest"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProvider_AuthorizationCodeFlow(t *testing.T) {
+	server := oidctest.NewServer("client-id")
+	defer server.Close()
+
+	provider := oidc.NewProvider(oidc.ProviderConfig{
+		Name:        "fake",
+		Issuer:      server.Issuer(),
+		ClientID:    "client-id",
+		RedirectURL: "https://dailyalu.mom/oidc/callback",
+	}, server.Client())
+
+	ctx := context.Background()
+	verifier, _ := oidc.GenerateRandomString()
+
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", "nonce-1", verifier)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	identity := oidctest.Identity{Subject: "sub-1", Email: "parent@example.com", EmailVerified: true, Name: "Parent"}
+	code, state, err := server.Authorize(authURL, identity)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state != "state-1" {
+		t.Errorf("expected state to round-trip, got %q", state)
+	}
+
+	if _, err := provider.Exchange(ctx, code, "wrong-verifier"); !errors.Is(err, oidc.ErrTokenExchange) {
+		t.Errorf("expected PKCE failure, got %v", err)
+	}
+
+	code, _, _ = server.Authorize(authURL, identity)
+	idToken, err := provider.Exchange(ctx, code, verifier)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	claims, err := provider.VerifyIDToken(ctx, idToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.Subject != "sub-1" || claims.Email != "parent@example.com" || !claims.EmailVerified {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+}
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	server := oidctest.NewServer("client-id")
+	defer server.Close()
+
+	other := oidctest.NewServer("client-id")
+	defer other.Close()
+
+	provider := oidc.NewProvider(oidc.ProviderConfig{
+		Name:     "fake",
+		Issuer:   server.Issuer(),
+		ClientID: "client-id",
+	}, server.Client())
+
+	identity := oidctest.Identity{Subject: "sub-1", Email: "parent@example.com"}
+
+	testCases := []struct {
+		name          string
+		token         string
+		nonce         string
+		expectedError error
+	}{
+		{
+			name:  "valid token",
+			token: server.SignIDToken(identity, "nonce", time.Now().Add(time.Hour)),
+			nonce: "nonce",
+		},
+		{
+			name:          "expired token",
+			token:         server.SignIDToken(identity, "nonce", time.Now().Add(-time.Hour)),
+			nonce:         "nonce",
+			expectedError: oidc.ErrInvalidIDToken,
+		},
+		{
+			name:          "nonce mismatch",
+			token:         server.SignIDToken(identity, "nonce", time.Now().Add(time.Hour)),
+			nonce:         "other-nonce",
+			expectedError: oidc.ErrNonceMismatch,
+		},
+		{
+			name:          "signed by another provider",
+			token:         other.SignIDToken(identity, "nonce", time.Now().Add(time.Hour)),
+			nonce:         "nonce",
+			expectedError: oidc.ErrInvalidIDToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := provider.VerifyIDToken(context.Background(), tc.token, tc.nonce)
+			if tc.expectedError == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedError != nil && !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package oidc
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Providers is the set of identity providers users can sign in with
+type Providers struct {
+	providers map[string]*Provider
+}
+
+// NewProviders creates a provider set from the given configurations
+func NewProviders(configs []ProviderConfig, httpClient *http.Client) *Providers {
+	providers := make(map[string]*Provider, len(configs))
+	for _, config := range configs {
+		providers[strings.ToLower(config.Name)] = NewProvider(config, httpClient)
+	}
+	return &Providers{providers: providers}
+}
+
+// NewProvidersFromConfig creates the provider set configured under oidc.providers
+func NewProvidersFromConfig() *Providers {
+	var configs []ProviderConfig
+	for name := range viper.GetStringMap("oidc.providers") {
+		key := "oidc.providers." + name
+		if !viper.GetBool(key + ".enabled") {
+			continue
+		}
+		configs = append(configs, ProviderConfig{
+			Name:         name,
+			Issuer:       viper.GetString(key + ".issuer"),
+			ClientID:     viper.GetString(key + ".client_id"),
+			ClientSecret: viper.GetString(key + ".client_secret"),
+			RedirectURL:  viper.GetString(key + ".redirect_url"),
+			Scopes:       viper.GetStringSlice(key + ".scopes"),
+		})
+	}
+	return NewProviders(configs, &http.Client{Timeout: 10 * time.Second})
+}
+
+// Get returns the provider registered under name
+func (p *Providers) Get(name string) (*Provider, error) {
+	provider, ok := p.providers[strings.ToLower(name)]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return provider, nil
+}
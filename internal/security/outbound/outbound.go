@@ -0,0 +1,148 @@
+// Package outbound guards requests to URLs chosen by users, such as webhook
+// endpoints, so they cannot reach the server's own network. URLs are checked
+// when they are saved, and every connection is checked again when it is
+// dialed, which also covers host names that later resolve elsewhere.
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// resolveTimeout limits looking up the host of a URL being validated
+const resolveTimeout = 5 * time.Second
+
+// Errors returned for URLs and connections the policy refuses
+var (
+	ErrInvalidURL       = errors.New("url must be an absolute http or https url")
+	ErrInsecureURL      = errors.New("url must use https")
+	ErrForbiddenAddress = errors.New("url must not point to a local or private network address")
+)
+
+// blockedPrefixes are ranges outside the checks of net.IP that must not be
+// reached either
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "This" network
+	netip.MustParsePrefix("100.64.0.0/10"), // Carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // Benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // Reserved, including broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64 of IPv4 addresses
+}
+
+// Policy decides which URLs outbound requests may reach
+type Policy struct {
+	// AllowHTTP accepts plain http URLs, which is meant for development only
+	AllowHTTP bool
+	// AllowPrivateNetworks accepts loopback, private and link-local
+	// addresses, e.g. to test against a receiver on the same machine
+	AllowPrivateNetworks bool
+}
+
+// NewPolicyFromConfig allows plain http in development and private networks
+// only when outbound.allow_private_networks is set
+func NewPolicyFromConfig() Policy {
+	return Policy{
+		AllowHTTP:            viper.GetString("server.env") == "development",
+		AllowPrivateNetworks: viper.GetBool("outbound.allow_private_networks"),
+	}
+}
+
+// ValidateURL checks that a URL uses an allowed scheme and that its host
+// resolves to allowed addresses only
+func (p Policy) ValidateURL(rawURL string) error {
+	target, err := url.Parse(rawURL)
+	if err != nil || target.Host == "" || target.Hostname() == "" {
+		return ErrInvalidURL
+	}
+	switch target.Scheme {
+	case "https":
+	case "http":
+		if !p.AllowHTTP {
+			return ErrInsecureURL
+		}
+	default:
+		return ErrInvalidURL
+	}
+
+	if p.AllowPrivateNetworks {
+		return nil
+	}
+
+	host := target.Hostname()
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return p.checkAddr(ip)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("%w: host %q cannot be resolved", ErrInvalidURL, host)
+	}
+	for _, addr := range addrs {
+		if err := p.checkAddr(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewClient returns an HTTP client whose connections are refused unless the
+// policy allows the address dialed. Redirects are not followed; the
+// redirect response is returned as is.
+func (p Policy) NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+			}
+			return p.checkAddr(addrPort.Addr())
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialed instead of the target, bypassing the check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkAddr refuses addresses of the local machine and private networks
+func (p Policy) checkAddr(addr netip.Addr) error {
+	if p.AllowPrivateNetworks {
+		return nil
+	}
+
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return ErrForbiddenAddress
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return ErrForbiddenAddress
+		}
+	}
+	return nil
+}
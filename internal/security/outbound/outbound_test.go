@@ -0,0 +1,79 @@
+package outbound
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestValidateURL(t *testing.T) {
+	production := Policy{}
+
+	testCases := []struct {
+		name     string
+		policy   Policy
+		url      string
+		expected error
+	}{
+		{"public https", production, "https://93.184.216.34/hooks", nil},
+		{"public IPv6", production, "https://[2606:2800:220:1::]/hooks", nil},
+		{"plain http", production, "http://93.184.216.34/hooks", ErrInsecureURL},
+		{"plain http in development", Policy{AllowHTTP: true}, "http://93.184.216.34/hooks", nil},
+		{"other scheme", production, "ftp://93.184.216.34/hooks", ErrInvalidURL},
+		{"no host", production, "https:///hooks", ErrInvalidURL},
+		{"loopback", production, "https://127.0.0.1:8080/hooks", ErrForbiddenAddress},
+		{"localhost", production, "https://localhost/hooks", ErrForbiddenAddress},
+		{"cloud metadata", production, "https://169.254.169.254/latest/meta-data", ErrForbiddenAddress},
+		{"private network", production, "https://10.0.0.7/hooks", ErrForbiddenAddress},
+		{"unspecified", production, "https://0.0.0.0/hooks", ErrForbiddenAddress},
+		{"carrier-grade NAT", production, "https://100.64.0.1/hooks", ErrForbiddenAddress},
+		{"IPv6 loopback", production, "https://[::1]/hooks", ErrForbiddenAddress},
+		{"IPv4-mapped loopback", production, "https://[::ffff:127.0.0.1]/hooks", ErrForbiddenAddress},
+		{"unique local IPv6", production, "https://[fd00::1]/hooks", ErrForbiddenAddress},
+		{"private network allowed", Policy{AllowPrivateNetworks: true}, "https://10.0.0.7/hooks", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.policy.ValidateURL(tc.url); !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/target", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// The test server listens on loopback, which is refused when dialing
+	// even though the URL was never validated
+	_, err := Policy{AllowHTTP: true}.NewClient(time.Second).Get(server.URL)
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("expected %v, got %v", ErrForbiddenAddress, err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no request to reach the server, got %d", requests)
+	}
+
+	// Redirects are returned instead of followed
+	client := Policy{AllowHTTP: true, AllowPrivateNetworks: true}.NewClient(time.Second)
+	resp, err := client.Get(server.URL + "/redirect")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound || requests != 1 {
+		t.Errorf("expected the redirect response only, got status %d after %d requests", resp.StatusCode, requests)
+	}
+}
@@ -0,0 +1,32 @@
+package outbound
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+)
+
+// Headers carrying the signature of a request
+const (
+	HeaderTimestamp = "X-DailyAlu-Timestamp"
+	HeaderSignature = "X-DailyAlu-Signature"
+)
+
+// Sign returns the signature of a payload sent at timestamp: the hex-encoded
+// HMAC-SHA256 of "<timestamp>.<payload>" keyed with the shared secret
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignRequest sets the timestamp and signature headers of a request whose
+// body is payload
+func SignRequest(req *http.Request, secret string, timestamp int64, payload []byte) {
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, payload))
+}
@@ -0,0 +1,67 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"embed"
+	"encoding/hex"
+	"strings"
+	"sync"
+)
+
+//go:embed data/breached_sha1.txt
+var breachedData embed.FS
+
+// breachedPrefixes maps the first 5 hex characters of a SHA-1 hash to the set
+// of known breached suffixes, mirroring a k-anonymity range lookup
+var (
+	breachedPrefixes map[string]map[string]struct{}
+	breachedOnce     sync.Once
+)
+
+func loadBreached() {
+	breachedPrefixes = make(map[string]map[string]struct{})
+
+	file, err := breachedData.Open("data/breached_sha1.txt")
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		prefix, suffix, found := strings.Cut(line, ":")
+		if !found || len(prefix) != 5 {
+			continue
+		}
+
+		prefix = strings.ToUpper(prefix)
+		if breachedPrefixes[prefix] == nil {
+			breachedPrefixes[prefix] = make(map[string]struct{})
+		}
+		breachedPrefixes[prefix][strings.ToUpper(suffix)] = struct{}{}
+	}
+}
+
+// IsBreached reports whether the password appears in the bundled list of
+// commonly breached passwords. Only the hash range for the password prefix
+// is consulted, the plain text is never compared.
+func IsBreached(password string) bool {
+	breachedOnce.Do(loadBreached)
+
+	sum := sha1.Sum([]byte(password))
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, ok := breachedPrefixes[digest[:5]]
+	if !ok {
+		return false
+	}
+
+	_, breached := suffixes[digest[5:]]
+	return breached
+}
@@ -1,6 +1,15 @@
 package password
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -10,17 +19,206 @@ const (
 	defaultCost = minCost
 )
 
-// Hash creates a bcrypt hash of the password using the default cost
-func Hash(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), defaultCost)
-	if err != nil {
-		return "", err
+// Supported hash algorithms
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+var ErrInvalidHash = errors.New("invalid password hash format")
+
+// Params configures how new hashes are created. Stored hashes carry their
+// own parameters, so changing these only affects hashes created afterwards.
+type Params struct {
+	Algorithm string
+	// Argon2id parameters, memory is in KiB
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+	// BcryptCost is used when Algorithm is bcrypt
+	BcryptCost int
+}
+
+// DefaultParams returns the RFC 9106 second recommended argon2id settings
+func DefaultParams() Params {
+	return Params{
+		Algorithm:   AlgorithmArgon2id,
+		Memory:      64 * 1024,
+		Iterations:  3,
+		Parallelism: 2,
+		SaltLength:  16,
+		KeyLength:   32,
+		BcryptCost:  defaultCost,
+	}
+}
+
+// NewParamsFromConfig creates the parameters configured under password.hash
+func NewParamsFromConfig() Params {
+	return Params{
+		Algorithm:   viper.GetString("password.hash.algorithm"),
+		Memory:      viper.GetUint32("password.hash.memory"),
+		Iterations:  viper.GetUint32("password.hash.iterations"),
+		Parallelism: uint8(viper.GetUint("password.hash.parallelism")),
+		SaltLength:  viper.GetUint32("password.hash.salt_length"),
+		KeyLength:   viper.GetUint32("password.hash.key_length"),
+		BcryptCost:  viper.GetInt("password.hash.bcrypt_cost"),
 	}
-	return string(bytes), nil
+}
+
+// Hasher creates and verifies password hashes
+type Hasher struct {
+	params Params
+}
+
+// NewHasher creates a hasher, falling back to the defaults for unset values
+func NewHasher(params Params) *Hasher {
+	defaults := DefaultParams()
+	if params.Algorithm != AlgorithmBcrypt {
+		params.Algorithm = AlgorithmArgon2id
+	}
+	if params.Memory == 0 {
+		params.Memory = defaults.Memory
+	}
+	if params.Iterations == 0 {
+		params.Iterations = defaults.Iterations
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = defaults.Parallelism
+	}
+	if params.SaltLength == 0 {
+		params.SaltLength = defaults.SaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = defaults.KeyLength
+	}
+	if params.BcryptCost < minCost || params.BcryptCost > maxCost {
+		params.BcryptCost = defaults.BcryptCost
+	}
+	return &Hasher{params: params}
+}
+
+var defaultHasher = NewHasher(DefaultParams())
+
+// SetDefaultHasher replaces the hasher used by the package level functions
+func SetDefaultHasher(h *Hasher) {
+	defaultHasher = h
+}
+
+// Hash creates a hash of the password with the default hasher
+func Hash(password string) (string, error) {
+	return defaultHasher.Hash(password)
 }
 
 // Verify checks if the password matches the hash
 func Verify(password, hash string) bool {
+	return defaultHasher.Verify(password, hash)
+}
+
+// NeedsRehash reports whether hash was created with a different algorithm or
+// weaker parameters than the default hasher uses
+func NeedsRehash(hash string) bool {
+	return defaultHasher.NeedsRehash(hash)
+}
+
+// Hash creates a hash of the password. Argon2id hashes use the PHC string
+// format: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.params.Algorithm == AlgorithmBcrypt {
+		bytes, err := bcrypt.GenerateFromPassword([]byte(password), h.params.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(bytes), nil
+	}
+
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.params.Memory,
+		h.params.Iterations,
+		h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify checks if the password matches an argon2id or legacy bcrypt hash
+func (h *Hasher) Verify(password, hash string) bool {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		decoded, err := decodeArgon2id(hash)
+		if err != nil {
+			return false
+		}
+		key := argon2.IDKey([]byte(password), decoded.salt, decoded.iterations, decoded.memory, decoded.parallelism, uint32(len(decoded.key)))
+		return subtle.ConstantTimeCompare(key, decoded.key) == 1
+	}
+
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
+
+// NeedsRehash reports whether hash should be replaced by a fresh hash
+func (h *Hasher) NeedsRehash(hash string) bool {
+	if h.params.Algorithm == AlgorithmBcrypt {
+		cost, err := bcrypt.Cost([]byte(hash))
+		return err != nil || cost < h.params.BcryptCost
+	}
+
+	decoded, err := decodeArgon2id(hash)
+	if err != nil {
+		return true
+	}
+
+	return decoded.version != argon2.Version ||
+		decoded.memory < h.params.Memory ||
+		decoded.iterations < h.params.Iterations ||
+		decoded.parallelism < h.params.Parallelism ||
+		uint32(len(decoded.salt)) < h.params.SaltLength ||
+		uint32(len(decoded.key)) < h.params.KeyLength
+}
+
+type argon2idHash struct {
+	version     int
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func decodeArgon2id(hash string) (*argon2idHash, error) {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return nil, ErrInvalidHash
+	}
+
+	decoded := &argon2idHash{}
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &decoded.version); err != nil {
+		return nil, ErrInvalidHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &decoded.memory, &decoded.iterations, &decoded.parallelism); err != nil {
+		return nil, ErrInvalidHash
+	}
+	if decoded.iterations == 0 || decoded.parallelism == 0 {
+		return nil, ErrInvalidHash
+	}
+
+	var err error
+	if decoded.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, ErrInvalidHash
+	}
+	if decoded.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(decoded.key) == 0 {
+		return nil, ErrInvalidHash
+	}
+
+	return decoded, nil
+}
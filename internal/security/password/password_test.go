@@ -0,0 +1,90 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testParams keeps argon2id cheap so the tests stay fast
+func testParams() Params {
+	return Params{Algorithm: AlgorithmArgon2id, Memory: 1024, Iterations: 1, Parallelism: 1}
+}
+
+func TestHasher_HashAndVerify(t *testing.T) {
+	hasher := NewHasher(testParams())
+
+	hash, err := hasher.Hash("Giraffe-Lamp-93")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("unexpected hash format: %s", hash)
+	}
+	if !hasher.Verify("Giraffe-Lamp-93", hash) {
+		t.Error("expected password to match")
+	}
+	if hasher.Verify("giraffe-lamp-93", hash) {
+		t.Error("expected wrong password not to match")
+	}
+	if hasher.NeedsRehash(hash) {
+		t.Error("expected current hash not to need a rehash")
+	}
+}
+
+func TestHasher_VerifyLegacyBcrypt(t *testing.T) {
+	legacy, err := bcrypt.GenerateFromPassword([]byte("Giraffe-Lamp-93"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hasher := NewHasher(testParams())
+	if !hasher.Verify("Giraffe-Lamp-93", string(legacy)) {
+		t.Error("expected legacy bcrypt hash to verify")
+	}
+	if !hasher.NeedsRehash(string(legacy)) {
+		t.Error("expected legacy bcrypt hash to need a rehash")
+	}
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	weak, err := NewHasher(testParams()).Hash("Giraffe-Lamp-93")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stronger := testParams()
+	stronger.Iterations = 2
+
+	testCases := []struct {
+		name     string
+		params   Params
+		hash     string
+		expected bool
+	}{
+		{name: "same parameters", params: testParams(), hash: weak, expected: false},
+		{name: "raised iterations", params: stronger, hash: weak, expected: true},
+		{name: "switched to bcrypt", params: Params{Algorithm: AlgorithmBcrypt}, hash: weak, expected: true},
+		{name: "malformed hash", params: testParams(), hash: "$argon2id$v=19$broken", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := NewHasher(tc.params).NeedsRehash(tc.hash); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestHasher_VerifyMalformedHash(t *testing.T) {
+	hasher := NewHasher(testParams())
+
+	for _, hash := range []string{"", "$argon2id$v=19$m=1024,t=0,p=1$c2FsdA$a2V5", "$argon2id$v=19$m=1024,t=1,p=1$!!$a2V5"} {
+		if hasher.Verify("password", hash) {
+			t.Errorf("expected %q not to verify", hash)
+		}
+	}
+}
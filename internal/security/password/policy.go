@@ -0,0 +1,188 @@
+package password
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/viper"
+)
+
+// Violation tags
+const (
+	ViolationMinLength    = "min_length"
+	ViolationUppercase    = "uppercase"
+	ViolationLowercase    = "lowercase"
+	ViolationDigit        = "digit"
+	ViolationSymbol       = "symbol"
+	ViolationPersonalInfo = "personal_info"
+	ViolationReused       = "reused"
+	ViolationBreached     = "breached"
+)
+
+// personalInfoMinLength is the shortest email or name fragment that is
+// rejected as a password substring
+const personalInfoMinLength = 3
+
+// Policy describes the rules a new password has to satisfy
+type Policy struct {
+	MinLength            int
+	RequireUppercase     bool
+	RequireLowercase     bool
+	RequireDigit         bool
+	RequireSymbol        bool
+	DisallowPersonalInfo bool
+	HistorySize          int
+	CheckBreached        bool
+}
+
+// PolicyInput carries the context a password is validated against
+type PolicyInput struct {
+	// Field is the request field reported in violations
+	Field string
+	Email string
+	Name  string
+	// PreviousHashes are the user's current and earlier password hashes, newest first
+	PreviousHashes []string
+}
+
+// Violation describes a single failed rule, in the same shape as request
+// validation errors
+type Violation struct {
+	Field string `json:"field"`
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// PolicyError is returned when a password does not satisfy the policy
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	tags := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		tags = append(tags, v.Tag)
+	}
+	return fmt.Sprintf("password does not meet policy: %s", strings.Join(tags, ", "))
+}
+
+// DefaultPolicy returns the policy used when nothing is configured
+func DefaultPolicy() *Policy {
+	return &Policy{
+		MinLength:            8,
+		RequireUppercase:     true,
+		RequireLowercase:     true,
+		RequireDigit:         true,
+		DisallowPersonalInfo: true,
+		HistorySize:          5,
+		CheckBreached:        true,
+	}
+}
+
+// NewPolicyFromConfig creates the policy configured under password.policy
+func NewPolicyFromConfig() *Policy {
+	return &Policy{
+		MinLength:            viper.GetInt("password.policy.min_length"),
+		RequireUppercase:     viper.GetBool("password.policy.require_uppercase"),
+		RequireLowercase:     viper.GetBool("password.policy.require_lowercase"),
+		RequireDigit:         viper.GetBool("password.policy.require_digit"),
+		RequireSymbol:        viper.GetBool("password.policy.require_symbol"),
+		DisallowPersonalInfo: viper.GetBool("password.policy.disallow_personal_info"),
+		HistorySize:          viper.GetInt("password.policy.history_size"),
+		CheckBreached:        viper.GetBool("password.policy.check_breached"),
+	}
+}
+
+// Validate checks password against the policy and returns a *PolicyError
+// listing every violated rule
+func (p *Policy) Validate(password string, input PolicyInput) error {
+	field := input.Field
+	if field == "" {
+		field = "password"
+	}
+
+	var violations []Violation
+	add := func(tag, value string) {
+		violations = append(violations, Violation{Field: field, Tag: tag, Value: value})
+	}
+
+	if len([]rune(password)) < p.MinLength {
+		add(ViolationMinLength, strconv.Itoa(p.MinLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.RequireUppercase && !hasUpper {
+		add(ViolationUppercase, "")
+	}
+	if p.RequireLowercase && !hasLower {
+		add(ViolationLowercase, "")
+	}
+	if p.RequireDigit && !hasDigit {
+		add(ViolationDigit, "")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		add(ViolationSymbol, "")
+	}
+
+	if p.DisallowPersonalInfo && containsPersonalInfo(password, input.Email, input.Name) {
+		add(ViolationPersonalInfo, "")
+	}
+
+	if p.HistorySize > 0 && reusesPrevious(password, input.PreviousHashes, p.HistorySize) {
+		add(ViolationReused, strconv.Itoa(p.HistorySize))
+	}
+
+	if p.CheckBreached && IsBreached(password) {
+		add(ViolationBreached, "")
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+func containsPersonalInfo(password, email, name string) bool {
+	lowered := strings.ToLower(password)
+
+	var fragments []string
+	if local, _, found := strings.Cut(email, "@"); found {
+		fragments = append(fragments, local)
+	}
+	fragments = append(fragments, strings.Fields(name)...)
+
+	for _, fragment := range fragments {
+		fragment = strings.ToLower(fragment)
+		if len(fragment) >= personalInfoMinLength && strings.Contains(lowered, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+func reusesPrevious(password string, hashes []string, historySize int) bool {
+	if len(hashes) > historySize {
+		hashes = hashes[:historySize]
+	}
+	for _, hash := range hashes {
+		if hash != "" && Verify(password, hash) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package password
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPolicy_Validate(t *testing.T) {
+	previousHash, err := Hash("OldSecret42")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name         string
+		policy       *Policy
+		password     string
+		input        PolicyInput
+		expectedTags []string
+	}{
+		{
+			name:     "valid password",
+			policy:   DefaultPolicy(),
+			password: "Giraffe-Lamp-93",
+			input:    PolicyInput{Email: "parent@example.com", Name: "Jane Doe"},
+		},
+		{
+			name:         "too short and missing classes",
+			policy:       DefaultPolicy(),
+			password:     "abc",
+			expectedTags: []string{ViolationMinLength, ViolationUppercase, ViolationDigit},
+		},
+		{
+			name:         "symbol required",
+			policy:       &Policy{MinLength: 8, RequireSymbol: true},
+			password:     "Giraffe93",
+			expectedTags: []string{ViolationSymbol},
+		},
+		{
+			name:         "contains email local part",
+			policy:       DefaultPolicy(),
+			password:     "Jane.Parent2024",
+			input:        PolicyInput{Email: "parent@example.com"},
+			expectedTags: []string{ViolationPersonalInfo},
+		},
+		{
+			name:         "contains name",
+			policy:       DefaultPolicy(),
+			password:     "Doe-Family-2024",
+			input:        PolicyInput{Name: "Jane Doe"},
+			expectedTags: []string{ViolationPersonalInfo},
+		},
+		{
+			name:         "reuses previous password",
+			policy:       DefaultPolicy(),
+			password:     "OldSecret42",
+			input:        PolicyInput{PreviousHashes: []string{previousHash}},
+			expectedTags: []string{ViolationReused},
+		},
+		{
+			name:     "reuse outside history window",
+			policy:   &Policy{MinLength: 8, HistorySize: 1},
+			password: "OldSecret42",
+			input:    PolicyInput{PreviousHashes: []string{"", previousHash}},
+		},
+		{
+			name:         "breached password",
+			policy:       DefaultPolicy(),
+			password:     "Password123",
+			expectedTags: []string{ViolationBreached},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Validate(tc.password, tc.input)
+
+			if len(tc.expectedTags) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var policyErr *PolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("expected PolicyError, got %v", err)
+			}
+
+			if len(policyErr.Violations) != len(tc.expectedTags) {
+				t.Fatalf("expected violations %v, got %+v", tc.expectedTags, policyErr.Violations)
+			}
+			for i, tag := range tc.expectedTags {
+				if policyErr.Violations[i].Tag != tag {
+					t.Errorf("expected violation %s, got %s", tag, policyErr.Violations[i].Tag)
+				}
+				if policyErr.Violations[i].Field != "password" {
+					t.Errorf("expected field password, got %s", policyErr.Violations[i].Field)
+				}
+			}
+		})
+	}
+}
+
+func TestIsBreached(t *testing.T) {
+	if !IsBreached("123456") {
+		t.Error("expected 123456 to be reported as breached")
+	}
+	if IsBreached("Giraffe-Lamp-93") {
+		t.Error("expected uncommon password not to be reported as breached")
+	}
+}
@@ -2,6 +2,7 @@ package token
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"time"
@@ -12,6 +13,7 @@ type TokenType string
 const (
 	EmailVerification TokenType = "email"
 	PasswordReset     TokenType = "password"
+	MagicLink         TokenType = "magic_link"
 )
 
 // TokenService handles secure token generation and verification for various purposes
@@ -23,8 +25,9 @@ type TokenService struct {
 func NewTokenService() *TokenService {
 	return &TokenService{
 		configs: map[TokenType]time.Duration{
-			EmailVerification: 24 * time.Hour, // Email verification tokens last 24 hours
-			PasswordReset:    1 * time.Hour,   // Password reset tokens last 1 hour for security
+			EmailVerification: 24 * time.Hour,   // Email verification tokens last 24 hours
+			PasswordReset:     1 * time.Hour,    // Password reset tokens last 1 hour for security
+			MagicLink:         15 * time.Minute, // Sign-in links are short lived and single use
 		},
 	}
 }
@@ -38,6 +41,22 @@ func (s *TokenService) GenerateToken() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
+// HashToken returns the hex encoded SHA-256 digest of a token, for tokens that
+// must not be stored in plain text
+func (s *TokenService) HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ExpiresAt returns the expiry time of a token of the given type created at createdAt
+func (s *TokenService) ExpiresAt(tokenType TokenType, createdAt time.Time) time.Time {
+	expiry, exists := s.configs[tokenType]
+	if !exists {
+		expiry = 24 * time.Hour // Default expiry
+	}
+	return createdAt.Add(expiry)
+}
+
 // IsTokenExpired checks if the token has expired based on creation time and token type
 func (s *TokenService) IsTokenExpired(tokenType TokenType, createdAt time.Time) bool {
 	expiry, exists := s.configs[tokenType]
@@ -56,3 +75,8 @@ func (s *TokenService) GenerateVerificationLink(baseURL, token string) string {
 func (s *TokenService) GeneratePasswordResetLink(baseURL, token string) string {
 	return fmt.Sprintf("%s/reset-password?token=%s", baseURL, token)
 }
+
+// GenerateMagicLink creates the full URL for passwordless sign-in
+func (s *TokenService) GenerateMagicLink(baseURL, token string) string {
+	return fmt.Sprintf("%s/magic-link?token=%s", baseURL, token)
+}
@@ -2,23 +2,114 @@ package domain
 
 import "context"
 
-const (
-	// Email templates
-	VerificationEmailTemplate = "verification_email.html"
-
-	//constants
-	NoReplyEmail = "no-reply@dailyalu.mom"
-
-	//subjects
-	WelcomeSubject = "Welcome to DailyAlu!"
-)
-
 type EmailVerificationData struct {
 	Name            string
 	VerificationURL string
 	To string
+	Locale          string
+}
+
+type MagicLinkEmailData struct {
+	Name             string
+	MagicLinkURL     string
+	ExpiresInMinutes int
+	To               string
+	Locale           string
 }
 
+type NotificationEmailData struct {
+	Name    string
+	Title   string
+	Message string
+	To      string
+	Locale  string
+}
+
+// WeeklyDigestEmailData summarizes the past week of each child of a user
+type WeeklyDigestEmailData struct {
+	Name string
+	// WeekStart and WeekEnd are the first and last day of the week, as
+	// YYYY-MM-DD in the user's timezone
+	WeekStart string
+	WeekEnd   string
+	Children  []DigestChild
+	To        string
+	Locale    string
+}
+
+// DigestChild holds the weekly totals of a child
+type DigestChild struct {
+	Name       string
+	SleepHours float64
+	Feeds      int
+	Diapers    int
+	// Growth lists the measures recorded during the week
+	Growth []DigestGrowth
+}
+
+// Growth measures
+const (
+	MeasureWeight            = "weight"
+	MeasureLength            = "length"
+	MeasureHeadCircumference = "head_circumference"
+)
+
+// DigestGrowth is the latest value of a measure taken during the week and its
+// change over the week, in the user's preferred unit
+type DigestGrowth struct {
+	Measure string
+	Value   float64
+	// Change is only set when the measure was taken before
+	Change    float64
+	HasChange bool
+	Unit      string
+}
+
+// ReportEmailData sends the report of a child over a period as a PDF
+// attachment
+type ReportEmailData struct {
+	Name      string
+	ChildName string
+	// PeriodStart and PeriodEnd are the first and last day of the report, as
+	// YYYY-MM-DD
+	PeriodStart string
+	PeriodEnd   string
+	Report      Attachment
+	To          string
+	Locale      string
+}
+
+// IMailerService sends the emails of the application. Each email is written
+// in the Locale of its data, such as the recipient's preferred locale, or in
+// English if it has no translation.
 type IMailerService interface {
 	SendVerificationEmail(ctx context.Context, data *EmailVerificationData) (error)
-}
\ No newline at end of file
+	SendMagicLinkEmail(ctx context.Context, data *MagicLinkEmailData) error
+	SendNotificationEmail(ctx context.Context, data *NotificationEmailData) error
+	SendWeeklyDigestEmail(ctx context.Context, data *WeeklyDigestEmailData) error
+	SendReportEmail(ctx context.Context, data *ReportEmailData) error
+}
+
+// Email is a rendered email ready to be sent
+type Email struct {
+	// From is the sender, either an address or "Name <address>"
+	From    string
+	To      string
+	Subject string
+	HTML    string
+	// Text is an optional plain text alternative to HTML
+	Text        string
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to an email
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// IProvider delivers rendered emails, e.g. over SMTP or through SES
+type IProvider interface {
+	Send(ctx context.Context, email *Email) error
+}
@@ -0,0 +1,79 @@
+package mailer
+
+import (
+	"context"
+	"dailyalu-server/internal/service/mailer/domain"
+	"dailyalu-server/internal/service/mailer/provider"
+	"dailyalu-server/internal/service/mailer/templates"
+
+	"github.com/spf13/viper"
+)
+
+// MailerService renders emails from templates and sends them through the
+// configured provider
+type MailerService struct {
+	provider  domain.IProvider
+	templates *templates.Registry
+	from      string
+}
+
+// NewMailerService creates a new mailer service sending from the address
+func NewMailerService(provider domain.IProvider, templates *templates.Registry, from string) domain.IMailerService {
+	return &MailerService{
+		provider:  provider,
+		templates: templates,
+		from:      from,
+	}
+}
+
+// NewMailerServiceFromConfig creates the mailer service with the provider
+// and sender configured under mailer, loading every template up front
+func NewMailerServiceFromConfig(ctx context.Context) (domain.IMailerService, error) {
+	mailProvider, err := provider.NewProviderFromConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	registry, err := templates.NewRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMailerService(mailProvider, registry, viper.GetString("mailer.from")), nil
+}
+
+func (m *MailerService) SendVerificationEmail(ctx context.Context, emailVerificationData *domain.EmailVerificationData) error {
+	return m.send(ctx, emailVerificationData.To, templates.Verification, emailVerificationData.Locale, emailVerificationData)
+}
+
+func (m *MailerService) SendMagicLinkEmail(ctx context.Context, magicLinkData *domain.MagicLinkEmailData) error {
+	return m.send(ctx, magicLinkData.To, templates.MagicLink, magicLinkData.Locale, magicLinkData)
+}
+
+func (m *MailerService) SendNotificationEmail(ctx context.Context, notificationData *domain.NotificationEmailData) error {
+	return m.send(ctx, notificationData.To, templates.Notification, notificationData.Locale, notificationData)
+}
+
+func (m *MailerService) SendWeeklyDigestEmail(ctx context.Context, digestData *domain.WeeklyDigestEmailData) error {
+	return m.send(ctx, digestData.To, templates.WeeklyDigest, digestData.Locale, digestData)
+}
+
+func (m *MailerService) SendReportEmail(ctx context.Context, reportData *domain.ReportEmailData) error {
+	return m.send(ctx, reportData.To, templates.Report, reportData.Locale, reportData, reportData.Report)
+}
+
+func (m *MailerService) send(ctx context.Context, to, name, locale string, data any, attachments ...domain.Attachment) error {
+	rendered, err := m.templates.Render(name, locale, data)
+	if err != nil {
+		return err
+	}
+
+	return m.provider.Send(ctx, &domain.Email{
+		From:        m.from,
+		To:          to,
+		Subject:     rendered.Subject,
+		HTML:        rendered.HTML,
+		Text:        rendered.Text,
+		Attachments: attachments,
+	})
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"dailyalu-server/internal/service/mailer/domain"
+	"dailyalu-server/pkg/app_log/zap_log"
+	"dailyalu-server/pkg/mailer/smtp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// unsafeFileChars are replaced in the recipient part of file names
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9@._-]+`)
+
+// DevProvider writes emails to .eml files instead of sending them, for local
+// development. The files open in any mail client.
+type DevProvider struct {
+	dir    string
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+// NewDevProvider creates a provider writing to dir, which is created if
+// missing
+func NewDevProvider(dir string) (*DevProvider, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	logger := zap_log.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &DevProvider{
+		dir:    dir,
+		logger: logger,
+		now:    time.Now,
+	}, nil
+}
+
+// Send writes the email as it would be sent over SMTP
+func (p *DevProvider) Send(ctx context.Context, email *domain.Email) error {
+	content, err := smtp.BuildMessage(message(email))
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", p.now().UTC().Format("20060102T150405.000000000"), unsafeFileChars.ReplaceAllString(email.To, "_"))
+	path := filepath.Join(p.dir, name)
+
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+
+	p.logger.Info("email written", zap.String("to", email.To), zap.String("subject", email.Subject), zap.String("path", path))
+	return nil
+}
@@ -0,0 +1,55 @@
+// Package provider implements the ways emails can be delivered. The mailer
+// service renders emails; a provider only sends what it is given.
+package provider
+
+import (
+	"context"
+	"dailyalu-server/internal/service/mailer/domain"
+	"dailyalu-server/pkg/mailer/ses"
+	"dailyalu-server/pkg/mailer/smtp"
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// Providers selectable with mailer.provider
+const (
+	ProviderSMTP = "smtp"
+	ProviderSES  = "ses"
+	ProviderDev  = "dev"
+)
+
+// NewProviderFromConfig creates the provider named by mailer.provider
+func NewProviderFromConfig(ctx context.Context) (domain.IProvider, error) {
+	switch name := viper.GetString("mailer.provider"); name {
+	case ProviderSMTP:
+		return NewSMTPProvider(smtp.InitSmtp()), nil
+	case ProviderSES:
+		client, err := ses.InitSes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return NewSESProvider(client), nil
+	case ProviderDev:
+		return NewDevProvider(viper.GetString("mailer.dev.dir"))
+	default:
+		return nil, fmt.Errorf("unknown mailer provider %q", name)
+	}
+}
+
+// message converts an email to the data the MIME builder takes
+func message(email *domain.Email) smtp.SendEmailData {
+	attachments := make([]smtp.Attachment, len(email.Attachments))
+	for i, attachment := range email.Attachments {
+		attachments[i] = smtp.Attachment(attachment)
+	}
+
+	return smtp.SendEmailData{
+		From:        email.From,
+		To:          email.To,
+		Subject:     email.Subject,
+		Text:        email.Text,
+		Content:     email.HTML,
+		Attachments: attachments,
+	}
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"dailyalu-server/internal/service/mailer/domain"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+)
+
+func TestDevProviderWritesEml(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	p, err := NewDevProvider(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.now = func() time.Time { return time.Date(2025, 3, 20, 3, 30, 0, 0, time.UTC) }
+
+	err = p.Send(context.Background(), &domain.Email{
+		From:    "DailyAlu <no-reply@dailyalu.mom>",
+		To:      "ani@example.com",
+		Subject: "Selamat datang di DailyAlu – verifikasi email",
+		HTML:    "<p>Verify your email</p>",
+		Text:    "Verify your email",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "20250320T033000.000000000-ani@example.com.eml"))
+	if err != nil {
+		t.Fatalf("expected the email to be written: %v", err)
+	}
+
+	// The file is a valid message with a decodable subject and both bodies
+	message, err := mail.ReadMessage(strings.NewReader(string(content)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if err != nil || subject != "Selamat datang di DailyAlu – verifikasi email" {
+		t.Errorf("unexpected subject %q: %v", subject, err)
+	}
+	if message.Header.Get("From") != "DailyAlu <no-reply@dailyalu.mom>" || message.Header.Get("To") != "ani@example.com" {
+		t.Errorf("unexpected headers: %v", message.Header)
+	}
+	body, _ := io.ReadAll(message.Body)
+	if !strings.Contains(string(body), "text/plain") || !strings.Contains(string(body), "<p>Verify your email</p>") {
+		t.Errorf("expected text and HTML parts, got %s", body)
+	}
+}
+
+// fakeSESClient records the emails it is asked to send
+type fakeSESClient struct {
+	inputs []*sesv2.SendEmailInput
+}
+
+func (f *fakeSESClient) SendEmail(ctx context.Context, params *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error) {
+	f.inputs = append(f.inputs, params)
+	return &sesv2.SendEmailOutput{}, nil
+}
+
+func TestSESProviderSendsHTML(t *testing.T) {
+	client := &fakeSESClient{}
+	p := &SESProvider{client: client}
+
+	err := p.Send(context.Background(), &domain.Email{
+		From:    "no-reply@dailyalu.mom",
+		To:      "ani@example.com",
+		Subject: "Your DailyAlu sign-in link",
+		HTML:    "<a href=\"https://dailyalu.mom\">Sign in</a>",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(client.inputs) != 1 {
+		t.Fatalf("expected one email, got %d", len(client.inputs))
+	}
+	input := client.inputs[0]
+	if *input.FromEmailAddress != "no-reply@dailyalu.mom" || input.Destination.ToAddresses[0] != "ani@example.com" {
+		t.Errorf("unexpected addresses: %+v", input)
+	}
+	body := input.Content.Simple.Body
+	if body.Html == nil || *body.Html.Data != "<a href=\"https://dailyalu.mom\">Sign in</a>" || body.Text != nil {
+		t.Errorf("expected an HTML body only, got %+v", body)
+	}
+}
+
+func TestSESProviderSendsAttachmentsRaw(t *testing.T) {
+	client := &fakeSESClient{}
+	p := &SESProvider{client: client}
+
+	err := p.Send(context.Background(), &domain.Email{
+		From:    "no-reply@dailyalu.mom",
+		To:      "ani@example.com",
+		Subject: "Budi's report",
+		HTML:    "<p>The report is attached</p>",
+		Text:    "The report is attached",
+		Attachments: []domain.Attachment{
+			{Filename: "budi-report.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4 sample")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content := client.inputs[0].Content
+	if content.Simple != nil || content.Raw == nil {
+		t.Fatalf("expected a raw message, got %+v", content)
+	}
+
+	// The message mixes the alternatives with the decodable attachment
+	message, err := mail.ReadMessage(bytes.NewReader(content.Raw.Data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("expected a multipart/mixed message, got %q: %v", mediaType, err)
+	}
+
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	alternatives, err := reader.NextPart()
+	if err != nil || !strings.HasPrefix(alternatives.Header.Get("Content-Type"), "multipart/alternative") {
+		t.Fatalf("expected the alternatives first: %v", err)
+	}
+	attachment, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("expected an attachment: %v", err)
+	}
+	if attachment.FileName() != "budi-report.pdf" || !strings.HasPrefix(attachment.Header.Get("Content-Type"), "application/pdf") {
+		t.Errorf("unexpected attachment headers: %v", attachment.Header)
+	}
+	data, _ := io.ReadAll(base64.NewDecoder(base64.StdEncoding, attachment))
+	if string(data) != "%PDF-1.4 sample" {
+		t.Errorf("unexpected attachment data %q", data)
+	}
+	if _, err := reader.NextPart(); err != io.EOF {
+		t.Errorf("expected two parts, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"dailyalu-server/internal/service/mailer/domain"
+	"dailyalu-server/pkg/mailer/smtp"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+// sesClient is the part of the SES client the provider uses
+type sesClient interface {
+	SendEmail(ctx context.Context, params *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error)
+}
+
+// SESProvider sends emails through Amazon SES. The sender address must be
+// verified in SES.
+type SESProvider struct {
+	client sesClient
+}
+
+// NewSESProvider creates an SES provider
+func NewSESProvider(client *sesv2.Client) *SESProvider {
+	return &SESProvider{client: client}
+}
+
+// Send sends the email with its HTML body and, if set, its text alternative.
+// Emails with attachments are sent as raw MIME messages.
+func (p *SESProvider) Send(ctx context.Context, email *domain.Email) error {
+	content, err := sesContent(email)
+	if err != nil {
+		return err
+	}
+
+	input := &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(email.From),
+		Destination: &types.Destination{
+			ToAddresses: []string{email.To},
+		},
+		Content: content,
+	}
+
+	if _, err := p.client.SendEmail(ctx, input); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func sesContent(email *domain.Email) (*types.EmailContent, error) {
+	if len(email.Attachments) > 0 {
+		raw, err := smtp.BuildMessage(message(email))
+		if err != nil {
+			return nil, err
+		}
+		return &types.EmailContent{Raw: &types.RawMessage{Data: raw}}, nil
+	}
+
+	body := &types.Body{}
+	if email.HTML != "" {
+		body.Html = &types.Content{Data: aws.String(email.HTML), Charset: aws.String("UTF-8")}
+	}
+	if email.Text != "" {
+		body.Text = &types.Content{Data: aws.String(email.Text), Charset: aws.String("UTF-8")}
+	}
+
+	return &types.EmailContent{
+		Simple: &types.Message{
+			Body:    body,
+			Subject: &types.Content{Data: aws.String(email.Subject), Charset: aws.String("UTF-8")},
+		},
+	}, nil
+}
@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"context"
+	"dailyalu-server/internal/service/mailer/domain"
+	"dailyalu-server/pkg/mailer/smtp"
+)
+
+// SMTPProvider sends emails through an SMTP relay
+type SMTPProvider struct {
+	smtp *smtp.Smtp
+}
+
+// NewSMTPProvider creates an SMTP provider
+func NewSMTPProvider(smtp *smtp.Smtp) *SMTPProvider {
+	return &SMTPProvider{smtp: smtp}
+}
+
+// Send sends the email. net/smtp cannot be cancelled, so the context is only
+// checked before connecting.
+func (p *SMTPProvider) Send(ctx context.Context, email *domain.Email) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	_, err := p.smtp.Send(message(email))
+	return err
+}
@@ -0,0 +1,132 @@
+// Package templates renders the emails the server sends. Every email has a
+// template per locale defining its "subject", "html" and "text" parts, which
+// are wrapped in the layout shared by all emails.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmlTemplate "html/template"
+	"strings"
+	textTemplate "text/template"
+)
+
+//go:embed layout.html layout.txt en id
+var files embed.FS
+
+// Emails that can be rendered
+const (
+	Verification = "verification"
+	MagicLink    = "magic_link"
+	Notification = "notification"
+	WeeklyDigest = "weekly_digest"
+	Report       = "report"
+)
+
+// DefaultLocale is used for users whose locale has no translation
+const DefaultLocale = "en"
+
+// Locales lists the languages emails are translated to
+var Locales = []string{"en", "id"}
+
+var names = []string{Verification, MagicLink, Notification, WeeklyDigest, Report}
+
+// Names lists the emails that can be rendered
+func Names() []string {
+	return append([]string(nil), names...)
+}
+
+// Rendered is an email ready to be sent
+type Rendered struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+type emailTemplate struct {
+	html *htmlTemplate.Template
+	text *textTemplate.Template
+}
+
+// Registry holds every email parsed once, by locale and name
+type Registry struct {
+	templates map[string]*emailTemplate
+}
+
+// NewRegistry parses all templates, failing if any email is missing in any
+// locale
+func NewRegistry() (*Registry, error) {
+	r := &Registry{templates: map[string]*emailTemplate{}}
+
+	for _, locale := range Locales {
+		for _, name := range names {
+			common := locale + "/common.tmpl"
+			email := locale + "/" + name + ".tmpl"
+
+			html, err := htmlTemplate.ParseFS(files, "layout.html", common, email)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", email, err)
+			}
+			text, err := textTemplate.ParseFS(files, "layout.txt", common, email)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", email, err)
+			}
+
+			for _, part := range []string{"subject", "html", "text"} {
+				if text.Lookup(part) == nil {
+					return nil, fmt.Errorf("%s does not define %q", email, part)
+				}
+			}
+
+			r.templates[key(locale, name)] = &emailTemplate{html: html, text: text}
+		}
+	}
+
+	return r, nil
+}
+
+// Render renders an email in the locale, or in the default locale if it has
+// no translation
+func (r *Registry) Render(name, locale string, data any) (*Rendered, error) {
+	t, ok := r.templates[key(ResolveLocale(locale), name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, html, text bytes.Buffer
+	if err := t.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := t.html.ExecuteTemplate(&html, "layout.html", data); err != nil {
+		return nil, err
+	}
+	if err := t.text.ExecuteTemplate(&text, "layout.txt", data); err != nil {
+		return nil, err
+	}
+
+	return &Rendered{
+		// Line breaks would end the Subject header
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
+
+// ResolveLocale returns the supported locale for a preference such as
+// "id-ID" or "en_US"
+func ResolveLocale(locale string) string {
+	language, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	language = strings.ToLower(language)
+
+	for _, supported := range Locales {
+		if language == supported {
+			return supported
+		}
+	}
+	return DefaultLocale
+}
+
+func key(locale, name string) string {
+	return locale + "/" + name
+}
@@ -0,0 +1,94 @@
+package templates
+
+import (
+	"dailyalu-server/internal/service/mailer/domain"
+	"strings"
+	"testing"
+)
+
+func TestRegistryRender(t *testing.T) {
+	registry, err := NewRegistry()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	verification := &domain.EmailVerificationData{Name: "Ani", VerificationURL: "https://dailyalu.mom/verify-email/abc?x=1&y=2"}
+
+	testCases := []struct {
+		locale   string
+		subject  string
+		greeting string
+	}{
+		{"en", "Welcome to DailyAlu!", "Hello Ani,"},
+		{"id-ID", "Selamat datang di DailyAlu!", "Halo Ani,"},
+		{"id_id", "Selamat datang di DailyAlu!", "Halo Ani,"},
+		{"fr-FR", "Welcome to DailyAlu!", "Hello Ani,"},
+		{"", "Welcome to DailyAlu!", "Hello Ani,"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.locale, func(t *testing.T) {
+			rendered, err := registry.Render(Verification, tc.locale, verification)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rendered.Subject != tc.subject {
+				t.Errorf("expected subject %q, got %q", tc.subject, rendered.Subject)
+			}
+			if !strings.Contains(rendered.HTML, tc.greeting) || !strings.HasPrefix(rendered.Text, tc.greeting) {
+				t.Errorf("expected both parts to greet with %q", tc.greeting)
+			}
+		})
+	}
+
+	rendered, err := registry.Render(Verification, "en", verification)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The HTML part is escaped and wrapped in the layout; the text part is not
+	if !strings.Contains(rendered.HTML, `href="https://dailyalu.mom/verify-email/abc?x=1&amp;y=2"`) || !strings.Contains(rendered.HTML, "The Daily Alu Team") {
+		t.Errorf("unexpected HTML part: %s", rendered.HTML)
+	}
+	if !strings.Contains(rendered.Text, "\nhttps://dailyalu.mom/verify-email/abc?x=1&y=2\n") || !strings.Contains(rendered.Text, "The Daily Alu Team") {
+		t.Errorf("unexpected text part: %s", rendered.Text)
+	}
+
+	// Subjects are plain text on one line
+	notification, err := registry.Render(Notification, "en", &domain.NotificationEmailData{Name: "Ani", Title: "Baby's\nvitamin D", Message: "1 drop <daily>"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if notification.Subject != "Baby's vitamin D" {
+		t.Errorf("unexpected subject %q", notification.Subject)
+	}
+	if !strings.Contains(notification.HTML, "1 drop &lt;daily&gt;") || !strings.Contains(notification.Text, "1 drop <daily>") {
+		t.Errorf("unexpected message rendering")
+	}
+
+	digest, err := registry.Render(WeeklyDigest, "en", &domain.WeeklyDigestEmailData{
+		Name:      "Ani",
+		WeekStart: "2025-03-10",
+		WeekEnd:   "2025-03-16",
+		Children: []domain.DigestChild{
+			{Name: "Budi", SleepHours: 98.5, Feeds: 52, Diapers: 41, Growth: []domain.DigestGrowth{
+				{Measure: domain.MeasureWeight, Value: 6.2, Change: 0.15, HasChange: true, Unit: "kg"},
+				{Measure: domain.MeasureLength, Value: 61.5, Unit: "cm"},
+			}},
+			{Name: "Sari"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if digest.Subject != "Your week with Budi, Sari" {
+		t.Errorf("unexpected subject %q", digest.Subject)
+	}
+	for _, line := range []string{"- Sleep: 98.5 hours", "- Weight: 6.20 kg (+0.15 kg)", "- Length: 61.50 cm\n"} {
+		if !strings.Contains(digest.Text, line) {
+			t.Errorf("expected %q in the digest, got %s", line, digest.Text)
+		}
+	}
+
+	if _, err := registry.Render("welcome_back", "en", nil); err == nil {
+		t.Error("expected an error for an unknown template")
+	}
+}
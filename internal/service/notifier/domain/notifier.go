@@ -0,0 +1,45 @@
+package domain
+
+import "context"
+
+// Delivery channels
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+	ChannelPush    = "push"
+)
+
+// Recipient is the user a notification is delivered to
+type Recipient struct {
+	UserID string
+	Email  string
+	Name   string
+	Locale string
+
+	// RemindersOff holds the channels the user turned reminders off for
+	RemindersOff map[string]bool
+}
+
+// Notification is a message delivered to a user through a channel
+type Notification struct {
+	Recipient Recipient
+	Title     string
+	Message   string
+
+	// WebhookURL is the target of the webhook channel, and WebhookSecret
+	// signs its requests
+	WebhookURL    string
+	WebhookSecret string
+
+	// Template selects how the push channel renders the notification
+	Template string
+
+	// Data carries structured details, e.g. the reminder it was sent for
+	Data map[string]interface{}
+}
+
+// INotifier delivers notifications through one channel. Returning an error
+// makes the caller retry the delivery later.
+type INotifier interface {
+	Notify(ctx context.Context, notification *Notification) error
+}
@@ -0,0 +1,35 @@
+package notifier
+
+import (
+	"context"
+	mailerDomain "dailyalu-server/internal/service/mailer/domain"
+	"dailyalu-server/internal/service/notifier/domain"
+	"errors"
+)
+
+// EmailNotifier delivers notifications by email
+type EmailNotifier struct {
+	mailerService mailerDomain.IMailerService
+}
+
+// NewEmailNotifier creates a notifier sending through the mailer service
+func NewEmailNotifier(mailerService mailerDomain.IMailerService) domain.INotifier {
+	return &EmailNotifier{
+		mailerService: mailerService,
+	}
+}
+
+// Notify emails the notification to the recipient
+func (n *EmailNotifier) Notify(ctx context.Context, notification *domain.Notification) error {
+	if notification.Recipient.Email == "" {
+		return errors.New("recipient has no email address")
+	}
+
+	return n.mailerService.SendNotificationEmail(ctx, &mailerDomain.NotificationEmailData{
+		Name:    notification.Recipient.Name,
+		Title:   notification.Title,
+		Message: notification.Message,
+		To:      notification.Recipient.Email,
+		Locale:  notification.Recipient.Locale,
+	})
+}
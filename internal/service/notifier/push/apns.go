@@ -0,0 +1,172 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	apnsProductionEndpoint  = "https://api.push.apple.com"
+	apnsDevelopmentEndpoint = "https://api.sandbox.push.apple.com"
+
+	// Apple rejects provider tokens older than an hour and throttles
+	// refreshing them more often than every 20 minutes
+	apnsTokenLifetime = 50 * time.Minute
+)
+
+// APNsProvider sends messages through the Apple Push Notification service
+// using token-based authentication
+type APNsProvider struct {
+	key      *ecdsa.PrivateKey
+	keyID    string
+	teamID   string
+	topic    string
+	endpoint string
+	client   *http.Client
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+// APNsConfig holds the APNs signing key and the app it sends to
+type APNsConfig struct {
+	KeyFile    string
+	KeyID      string
+	TeamID     string
+	Topic      string
+	Production bool
+	Timeout    time.Duration
+}
+
+// NewAPNsProvider creates a provider from a .p8 signing key
+func NewAPNsProvider(config APNsConfig) (*APNsProvider, error) {
+	data, err := os.ReadFile(config.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read APNs key: %w", err)
+	}
+
+	key, err := jwt.ParseECPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid APNs key: %w", err)
+	}
+
+	if config.KeyID == "" || config.TeamID == "" || config.Topic == "" {
+		return nil, fmt.Errorf("APNs needs key_id, team_id and topic")
+	}
+
+	endpoint := apnsDevelopmentEndpoint
+	if config.Production {
+		endpoint = apnsProductionEndpoint
+	}
+
+	return &APNsProvider{
+		key:      key,
+		keyID:    config.KeyID,
+		teamID:   config.TeamID,
+		topic:    config.Topic,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+// Send delivers a message to one device token
+func (p *APNsProvider) Send(ctx context.Context, message *Message) error {
+	providerToken, err := p.providerToken()
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"aps": map[string]interface{}{
+			"alert": map[string]string{
+				"title": message.Title,
+				"body":  message.Body,
+			},
+			"sound": "default",
+		},
+	}
+	for key, value := range message.Data {
+		if key != "aps" {
+			payload[key] = value
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/3/device/%s", p.endpoint, url.PathEscape(message.Token))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "bearer "+providerToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("apns-topic", p.topic)
+	req.Header.Set("apns-push-type", "alert")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var failure struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&failure)
+
+	switch {
+	case resp.StatusCode == http.StatusGone,
+		failure.Reason == "BadDeviceToken",
+		failure.Reason == "Unregistered",
+		failure.Reason == "DeviceTokenNotForTopic":
+		return ErrInvalidToken
+	}
+
+	return fmt.Errorf("APNs responded with status %d: %s", resp.StatusCode, failure.Reason)
+}
+
+// providerToken returns the signed authentication token, reissuing it before
+// Apple considers it expired
+func (p *APNsProvider) providerToken() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && time.Since(p.issuedAt) < apnsTokenLifetime {
+		return p.token, nil
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": p.teamID,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = p.keyID
+
+	signed, err := token.SignedString(p.key)
+	if err != nil {
+		return "", err
+	}
+
+	p.token = signed
+	p.issuedAt = now
+
+	return p.token, nil
+}
@@ -0,0 +1,43 @@
+package push
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeProvider records messages instead of sending them. It is used in tests
+// and for local development without push credentials.
+type FakeProvider struct {
+	mu   sync.Mutex
+	sent []Message
+
+	// InvalidTokens are rejected with ErrInvalidToken
+	InvalidTokens map[string]bool
+}
+
+// NewFakeProvider creates a provider that only records messages
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{
+		InvalidTokens: map[string]bool{},
+	}
+}
+
+// Send records the message
+func (p *FakeProvider) Send(ctx context.Context, message *Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.InvalidTokens[message.Token] {
+		return ErrInvalidToken
+	}
+	p.sent = append(p.sent, *message)
+	return nil
+}
+
+// Sent returns the messages recorded so far
+func (p *FakeProvider) Sent() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Message(nil), p.sent...)
+}
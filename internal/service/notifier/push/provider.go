@@ -0,0 +1,32 @@
+// Package push sends notifications to phones and browsers through push
+// providers such as Firebase Cloud Messaging and the Apple Push Notification
+// service.
+package push
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidToken is returned by providers for device tokens that will never
+// work again, e.g. after the app was uninstalled. Such devices are removed.
+var ErrInvalidToken = errors.New("invalid device token")
+
+// Message is a rendered notification for one device
+type Message struct {
+	Token string
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// Device is a registered push token and the provider that issued it
+type Device struct {
+	Token    string
+	Provider string
+}
+
+// Provider delivers messages to the devices of one push service
+type Provider interface {
+	Send(ctx context.Context, message *Message) error
+}
@@ -0,0 +1,155 @@
+package push
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTemplatesFallBackToDefault(t *testing.T) {
+	templates, err := NewTemplates(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	title, body, err := templates.Render("unknown", &TemplateData{Title: "Vitamin D", Message: "400 IU"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if title != "Vitamin D" || body != "400 IU" {
+		t.Errorf("unexpected rendering: %q %q", title, body)
+	}
+
+	if _, err := NewTemplates(map[string]TemplateSource{"broken": {Title: "{{.Title"}}); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+}
+
+func TestFCMProviderSend(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tokenRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			tokenRequests++
+			w.Write([]byte(`{"access_token":"access","expires_in":3600}`))
+		case r.Header.Get("Authorization") != "Bearer access":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path != "/v1/projects/dailyalu/messages:send":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			switch {
+			case strings.Contains(string(body), `"token":"stale"`):
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":{"code":404,"status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`))
+				return
+			case strings.Contains(string(body), `"token":"malformed"`):
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":{"code":400,"message":"The registration token is not a valid FCM registration token","status":"INVALID_ARGUMENT","details":[{"errorCode":"INVALID_ARGUMENT"}]}}`))
+				return
+			case strings.Contains(string(body), `"title":"oversized"`):
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":{"code":400,"message":"Message is too big","status":"INVALID_ARGUMENT","details":[{"errorCode":"INVALID_ARGUMENT"}]}}`))
+				return
+			case strings.Contains(string(body), `"token":"moved"`):
+				// e.g. a misconfigured project, which says nothing about the token
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":{"code":404,"message":"Requested entity was not found.","status":"NOT_FOUND"}}`))
+				return
+			}
+			w.Write([]byte(`{"name":"projects/dailyalu/messages/1"}`))
+		}
+	}))
+	defer server.Close()
+
+	p := &FCMProvider{
+		projectID:   "dailyalu",
+		clientEmail: "push@dailyalu.iam.gserviceaccount.com",
+		privateKey:  key,
+		tokenURI:    server.URL + "/token",
+		endpoint:    server.URL,
+		client:      server.Client(),
+	}
+
+	if err := p.Send(context.Background(), &Message{Token: "phone", Title: "Vitamin D"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, token := range []string{"stale", "malformed"} {
+		if err := p.Send(context.Background(), &Message{Token: token}); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("expected ErrInvalidToken for %s, got %v", token, err)
+		}
+	}
+	for _, message := range []*Message{{Token: "moved"}, {Token: "phone", Title: "oversized"}} {
+		if err := p.Send(context.Background(), message); err == nil || errors.Is(err, ErrInvalidToken) {
+			t.Errorf("expected an ordinary error for %+v, got %v", message, err)
+		}
+	}
+	if tokenRequests != 1 {
+		t.Errorf("expected the access token to be cached, got %d token requests", tokenRequests)
+	}
+}
+
+func TestAPNsProviderSend(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("apns-topic") != "com.example.dailyalu" || !strings.HasPrefix(r.Header.Get("Authorization"), "bearer ") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/3/device/phone":
+			w.WriteHeader(http.StatusOK)
+		case "/3/device/uninstalled":
+			w.WriteHeader(http.StatusGone)
+			w.Write([]byte(`{"reason":"Unregistered"}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"reason":"ServiceUnavailable"}`))
+		}
+	}))
+	defer server.Close()
+
+	p := &APNsProvider{
+		key:      key,
+		keyID:    "KEY123",
+		teamID:   "TEAM123",
+		topic:    "com.example.dailyalu",
+		endpoint: server.URL,
+		client:   server.Client(),
+	}
+
+	testCases := []struct {
+		token    string
+		expected error
+	}{
+		{"phone", nil},
+		{"uninstalled", ErrInvalidToken},
+	}
+	for _, tc := range testCases {
+		if err := p.Send(context.Background(), &Message{Token: tc.token, Title: "Vitamin D"}); !errors.Is(err, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.token, tc.expected, err)
+		}
+	}
+
+	// Temporary failures are retried instead of removing the device
+	if err := p.Send(context.Background(), &Message{Token: "busy"}); err == nil || errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected a temporary error, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package push
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// Provider names, matching the provider a device token was registered for
+const (
+	ProviderFCM  = "fcm"
+	ProviderAPNs = "apns"
+)
+
+// Sender renders push messages and routes them to the provider of each
+// device
+type Sender struct {
+	providers map[string]Provider
+	templates *Templates
+}
+
+// NewSender creates a sender for the given providers
+func NewSender(providers map[string]Provider, templates *Templates) *Sender {
+	return &Sender{
+		providers: providers,
+		templates: templates,
+	}
+}
+
+// NewSenderFromConfig creates the sender configured under notifier.push. With
+// notifier.push.fake set, every provider only records messages.
+func NewSenderFromConfig() (*Sender, error) {
+	overrides := map[string]TemplateSource{}
+	for name := range viper.GetStringMap("notifier.push.templates") {
+		key := "notifier.push.templates." + name
+		overrides[name] = TemplateSource{
+			Title: viper.GetString(key + ".title"),
+			Body:  viper.GetString(key + ".body"),
+		}
+	}
+
+	templates, err := NewTemplates(overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := map[string]Provider{}
+	if viper.GetBool("notifier.push.fake") {
+		fake := NewFakeProvider()
+		providers[ProviderFCM] = fake
+		providers[ProviderAPNs] = fake
+		return NewSender(providers, templates), nil
+	}
+
+	timeout := viper.GetDuration("notifier.push.timeout")
+
+	if viper.GetBool("notifier.push.fcm.enabled") {
+		fcm, err := NewFCMProvider(
+			viper.GetString("notifier.push.fcm.credentials_file"),
+			viper.GetString("notifier.push.fcm.project_id"),
+			timeout,
+		)
+		if err != nil {
+			return nil, err
+		}
+		providers[ProviderFCM] = fcm
+	}
+
+	if viper.GetBool("notifier.push.apns.enabled") {
+		apns, err := NewAPNsProvider(APNsConfig{
+			KeyFile:    viper.GetString("notifier.push.apns.key_file"),
+			KeyID:      viper.GetString("notifier.push.apns.key_id"),
+			TeamID:     viper.GetString("notifier.push.apns.team_id"),
+			Topic:      viper.GetString("notifier.push.apns.topic"),
+			Production: viper.GetBool("notifier.push.apns.production"),
+			Timeout:    timeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+		providers[ProviderAPNs] = apns
+	}
+
+	return NewSender(providers, templates), nil
+}
+
+// Enabled reports whether any provider is configured
+func (s *Sender) Enabled() bool {
+	return len(s.providers) > 0
+}
+
+// Render returns the title and body of a message from a template
+func (s *Sender) Render(name string, data *TemplateData) (string, string, error) {
+	return s.templates.Render(name, data)
+}
+
+// Send delivers a message through the named provider
+func (s *Sender) Send(ctx context.Context, provider string, message *Message) error {
+	p, ok := s.providers[provider]
+	if !ok {
+		return fmt.Errorf("push provider %s is not configured", provider)
+	}
+	return p.Send(ctx, message)
+}
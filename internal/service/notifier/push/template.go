@@ -0,0 +1,93 @@
+package push
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// Template names. Reminders are the only notifications sent by push.
+const (
+	TemplateDefault  = "default"
+	TemplateReminder = "reminder"
+)
+
+// TemplateSource is the text of a template before parsing
+type TemplateSource struct {
+	Title string
+	Body  string
+}
+
+// TemplateData is what templates are rendered with
+type TemplateData struct {
+	Name    string
+	Title   string
+	Message string
+	Data    map[string]string
+}
+
+// builtinTemplates are used unless configuration overrides them
+var builtinTemplates = map[string]TemplateSource{
+	TemplateDefault: {
+		Title: "{{.Title}}",
+		Body:  "{{.Message}}",
+	},
+	TemplateReminder: {
+		Title: "{{.Title}}",
+		Body:  "{{if .Message}}{{.Message}}{{else}}Reminder from Daily Alu{{end}}",
+	},
+}
+
+type parsedTemplate struct {
+	title, body *template.Template
+}
+
+// Templates renders the title and body of push messages by template name.
+// Unknown names fall back to the default template.
+type Templates struct {
+	templates map[string]parsedTemplate
+}
+
+// NewTemplates parses the built-in templates merged with overrides
+func NewTemplates(overrides map[string]TemplateSource) (*Templates, error) {
+	sources := make(map[string]TemplateSource, len(builtinTemplates)+len(overrides))
+	for name, source := range builtinTemplates {
+		sources[name] = source
+	}
+	for name, source := range overrides {
+		sources[name] = source
+	}
+
+	t := &Templates{templates: make(map[string]parsedTemplate, len(sources))}
+	for name, source := range sources {
+		title, err := template.New(name + ".title").Option("missingkey=zero").Parse(source.Title)
+		if err != nil {
+			return nil, fmt.Errorf("invalid push template %s: %w", name, err)
+		}
+		body, err := template.New(name + ".body").Option("missingkey=zero").Parse(source.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid push template %s: %w", name, err)
+		}
+		t.templates[name] = parsedTemplate{title: title, body: body}
+	}
+
+	return t, nil
+}
+
+// Render returns the title and body of a message
+func (t *Templates) Render(name string, data *TemplateData) (string, string, error) {
+	tmpl, ok := t.templates[name]
+	if !ok {
+		tmpl = t.templates[TemplateDefault]
+	}
+
+	var title, body bytes.Buffer
+	if err := tmpl.title.Execute(&title, data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return "", "", err
+	}
+
+	return title.String(), body.String(), nil
+}
@@ -0,0 +1,106 @@
+package notifier
+
+import (
+	"context"
+	"dailyalu-server/internal/service/notifier/domain"
+	"dailyalu-server/internal/service/notifier/push"
+	"dailyalu-server/pkg/app_log/zap_log"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DeviceLister returns the push devices registered by a user
+type DeviceLister func(userID string) ([]push.Device, error)
+
+// DeviceRemover unregisters the device of a token the provider rejected
+type DeviceRemover func(token string) error
+
+// PushNotifier delivers notifications to every device of the recipient
+type PushNotifier struct {
+	sender       *push.Sender
+	listDevices  DeviceLister
+	removeDevice DeviceRemover
+}
+
+// NewPushNotifier creates a notifier sending through the push sender
+func NewPushNotifier(sender *push.Sender, listDevices DeviceLister, removeDevice DeviceRemover) domain.INotifier {
+	return &PushNotifier{
+		sender:       sender,
+		listDevices:  listDevices,
+		removeDevice: removeDevice,
+	}
+}
+
+// Notify renders the notification's template and sends it to each device.
+// Devices whose token the provider rejects are removed. The delivery fails
+// only if no device received it.
+func (n *PushNotifier) Notify(ctx context.Context, notification *domain.Notification) error {
+	devices, err := n.listDevices(notification.Recipient.UserID)
+	if err != nil {
+		return err
+	}
+	if len(devices) == 0 {
+		return errors.New("recipient has no registered devices")
+	}
+
+	data := stringData(notification.Data)
+
+	title, body, err := n.sender.Render(notification.Template, &push.TemplateData{
+		Name:    notification.Recipient.Name,
+		Title:   notification.Title,
+		Message: notification.Message,
+		Data:    data,
+	})
+	if err != nil {
+		return err
+	}
+
+	delivered := 0
+	var lastErr error
+	for _, device := range devices {
+		err := n.sender.Send(ctx, device.Provider, &push.Message{
+			Token: device.Token,
+			Title: title,
+			Body:  body,
+			Data:  data,
+		})
+		if err == nil {
+			delivered++
+			continue
+		}
+
+		lastErr = err
+		if errors.Is(err, push.ErrInvalidToken) {
+			if err := n.removeDevice(device.Token); err != nil && zap_log.Logger != nil {
+				zap_log.Logger.Error("Failed to remove device with invalid push token", zap.Error(err))
+			}
+		}
+	}
+
+	if delivered == 0 {
+		return fmt.Errorf("push delivery failed on all devices: %w", lastErr)
+	}
+
+	return nil
+}
+
+// stringData converts notification data to the string values push payloads
+// carry, with times in RFC 3339
+func stringData(data map[string]interface{}) map[string]string {
+	values := make(map[string]string, len(data))
+	for key, value := range data {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			values[key] = v
+		case time.Time:
+			values[key] = v.Format(time.RFC3339)
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,66 @@
+package notifier
+
+import (
+	"context"
+	"dailyalu-server/internal/service/notifier/domain"
+	"dailyalu-server/internal/service/notifier/push"
+	"testing"
+	"time"
+)
+
+func TestPushNotifierRemovesInvalidTokens(t *testing.T) {
+	fake := push.NewFakeProvider()
+	fake.InvalidTokens["uninstalled"] = true
+
+	templates, err := push.NewTemplates(map[string]push.TemplateSource{
+		push.TemplateReminder: {Title: "{{.Title}}", Body: "Hi {{.Name}}, reminder {{.Data.reminder_id}} at {{.Data.scheduled_at}}"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sender := push.NewSender(map[string]push.Provider{push.ProviderFCM: fake, push.ProviderAPNs: fake}, templates)
+
+	var removed []string
+	n := NewPushNotifier(sender, func(userID string) ([]push.Device, error) {
+		return []push.Device{
+			{Token: "phone", Provider: push.ProviderFCM},
+			{Token: "uninstalled", Provider: push.ProviderAPNs},
+		}, nil
+	}, func(token string) error {
+		removed = append(removed, token)
+		return nil
+	})
+
+	err = n.Notify(context.Background(), &domain.Notification{
+		Recipient: domain.Recipient{UserID: "user-1", Name: "Ani"},
+		Title:     "Vitamin D",
+		Template:  push.TemplateReminder,
+		Data: map[string]interface{}{
+			"reminder_id":  int64(7),
+			"scheduled_at": time.Date(2025, 3, 21, 1, 0, 0, 0, time.UTC),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sent := fake.Sent()
+	if len(sent) != 1 || sent[0].Token != "phone" {
+		t.Fatalf("expected one message to the phone, got %+v", sent)
+	}
+	if sent[0].Title != "Vitamin D" || sent[0].Body != "Hi Ani, reminder 7 at 2025-03-21T01:00:00Z" {
+		t.Errorf("unexpected message: %+v", sent[0])
+	}
+	if sent[0].Data["reminder_id"] != "7" {
+		t.Errorf("expected string data, got %v", sent[0].Data)
+	}
+	if len(removed) != 1 || removed[0] != "uninstalled" {
+		t.Errorf("expected the invalid token to be removed, got %v", removed)
+	}
+
+	// Without a working device the delivery fails so it is retried
+	fake.InvalidTokens["phone"] = true
+	if err := n.Notify(context.Background(), &domain.Notification{Recipient: domain.Recipient{UserID: "user-1"}}); err == nil {
+		t.Error("expected an error when no device received the notification")
+	}
+}
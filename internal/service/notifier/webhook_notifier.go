@@ -0,0 +1,88 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"dailyalu-server/internal/security/outbound"
+	"dailyalu-server/internal/service/notifier/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// WebhookNotifier delivers notifications as JSON POST requests, signed like
+// webhook subscription deliveries
+type WebhookNotifier struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// webhookPayload is the body posted to webhook targets
+type webhookPayload struct {
+	UserID  string                 `json:"user_id"`
+	Title   string                 `json:"title"`
+	Message string                 `json:"message"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+	SentAt  time.Time              `json:"sent_at"`
+}
+
+// NewWebhookNotifier creates a webhook notifier whose requests time out
+// after the given duration. Requests only connect to addresses urlPolicy
+// allows, and redirects are not followed.
+func NewWebhookNotifier(timeout time.Duration, urlPolicy outbound.Policy) domain.INotifier {
+	return &WebhookNotifier{
+		client: urlPolicy.NewClient(timeout),
+		now:    time.Now,
+	}
+}
+
+// NewWebhookNotifierFromConfig creates the webhook notifier configured under
+// notifier.webhook
+func NewWebhookNotifierFromConfig(urlPolicy outbound.Policy) domain.INotifier {
+	return NewWebhookNotifier(viper.GetDuration("notifier.webhook.timeout"), urlPolicy)
+}
+
+// Notify posts the notification to its webhook URL. Any response other than
+// 2xx is a failed delivery.
+func (n *WebhookNotifier) Notify(ctx context.Context, notification *domain.Notification) error {
+	if notification.WebhookURL == "" {
+		return errors.New("notification has no webhook URL")
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		UserID:  notification.Recipient.UserID,
+		Title:   notification.Title,
+		Message: notification.Message,
+		Data:    notification.Data,
+		SentAt:  n.now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, notification.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "DailyAlu-Notifier/1.0")
+	if notification.WebhookSecret != "" {
+		outbound.SignRequest(req, notification.WebhookSecret, n.now().Unix(), body)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
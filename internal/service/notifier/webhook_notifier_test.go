@@ -0,0 +1,68 @@
+package notifier
+
+import (
+	"context"
+	"dailyalu-server/internal/security/outbound"
+	"dailyalu-server/internal/service/notifier/domain"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotifierSignsRequests(t *testing.T) {
+	var header http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	now := time.Date(2025, 3, 21, 1, 0, 0, 0, time.UTC)
+	n := NewWebhookNotifier(time.Second, outbound.Policy{AllowHTTP: true, AllowPrivateNetworks: true}).(*WebhookNotifier)
+	n.now = func() time.Time { return now }
+
+	err := n.Notify(context.Background(), &domain.Notification{
+		Recipient:     domain.Recipient{UserID: "user-1"},
+		Title:         "Vitamin D",
+		WebhookURL:    server.URL,
+		WebhookSecret: "whsec_test",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := header.Get(outbound.HeaderTimestamp); got != strconv.FormatInt(now.Unix(), 10) {
+		t.Errorf("unexpected timestamp %q", got)
+	}
+	if got, want := header.Get(outbound.HeaderSignature), outbound.Sign("whsec_test", now.Unix(), body); got != want {
+		t.Errorf("expected signature %q, got %q", want, got)
+	}
+}
+
+func TestWebhookNotifierStaysOffPrivateNetworks(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := NewWebhookNotifier(time.Second, outbound.Policy{AllowHTTP: true})
+	err := n.Notify(context.Background(), &domain.Notification{
+		Recipient:  domain.Recipient{UserID: "user-1"},
+		Title:      "Vitamin D",
+		WebhookURL: server.URL,
+	})
+	if !errors.Is(err, outbound.ErrForbiddenAddress) {
+		t.Errorf("expected %v, got %v", outbound.ErrForbiddenAddress, err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no request to reach the server, got %d", requests)
+	}
+}
@@ -0,0 +1,30 @@
+package realtime
+
+import (
+	"dailyalu-server/internal/service/realtime/domain"
+	"database/sql"
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// Broker kinds
+const (
+	BrokerMemory   = "memory"
+	BrokerPostgres = "postgres"
+)
+
+// NewBrokerFromConfig creates the broker configured under realtime. The
+// postgres broker needs the DSN of the database to listen on.
+func NewBrokerFromConfig(db *sql.DB, dsn string) (domain.IBroker, error) {
+	buffer := viper.GetInt("realtime.buffer")
+
+	switch kind := viper.GetString("realtime.broker"); kind {
+	case "", BrokerMemory:
+		return NewMemoryBroker(buffer), nil
+	case BrokerPostgres:
+		return NewPostgresBroker(db, dsn, viper.GetString("realtime.channel"), buffer)
+	default:
+		return nil, fmt.Errorf("unknown realtime broker %q", kind)
+	}
+}
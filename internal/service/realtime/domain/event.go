@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Event is a change pushed to connected clients. Events are scoped to a child
+// and only delivered to users who can access that child.
+type Event struct {
+	Type       string          `json:"type"`
+	ChildID    int64           `json:"child_id"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// Subscription receives the events published after it was created. The
+// channel is closed when the subscription is closed, or when the subscriber
+// falls too far behind; clients should then reconnect and refetch.
+type Subscription interface {
+	Events() <-chan *Event
+	Close()
+}
+
+// IBroker fans events out to subscribers, within one server instance or
+// across all of them
+type IBroker interface {
+	Publish(ctx context.Context, event *Event) error
+	Subscribe() Subscription
+	Close() error
+}
@@ -0,0 +1,98 @@
+// Package realtime delivers live events, such as logged activities, to
+// connected clients.
+package realtime
+
+import (
+	"context"
+	"dailyalu-server/internal/service/realtime/domain"
+	"sync"
+)
+
+// MemoryBroker is an in-process broker. Events only reach subscribers of the
+// same server instance.
+type MemoryBroker struct {
+	mu          sync.Mutex
+	subscribers map[*subscription]struct{}
+	buffer      int
+}
+
+// NewMemoryBroker creates a broker buffering up to buffer events per
+// subscriber
+func NewMemoryBroker(buffer int) *MemoryBroker {
+	if buffer < 1 {
+		buffer = 1
+	}
+	return &MemoryBroker{
+		subscribers: map[*subscription]struct{}{},
+		buffer:      buffer,
+	}
+}
+
+type subscription struct {
+	broker *MemoryBroker
+	events chan *domain.Event
+}
+
+// Events returns the channel events are received on
+func (s *subscription) Events() <-chan *domain.Event {
+	return s.events
+}
+
+// Close stops the subscription
+func (s *subscription) Close() {
+	s.broker.remove(s)
+}
+
+// Publish delivers the event to every subscriber without blocking. A
+// subscriber whose buffer is full is dropped rather than slowing down the
+// publisher.
+func (b *MemoryBroker) Publish(ctx context.Context, event *domain.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			delete(b.subscribers, sub)
+			close(sub.events)
+		}
+	}
+	return nil
+}
+
+// Subscribe registers a new subscriber
+func (b *MemoryBroker) Subscribe() domain.Subscription {
+	sub := &subscription{
+		broker: b,
+		events: make(chan *domain.Event, b.buffer),
+	}
+
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub
+}
+
+// Close drops all subscribers
+func (b *MemoryBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		delete(b.subscribers, sub)
+		close(sub.events)
+	}
+	return nil
+}
+
+func (b *MemoryBroker) remove(sub *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.events)
+	}
+}
@@ -0,0 +1,53 @@
+package realtime
+
+import (
+	"context"
+	"dailyalu-server/internal/service/realtime/domain"
+	"testing"
+)
+
+func TestMemoryBrokerFanOut(t *testing.T) {
+	broker := NewMemoryBroker(2)
+	first := broker.Subscribe()
+	second := broker.Subscribe()
+
+	event := &domain.Event{Type: "activity.created", ChildID: 1}
+	if err := broker.Publish(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, sub := range []domain.Subscription{first, second} {
+		if received := <-sub.Events(); received != event {
+			t.Errorf("expected the published event, got %+v", received)
+		}
+	}
+
+	// Closed subscriptions stop receiving and can be closed again safely
+	second.Close()
+	second.Close()
+	broker.Publish(context.Background(), event)
+	if _, ok := <-second.Events(); ok {
+		t.Error("expected the closed subscription's channel to be closed")
+	}
+	if received := <-first.Events(); received != event {
+		t.Errorf("expected the published event, got %+v", received)
+	}
+}
+
+func TestMemoryBrokerDropsSlowSubscribers(t *testing.T) {
+	broker := NewMemoryBroker(1)
+	slow := broker.Subscribe()
+
+	for i := 0; i < 2; i++ {
+		broker.Publish(context.Background(), &domain.Event{Type: "activity.updated", ChildID: 1})
+	}
+
+	// The buffered event is still delivered before the channel closes
+	if _, ok := <-slow.Events(); !ok {
+		t.Fatal("expected the buffered event")
+	}
+	if _, ok := <-slow.Events(); ok {
+		t.Error("expected the slow subscriber to be dropped")
+	}
+	slow.Close()
+}
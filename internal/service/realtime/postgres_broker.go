@@ -0,0 +1,119 @@
+package realtime
+
+import (
+	"context"
+	"dailyalu-server/internal/service/realtime/domain"
+	"dailyalu-server/pkg/app_log/zap_log"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// maxNotifyPayload stays below the 8000 byte limit of NOTIFY payloads
+const maxNotifyPayload = 7900
+
+// PostgresBroker publishes events with NOTIFY and receives them with LISTEN,
+// so clients connected to any server instance get every event. Events
+// published while an instance is reconnecting to the database are missed by
+// its clients.
+type PostgresBroker struct {
+	db       *sql.DB
+	listener *pq.Listener
+	channel  string
+	local    *MemoryBroker
+	done     chan struct{}
+}
+
+// NewPostgresBroker listens on the channel using a dedicated connection to
+// dsn and publishes through db
+func NewPostgresBroker(db *sql.DB, dsn, channel string, buffer int) (*PostgresBroker, error) {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil && zap_log.Logger != nil {
+			zap_log.Logger.Warn("Realtime listener connection problem", zap.Error(err))
+		}
+	})
+	if err := listener.Listen(channel); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to listen on %s: %w", channel, err)
+	}
+
+	b := &PostgresBroker{
+		db:       db,
+		listener: listener,
+		channel:  channel,
+		local:    NewMemoryBroker(buffer),
+		done:     make(chan struct{}),
+	}
+	go b.receive()
+
+	return b, nil
+}
+
+// Publish notifies all instances of the event. The data of events too large
+// for a notification is left out; clients refetch it.
+func (b *PostgresBroker) Publish(ctx context.Context, event *domain.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxNotifyPayload {
+		trimmed := *event
+		trimmed.Data = nil
+		if payload, err = json.Marshal(&trimmed); err != nil {
+			return err
+		}
+	}
+
+	_, err = b.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, b.channel, string(payload))
+	return err
+}
+
+// Subscribe registers a subscriber on this instance
+func (b *PostgresBroker) Subscribe() domain.Subscription {
+	return b.local.Subscribe()
+}
+
+// Close stops listening and drops all subscribers
+func (b *PostgresBroker) Close() error {
+	close(b.done)
+	err := b.listener.Close()
+	b.local.Close()
+	return err
+}
+
+// receive forwards notifications to the local subscribers, pinging the
+// connection while idle so a dead connection is noticed and re-established
+func (b *PostgresBroker) receive() {
+	ping := time.NewTicker(90 * time.Second)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-b.done:
+			return
+		case notification, ok := <-b.listener.Notify:
+			if !ok {
+				return
+			}
+			// A nil notification signals a reconnect
+			if notification == nil {
+				continue
+			}
+
+			var event domain.Event
+			if err := json.Unmarshal([]byte(notification.Extra), &event); err != nil {
+				if zap_log.Logger != nil {
+					zap_log.Logger.Warn("Ignoring malformed realtime event", zap.Error(err))
+				}
+				continue
+			}
+			b.local.Publish(context.Background(), &event)
+		case <-ping.C:
+			go b.listener.Ping()
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrObjectNotFound is returned when reading an object that does not exist
+var ErrObjectNotFound = errors.New("stored object not found")
+
+// IStorage keeps uploaded files by key. Keys are slash separated paths such
+// as "children/1/3f2a.jpg".
+type IStorage interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+	// Get opens an object; the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes an object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a URL the object can be downloaded from without
+	// other credentials until expires
+	SignedURL(ctx context.Context, key string, expires time.Time) (string, error)
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"dailyalu-server/internal/service/storage/domain"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// LocalFilesPath is the route local files are downloaded from, followed by
+// their key
+const LocalFilesPath = "/v1/files/"
+
+// Errors about signed local file URLs
+var (
+	ErrInvalidSignature = errors.New("invalid file signature")
+	ErrURLExpired       = errors.New("file URL has expired")
+)
+
+// LocalStorage keeps files in a directory of the server. Its signed URLs
+// point to the server itself, which checks them with Verify before serving
+// the file.
+type LocalStorage struct {
+	directory string
+	baseURL   string
+	secret    []byte
+}
+
+// NewLocalStorage creates a storage writing under directory. Signed URLs
+// are baseURL followed by the key, signed with secret.
+func NewLocalStorage(directory, baseURL string, secret []byte) (*LocalStorage, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("local storage needs a signing secret")
+	}
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &LocalStorage{directory: directory, baseURL: baseURL, secret: secret}, nil
+}
+
+// Put writes the object to a temporary file first, so readers never see a
+// partial file
+func (s *LocalStorage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get opens the file of an object
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domain.ErrObjectNotFound
+	}
+	return file, err
+}
+
+// Delete removes the file of an object
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// SignedURL returns the server URL of an object with its expiry and an
+// HMAC of both
+func (s *LocalStorage) SignedURL(ctx context.Context, key string, expires time.Time) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+	query.Set("signature", s.sign(key, expires.Unix()))
+	return s.baseURL + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode(), nil
+}
+
+// Verify checks the expiry and signature given with a signed URL
+func (s *LocalStorage) Verify(key, expires, signature string, now time.Time) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, unix))) {
+		return ErrInvalidSignature
+	}
+	if now.Unix() > unix {
+		return ErrURLExpired
+	}
+	return nil
+}
+
+func (s *LocalStorage) sign(key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.directory, filepath.FromSlash(key)), nil
+}
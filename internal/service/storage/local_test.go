@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"dailyalu-server/internal/service/storage/domain"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStorage(t.TempDir(), "https://api.dailyalu.mom/v1/files/", []byte("secret"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := store.Put(ctx, "children/1/photo.jpg", []byte("jpeg"), "image/jpeg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	file, err := store.Get(ctx, "children/1/photo.jpg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, _ := io.ReadAll(file)
+	file.Close()
+	if string(content) != "jpeg" {
+		t.Errorf("expected the stored content, got %q", content)
+	}
+
+	if err := store.Delete(ctx, "children/1/photo.jpg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Get(ctx, "children/1/photo.jpg"); !errors.Is(err, domain.ErrObjectNotFound) {
+		t.Errorf("expected ErrObjectNotFound after deleting, got %v", err)
+	}
+	if err := store.Delete(ctx, "children/1/photo.jpg"); err != nil {
+		t.Errorf("expected deleting a missing object to succeed, got %v", err)
+	}
+
+	for _, key := range []string{"", "/etc/passwd", "children/../../secret", "children//1", `children\1`} {
+		if err := store.Put(ctx, key, []byte("x"), "text/plain"); err == nil {
+			t.Errorf("expected key %q to be rejected", key)
+		}
+	}
+}
+
+func TestLocalStorageSignedURL(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir(), "https://api.dailyalu.mom/v1/files/", []byte("secret"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now := time.Date(2025, 3, 20, 8, 0, 0, 0, time.UTC)
+	signed, err := store.SignedURL(context.Background(), "children/1/photo.jpg", now.Add(15*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(signed, "https://api.dailyalu.mom/v1/files/children/1/photo.jpg?") {
+		t.Fatalf("unexpected URL %s", signed)
+	}
+
+	parsed, _ := url.Parse(signed)
+	expires, signature := parsed.Query().Get("expires"), parsed.Query().Get("signature")
+
+	if err := store.Verify("children/1/photo.jpg", expires, signature, now); err != nil {
+		t.Errorf("expected the URL to be valid, got %v", err)
+	}
+	if err := store.Verify("children/1/photo.jpg", expires, signature, now.Add(16*time.Minute)); !errors.Is(err, ErrURLExpired) {
+		t.Errorf("expected ErrURLExpired, got %v", err)
+	}
+	if err := store.Verify("children/2/photo.jpg", expires, signature, now); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected the signature to be bound to the key, got %v", err)
+	}
+	if err := store.Verify("children/1/photo.jpg", "9"+expires, signature, now); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected the signature to be bound to the expiry, got %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"dailyalu-server/internal/service/storage/domain"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/spf13/viper"
+)
+
+// maxPresignExpiry is the longest validity S3 accepts for a presigned URL
+const maxPresignExpiry = 7 * 24 * time.Hour
+
+// S3Config locates the bucket of an S3Storage
+type S3Config struct {
+	// Endpoint is the base URL of an S3-compatible service such as MinIO.
+	// Empty means Amazon S3 in Region.
+	Endpoint string
+	Region   string
+	Bucket   string
+	// PathStyle addresses the bucket in the path instead of the host name,
+	// as most S3-compatible services require
+	PathStyle bool
+}
+
+// S3Storage keeps files in an S3 bucket through the S3 REST API, signing
+// requests with Signature Version 4. Any S3-compatible service works.
+type S3Storage struct {
+	config      S3Config
+	credentials aws.CredentialsProvider
+	client      *http.Client
+	signer      *v4.Signer
+	now         func() time.Time
+}
+
+// NewS3Storage creates a storage for the bucket
+func NewS3Storage(cfg S3Config, credentials aws.CredentialsProvider, client *http.Client) *S3Storage {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &S3Storage{
+		config:      cfg,
+		credentials: credentials,
+		client:      client,
+		signer: v4.NewSigner(func(o *v4.SignerOptions) {
+			// S3 signs the path as sent, without escaping it again
+			o.DisableURIPathEscaping = true
+		}),
+		now: time.Now,
+	}
+}
+
+// NewS3StorageFromConfig creates a storage for aws.s3.bucket. Without
+// aws.s3.access_key, credentials come from the default chain, e.g. the
+// environment or an instance role.
+func NewS3StorageFromConfig(ctx context.Context) (*S3Storage, error) {
+	options := []func(*config.LoadOptions) error{
+		config.WithRegion(viper.GetString("aws.region")),
+	}
+	if accessKey := viper.GetString("aws.s3.access_key"); accessKey != "" {
+		options = append(options, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(accessKey, viper.GetString("aws.s3.access_secret_key"), ""),
+		))
+	}
+
+	defaultConfig, err := config.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	cfg := S3Config{
+		Endpoint:  viper.GetString("aws.s3.endpoint"),
+		Region:    defaultConfig.Region,
+		Bucket:    viper.GetString("aws.s3.bucket"),
+		PathStyle: viper.GetBool("aws.s3.use_path_style"),
+	}
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("aws.s3.bucket is required by the s3 storage driver")
+	}
+
+	return NewS3Storage(cfg, defaultConfig.Credentials, &http.Client{Timeout: viper.GetDuration("storage.timeout")}), nil
+}
+
+// Put uploads an object
+func (s *S3Storage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	req, err := s.request(ctx, http.MethodPut, key, content)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req, content)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Get downloads an object
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete removes an object. S3 reports success for missing objects too.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req, nil)
+	if err == domain.ErrObjectNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// SignedURL presigns a GET request for the object. S3 checks the signature
+// and expiry itself.
+func (s *S3Storage) SignedURL(ctx context.Context, key string, expires time.Time) (string, error) {
+	req, err := s.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return "", err
+	}
+
+	now := s.now()
+	validity := expires.Sub(now).Round(time.Second)
+	if validity <= 0 || validity > maxPresignExpiry {
+		return "", fmt.Errorf("presigned URLs must expire within %s", maxPresignExpiry)
+	}
+	query := req.URL.Query()
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(validity/time.Second), 10))
+	req.URL.RawQuery = query.Encode()
+
+	creds, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	signed, _, err := s.signer.PresignHTTP(ctx, creds, req, "UNSIGNED-PAYLOAD", "s3", s.config.Region, now)
+	return signed, err
+}
+
+// request builds an unsigned request for an object
+func (s *S3Storage) request(ctx context.Context, method, key string, content []byte) (*http.Request, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+
+	endpoint := s.config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", s.config.Region)
+	}
+	base, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+	}
+
+	if s.config.PathStyle {
+		base.Path += "/" + s.config.Bucket
+	} else {
+		base.Host = s.config.Bucket + "." + base.Host
+	}
+	base.Path += "/" + key
+
+	var body io.Reader
+	if content != nil {
+		body = bytes.NewReader(content)
+	}
+	return http.NewRequestWithContext(ctx, method, base.String(), body)
+}
+
+// do signs and sends a request. Responses other than 2xx are returned as
+// errors, with their body closed.
+func (s *S3Storage) do(req *http.Request, content []byte) (*http.Response, error) {
+	creds, err := s.credentials.Retrieve(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	sum := sha256.Sum256(content)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if err := s.signer.SignHTTP(req.Context(), creds, req, payloadHash, "s3", s.config.Region, s.now()); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, domain.ErrObjectNotFound
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, bytes.TrimSpace(message))
+}
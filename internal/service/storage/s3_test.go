@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"dailyalu-server/internal/service/storage/domain"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+// fakeS3 is a local stand-in for an S3-compatible service with path-style
+// addressing. It checks that requests are signed for the access key, and
+// that presigned URLs have not expired.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+	now     time.Time
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	query := r.URL.Query()
+	if query.Get("X-Amz-Signature") != "" {
+		signedAt, _ := time.Parse("20060102T150405Z", query.Get("X-Amz-Date"))
+		seconds, _ := strconv.Atoi(query.Get("X-Amz-Expires"))
+		if !strings.HasPrefix(query.Get("X-Amz-Credential"), "AKID/") || f.now.After(signedAt.Add(time.Duration(seconds)*time.Second)) {
+			http.Error(w, "AccessDenied", http.StatusForbidden)
+			return
+		}
+	} else if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/photos/")
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(body)
+		if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+			http.Error(w, "XAmzContentSHA256Mismatch", http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = body
+		f.types[key] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		body, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", f.types[key])
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	now := time.Now().UTC()
+	fake := &fakeS3{objects: map[string][]byte{}, types: map[string]string{}, now: now}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store := NewS3Storage(
+		S3Config{Endpoint: server.URL, Region: "ap-southeast-1", Bucket: "photos", PathStyle: true},
+		credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		server.Client(),
+	)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "children/1/photo.jpg", []byte("jpeg"), "image/jpeg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fake.types["children/1/photo.jpg"] != "image/jpeg" {
+		t.Errorf("expected the content type to be stored, got %q", fake.types["children/1/photo.jpg"])
+	}
+
+	body, err := store.Get(ctx, "children/1/photo.jpg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, _ := io.ReadAll(body)
+	body.Close()
+	if string(content) != "jpeg" {
+		t.Errorf("expected the stored content, got %q", content)
+	}
+
+	// Presigned URLs work without credentials until they expire
+	signed, err := store.SignedURL(ctx, "children/1/photo.jpg", now.Add(15*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := http.Get(signed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the presigned URL to work, got %s", resp.Status)
+	}
+
+	fake.now = now.Add(16 * time.Minute)
+	resp, err = http.Get(signed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected the presigned URL to expire, got %s", resp.Status)
+	}
+
+	if _, err := store.SignedURL(ctx, "children/1/photo.jpg", now.Add(8*24*time.Hour)); err == nil {
+		t.Error("expected presigned URLs valid for over a week to be refused")
+	}
+
+	if err := store.Delete(ctx, "children/1/photo.jpg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Get(ctx, "children/1/photo.jpg"); !errors.Is(err, domain.ErrObjectNotFound) {
+		t.Errorf("expected ErrObjectNotFound after deleting, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package storage keeps uploaded files on the local disk or in an
+// S3-compatible bucket, selected by storage.driver. Both hand out signed
+// download URLs that stop working after a while.
+package storage
+
+import (
+	"context"
+	"dailyalu-server/internal/service/storage/domain"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Drivers selectable with storage.driver
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// NewStorageFromConfig creates the storage named by storage.driver
+func NewStorageFromConfig(ctx context.Context) (domain.IStorage, error) {
+	switch driver := viper.GetString("storage.driver"); driver {
+	case "", DriverLocal:
+		secret := viper.GetString("storage.signing_secret")
+		if secret == "" {
+			secret = viper.GetString("jwt.secret")
+		}
+		return NewLocalStorage(
+			viper.GetString("storage.local.directory"),
+			strings.TrimSuffix(viper.GetString("server.public_url"), "/")+LocalFilesPath,
+			[]byte(secret),
+		)
+	case DriverS3:
+		return NewS3StorageFromConfig(ctx)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", driver)
+	}
+}
+
+// validKey rejects keys that could escape the storage root
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid storage key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid storage key %q", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+// Package worker runs background jobs stored in the database. Every instance
+// of the server may run the same worker: jobs are claimed one at a time with
+// row locks and a lease that outlasts one attempt, so each job is handled by
+// one instance at a time, and again only if that instance stops before
+// recording the outcome (at-least-once).
+package worker
+
+import (
+	"context"
+	"dailyalu-server/pkg/app_log/zap_log"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// ErrPermanent marks errors that retrying cannot fix. A job failing with an
+// error wrapping it is given up at once.
+var ErrPermanent = errors.New("permanent failure")
+
+// ErrLeaseLost is returned when recording the outcome of a job whose lease
+// expired, and which another instance may have claimed since
+var ErrLeaseLost = errors.New("lease expired before the outcome was recorded")
+
+// Config controls polling and retries
+type Config struct {
+	// PollInterval is the time between two looks for due jobs
+	PollInterval time.Duration
+	// BatchSize is the maximum number of jobs handled in one round. Jobs are
+	// claimed one at a time, so each lease covers a single attempt.
+	BatchSize int
+	// Lease is how long a claimed job is hidden from other instances. It
+	// must be longer than Timeout.
+	Lease time.Duration
+	// MaxAttempts is the number of attempts before a job is given up
+	MaxAttempts int
+	// RetryBackoff is the delay before the first retry, doubled on each
+	// further attempt
+	RetryBackoff time.Duration
+	// Timeout limits each attempt; zero means no limit
+	Timeout time.Duration
+}
+
+// NewConfigFromConfig reads the settings under prefix, e.g. outbox
+func NewConfigFromConfig(prefix string) Config {
+	return Config{
+		PollInterval: viper.GetDuration(prefix + ".poll_interval"),
+		BatchSize:    viper.GetInt(prefix + ".batch_size"),
+		Lease:        viper.GetDuration(prefix + ".lease"),
+		MaxAttempts:  viper.GetInt(prefix + ".max_attempts"),
+		RetryBackoff: viper.GetDuration(prefix + ".retry_backoff"),
+		Timeout:      viper.GetDuration(prefix + ".timeout"),
+	}
+}
+
+// Validate refuses settings under which an attempt could outlive the lease of
+// its job, letting another instance claim it while it is still in progress
+func (c Config) Validate() error {
+	switch {
+	case c.PollInterval <= 0:
+		return errors.New("poll_interval must be positive")
+	case c.BatchSize <= 0:
+		return errors.New("batch_size must be positive")
+	case c.Timeout <= 0:
+		return errors.New("timeout must be positive")
+	case c.Lease <= c.Timeout:
+		return fmt.Errorf("lease (%s) must be longer than timeout (%s)", c.Lease, c.Timeout)
+	}
+	return nil
+}
+
+// CheckLease returns ErrLeaseLost when an update conditional on the lease of
+// a job, e.g. "WHERE id = $1 AND locked_until = $2", changed no row
+func CheckLease(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+// Attempt is the outcome of handling a claimed job
+type Attempt struct {
+	// Number counts the attempts made at the job, this one included
+	Number int
+	// StartedAt is when the attempt started
+	StartedAt time.Time
+	// Err is the error of a failed attempt
+	Err error
+	// RetryAt is when a failed job is due again. It is nil once the job is
+	// given up.
+	RetryAt *time.Time
+	// LeasedUntil is the lease the job was claimed with. The outcome should
+	// only be recorded while the job still holds it.
+	LeasedUntil time.Time
+}
+
+// GaveUp reports whether the job failed and is not retried
+func (a Attempt) GaveUp() bool {
+	return a.Err != nil && a.RetryAt == nil
+}
+
+// Jobs are the callbacks through which a runner works on jobs of type T
+type Jobs[T any] struct {
+	// Claim leases up to limit jobs due at now until leaseUntil. The runner
+	// claims one job at a time.
+	Claim func(now, leaseUntil time.Time, limit int) ([]T, error)
+	// Attempts returns the number of earlier attempts at a job. Jobs are
+	// treated as new when it is nil.
+	Attempts func(job *T) int
+	// Handle does the work of a job
+	Handle func(ctx context.Context, job *T) error
+	// Record stores the outcome of an attempt, which also releases the
+	// lease. Stores should be conditional on attempt.LeasedUntil.
+	Record func(job *T, attempt Attempt)
+}
+
+// Runner claims due jobs, handles them and records the outcome
+type Runner[T any] struct {
+	name   string
+	jobs   Jobs[T]
+	config Config
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+// NewRunner creates a runner. name describes the jobs in logs, e.g. "outbox
+// messages". now is called for the time of each claim and attempt; it
+// defaults to time.Now.
+func NewRunner[T any](name string, jobs Jobs[T], config Config, now func() time.Time) *Runner[T] {
+	logger := zap_log.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Runner[T]{
+		name:   name,
+		jobs:   jobs,
+		config: config,
+		logger: logger,
+		now:    now,
+	}
+}
+
+// Run handles due jobs until the context is cancelled
+func (r *Runner[T]) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the backlog before waiting for the next tick
+		for {
+			claimed, err := r.RunOnce(ctx)
+			if err != nil {
+				r.logger.Error("failed to claim due "+r.name, zap.Error(err))
+			}
+			if err != nil || claimed < r.config.BatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce handles up to one batch of due jobs, claiming each right before it
+// is attempted. It returns the number of jobs claimed.
+func (r *Runner[T]) RunOnce(ctx context.Context) (int, error) {
+	claimed := 0
+	for claimed < r.config.BatchSize && ctx.Err() == nil {
+		now := r.now()
+		// Postgres keeps microseconds; the lease is compared when recording
+		leaseUntil := now.Add(r.config.Lease).Truncate(time.Microsecond)
+
+		jobs, err := r.jobs.Claim(now, leaseUntil, 1)
+		if err != nil {
+			return claimed, err
+		}
+		if len(jobs) == 0 {
+			break
+		}
+
+		claimed++
+		r.process(ctx, &jobs[0], leaseUntil)
+	}
+
+	return claimed, nil
+}
+
+// process attempts a claimed job and records the outcome. An attempt cut
+// short by shutdown is not recorded: the job is claimed again once its lease
+// expires.
+func (r *Runner[T]) process(ctx context.Context, job *T, leaseUntil time.Time) {
+	attempt := Attempt{Number: 1, StartedAt: r.now(), LeasedUntil: leaseUntil}
+	if r.jobs.Attempts != nil {
+		attempt.Number += r.jobs.Attempts(job)
+	}
+
+	attempt.Err = r.handle(ctx, job)
+	if attempt.Err != nil && ctx.Err() != nil {
+		return
+	}
+
+	if attempt.Err != nil && !errors.Is(attempt.Err, ErrPermanent) && attempt.Number < r.config.MaxAttempts {
+		retryAt := attempt.StartedAt.Add(r.config.RetryBackoff << (attempt.Number - 1))
+		attempt.RetryAt = &retryAt
+	}
+
+	r.jobs.Record(job, attempt)
+}
+
+func (r *Runner[T]) handle(ctx context.Context, job *T) error {
+	if r.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.config.Timeout)
+		defer cancel()
+	}
+
+	return r.jobs.Handle(ctx, job)
+}
@@ -0,0 +1,146 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type job struct {
+	ID       int
+	Attempts int
+	Err      error
+}
+
+// claimFrom returns a claim callback serving jobs in order
+func claimFrom(jobs []job) func(now, leaseUntil time.Time, limit int) ([]job, error) {
+	return func(now, leaseUntil time.Time, limit int) ([]job, error) {
+		claimed := jobs[:min(limit, len(jobs))]
+		jobs = jobs[len(claimed):]
+		return claimed, nil
+	}
+}
+
+func newTestRunner(jobs []job, recorded map[int]Attempt, now time.Time) *Runner[job] {
+	claim := claimFrom(jobs)
+	return NewRunner("test jobs", Jobs[job]{
+		Claim: func(claimedAt, leaseUntil time.Time, limit int) ([]job, error) {
+			// Jobs are claimed one at a time, each with a fresh lease
+			if !leaseUntil.Equal(claimedAt.Add(time.Minute)) || limit != 1 {
+				return nil, fmt.Errorf("unexpected lease %v or limit %d", leaseUntil, limit)
+			}
+			return claim(claimedAt, leaseUntil, limit)
+		},
+		Attempts: func(j *job) int { return j.Attempts },
+		Handle:   func(ctx context.Context, j *job) error { return j.Err },
+		Record:   func(j *job, attempt Attempt) { recorded[j.ID] = attempt },
+	}, Config{BatchSize: 10, Lease: time.Minute, MaxAttempts: 3, RetryBackoff: time.Minute}, func() time.Time { return now })
+}
+
+func TestRunOnce(t *testing.T) {
+	now := time.Date(2025, 3, 20, 8, 0, 0, 0, time.UTC)
+	recorded := map[int]Attempt{}
+	r := newTestRunner([]job{
+		{ID: 1},
+		{ID: 2, Attempts: 1, Err: errors.New("timeout")},
+		{ID: 3, Attempts: 2, Err: errors.New("timeout")},
+		{ID: 4, Err: fmt.Errorf("%w: bad payload", ErrPermanent)},
+	}, recorded, now)
+
+	claimed, err := r.RunOnce(context.Background())
+	if err != nil || claimed != 4 {
+		t.Fatalf("expected 4 jobs claimed, got %d (%v)", claimed, err)
+	}
+
+	if done := recorded[1]; done.Err != nil || done.RetryAt != nil || done.Number != 1 || !done.StartedAt.Equal(now) || !done.LeasedUntil.Equal(now.Add(time.Minute)) {
+		t.Errorf("unexpected outcome of a successful job: %+v", done)
+	}
+
+	// The second attempt waits twice the backoff
+	if retried := recorded[2]; retried.Number != 2 || retried.RetryAt == nil || !retried.RetryAt.Equal(now.Add(2*time.Minute)) || retried.GaveUp() {
+		t.Errorf("expected a retry, got %+v", retried)
+	}
+
+	// The last attempt and permanent errors are given up
+	for _, id := range []int{3, 4} {
+		if failed := recorded[id]; !failed.GaveUp() {
+			t.Errorf("expected job %d to be given up, got %+v", id, failed)
+		}
+	}
+}
+
+func TestRunOnceLeavesInterruptedJobs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	recorded := map[int]Attempt{}
+	r := NewRunner("test jobs", Jobs[job]{
+		Claim: claimFrom([]job{{ID: 1}}),
+		Handle: func(ctx context.Context, j *job) error {
+			cancel()
+			return ctx.Err()
+		},
+		Record: func(j *job, attempt Attempt) { recorded[j.ID] = attempt },
+	}, Config{BatchSize: 10, Lease: time.Minute, MaxAttempts: 3}, nil)
+
+	if _, err := r.RunOnce(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recorded) != 0 {
+		t.Errorf("expected the interrupted job to be left for its lease to expire, got %+v", recorded)
+	}
+}
+
+func TestRunOnceTimesOutAttempts(t *testing.T) {
+	recorded := map[int]Attempt{}
+	r := NewRunner("test jobs", Jobs[job]{
+		Claim: claimFrom([]job{{ID: 1}}),
+		Handle: func(ctx context.Context, j *job) error {
+			<-ctx.Done()
+			return errors.New("no response")
+		},
+		Record: func(j *job, attempt Attempt) { recorded[j.ID] = attempt },
+	}, Config{BatchSize: 10, Lease: time.Minute, MaxAttempts: 3, Timeout: time.Millisecond}, nil)
+
+	if _, err := r.RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempt, ok := recorded[1]; !ok || attempt.Err == nil || attempt.RetryAt == nil {
+		t.Errorf("expected the timed out attempt to be retried, got %+v", attempt)
+	}
+}
+
+func TestRunOnceStopsAtBatchSize(t *testing.T) {
+	recorded := map[int]Attempt{}
+	r := NewRunner("test jobs", Jobs[job]{
+		Claim:  claimFrom([]job{{ID: 1}, {ID: 2}, {ID: 3}}),
+		Handle: func(ctx context.Context, j *job) error { return nil },
+		Record: func(j *job, attempt Attempt) { recorded[j.ID] = attempt },
+	}, Config{BatchSize: 2, Lease: time.Minute, MaxAttempts: 3}, nil)
+
+	if claimed, err := r.RunOnce(context.Background()); err != nil || claimed != 2 || len(recorded) != 2 {
+		t.Errorf("expected 2 jobs handled, got %d (%v)", claimed, err)
+	}
+	if claimed, err := r.RunOnce(context.Background()); err != nil || claimed != 1 {
+		t.Errorf("expected the last job in the next round, got %d (%v)", claimed, err)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := Config{PollInterval: time.Second, BatchSize: 50, Lease: 2 * time.Minute, MaxAttempts: 5, Timeout: 30 * time.Second}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	short := valid
+	short.Lease = valid.Timeout
+	if err := short.Validate(); err == nil {
+		t.Error("expected a lease no longer than the timeout to be refused")
+	}
+
+	unbounded := valid
+	unbounded.Timeout = 0
+	if err := unbounded.Validate(); err == nil {
+		t.Error("expected a missing timeout to be refused")
+	}
+}
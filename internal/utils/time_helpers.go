@@ -1,30 +1,82 @@
 package utils
 
 import (
-    "context"
-    "fmt"
-    "time"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
 )
 
-// TimeInputParsing parses a time input string into a time.Time
+// TimezoneHeader lets clients override the user's timezone for a single request
+const TimezoneHeader = "X-Timezone"
+
+type locationContextKey struct{}
+
+// WithLocation returns a copy of ctx carrying the user's time zone
+func WithLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, locationContextKey{}, loc)
+}
+
+// SetLocation stores the user's time zone for the current request. It is
+// visible through c.Context() in use cases.
+func SetLocation(c *fiber.Ctx, loc *time.Location) {
+	c.Locals(locationContextKey{}, loc)
+}
+
+// LocationFromContext returns the user's time zone, or UTC when none is set
+func LocationFromContext(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(locationContextKey{}).(*time.Location); ok && loc != nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// LoadLocation loads an IANA time zone name. "Local" is rejected because it
+// depends on the server configuration.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return time.LoadLocation(name)
+}
+
+// TimeLocationParsing parses a time input string into a time.Time in the
+// time zone of the context. Inputs without an offset are wall clock times in
+// that zone, inputs with an offset keep their instant.
 func TimeLocationParsing(ctx context.Context, timeInput string) (time.Time, error) {
-    parsedTime, err := time.Parse("2006-01-02T15:04:05.999", timeInput)
-
-    if err != nil {
-        parsedTime, err = time.Parse(time.RFC3339, timeInput)
-        if err != nil {
-            return time.Time{}, fmt.Errorf("invalid happens_at format: %w", err)
-        }
-    }
-
-    if parsedTime.Location() == time.UTC {
-        loc, err := time.LoadLocation("Asia/Bangkok")
-        if err != nil {
-            return time.Time{}, fmt.Errorf("failed to load timezone: %w", err)
-        }
-
-        parsedTime = parsedTime.In(loc)
-    }
-
-    return parsedTime, nil
-}
\ No newline at end of file
+	loc := LocationFromContext(ctx)
+
+	parsedTime, err := time.ParseInLocation("2006-01-02T15:04:05.999", timeInput, loc)
+	if err == nil {
+		return parsedTime, nil
+	}
+
+	parsedTime, err = time.Parse(time.RFC3339, timeInput)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid happens_at format: %w", err)
+	}
+
+	return parsedTime.In(loc), nil
+}
+
+// DateRangeParsing parses a search bound given either as RFC3339 or as a
+// date. Dates cover the whole day in the time zone of the context, so an end
+// date is moved to the last instant of that day.
+func DateRangeParsing(ctx context.Context, input string, end bool) (time.Time, error) {
+	loc := LocationFromContext(ctx)
+
+	if date, err := time.ParseInLocation("2006-01-02", input, loc); err == nil {
+		if end {
+			return date.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+		}
+		return date, nil
+	}
+
+	parsedTime, err := time.Parse(time.RFC3339, input)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return parsedTime.In(loc), nil
+}
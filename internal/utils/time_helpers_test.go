@@ -32,4 +32,57 @@ func TestTimeLocationParsingFailedInvalidFormat(t *testing.T) {
 	if err.Error() != expectedError {
 		t.Errorf("Expected error: %v, but got: %v", expectedError, err)
 	}
-}
\ No newline at end of file
+}
+func TestTimeLocationParsingUsesContextLocation(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx := WithLocation(context.Background(), jakarta)
+
+	// Wall clock input is interpreted in the user's zone
+	parsedTime, err := TimeLocationParsing(ctx, "2025-03-28T07:40:00")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := time.Date(2025, 3, 28, 0, 40, 0, 0, time.UTC); !parsedTime.Equal(expected) {
+		t.Errorf("Expected time: %v, but got: %v", expected, parsedTime)
+	}
+
+	// Input with an offset keeps its instant and is presented in the user's zone
+	parsedTime, err = TimeLocationParsing(ctx, "2025-03-28T07:40:00Z")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if parsedTime.Location() != jakarta || parsedTime.Hour() != 14 {
+		t.Errorf("Expected 14:40 in Asia/Jakarta, but got: %v", parsedTime)
+	}
+}
+
+func TestDateRangeParsing(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx := WithLocation(context.Background(), sydney)
+
+	start, err := DateRangeParsing(ctx, "2025-03-28", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	end, err := DateRangeParsing(ctx, "2025-03-28", true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if expected := time.Date(2025, 3, 28, 0, 0, 0, 0, sydney); !start.Equal(expected) {
+		t.Errorf("Expected start: %v, but got: %v", expected, start)
+	}
+	if expected := time.Date(2025, 3, 29, 0, 0, 0, 0, sydney).Add(-time.Nanosecond); !end.Equal(expected) {
+		t.Errorf("Expected end: %v, but got: %v", expected, end)
+	}
+
+	if _, err := DateRangeParsing(ctx, "28/03/2025", false); err == nil {
+		t.Errorf("Expected error, but got nil")
+	}
+}
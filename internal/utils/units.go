@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+)
+
+// significantDigits is the precision of converted values. It drops the
+// noise of float arithmetic while keeping small quantities, such as a 2.5 ml
+// dose in ounces, meaningful.
+const significantDigits = 6
+
+// unitFactors maps each supported unit to its dimension and the factor to
+// the base unit of that dimension (ml, kg, cm)
+var unitFactors = map[string]struct {
+	dimension string
+	factor    float64
+}{
+	"ml": {"volume", 1},
+	"oz": {"volume", 29.5735},
+	"g":  {"weight", 0.001},
+	"kg": {"weight", 1},
+	"lb": {"weight", 0.45359237},
+	"cm": {"length", 1},
+	"in": {"length", 2.54},
+}
+
+// preferenceUnits are the units users can pick in their preferences. Only
+// quantities recorded in one of them are converted in activity details, so a
+// 30 g solid feed is not rewritten as kilograms.
+var preferenceUnits = map[string]bool{
+	"ml": true, "oz": true,
+	"kg": true, "lb": true,
+	"cm": true, "in": true,
+}
+
+// quantityFields are the detail fields described by a plain "unit" field
+var quantityFields = []string{"amount", "volume", "weight", "length", "height", "value"}
+
+// ConvertUnit converts value between two units of the same dimension. It
+// reports false when either unit is unknown or the dimensions differ.
+func ConvertUnit(value float64, from, to string) (float64, bool) {
+	source, ok := unitFactors[strings.ToLower(from)]
+	if !ok {
+		return 0, false
+	}
+	target, ok := unitFactors[strings.ToLower(to)]
+	if !ok || source.dimension != target.dimension {
+		return 0, false
+	}
+	if strings.EqualFold(from, to) {
+		return value, true
+	}
+	return roundSignificant(value*source.factor/target.factor, significantDigits), true
+}
+
+// roundSignificant rounds value to the given number of significant digits
+func roundSignificant(value float64, digits int) float64 {
+	if value == 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return value
+	}
+	scale := math.Pow(10, float64(digits)-math.Ceil(math.Log10(math.Abs(value))))
+	return math.Round(value*scale) / scale
+}
+
+// UnitTargets holds the preferred unit for each dimension
+type UnitTargets struct {
+	Volume string
+	Weight string
+	Length string
+}
+
+func (t UnitTargets) forDimension(dimension string) string {
+	switch dimension {
+	case "volume":
+		return t.Volume
+	case "weight":
+		return t.Weight
+	case "length":
+		return t.Length
+	}
+	return ""
+}
+
+// ConvertDetailUnits rewrites the quantities of an activity details object
+// into the preferred units. Quantities are recognised either as one of the
+// common fields next to a "unit" field ({"amount": 120, "unit": "ml"}) or as
+// a field with a matching "<field>_unit" ({"weight": 5.2, "weight_unit": "kg"}).
+// Only quantities in one of the preference units are converted. Details that
+// are not a JSON object are returned unchanged.
+func ConvertDetailUnits(details json.RawMessage, targets UnitTargets) json.RawMessage {
+	if len(details) == 0 {
+		return details
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(details))
+	decoder.UseNumber()
+
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil || fields == nil {
+		return details
+	}
+
+	changed := false
+	convert := func(field, unitField string) {
+		number, ok := fields[field].(json.Number)
+		if !ok {
+			return
+		}
+		unit, ok := fields[unitField].(string)
+		if !ok {
+			return
+		}
+		if !preferenceUnits[strings.ToLower(unit)] {
+			return
+		}
+		source := unitFactors[strings.ToLower(unit)]
+		target := targets.forDimension(source.dimension)
+		if target == "" || strings.EqualFold(unit, target) {
+			return
+		}
+		value, err := number.Float64()
+		if err != nil {
+			return
+		}
+		if converted, ok := ConvertUnit(value, unit, target); ok {
+			fields[field] = converted
+			fields[unitField] = target
+			changed = true
+		}
+	}
+
+	for key := range fields {
+		if field, found := strings.CutSuffix(key, "_unit"); found && field != "" {
+			convert(field, key)
+		}
+	}
+
+	// A plain "unit" describes a single quantity field, converting more than
+	// one would rewrite the shared unit twice
+	for _, field := range quantityFields {
+		if _, ok := fields[field].(json.Number); ok {
+			convert(field, "unit")
+			break
+		}
+	}
+
+	if !changed {
+		return details
+	}
+
+	converted, err := json.Marshal(fields)
+	if err != nil {
+		return details
+	}
+	return converted
+}
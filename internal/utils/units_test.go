@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConvertUnit(t *testing.T) {
+	testCases := []struct {
+		value    float64
+		from     string
+		to       string
+		expected float64
+		ok       bool
+	}{
+		{value: 120, from: "ml", to: "oz", expected: 4.05769, ok: true},
+		{value: 4, from: "oz", to: "ml", expected: 118.294, ok: true},
+		{value: 3.5, from: "kg", to: "lb", expected: 7.71618, ok: true},
+		{value: 3500, from: "g", to: "kg", expected: 3.5, ok: true},
+		{value: 50, from: "cm", to: "in", expected: 19.685, ok: true},
+		{value: 2.5, from: "ml", to: "oz", expected: 0.0845351, ok: true},
+		{value: 50, from: "cm", to: "cm", expected: 50, ok: true},
+		{value: 1, from: "kg", to: "ml", ok: false},
+		{value: 1, from: "cup", to: "ml", ok: false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := ConvertUnit(tc.value, tc.from, tc.to)
+		if ok != tc.ok || got != tc.expected {
+			t.Errorf("ConvertUnit(%v, %s, %s) = %v, %v; expected %v, %v", tc.value, tc.from, tc.to, got, ok, tc.expected, tc.ok)
+		}
+	}
+}
+
+func TestConvertDetailUnits(t *testing.T) {
+	imperial := UnitTargets{Volume: "oz", Weight: "lb", Length: "in"}
+
+	testCases := []struct {
+		name     string
+		details  string
+		expected map[string]interface{}
+	}{
+		{
+			name:     "feeding volume",
+			details:  `{"amount": 120, "unit": "ml", "notes": "Formula milk"}`,
+			expected: map[string]interface{}{"amount": 4.05769, "unit": "oz", "notes": "Formula milk"},
+		},
+		{
+			name:     "suffixed unit fields",
+			details:  `{"weight": 3.5, "weight_unit": "kg", "height": 50, "height_unit": "cm"}`,
+			expected: map[string]interface{}{"weight": 7.71618, "weight_unit": "lb", "height": 19.685, "height_unit": "in"},
+		},
+		{
+			name:     "small dose",
+			details:  `{"amount": 2.5, "unit": "ml"}`,
+			expected: map[string]interface{}{"amount": 0.0845351, "unit": "oz"},
+		},
+		{
+			name:     "unit outside the preferences",
+			details:  `{"amount": 30, "unit": "g", "food": "rice"}`,
+			expected: map[string]interface{}{"amount": 30.0, "unit": "g"},
+		},
+		{
+			name:     "already in preferred unit",
+			details:  `{"amount": 4, "unit": "oz"}`,
+			expected: map[string]interface{}{"amount": 4.0, "unit": "oz"},
+		},
+		{
+			name:     "no unit",
+			details:  `{"type": "wet"}`,
+			expected: map[string]interface{}{"type": "wet"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got map[string]interface{}
+			if err := json.Unmarshal(ConvertDetailUnits(json.RawMessage(tc.details), imperial), &got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for key, value := range tc.expected {
+				if got[key] != value {
+					t.Errorf("expected %s to be %v, got %v", key, value, got[key])
+				}
+			}
+		})
+	}
+
+	if got := ConvertDetailUnits(json.RawMessage(`[1, 2]`), imperial); string(got) != `[1, 2]` {
+		t.Errorf("expected non-object details to be unchanged, got %s", got)
+	}
+}
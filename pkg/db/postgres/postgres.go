@@ -15,11 +15,14 @@ type Config struct {
 	SSLMode  string
 }
 
-func NewConnection(config Config) (*sql.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+// DSN returns the connection string for the config
+func (config Config) DSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		config.Host, config.Port, config.User, config.Password, config.DBName, config.SSLMode)
+}
 
-	db, err := sql.Open("postgres", dsn)
+func NewConnection(config Config) (*sql.DB, error) {
+	db, err := sql.Open("postgres", config.DSN())
 	if err != nil {
 		return nil, fmt.Errorf("error opening database: %w", err)
 	}
@@ -10,15 +10,23 @@ import (
 	"github.com/spf13/viper"
 )
 
+// InitSes creates an SES client. Without aws.ses.access_key, credentials come
+// from the default chain, e.g. the environment or an instance role.
 func InitSes(ctx context.Context) (*sesv2.Client, error) {
-	defaultConfig, err := config.LoadDefaultConfig(
-		ctx,
+	options := []func(*config.LoadOptions) error{
 		config.WithRegion(viper.GetString("aws.region")),
-		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(viper.GetString("aws.ses.access_key"), viper.GetString("aws.ses.access_secret_key"), "" )))
+	}
+	if accessKey := viper.GetString("aws.ses.access_key"); accessKey != "" {
+		options = append(options, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(accessKey, viper.GetString("aws.ses.access_secret_key"), ""),
+		))
+	}
+
+	defaultConfig, err := config.LoadDefaultConfig(ctx, options...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize logger: %w", err)
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
 	}
-	
+
 	client := sesv2.NewFromConfig(defaultConfig)
 	return client, nil
-}
\ No newline at end of file
+}
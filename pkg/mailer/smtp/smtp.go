@@ -2,9 +2,13 @@ package smtp
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"mime"
 	"mime/multipart"
 	"mime/quotedprintable"
+	"net/mail"
 	"net/smtp"
 	"time"
 
@@ -24,11 +28,22 @@ type Config struct {
 	From     string
 }
 
+// SendEmailData is an email to send. Text and Content, the HTML body, are
+// both optional; From falls back to the configured sender.
 type SendEmailData struct {
 	From,
 	To,
 	Subject,
+	Text,
 	Content string
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to an email
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
 }
 
 func InitSmtp() *Smtp {
@@ -60,82 +75,154 @@ func (s *Smtp) GetSMTPAddress() string {
 
 func (s *Smtp) Send(data SendEmailData) (output string, err error) {
 	// If from is empty, use the default from address
-	from := data.From
-	if from == "" {
-		from = s.Config.From
+	if data.From == "" {
+		data.From = s.Config.From
+	}
+
+	// The envelope takes the bare address of a "Name <address>" sender
+	sender, err := mail.ParseAddress(data.From)
+	if err != nil {
+		return "", fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	message, err := BuildMessage(data)
+	if err != nil {
+		return "", err
+	}
+
+	// Connect to the SMTP server and send the email
+	auth := s.GetSMTPAuth()
+	smtpAddr := s.GetSMTPAddress()
+
+	// Send the email
+	err = smtp.SendMail(
+		smtpAddr,
+		auth,
+		sender.Address,
+		[]string{data.To},
+		message,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to send email: %w", err)
 	}
 
+	return fmt.Sprintf("Email sent to %s at %s", data.To, time.Now().Format(time.RFC3339)), nil
+}
+
+// BuildMessage renders the email as a MIME message with plain text and HTML
+// alternatives, as sent over SMTP or saved to an .eml file. Attachments wrap
+// the alternatives in a multipart/mixed message.
+func BuildMessage(data SendEmailData) ([]byte, error) {
 	// Create email message with proper headers
 	body := &bytes.Buffer{}
 
 	// Setup multipart
 	writer := multipart.NewWriter(body)
-	boundary := writer.Boundary()
+	contentType := "multipart/alternative; boundary=" + writer.Boundary()
+
+	alternatives := writer
+	if len(data.Attachments) > 0 {
+		contentType = "multipart/mixed; boundary=" + writer.Boundary()
+
+		// The alternatives get a random boundary of their own, nested in a
+		// part of the mixed message
+		boundary := multipart.NewWriter(io.Discard).Boundary()
+		alternativesPart, err := writer.CreatePart(map[string][]string{
+			"Content-Type": {"multipart/alternative; boundary=" + boundary},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create message part: %w", err)
+		}
+		alternatives = multipart.NewWriter(alternativesPart)
+		if err := alternatives.SetBoundary(boundary); err != nil {
+			return nil, fmt.Errorf("failed to create message part: %w", err)
+		}
+	}
 
-	// Set headers
+	// Set headers; the subject may contain non-ASCII characters
 	headers := fmt.Sprintf(
 		"From: %s\r\n"+
-		"To: %s\r\n"+
-		"Subject: %s\r\n"+
-		"MIME-Version: 1.0\r\n"+
-		"Content-Type: multipart/alternative; boundary=%s\r\n\r\n",
-		from, data.To, data.Subject, boundary)
-
-	// Add text part
-	textPart, err := writer.CreatePart(map[string][]string{
-		"Content-Type":              {"text/plain; charset=UTF-8"},
-		"Content-Transfer-Encoding": {"quoted-printable"},
-	})
-	if err != nil {
-		return "", fmt.Errorf("failed to create text part: %w", err)
+			"To: %s\r\n"+
+			"Subject: %s\r\n"+
+			"Date: %s\r\n"+
+			"MIME-Version: 1.0\r\n"+
+			"Content-Type: %s\r\n\r\n",
+		data.From, data.To, mime.QEncoding.Encode("utf-8", data.Subject), time.Now().Format(time.RFC1123Z), contentType)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", data.Text},
+		{"text/html; charset=UTF-8", data.Content},
 	}
 
-	qpWriter := quotedprintable.NewWriter(textPart)
-	// _, err = qpWriter.Write([]byte(data.TextMessage))
-	// if err != nil {
-	// 	return "", fmt.Errorf("failed to write text message: %w", err)
-	// }
-	// qpWriter.Close()
-
-	// Add HTML part if provided
-	if data.Content != "" {
-		htmlPart, err := writer.CreatePart(map[string][]string{
-			"Content-Type":              {"text/html; charset=UTF-8"},
+	for _, part := range parts {
+		if part.content == "" {
+			continue
+		}
+
+		partWriter, err := alternatives.CreatePart(map[string][]string{
+			"Content-Type":              {part.contentType},
 			"Content-Transfer-Encoding": {"quoted-printable"},
 		})
 		if err != nil {
-			return "", fmt.Errorf("failed to create HTML part: %w", err)
+			return nil, fmt.Errorf("failed to create message part: %w", err)
 		}
 
-		qpWriter = quotedprintable.NewWriter(htmlPart)
-		_, err = qpWriter.Write([]byte(data.Content))
-		if err != nil {
-			return "", fmt.Errorf("failed to write HTML message: %w", err)
+		qpWriter := quotedprintable.NewWriter(partWriter)
+		if _, err := qpWriter.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("failed to write message part: %w", err)
+		}
+		if err := qpWriter.Close(); err != nil {
+			return nil, fmt.Errorf("failed to write message part: %w", err)
+		}
+	}
+
+	if alternatives != writer {
+		if err := alternatives.Close(); err != nil {
+			return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+		}
+	}
+
+	for _, attachment := range data.Attachments {
+		if err := writeAttachment(writer, attachment); err != nil {
+			return nil, err
 		}
-		qpWriter.Close()
 	}
 
 	// Close the multipart writer
-	err = writer.Close()
-	if err != nil {
-		return "", fmt.Errorf("failed to close multipart writer: %w", err)
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
 	}
 
-	// Connect to the SMTP server and send the email
-	auth := s.GetSMTPAuth()
-	smtpAddr := s.GetSMTPAddress()
+	return append([]byte(headers), body.Bytes()...), nil
+}
 
-	// Send the email
-	err = smtp.SendMail(
-		smtpAddr,
-		auth,
-		from,
-		[]string{data.To},
-		[]byte(headers+body.String()),
-	)
+// writeAttachment adds a base64 encoded attachment part, wrapping lines at
+// 76 characters
+func writeAttachment(writer *multipart.Writer, attachment Attachment) error {
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	partWriter, err := writer.CreatePart(map[string][]string{
+		"Content-Type":              {mime.FormatMediaType(contentType, map[string]string{"name": attachment.Filename})},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to send email: %w", err)
+		return fmt.Errorf("failed to create attachment part: %w", err)
 	}
 
-	return fmt.Sprintf("Email sent to %s at %s", data.To, time.Now().Format(time.RFC3339)), nil
-}
\ No newline at end of file
+	encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+	for len(encoded) > 0 {
+		n := min(76, len(encoded))
+		if _, err := io.WriteString(partWriter, encoded[:n]+"\r\n"); err != nil {
+			return fmt.Errorf("failed to write attachment: %w", err)
+		}
+		encoded = encoded[n:]
+	}
+	return nil
+}
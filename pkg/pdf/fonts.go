@@ -0,0 +1,111 @@
+package pdf
+
+// Glyph widths of the printable ASCII characters (32-126) in thousandths of
+// the font size, from the Adobe font metrics of the standard fonts
+var asciiWidths = [][95]uint16{
+	Helvetica: {
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	},
+	HelveticaBold: {
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	},
+}
+
+// otherWidth approximates the glyphs outside ASCII, mostly accented letters
+const otherWidth = 556
+
+// TextWidth returns the width of text in points
+func TextWidth(font Font, size float64, text string) float64 {
+	total := 0
+	for _, c := range []byte(encode(text)) {
+		if c >= 32 && c <= 126 {
+			total += int(asciiWidths[font][c-32])
+		} else {
+			total += otherWidth
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// Truncate shortens text to fit in width, ending it with an ellipsis
+func Truncate(font Font, size, width float64, text string) string {
+	if TextWidth(font, size, text) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := string(runes) + "…"
+		if TextWidth(font, size, candidate) <= width {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// Wrap splits text into lines that fit in width, breaking between words.
+// Words longer than a line are truncated.
+func Wrap(font Font, size, width float64, text string) []string {
+	var lines []string
+	for _, paragraph := range splitLines(text) {
+		line := ""
+		for _, word := range splitWords(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if TextWidth(font, size, candidate) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = Truncate(font, size, width, word)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func splitLines(text string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			lines = append(lines, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(lines, text[start:])
+}
+
+func splitWords(text string) []string {
+	var words []string
+	word := ""
+	for _, r := range text {
+		if r == ' ' || r == '\t' || r == '\r' {
+			if word != "" {
+				words = append(words, word)
+				word = ""
+			}
+			continue
+		}
+		word += string(r)
+	}
+	if word != "" {
+		words = append(words, word)
+	}
+	return words
+}
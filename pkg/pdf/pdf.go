@@ -0,0 +1,272 @@
+// Package pdf writes simple PDF documents in pure Go: text in the standard
+// Helvetica fonts, lines, rectangles and circles. It covers printable reports
+// and is not a general purpose PDF library; text is limited to the Windows
+// ANSI (Latin) character set.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A4 page size in points
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Font is one of the standard fonts every PDF reader provides
+type Font int
+
+// Standard fonts
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+var fontNames = []string{"Helvetica", "Helvetica-Bold"}
+
+// Color is an RGB color with components between 0 and 1
+type Color struct {
+	R, G, B float64
+}
+
+// RGB returns the color of 8-bit components
+func RGB(r, g, b uint8) Color {
+	return Color{float64(r) / 255, float64(g) / 255, float64(b) / 255}
+}
+
+// Black is the default text color
+var Black = Color{}
+
+// Point is a position on a page
+type Point struct {
+	X, Y float64
+}
+
+// Document is a PDF document made of pages of the same size
+type Document struct {
+	width     float64
+	height    float64
+	title     string
+	createdAt time.Time
+	pages     []*Page
+}
+
+// New creates an empty A4 portrait document
+func New() *Document {
+	return &Document{
+		width:     A4Width,
+		height:    A4Height,
+		createdAt: time.Now(),
+	}
+}
+
+// SetTitle sets the title shown by PDF readers
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+// Width returns the page width in points
+func (d *Document) Width() float64 {
+	return d.width
+}
+
+// Height returns the page height in points
+func (d *Document) Height() float64 {
+	return d.height
+}
+
+// AddPage appends a blank page and returns it
+func (d *Document) AddPage() *Page {
+	page := &Page{doc: d}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// PageCount returns the number of pages
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Page is a page of a document. Positions are in points from the top-left
+// corner of the page.
+type Page struct {
+	doc     *Document
+	content bytes.Buffer
+}
+
+// Text draws text with its baseline at y
+func (p *Page) Text(x, y float64, font Font, size float64, color Color, text string) {
+	fmt.Fprintf(&p.content, "BT /F%d %s Tf %s rg %s %s Td (%s) Tj ET\n",
+		font+1, num(size), colorOps(color), num(x), num(p.doc.height-y), escape(encode(text)))
+}
+
+// Line draws a straight line
+func (p *Page) Line(x1, y1, x2, y2, width float64, color Color) {
+	fmt.Fprintf(&p.content, "%s w %s RG %s %s m %s %s l S\n",
+		num(width), colorOps(color), num(x1), num(p.doc.height-y1), num(x2), num(p.doc.height-y2))
+}
+
+// Polyline draws connected straight lines through the points
+func (p *Page) Polyline(points []Point, width float64, color Color) {
+	if len(points) < 2 {
+		return
+	}
+
+	fmt.Fprintf(&p.content, "%s w 1 j %s RG %s %s m", num(width), colorOps(color), num(points[0].X), num(p.doc.height-points[0].Y))
+	for _, point := range points[1:] {
+		fmt.Fprintf(&p.content, " %s %s l", num(point.X), num(p.doc.height-point.Y))
+	}
+	p.content.WriteString(" S\n")
+}
+
+// Rect fills a rectangle whose top-left corner is at x, y
+func (p *Page) Rect(x, y, width, height float64, color Color) {
+	fmt.Fprintf(&p.content, "%s rg %s %s %s %s re f\n",
+		colorOps(color), num(x), num(p.doc.height-y-height), num(width), num(height))
+}
+
+// Circle fills a circle
+func (p *Page) Circle(x, y, radius float64, color Color) {
+	// Four Bézier curves, each approximating a quarter of the circle
+	k := 0.5523 * radius
+	cy := p.doc.height - y
+	fmt.Fprintf(&p.content, "%s rg %s %s m", colorOps(color), num(x+radius), num(cy))
+	curves := [][6]float64{
+		{x + radius, cy + k, x + k, cy + radius, x, cy + radius},
+		{x - k, cy + radius, x - radius, cy + k, x - radius, cy},
+		{x - radius, cy - k, x - k, cy - radius, x, cy - radius},
+		{x + k, cy - radius, x + radius, cy - k, x + radius, cy},
+	}
+	for _, c := range curves {
+		fmt.Fprintf(&p.content, " %s %s %s %s %s %s c", num(c[0]), num(c[1]), num(c[2]), num(c[3]), num(c[4]), num(c[5]))
+	}
+	p.content.WriteString(" f\n")
+}
+
+// Bytes returns the encoded document
+func (d *Document) Bytes() ([]byte, error) {
+	var b bytes.Buffer
+	if _, err := d.WriteTo(&b); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// WriteTo encodes the document. A document without pages gets a blank one.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	// Objects 1 and 2 are the catalog and the page tree, followed by the
+	// fonts, the document information and a page and its content per page
+	const firstFont = 3
+	info := firstFont + len(fontNames)
+	firstPage := info + 1
+
+	var objects []string
+	objects = append(objects, "<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	objects = append(objects, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	for _, name := range fontNames {
+		objects = append(objects, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+	}
+
+	objects = append(objects, fmt.Sprintf("<< /Title (%s) /Producer (DailyAlu) /CreationDate (D:%s) >>",
+		escape(encode(d.title)), d.createdAt.UTC().Format("20060102150405Z")))
+
+	fonts := make([]string, len(fontNames))
+	for i := range fontNames {
+		fonts[i] = fmt.Sprintf("/F%d %d 0 R", i+1, firstFont+i)
+	}
+	for i, page := range d.pages {
+		objects = append(objects, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			num(d.width), num(d.height), strings.Join(fonts, " "), firstPage+2*i+1))
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(page.content.Bytes()); err != nil {
+			return 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+		objects = append(objects, fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.String()))
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, info, xref)
+
+	return out.WriteTo(w)
+}
+
+// num formats a number with at most two decimals
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
+
+func colorOps(c Color) string {
+	return num(c.R) + " " + num(c.G) + " " + num(c.B)
+}
+
+// escape escapes the delimiters of a literal string
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`, "\r", `\r`, "\n", `\n`).Replace(s)
+}
+
+// winAnsiSpecials maps the characters Windows-1252 places in 0x80-0x9F
+var winAnsiSpecials = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// encode converts text to WinAnsiEncoding, replacing characters outside it
+// with "?"
+func encode(text string) string {
+	b := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r >= 0x20 && r < 0x7F, r >= 0xA0 && r <= 0xFF:
+			b = append(b, byte(r))
+		case winAnsiSpecials[r] != 0:
+			b = append(b, winAnsiSpecials[r])
+		case r == '\t':
+			b = append(b, ' ')
+		default:
+			b = append(b, '?')
+		}
+	}
+	return string(b)
+}
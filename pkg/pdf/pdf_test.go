@@ -0,0 +1,89 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocumentStructure(t *testing.T) {
+	doc := New()
+	doc.SetTitle("Growth report (Alu)")
+	page := doc.AddPage()
+	page.Text(40, 60, HelveticaBold, 18, Black, "Alu – café (report)")
+	page.Line(40, 70, 200, 70, 1, RGB(200, 200, 200))
+	doc.AddPage().Rect(10, 10, 50, 20, RGB(255, 0, 0))
+
+	content, err := doc.Bytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.HasPrefix(content, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(content, []byte("%%EOF\n")) {
+		t.Fatalf("expected a PDF header and trailer")
+	}
+	if !bytes.Contains(content, []byte("/Count 2")) {
+		t.Errorf("expected two pages")
+	}
+	if !bytes.Contains(content, []byte(`/Title (Growth report \(Alu\))`)) {
+		t.Errorf("expected an escaped title")
+	}
+
+	// Every xref entry points at the start of its object
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(content)
+	if startxref == nil {
+		t.Fatalf("expected startxref")
+	}
+	offset, _ := strconv.Atoi(string(startxref[1]))
+	if !bytes.HasPrefix(content[offset:], []byte("xref\n")) {
+		t.Fatalf("startxref does not point at the xref table")
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(content[offset:], -1)
+	if len(entries) != 9 {
+		t.Fatalf("expected 9 objects, got %d", len(entries))
+	}
+	for i, entry := range entries {
+		at, _ := strconv.Atoi(string(entry[1]))
+		if !bytes.HasPrefix(content[at:], []byte(strconv.Itoa(i+1)+" 0 obj\n")) {
+			t.Errorf("xref entry %d does not point at its object", i+1)
+		}
+	}
+
+	// The first content stream holds the WinAnsi encoded text, flipped to
+	// bottom-left coordinates
+	stream := regexp.MustCompile(`(?s)/Length (\d+) /Filter /FlateDecode >>\nstream\n`).FindSubmatchIndex(content)
+	length, _ := strconv.Atoi(string(content[stream[2]:stream[3]]))
+	r, err := zlib.NewReader(bytes.NewReader(content[stream[1] : stream[1]+length]))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ops, _ := io.ReadAll(r)
+	if !strings.Contains(string(ops), "BT /F2 18 Tf 0 0 0 rg 40 781.89 Td (Alu \x96 caf\xe9 \\(report\\)) Tj ET") {
+		t.Errorf("unexpected text operators: %q", ops)
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	if got := TextWidth(Helvetica, 10, "Hi"); got != 9.44 {
+		t.Errorf("expected 9.44, got %v", got)
+	}
+	if TextWidth(HelveticaBold, 10, "Hi") <= TextWidth(Helvetica, 10, "Hi") {
+		t.Errorf("expected bold text to be wider")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	lines := Wrap(Helvetica, 10, 75, "Slept well after the evening feed\nNo fever")
+	expected := []string{"Slept well after", "the evening feed", "No fever"}
+	if strings.Join(lines, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected %q, got %q", expected, lines)
+	}
+
+	if got := Truncate(Helvetica, 10, 30, "Paracetamol"); got != "Para…" {
+		t.Errorf("unexpected truncation %q", got)
+	}
+}
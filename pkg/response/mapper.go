@@ -1,13 +1,31 @@
 package response
 
 import (
+	activityUsecase "dailyalu-server/internal/module/activity/usecase"
+	attachmentUsecase "dailyalu-server/internal/module/attachment/usecase"
 	childrenUsecase "dailyalu-server/internal/module/children/usecase"
+	deviceUsecase "dailyalu-server/internal/module/device/usecase"
+	growthUsecase "dailyalu-server/internal/module/growth/usecase"
+	immunizationUsecase "dailyalu-server/internal/module/immunization/usecase"
+	importUsecase "dailyalu-server/internal/module/importer/usecase"
+	medicationUsecase "dailyalu-server/internal/module/medication/usecase"
+	milestoneUsecase "dailyalu-server/internal/module/milestone/usecase"
+	outboxUsecase "dailyalu-server/internal/module/outbox/usecase"
+	reminderUsecase "dailyalu-server/internal/module/reminder/usecase"
+	reportUsecase "dailyalu-server/internal/module/report/usecase"
 	userUsecase "dailyalu-server/internal/module/user/usecase"
+	webhookUsecase "dailyalu-server/internal/module/webhook/usecase"
+	"dailyalu-server/internal/security/password"
 	"errors"
 )
 
 // MapDomainError maps domain-specific errors to standardized API errors
 func MapDomainError(err error) *AppError {
+	var policyErr *password.PolicyError
+	if errors.As(err, &policyErr) {
+		return NewValidationErrorWithDetails("Password does not meet the password policy", policyErr.Violations)
+	}
+
 	// User domain errors
 	switch {
 	case errors.Is(err, userUsecase.ErrEmailAlreadyExists):
@@ -28,6 +46,30 @@ func MapDomainError(err error) *AppError {
 		return NewBadRequestError("Password reset token has expired")
 	case errors.Is(err, userUsecase.ErrInvalidOldPassword):
 		return NewBadRequestError("Invalid old password")
+	case errors.Is(err, userUsecase.ErrUserBlocked):
+		return NewForbiddenError("Your account has been blocked")
+	case errors.Is(err, userUsecase.ErrEmailNotVerified):
+		return NewForbiddenError("Please verify your email address before signing in")
+	case errors.Is(err, userUsecase.ErrInvalidMagicLinkToken):
+		return NewUnauthorizedError("Invalid sign-in link")
+	case errors.Is(err, userUsecase.ErrMagicLinkTokenExpired):
+		return NewUnauthorizedError("Sign-in link has expired")
+	case errors.Is(err, userUsecase.ErrUnsupportedProvider):
+		return NewNotFoundError("Unsupported identity provider")
+	case errors.Is(err, userUsecase.ErrInvalidOIDCState):
+		return NewBadRequestError("Invalid or expired sign-in state")
+	case errors.Is(err, userUsecase.ErrOIDCAuthenticationFailed):
+		return NewUnauthorizedError("Sign-in with identity provider failed").WithInternal(err)
+	case errors.Is(err, userUsecase.ErrOIDCEmailNotVerified):
+		return NewForbiddenError("Your identity provider account has no verified email address")
+	case errors.Is(err, userUsecase.ErrIdentityAlreadyLinked):
+		return NewBadRequestError("This identity is already linked to another account")
+	case errors.Is(err, userUsecase.ErrIdentityNotFound):
+		return NewNotFoundError("Linked identity not found")
+	case errors.Is(err, userUsecase.ErrCannotUnlinkLastLogin):
+		return NewBadRequestError("Set a password before unlinking your only sign-in method")
+	case errors.Is(err, userUsecase.ErrInvalidTimezone):
+		return NewBadRequestError("Invalid timezone")
 	
 	// Children domain errors
 	case errors.Is(err, childrenUsecase.ErrChildNotFound):
@@ -36,7 +78,137 @@ func MapDomainError(err error) *AppError {
 		return NewForbiddenError("You do not have permission to access this child's data")
 	case errors.Is(err, childrenUsecase.ErrInvalidChildData):
 		return NewBadRequestError("Invalid child data")
+	case errors.Is(err, childrenUsecase.ErrInvalidDateOfBirth):
+		return NewBadRequestError("Date of birth cannot be in the future")
+
+	// Growth domain errors
+	case errors.Is(err, growthUsecase.ErrMeasurementNotFound):
+		return NewNotFoundError("Measurement not found")
+	case errors.Is(err, growthUsecase.ErrEmptyMeasurement):
+		return NewBadRequestError("At least one of weight_kg, length_cm or head_circumference_cm is required")
+	case errors.Is(err, growthUsecase.ErrInvalidMeasurementDate):
+		return NewBadRequestError("Measurement date must be between the date of birth and today")
+	case errors.Is(err, growthUsecase.ErrInvalidIndicator):
+		return NewBadRequestError("Invalid growth indicator")
+	case errors.Is(err, growthUsecase.ErrIncompleteBirthProfile):
+		return NewBadRequestError("Set the child's date of birth and sex to see growth charts")
+
+	// Report domain errors
+	case errors.Is(err, reportUsecase.ErrInvalidReportPeriod):
+		return NewBadRequestError("The report must end on or after the day it starts")
+	case errors.Is(err, reportUsecase.ErrReportPeriodTooLong):
+		return NewBadRequestError("The report can cover at most 93 days")
+
+	// Milestone domain errors
+	case errors.Is(err, milestoneUsecase.ErrMilestoneNotFound):
+		return NewNotFoundError("Milestone not found")
+	case errors.Is(err, milestoneUsecase.ErrAchievementNotFound):
+		return NewNotFoundError("Milestone has not been recorded for this child")
+	case errors.Is(err, milestoneUsecase.ErrInvalidAchievementDate):
+		return NewBadRequestError("Achievement date must be between the date of birth and today")
+	case errors.Is(err, milestoneUsecase.ErrDateOfBirthRequired):
+		return NewBadRequestError("Set the child's date of birth to see milestone progress")
+
+	// Immunization domain errors
+	case errors.Is(err, immunizationUsecase.ErrDoseRecordNotFound):
+		return NewNotFoundError("Dose record not found")
+	case errors.Is(err, immunizationUsecase.ErrUnknownVaccineDose):
+		return NewBadRequestError("Vaccine dose is not part of the immunization schedule")
+	case errors.Is(err, immunizationUsecase.ErrDoseAlreadyRecorded):
+		return NewBadRequestError("This dose has already been recorded")
+	case errors.Is(err, immunizationUsecase.ErrInvalidDoseDate):
+		return NewBadRequestError("Dose date must be between the date of birth and today")
+	case errors.Is(err, immunizationUsecase.ErrInvalidStatusFilter):
+		return NewBadRequestError("Status must be completed, overdue, due or upcoming")
+	case errors.Is(err, immunizationUsecase.ErrDateOfBirthRequired):
+		return NewBadRequestError("Set the child's date of birth to see the immunization schedule")
+
+	// Medication domain errors
+	case errors.Is(err, medicationUsecase.ErrPlanNotFound):
+		return NewNotFoundError("Medication plan not found")
+	case errors.Is(err, medicationUsecase.ErrInvalidPlanTime):
+		return NewBadRequestError("Invalid medication plan time format")
+	case errors.Is(err, medicationUsecase.ErrInvalidPlanPeriod):
+		return NewBadRequestError("Medication plan must end after it starts")
+	case errors.Is(err, medicationUsecase.ErrDailyMaxExceeded):
+		return NewBadRequestError("Dose would exceed the plan's maximum doses per 24 hours; set allow_exceeding_max to log it anyway")
+
+	// Reminder domain errors
+	case errors.Is(err, reminderUsecase.ErrReminderNotFound):
+		return NewNotFoundError("Reminder not found")
+	case errors.Is(err, reminderUsecase.ErrInvalidReminderSchedule):
+		return NewBadRequestError("Set either fire_at or rule")
+	case errors.Is(err, reminderUsecase.ErrInvalidRule):
+		return NewBadRequestError("Rule must be a cron expression, a descriptor like @daily or an interval like @every 3h")
+	case errors.Is(err, reminderUsecase.ErrInvalidReminderTime):
+		return NewBadRequestError("Invalid fire_at format")
+	case errors.Is(err, reminderUsecase.ErrFireAtInPast):
+		return NewBadRequestError("fire_at must be in the future")
+	case errors.Is(err, reminderUsecase.ErrRuleNeverMatches):
+		return NewBadRequestError("Rule has no upcoming occurrence")
+	case errors.Is(err, reminderUsecase.ErrChannelUnavailable):
+		return NewBadRequestError("Notification channel is not available")
+	case errors.Is(err, reminderUsecase.ErrInvalidWebhookURL):
+		return NewBadRequestError("Webhook URL must be a public https URL")
+
+	// Activity domain errors
+	case errors.Is(err, activityUsecase.ErrMedicationPlanRequiresMedicine):
+		return NewBadRequestError("Only medicine activities can reference a medication plan")
+	case errors.Is(err, activityUsecase.ErrInvalidExportFormat):
+		return NewBadRequestError("Format must be csv, json or ics")
+
+	// Device domain errors
+	case errors.Is(err, deviceUsecase.ErrDeviceNotFound):
+		return NewNotFoundError("Device not found")
+	case errors.Is(err, deviceUsecase.ErrUnsupportedPushTarget):
+		return NewBadRequestError("APNs tokens can only be registered for iOS devices")
+
+	// Webhook domain errors
+	case errors.Is(err, webhookUsecase.ErrSubscriptionNotFound):
+		return NewNotFoundError("Webhook not found")
+	case errors.Is(err, webhookUsecase.ErrDeliveryNotFound):
+		return NewNotFoundError("Webhook delivery not found")
+	case errors.Is(err, webhookUsecase.ErrUnknownEventType):
+		return NewBadRequestError(err.Error())
+	case errors.Is(err, webhookUsecase.ErrInvalidWebhookURL):
+		return NewBadRequestError("Webhook URL must be a public https URL")
+
+	// Outbox domain errors
+	case errors.Is(err, outboxUsecase.ErrMessageNotFound):
+		return NewNotFoundError("Outbox message not found")
+	case errors.Is(err, outboxUsecase.ErrMessageNotDead):
+		return NewBadRequestError("Only dead outbox messages can be retried")
 	
+	// Import domain errors
+	case errors.Is(err, importUsecase.ErrImportNotFound):
+		return NewNotFoundError("Import not found")
+	case errors.Is(err, importUsecase.ErrUnreadableFile):
+		return NewBadRequestError(err.Error())
+	case errors.Is(err, importUsecase.ErrImportNotCommittable):
+		return NewBadRequestError("Only previewed or failed imports can be committed")
+	case errors.Is(err, importUsecase.ErrNothingToImport):
+		return NewBadRequestError("The import has no rows left to import")
+	case errors.Is(err, importUsecase.ErrImportInProgress):
+		return NewBadRequestError("The import is in progress")
+
+	// Attachment domain errors
+	case errors.Is(err, attachmentUsecase.ErrAttachmentNotFound):
+		return NewNotFoundError("Attachment not found")
+	case errors.Is(err, attachmentUsecase.ErrPhotoNotFound):
+		return NewNotFoundError("The child has no profile photo")
+	case errors.Is(err, attachmentUsecase.ErrActivityNotFound):
+		return NewNotFoundError("Activity not found")
+	case errors.Is(err, attachmentUsecase.ErrEmptyFile):
+		return NewBadRequestError("The file is empty")
+	case errors.Is(err, attachmentUsecase.ErrFileTooLarge):
+		return NewBadRequestError(err.Error())
+	case errors.Is(err, attachmentUsecase.ErrUnsupportedFileType):
+		return NewBadRequestError("Only JPEG, PNG, GIF and WebP images and PDF documents can be uploaded")
+	case errors.Is(err, attachmentUsecase.ErrPhotoNotImage):
+		return NewBadRequestError("The profile photo must be an image")
+	case errors.Is(err, attachmentUsecase.ErrInvalidImage):
+		return NewBadRequestError(err.Error())
+
 	// Default case - internal error
 	default:
 		return NewInternalError(err)